@@ -13,8 +13,14 @@
 	"github.com/gin-gonic/gin"
 	_ "github.com/lib/pq"
 	"github.com/mmorpg-template/backend/internal/adapters/auth"
+	natsAuth "github.com/mmorpg-template/backend/internal/adapters/auth/nats"
+	natsAdapter "github.com/mmorpg-template/backend/internal/adapters/nats"
+	redisAdapter "github.com/mmorpg-template/backend/internal/adapters/redis"
+	"github.com/mmorpg-template/backend/internal/adapters/serviceauth"
 	appAuth "github.com/mmorpg-template/backend/internal/application/auth"
+	authDomain "github.com/mmorpg-template/backend/internal/domain/auth"
 	"github.com/mmorpg-template/backend/internal/config"
+	"github.com/mmorpg-template/backend/internal/ports"
 	portsAuth "github.com/mmorpg-template/backend/internal/ports/auth"
 	"github.com/mmorpg-template/backend/pkg/db"
 	"github.com/mmorpg-template/backend/pkg/logger"
@@ -70,18 +76,63 @@ func main() {
 	}
 	defer nc.Close()
 
+	// Initialize NATS message queue for account events
+	mqConfig := &ports.MessageQueueConfig{
+		URL:           cfg.NATSURL(),
+		ClientID:      "auth-service",
+		MaxReconnects: 10,
+		ReconnectWait: 2 * time.Second,
+		PingInterval:  30 * time.Second,
+		MaxPingsOut:   5,
+	}
+
+	mq := natsAdapter.NewNATSMessageQueue(mqConfig, log)
+	if err := mq.Connect(context.Background()); err != nil {
+		log.WithError(err).Fatal("Failed to connect to NATS")
+	}
+	defer mq.Close()
+
+	eventPublisher := natsAuth.NewEventPublisher(mq, log)
+	if err := eventPublisher.Initialize(context.Background()); err != nil {
+		log.WithError(err).Fatal("Failed to initialize event publisher")
+	}
+
 	// Initialize repositories
 	userRepo := auth.NewPostgresUserRepository(database)
 	sessionRepo := auth.NewPostgresSessionRepository(database)
+	securityEventRepo := auth.NewPostgresSecurityEventRepository(database)
+	knownDeviceRepo := auth.NewPostgresKnownDeviceRepository(database)
+	identityHistoryRepo := auth.NewPostgresIdentityHistoryRepository(database)
+	serviceAccountRepo := auth.NewPostgresServiceAccountRepository(database)
+	loginHistoryRepo := auth.NewPostgresLoginHistoryRepository(database)
+	parentalControlsRepo := auth.NewPostgresParentalControlsRepository(database)
+	playtimeRepo := auth.NewPostgresPlaytimeRepository(database)
 
 	// Initialize adapters
 	tokenGenerator := auth.NewJWTGenerator(
 		cfg.Auth.JWTAccessSecret,
 		cfg.Auth.JWTRefreshSecret,
+		cfg.Auth.JWTServiceSecret,
 		"mmorpg-auth",
 	)
 	passwordHasher := auth.NewBcryptPasswordHasher(12)
 	tokenCache := auth.NewRedisTokenCache(redisClient, "auth")
+	notifier, err := initNotifier(cfg, log)
+	if err != nil {
+		log.WithError(err).Fatal("Failed to initialize notifier")
+	}
+	passwordScreener, err := auth.NewFilePasswordScreener(
+		cfg.Auth.BreachedPasswordDir,
+		cfg.Auth.PasswordDenylistFile,
+		cfg.Auth.BreachedPasswordMinCount,
+	)
+	if err != nil {
+		log.WithError(err).Fatal("Failed to load password screening data")
+	}
+	geoLocator, err := auth.NewFileGeoLocator(cfg.Auth.GeoIPDatabaseFile)
+	if err != nil {
+		log.WithError(err).Fatal("Failed to load GeoIP database")
+	}
 
 	// Initialize auth service
 	authConfig := &appAuth.Config{
@@ -90,6 +141,26 @@ func main() {
 		LoginRateLimitWindow: 15 * time.Minute,
 		SessionDuration:      7 * 24 * time.Hour,
 		MaxLoginAttempts:     5,
+		SecurityEventRetention: time.Duration(cfg.Auth.SecurityEventRetentionDays) * 24 * time.Hour,
+		AccountLockoutThreshold: cfg.Auth.AccountLockoutThreshold,
+		AccountLockoutWindow:    time.Duration(cfg.Auth.AccountLockoutWindow) * time.Second,
+		AccountLockoutBase:      time.Duration(cfg.Auth.AccountLockoutBase) * time.Second,
+		AccountLockoutMax:       time.Duration(cfg.Auth.AccountLockoutMax) * time.Second,
+		RequireNewDeviceConfirmation: cfg.Auth.RequireNewDeviceConfirmation,
+		GuestCreationLimit:           cfg.Auth.GuestCreationLimit,
+		GuestCreationWindow:          time.Duration(cfg.Auth.GuestCreationWindow) * time.Second,
+		GuestIdleTimeout:             time.Duration(cfg.Auth.GuestIdleDays) * 24 * time.Hour,
+		UsernameChangeCooldown:       time.Duration(cfg.Auth.UsernameChangeCooldownDays) * 24 * time.Hour,
+		UsernameHoldPeriod:           time.Duration(cfg.Auth.UsernameHoldDays) * 24 * time.Hour,
+		RegistrationPowIPThreshold:     cfg.Auth.RegistrationPowIPThreshold,
+		RegistrationPowSubnetThreshold: cfg.Auth.RegistrationPowSubnetThreshold,
+		RegistrationPowWindow:          time.Duration(cfg.Auth.RegistrationPowWindow) * time.Second,
+		RegistrationPowBaseDifficulty:  cfg.Auth.RegistrationPowBaseDifficulty,
+		RegistrationPowMaxDifficulty:   cfg.Auth.RegistrationPowMaxDifficulty,
+		LoginHistoryRetention:          time.Duration(cfg.Auth.LoginHistoryRetentionDays) * 24 * time.Hour,
+	}
+	for _, minutes := range cfg.Auth.PlaytimeWarningMinutes {
+		authConfig.PlaytimeWarnings = append(authConfig.PlaytimeWarnings, time.Duration(minutes)*time.Minute)
 	}
 
 	authService := appAuth.NewAuthService(
@@ -98,6 +169,17 @@ func main() {
 		tokenGenerator,
 		passwordHasher,
 		tokenCache,
+		securityEventRepo,
+		knownDeviceRepo,
+		notifier,
+		passwordScreener,
+		identityHistoryRepo,
+		eventPublisher,
+		serviceAccountRepo,
+		loginHistoryRepo,
+		geoLocator,
+		parentalControlsRepo,
+		playtimeRepo,
 		authConfig,
 		log,
 	)
@@ -126,7 +208,33 @@ func main() {
 	}()
 
 	// Setup NATS subscriptions
-	setupNATSSubscriptions(nc, authService, log)
+	serviceValidator := serviceauth.NewValidator(cfg.Auth.JWTServiceSecret, "mmorpg-auth")
+	setupNATSSubscriptions(nc, authService, serviceValidator, log)
+
+	validationResponder := natsAuth.NewValidationResponder(mq, authService, serviceValidator, log)
+	if err := validationResponder.Start(context.Background()); err != nil {
+		log.WithError(err).Fatal("Failed to start token validation responders")
+	}
+
+	presenceConsumer := natsAuth.NewPresenceConsumer(mq, authService, log)
+	if err := presenceConsumer.Start(context.Background()); err != nil {
+		log.WithError(err).Fatal("Failed to start character presence consumer")
+	}
+
+	characterCountConsumer := natsAuth.NewCharacterCountConsumer(mq, authService, log)
+	if err := characterCountConsumer.Start(context.Background()); err != nil {
+		log.WithError(err).Fatal("Failed to start character count consumer")
+	}
+
+	// Start background maintenance; the lock keeps replicas from reconciling counts together
+	authService.SetLocker(redisAdapter.NewRedisLocker(redisClient, "auth"))
+	maintenanceCtx, stopMaintenance := context.WithCancel(context.Background())
+	defer stopMaintenance()
+	go runSecurityEventMaintenance(maintenanceCtx, authService, log)
+	go runGuestCleanup(maintenanceCtx, authService, log)
+	go runLoginHistoryPurge(maintenanceCtx, authService, log)
+	go runPlaytimeEnforcement(maintenanceCtx, authService, log)
+	go runCharacterCountReconciliation(maintenanceCtx, authService, log)
 
 	// Wait for interrupt signal
 	quit := make(chan os.Signal, 1)
@@ -188,6 +296,26 @@ func initRedis(redisURL string) (*redisClient.Client, error) {
 	return client, nil
 }
 
+// initNotifier selects the notification delivery configured in auth.notifier
+func initNotifier(cfg *config.Config, log logger.Logger) (portsAuth.Notifier, error) {
+	switch cfg.Auth.Notifier {
+	case "smtp":
+		return auth.NewSMTPNotifier(auth.SMTPConfig{
+			Host:        cfg.Auth.SMTPHost,
+			Port:        cfg.Auth.SMTPPort,
+			Username:    cfg.Auth.SMTPUsername,
+			Password:    cfg.Auth.SMTPPassword,
+			From:        cfg.Auth.SMTPFrom,
+			LinkBaseURL: cfg.Auth.NotificationLinkURL,
+		})
+	case "log":
+		log.Warn("Notifications are written to the log and not delivered; use auth.notifier=smtp outside development")
+		return auth.NewLogNotifier(log), nil
+	default:
+		return nil, fmt.Errorf("unknown notifier %q", cfg.Auth.Notifier)
+	}
+}
+
 func setupRouter(handler *auth.HTTPHandler) *gin.Engine {
 	// Set gin mode based on environment
 	if os.Getenv("GIN_MODE") != "debug" {
@@ -213,10 +341,19 @@ func setupRouter(handler *auth.HTTPHandler) *gin.Engine {
 	v1 := router.Group("/api/v1")
 	{
 		auth := v1.Group("/auth")
+		auth.Use(handler.ClientContext())
 		{
 			auth.POST("/register", handler.Register)
+			auth.GET("/register/challenge", handler.RegistrationChallenge)
 			auth.POST("/login", handler.Login)
 			auth.POST("/refresh", handler.RefreshToken)
+			auth.POST("/unlock/request", handler.RequestAccountUnlock)
+			auth.POST("/unlock", handler.UnlockAccount)
+			auth.POST("/devices/confirm", handler.ConfirmDevice)
+			auth.POST("/guest", handler.GuestLogin)
+			auth.POST("/email/confirm", handler.ConfirmEmailChange)
+			auth.POST("/guardian/confirm", handler.ConfirmGuardianLink)
+			auth.POST("/service/token", handler.IssueServiceToken)
 			
 			// Protected routes
 			protected := auth.Group("")
@@ -224,6 +361,42 @@ func setupRouter(handler *auth.HTTPHandler) *gin.Engine {
 			{
 				protected.POST("/logout", handler.Logout)
 				protected.GET("/verify", handler.VerifyToken)
+				protected.GET("/devices", handler.ListDevices)
+				protected.DELETE("/devices/:id", handler.RemoveDevice)
+				protected.POST("/guest/upgrade", handler.UpgradeGuest)
+				protected.PUT("/me/username", handler.ChangeUsername)
+				protected.POST("/me/email", handler.RequestEmailChange)
+				protected.GET("/me/identity-history", handler.ListIdentityHistory)
+				protected.GET("/me/logins", handler.ListMyLogins)
+				protected.GET("/me/playtime", handler.GetMyPlaytime)
+				protected.POST("/guardian/wards", handler.AddWard)
+				protected.GET("/guardian/wards", handler.ListWards)
+				protected.GET("/guardian/wards/:id", handler.GetWard)
+				protected.PUT("/guardian/wards/:id/limits", handler.SetWardLimits)
+				protected.DELETE("/guardian/wards/:id", handler.RemoveWard)
+			}
+
+			// Support and admin routes
+			admin := auth.Group("/admin")
+			admin.Use(handler.Middleware())
+			{
+				support := admin.Group("")
+				support.Use(handler.RequireSupport())
+				{
+					support.GET("/security-events", handler.ListSecurityEvents)
+					support.GET("/users/:id/logins", handler.ListUserLogins)
+				}
+
+				adminOnly := admin.Group("")
+				adminOnly.Use(handler.RequireAdmin())
+				{
+					adminOnly.PUT("/users/:id/roles", handler.UpdateUserRoles)
+					adminOnly.PUT("/users/:id/status", handler.SetAccountStatus)
+					adminOnly.GET("/service-accounts", handler.ListServiceAccounts)
+					adminOnly.POST("/service-accounts", handler.CreateServiceAccount)
+					adminOnly.PUT("/service-accounts/:client_id", handler.UpdateServiceAccount)
+					adminOnly.POST("/service-accounts/:client_id/rotate", handler.RotateServiceAccountSecret)
+				}
 			}
 		}
 	}
@@ -231,9 +404,111 @@ func setupRouter(handler *auth.HTTPHandler) *gin.Engine {
 	return router
 }
 
-func setupNATSSubscriptions(nc *nats.Conn, authService portsAuth.AuthService, log logger.Logger) {
+// runSecurityEventMaintenance keeps audit partitions ahead of time and enforces retention
+func runSecurityEventMaintenance(ctx context.Context, authService *appAuth.AuthServiceImpl, log logger.Logger) {
+	ticker := time.NewTicker(6 * time.Hour)
+	defer ticker.Stop()
+
+	for {
+		if err := authService.MaintainSecurityEvents(ctx); err != nil {
+			log.WithError(err).Error("Failed to maintain security event partitions")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runGuestCleanup periodically deletes guest accounts past the idle timeout
+func runGuestCleanup(ctx context.Context, authService *appAuth.AuthServiceImpl, log logger.Logger) {
+	ticker := time.NewTicker(1 * time.Hour)
+	defer ticker.Stop()
+
+	for {
+		if _, err := authService.CleanupIdleGuests(ctx); err != nil {
+			log.WithError(err).Error("Failed to clean up idle guests")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runLoginHistoryPurge periodically deletes login history past the retention period
+func runLoginHistoryPurge(ctx context.Context, authService *appAuth.AuthServiceImpl, log logger.Logger) {
+	ticker := time.NewTicker(6 * time.Hour)
+	defer ticker.Stop()
+
+	for {
+		if _, err := authService.PurgeLoginHistory(ctx); err != nil {
+			log.WithError(err).Error("Failed to purge login history")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runPlaytimeEnforcement periodically accounts play time of wards online and logs out those over their limits
+func runPlaytimeEnforcement(ctx context.Context, authService *appAuth.AuthServiceImpl, log logger.Logger) {
+	ticker := time.NewTicker(1 * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		if err := authService.EnforcePlaytime(ctx); err != nil {
+			log.WithError(err).Error("Failed to enforce play time limits")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runCharacterCountReconciliation periodically corrects character counts that missed events
+func runCharacterCountReconciliation(ctx context.Context, authService *appAuth.AuthServiceImpl, log logger.Logger) {
+	ticker := time.NewTicker(1 * time.Hour)
+	defer ticker.Stop()
+
+	for {
+		if _, err := authService.ReconcileCharacterCounts(ctx); err != nil {
+			log.WithError(err).Error("Failed to reconcile character counts")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// requireService only runs handler for messages carrying a service token with the given scope
+func requireService(validator *serviceauth.Validator, scope string, log logger.Logger, handler nats.MsgHandler) nats.MsgHandler {
+	return func(m *nats.Msg) {
+		if _, err := validator.Authorize(m.Header.Get(serviceauth.AuthorizationHeader), scope); err != nil {
+			log.WithError(err).Warnf("Rejected unauthenticated request on %s", m.Subject)
+			m.Respond([]byte(fmt.Sprintf(`{"error":"%s"}`, err.Error())))
+			return
+		}
+		handler(m)
+	}
+}
+
+func setupNATSSubscriptions(nc *nats.Conn, authService portsAuth.AuthService, validator *serviceauth.Validator, log logger.Logger) {
 	// Subscribe to auth validation requests from other services
-	nc.Subscribe("auth.validate", func(m *nats.Msg) {
+	nc.Subscribe("auth.validate", requireService(validator, authDomain.ScopeAuthValidate, log, func(m *nats.Msg) {
 		// Parse token from message
 		token := string(m.Data)
 		
@@ -248,10 +523,10 @@ func setupNATSSubscriptions(nc *nats.Conn, authService portsAuth.AuthService, lo
 		response := fmt.Sprintf(`{"valid":true,"user_id":"%s","session_id":"%s","roles":%v}`,
 			claims.UserID, claims.SessionID, claims.Roles)
 		m.Respond([]byte(response))
-	})
+	}))
 
 	// Subscribe to user info requests
-	nc.Subscribe("auth.user.get", func(m *nats.Msg) {
+	nc.Subscribe("auth.user.get", requireService(validator, authDomain.ScopeUserRead, log, func(m *nats.Msg) {
 		userID := string(m.Data)
 		
 		user, err := authService.GetUser(context.Background(), userID)
@@ -264,7 +539,7 @@ func setupNATSSubscriptions(nc *nats.Conn, authService portsAuth.AuthService, lo
 		response := fmt.Sprintf(`{"id":"%s","email":"%s","username":"%s","roles":%v}`,
 			user.ID, user.Email, user.Username, user.Roles)
 		m.Respond([]byte(response))
-	})
+	}))
 
 	log.Info("NATS subscriptions established")
 }
\ No newline at end of file
@@ -15,8 +15,13 @@
 	"github.com/mmorpg-template/backend/internal/adapters/character"
 	redisCharacter "github.com/mmorpg-template/backend/internal/adapters/character/redis"
 	natsCharacter "github.com/mmorpg-template/backend/internal/adapters/character/nats"
+	natsAuth "github.com/mmorpg-template/backend/internal/adapters/auth/nats"
 	natsAdapter "github.com/mmorpg-template/backend/internal/adapters/nats"
+	redisAdapter "github.com/mmorpg-template/backend/internal/adapters/redis"
+	"github.com/mmorpg-template/backend/internal/adapters/serviceauth"
 	appCharacter "github.com/mmorpg-template/backend/internal/application/character"
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+	characterDomain "github.com/mmorpg-template/backend/internal/domain/character"
 	"github.com/mmorpg-template/backend/internal/ports"
 	portsCharacter "github.com/mmorpg-template/backend/internal/ports/character"
 	"github.com/mmorpg-template/backend/internal/config"
@@ -101,13 +106,38 @@ func main() {
 		nil, // Use default TTL configuration
 	)
 
+	selectionPolicy, err := characterDomain.ParseSelectionPolicy(cfg.Character.SelectionPolicy)
+	if err != nil {
+		log.WithError(err).Fatal("Invalid character selection policy")
+	}
+
 	// Initialize character service
 	characterConfig := &appCharacter.Config{
 		MaxCharactersPerUser: 5,
+		MaxCharactersPerGuest: cfg.Character.MaxCharactersPerGuest,
 		MaxCharacterNameLength: 30,
 		MinCharacterNameLength: 3,
 		DefaultStartingLevel: 1,
 		DefaultStartingExperience: 0,
+		Respec: characterDomain.RespecPolicy{
+			FreeRespecs:        cfg.Character.RespecFreeCount,
+			BaseCost:           cfg.Character.RespecBaseCost,
+			CostMultiplier:     cfg.Character.RespecCostMultiplier,
+			MaxCost:            cfg.Character.RespecMaxCost,
+			BaseCooldown:       time.Duration(cfg.Character.RespecBaseCooldownMinutes) * time.Minute,
+			CooldownMultiplier: cfg.Character.RespecCooldownMultiplier,
+			MaxCooldown:        time.Duration(cfg.Character.RespecMaxCooldownHours) * time.Hour,
+			UndoWindow:         time.Duration(cfg.Character.StatUndoWindowMinutes) * time.Minute,
+			MaxUndo:            cfg.Character.StatUndoMaxAllocations,
+		},
+		RenameCooldown: time.Duration(cfg.Character.RenameCooldownDays) * 24 * time.Hour,
+		NameHoldPeriod: time.Duration(cfg.Character.NameHoldDays) * 24 * time.Hour,
+		PurgeBatchSize: cfg.Character.PurgeBatchSize,
+
+		RestorePointRetention: time.Duration(cfg.Character.RestorePointRetentionDays) * 24 * time.Hour,
+
+		SelectionLease:  time.Duration(cfg.Character.PlaySessionTimeoutSeconds) * time.Second,
+		SelectionPolicy: selectionPolicy,
 	}
 
 	characterService := appCharacter.NewCharacterService(
@@ -121,6 +151,50 @@ func main() {
 		log,
 	)
 
+	// Record stat allocations and respecs alongside stat changes
+	characterService.SetStatsTransactor(character.NewPostgresStatsTransactor(database))
+
+	// Record renames and hold released names
+	characterService.SetNameHistory(character.NewPostgresNameHistoryRepository(database))
+
+	// Load progression data; the file is re-read when it changes
+	progression, err := character.NewFileProgressionProvider(cfg.Character.ProgressionFile, log)
+	if err != nil {
+		log.WithError(err).Fatal("Failed to load progression data")
+	}
+	characterService.SetProgression(progression)
+	dataCtx, stopData := context.WithCancel(context.Background())
+	defer stopData()
+	go progression.Watch(dataCtx, time.Duration(cfg.Character.ProgressionReloadSeconds)*time.Second)
+	log.WithFields(map[string]interface{}{
+		"version":   progression.Progression().Version,
+		"max_level": progression.Progression().MaxLevel,
+	}).Info("Loaded progression table")
+
+	// Load class and race definitions; the file is re-read when it changes
+	definitions, err := character.NewFileDefinitionsProvider(cfg.Character.DefinitionsFile, log)
+	if err != nil {
+		log.WithError(err).Fatal("Failed to load class and race definitions")
+	}
+	characterService.SetDefinitions(definitions)
+	go definitions.Watch(dataCtx, time.Duration(cfg.Character.DefinitionsReloadSeconds)*time.Second)
+	log.WithFields(map[string]interface{}{
+		"version": definitions.Definitions().Version,
+		"classes": len(definitions.Definitions().Classes),
+		"races":   len(definitions.Definitions().Races),
+	}).Info("Loaded class and race definitions")
+
+	// Load the name policy; the file is re-read when it changes
+	namePolicy, err := character.NewFileNamePolicyProvider(cfg.Character.NamePolicyFile, log)
+	if err != nil {
+		log.WithError(err).Fatal("Failed to load name policy")
+	}
+	characterService.SetNamePolicy(namePolicy)
+	go namePolicy.Watch(dataCtx, time.Duration(cfg.Character.NamePolicyReloadSeconds)*time.Second)
+	log.WithFields(map[string]interface{}{
+		"version": namePolicy.NamePolicy().Version,
+	}).Info("Loaded name policy")
+
 	// Initialize JWT middleware
 	jwtConfig := &character.JWTConfig{
 		AccessSecret: cfg.Auth.JWTAccessSecret,
@@ -128,6 +202,40 @@ func main() {
 	}
 	jwtMiddleware := character.NewJWTMiddleware(jwtConfig, log)
 
+	// Confirm tokens with the auth service so logged-out and revoked tokens are rejected
+	var tokenSource *serviceauth.TokenSource
+	if cfg.Auth.ServiceClientID != "" {
+		tokenSource = serviceauth.NewTokenSource(
+			cfg.Auth.ServiceURL,
+			cfg.Auth.ServiceClientID,
+			cfg.Auth.ServiceClientSecret,
+			[]string{auth.ScopeAuthValidate},
+		)
+		validationClient := natsAuth.NewValidationClient(mq, tokenSource,
+			time.Duration(cfg.Auth.TokenValidationCacheSeconds)*time.Second)
+		jwtMiddleware.SetTokenValidator(validationClient)
+		characterService.SetPlaytimeChecker(validationClient)
+	} else {
+		if cfg.Character.EnforceParentalControls {
+			log.Fatal("Service credentials are required to enforce parental controls; set auth.serviceClientID or disable character.enforceParentalControls")
+		}
+		log.Warn("No service credentials configured; user tokens are only checked locally and stay valid after logout")
+		log.Error("Parental controls play time limits are not enforced at character selection")
+	}
+
+	// Let support staff move characters between accounts once their trades and mail settle
+	characterService.SetTransfers(character.NewPostgresTransferRepository(database))
+	characterService.SetPendingActivityChecker(natsCharacter.NewPendingActivityClient(mq, tokenSource, nil, cfg.Character.OptionalPendingActivity, log))
+
+	// Let support staff restore characters from exported snapshots
+	characterService.SetImporter(character.NewPostgresCharacterImporter(database))
+
+	// Keep restore points so game masters can roll characters back
+	characterService.SetRestorePoints(character.NewPostgresRestorePointRepository(database))
+
+	// Track play sessions so play time accumulates per character and per day
+	characterService.SetPlaySessions(character.NewPostgresPlaySessionRepository(database))
+
 	// Initialize HTTP handler
 	httpHandler := character.NewHTTPHandler(characterService, jwtMiddleware, log)
 
@@ -158,7 +266,40 @@ func main() {
 	}()
 
 	// Setup NATS subscriptions
-	setupNATSSubscriptions(mq, characterService, log)
+	serviceValidator := serviceauth.NewValidator(cfg.Auth.JWTServiceSecret, "mmorpg-auth")
+	setupNATSSubscriptions(mq, characterService, serviceValidator, log)
+
+	commandResponder := natsCharacter.NewCommandResponder(mq, characterService, serviceValidator, log)
+	if err := commandResponder.Start(context.Background()); err != nil {
+		log.WithError(err).Fatal("Failed to start character command responders")
+	}
+
+	// Take characters offline when their login session ends
+	loginSessionConsumer := natsCharacter.NewLoginSessionConsumer(mq, characterService, log)
+	if err := loginSessionConsumer.Start(context.Background()); err != nil {
+		log.WithError(err).Fatal("Failed to start login session consumer")
+	}
+
+	// Drop what is held for the characters of guests deleted for inactivity
+	guestDeletedConsumer := natsCharacter.NewGuestDeletedConsumer(mq, characterService, log)
+	if err := guestDeletedConsumer.Start(context.Background()); err != nil {
+		log.WithError(err).Fatal("Failed to start guest deleted consumer")
+	}
+
+	// Purge expired soft-deleted characters; the lock keeps replicas from running it together
+	characterService.SetLocker(redisAdapter.NewRedisLocker(redisClient, "character"))
+	maintenanceCtx, stopMaintenance := context.WithCancel(context.Background())
+	defer stopMaintenance()
+	if cfg.Character.PurgeIntervalMinutes > 0 {
+		go runCharacterPurge(maintenanceCtx, characterService, time.Duration(cfg.Character.PurgeIntervalMinutes)*time.Minute, log)
+	}
+	if cfg.Character.RestorePointIntervalMinutes > 0 {
+		go runRestorePointCapture(maintenanceCtx, characterService, time.Duration(cfg.Character.RestorePointIntervalMinutes)*time.Minute, log)
+	}
+	if cfg.Character.PlaySessionSweepSeconds > 0 {
+		go runPlaySessionSweep(maintenanceCtx, characterService, time.Duration(cfg.Character.PlaySessionSweepSeconds)*time.Second,
+			time.Duration(cfg.Character.PlaySessionTimeoutSeconds)*time.Second, log)
+	}
 
 	// Wait for interrupt signal
 	quit := make(chan os.Signal, 1)
@@ -178,6 +319,64 @@ func main() {
 	log.Info("Character service stopped")
 }
 
+// runCharacterPurge periodically removes soft-deleted characters past their recovery period
+func runCharacterPurge(ctx context.Context, characterService *appCharacter.CharacterService, interval time.Duration, log logger.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := characterService.PurgeDeletedCharacters(ctx); err != nil {
+			log.WithError(err).Error("Failed to purge deleted characters")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runRestorePointCapture periodically takes restore points of characters that changed since the last run
+func runRestorePointCapture(ctx context.Context, characterService *appCharacter.CharacterService, interval time.Duration, log logger.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	since := time.Now().Add(-interval)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		started := time.Now()
+		if _, err := characterService.CaptureRestorePoints(ctx, since); err != nil {
+			log.WithError(err).Error("Failed to capture restore points")
+			continue
+		}
+		since = started
+	}
+}
+
+// runPlaySessionSweep periodically closes play sessions whose game clients stopped sending heartbeats
+func runPlaySessionSweep(ctx context.Context, characterService *appCharacter.CharacterService, interval, timeout time.Duration, log logger.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if _, err := characterService.CloseStalePlaySessions(ctx, time.Now().Add(-timeout)); err != nil {
+			log.WithError(err).Error("Failed to close stale play sessions")
+		}
+	}
+}
+
 func initDatabase(databaseURL string) (*sql.DB, error) {
 	db, err := sql.Open("postgres", databaseURL)
 	if err != nil {
@@ -246,9 +445,9 @@ func setupRouter(handler *character.HTTPHandler) *gin.Engine {
 	return router
 }
 
-func setupNATSSubscriptions(mq ports.MessageQueue, characterService portsCharacter.CharacterService, log logger.Logger) {
-	// Subscribe to character validation requests
-	mq.Subscribe(context.Background(), "character.validate", func(msg *ports.QueueMessage) error {
+func setupNATSSubscriptions(mq ports.MessageQueue, characterService portsCharacter.CharacterService, validator *serviceauth.Validator, log logger.Logger) {
+	// Subscribe to character validation requests; callers must present a service token
+	mq.Subscribe(context.Background(), "character.validate", validator.RequireScopeNATS(mq, func(msg *ports.QueueMessage) error {
 		// Parse character ID from message
 		characterID := string(msg.Data)
 		
@@ -265,10 +464,10 @@ func setupNATSSubscriptions(mq ports.MessageQueue, characterService portsCharact
 		// Would need to implement reply mechanism
 		log.Infof("Validated character: %s", response)
 		return nil
-	})
+	}, auth.ScopeCharacterRead))
 
 	// Subscribe to character list requests for a user
-	mq.Subscribe(context.Background(), "character.list.byuser", func(msg *ports.QueueMessage) error {
+	mq.Subscribe(context.Background(), "character.list.byuser", validator.RequireScopeNATS(mq, func(msg *ports.QueueMessage) error {
 		userID := string(msg.Data)
 		
 		characters, err := characterService.ListCharactersByUser(context.Background(), userID)
@@ -280,7 +479,7 @@ func setupNATSSubscriptions(mq ports.MessageQueue, characterService portsCharact
 		response := fmt.Sprintf(`{"count":%d}`, len(characters))
 		log.Infof("Listed characters for user %s: %s", userID, response)
 		return nil
-	})
+	}, auth.ScopeCharacterRead))
 
 	log.Info("NATS subscriptions established for character service")
 }
\ No newline at end of file
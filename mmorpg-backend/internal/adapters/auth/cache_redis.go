@@ -161,6 +161,99 @@ func (c *RedisTokenCache) DeletePasswordResetToken(ctx context.Context, token st
 	return nil
 }
 
+// SetAccountLock locks an account until the given time
+func (c *RedisTokenCache) SetAccountLock(ctx context.Context, userID string, lockedUntil time.Time) error {
+	key := fmt.Sprintf("%s:account_lock:%s", c.prefix, userID)
+	ttl := time.Until(lockedUntil)
+	if ttl <= 0 {
+		return nil
+	}
+	err := c.client.Set(ctx, key, lockedUntil.Unix(), ttl).Err()
+	if err != nil {
+		return fmt.Errorf("failed to set account lock: %w", err)
+	}
+	return nil
+}
+
+// GetAccountLock returns when the account lock expires (zero time if not locked)
+func (c *RedisTokenCache) GetAccountLock(ctx context.Context, userID string) (time.Time, error) {
+	key := fmt.Sprintf("%s:account_lock:%s", c.prefix, userID)
+	unix, err := c.client.Get(ctx, key).Int64()
+	if err != nil {
+		if err == redis.Nil {
+			return time.Time{}, nil
+		}
+		return time.Time{}, fmt.Errorf("failed to get account lock: %w", err)
+	}
+	return time.Unix(unix, 0), nil
+}
+
+// DeleteAccountLock removes an account lock
+func (c *RedisTokenCache) DeleteAccountLock(ctx context.Context, userID string) error {
+	key := fmt.Sprintf("%s:account_lock:%s", c.prefix, userID)
+	err := c.client.Del(ctx, key).Err()
+	if err != nil {
+		return fmt.Errorf("failed to delete account lock: %w", err)
+	}
+	return nil
+}
+
+// SetVerificationToken stores a one-time token hash for a purpose
+func (c *RedisTokenCache) SetVerificationToken(ctx context.Context, purpose auth.TokenPurpose, tokenHash string, value string, expiration time.Duration) error {
+	key := fmt.Sprintf("%s:verify:%s:%s", c.prefix, purpose, tokenHash)
+	err := c.client.Set(ctx, key, value, expiration).Err()
+	if err != nil {
+		return fmt.Errorf("failed to cache verification token: %w", err)
+	}
+	return nil
+}
+
+// GetVerificationToken retrieves the value stored for a one-time token hash
+func (c *RedisTokenCache) GetVerificationToken(ctx context.Context, purpose auth.TokenPurpose, tokenHash string) (string, error) {
+	key := fmt.Sprintf("%s:verify:%s:%s", c.prefix, purpose, tokenHash)
+	value, err := c.client.Get(ctx, key).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return "", auth.ErrInvalidToken
+		}
+		return "", fmt.Errorf("failed to get verification token: %w", err)
+	}
+	return value, nil
+}
+
+// DeleteVerificationToken removes a one-time token
+func (c *RedisTokenCache) DeleteVerificationToken(ctx context.Context, purpose auth.TokenPurpose, tokenHash string) error {
+	key := fmt.Sprintf("%s:verify:%s:%s", c.prefix, purpose, tokenHash)
+	err := c.client.Del(ctx, key).Err()
+	if err != nil {
+		return fmt.Errorf("failed to delete verification token: %w", err)
+	}
+	return nil
+}
+
+// confirmSideScript marks a side in the confirmation hash and, when the other side is
+// already there, deletes the hash so no later call can complete it again
+var confirmSideScript = redis.NewScript(`
+redis.call('HSET', KEYS[1], ARGV[1], '1')
+redis.call('PEXPIRE', KEYS[1], ARGV[3])
+if redis.call('HEXISTS', KEYS[1], ARGV[2]) == 1 then
+	redis.call('DEL', KEYS[1])
+	return 1
+end
+return 0
+`)
+
+// ConfirmSide atomically marks one side of a two-sided confirmation and reports whether
+// this call completed it
+func (c *RedisTokenCache) ConfirmSide(ctx context.Context, purpose auth.TokenPurpose, id string, side, otherSide string, expiration time.Duration) (bool, error) {
+	key := fmt.Sprintf("%s:confirm:%s:%s", c.prefix, purpose, id)
+	completed, err := confirmSideScript.Run(ctx, c.client, []string{key}, side, otherSide, expiration.Milliseconds()).Int()
+	if err != nil {
+		return false, fmt.Errorf("failed to confirm %s: %w", purpose, err)
+	}
+	return completed == 1, nil
+}
+
 // CacheSession is a helper method to cache a session struct
 func (c *RedisTokenCache) CacheSession(ctx context.Context, session *auth.Session) error {
 	data, err := json.Marshal(session)
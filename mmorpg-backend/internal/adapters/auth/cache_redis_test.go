@@ -0,0 +1,56 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisTokenCache_ConfirmSide(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	cache := NewRedisTokenCache(client, "test")
+
+	confirm := func(side, other string) bool {
+		complete, err := cache.ConfirmSide(ctx, auth.TokenPurposeEmailChange, "change-1", side, other, time.Hour)
+		require.NoError(t, err)
+		return complete
+	}
+
+	t.Run("second side completes", func(t *testing.T) {
+		assert.False(t, confirm("old", "new"))
+		assert.False(t, confirm("old", "new"))
+		assert.True(t, confirm("new", "old"))
+		assert.False(t, server.Exists("test:confirm:email_change:change-1"))
+	})
+
+	t.Run("sides confirmed together complete exactly once", func(t *testing.T) {
+		var (
+			wg        sync.WaitGroup
+			mu        sync.Mutex
+			completed int
+		)
+		for _, sides := range [][2]string{{"old", "new"}, {"new", "old"}} {
+			wg.Add(1)
+			go func(side, other string) {
+				defer wg.Done()
+				if confirm(side, other) {
+					mu.Lock()
+					completed++
+					mu.Unlock()
+				}
+			}(sides[0], sides[1])
+		}
+		wg.Wait()
+		assert.Equal(t, 1, completed)
+	})
+}
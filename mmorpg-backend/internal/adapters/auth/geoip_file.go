@@ -0,0 +1,106 @@
+package auth
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+	portsAuth "github.com/mmorpg-template/backend/internal/ports/auth"
+)
+
+// geoRange maps an inclusive IP range to a location
+type geoRange struct {
+	start    net.IP
+	end      net.IP
+	location auth.GeoLocation
+}
+
+// FileGeoLocator implements GeoLocator using an offline CSV database loaded into memory.
+//
+// Each line holds "start_ip,end_ip,country_code[,region[,city]]" for an inclusive
+// IPv4 or IPv6 range, the layout of the free country and city "lite" exports.
+// Blank lines and lines starting with '#' are ignored. Ranges must not overlap.
+type FileGeoLocator struct {
+	ranges []geoRange
+}
+
+// NewFileGeoLocator loads a GeoIP database. An empty path returns a locator that knows no addresses.
+func NewFileGeoLocator(path string) (portsAuth.GeoLocator, error) {
+	locator := &FileGeoLocator{}
+	if path == "" {
+		return locator, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open GeoIP database: %w", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.Comment = '#'
+	reader.TrimLeadingSpace = true
+
+	for line := 1; ; line++ {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read GeoIP database: %w", err)
+		}
+		if len(fields) < 3 {
+			return nil, fmt.Errorf("GeoIP database line %d: expected at least 3 fields", line)
+		}
+
+		start, end := net.ParseIP(fields[0]).To16(), net.ParseIP(fields[1]).To16()
+		if start == nil || end == nil || bytes.Compare(start, end) > 0 {
+			return nil, fmt.Errorf("GeoIP database line %d: invalid range %s-%s", line, fields[0], fields[1])
+		}
+
+		r := geoRange{
+			start:    start,
+			end:      end,
+			location: auth.GeoLocation{CountryCode: strings.ToUpper(fields[2])},
+		}
+		if len(fields) > 3 {
+			r.location.Region = fields[3]
+		}
+		if len(fields) > 4 {
+			r.location.City = fields[4]
+		}
+		locator.ranges = append(locator.ranges, r)
+	}
+
+	sort.Slice(locator.ranges, func(i, j int) bool {
+		return bytes.Compare(locator.ranges[i].start, locator.ranges[j].start) < 0
+	})
+
+	return locator, nil
+}
+
+// Locate returns the location of an IP address, or nil if no range covers it
+func (l *FileGeoLocator) Locate(ipAddress string) *auth.GeoLocation {
+	ip := net.ParseIP(ipAddress).To16()
+	if ip == nil {
+		return nil
+	}
+
+	// Find the last range starting at or before the address
+	i := sort.Search(len(l.ranges), func(i int) bool {
+		return bytes.Compare(l.ranges[i].start, ip) > 0
+	}) - 1
+	if i < 0 || bytes.Compare(ip, l.ranges[i].end) > 0 {
+		return nil
+	}
+
+	location := l.ranges[i].location
+	return &location
+}
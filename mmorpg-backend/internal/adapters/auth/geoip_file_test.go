@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileGeoLocator(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "geoip.csv")
+	require.NoError(t, os.WriteFile(path, []byte(
+		"# start,end,country,region,city\n"+
+			"81.2.69.0,81.2.69.255,gb,England,London\n"+
+			"1.0.0.0,1.0.0.255,AU\n"+
+			"2001:db8::,2001:db8::ffff,DE,\"Berlin, State\",Berlin\n"), 0o644))
+
+	locator, err := NewFileGeoLocator(path)
+	require.NoError(t, err)
+
+	t.Run("finds IPv4 range with city", func(t *testing.T) {
+		assert.Equal(t, &auth.GeoLocation{CountryCode: "GB", Region: "England", City: "London"}, locator.Locate("81.2.69.142"))
+	})
+
+	t.Run("country-only range", func(t *testing.T) {
+		assert.Equal(t, &auth.GeoLocation{CountryCode: "AU"}, locator.Locate("1.0.0.1"))
+	})
+
+	t.Run("range bounds are inclusive", func(t *testing.T) {
+		assert.NotNil(t, locator.Locate("81.2.69.0"))
+		assert.NotNil(t, locator.Locate("81.2.69.255"))
+		assert.Nil(t, locator.Locate("81.2.70.0"))
+	})
+
+	t.Run("finds IPv6 range", func(t *testing.T) {
+		location := locator.Locate("2001:db8::1")
+		require.NotNil(t, location)
+		assert.Equal(t, "Berlin, State", location.Region)
+	})
+
+	t.Run("unknown and invalid addresses", func(t *testing.T) {
+		assert.Nil(t, locator.Locate("10.0.0.1"))
+		assert.Nil(t, locator.Locate("0.0.0.1"))
+		assert.Nil(t, locator.Locate("not-an-ip"))
+	})
+
+	t.Run("empty path disables lookups", func(t *testing.T) {
+		empty, err := NewFileGeoLocator("")
+		require.NoError(t, err)
+		assert.Nil(t, empty.Locate("81.2.69.142"))
+	})
+
+	t.Run("invalid range fails at startup", func(t *testing.T) {
+		bad := filepath.Join(dir, "bad.csv")
+		require.NoError(t, os.WriteFile(bad, []byte("1.0.0.255,1.0.0.0,AU\n"), 0o644))
+		_, err := NewFileGeoLocator(bad)
+		assert.Error(t, err)
+	})
+}
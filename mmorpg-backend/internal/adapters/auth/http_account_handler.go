@@ -0,0 +1,133 @@
+package auth
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mmorpg-template/backend/pkg/proto"
+)
+
+// ChangeUsernameRequest is the body for PUT /auth/me/username
+type ChangeUsernameRequest struct {
+	Username string `json:"username" binding:"required"`
+}
+
+// ChangeEmailRequest is the body for POST /auth/me/email
+type ChangeEmailRequest struct {
+	Email    string `json:"email" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// ConfirmEmailChangeRequest is the body for POST /auth/email/confirm
+type ConfirmEmailChangeRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// IdentityChangeResponse is the JSON representation of a username or email change
+type IdentityChangeResponse struct {
+	Field     string     `json:"field"`
+	OldValue  string     `json:"old_value"`
+	NewValue  string     `json:"new_value"`
+	HoldUntil *time.Time `json:"hold_until,omitempty"`
+	ChangedAt time.Time  `json:"changed_at"`
+}
+
+// ChangeUsername renames the current user
+func (h *HTTPHandler) ChangeUsername(c *gin.Context) {
+	claims, ok := h.getClaimsFromContext(c)
+	if !ok {
+		h.respondWithError(c, http.StatusUnauthorized, proto.ErrorCode_ERROR_CODE_UNAUTHORIZED, "Unauthorized")
+		return
+	}
+
+	var req ChangeUsernameRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondWithError(c, http.StatusBadRequest, proto.ErrorCode_ERROR_CODE_INVALID_REQUEST, "Invalid request format")
+		return
+	}
+
+	user, err := h.authService.ChangeUsername(c.Request.Context(), claims.UserID, req.Username)
+	if err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":   true,
+		"user_info": h.userInfo(user),
+	})
+}
+
+// RequestEmailChange sends confirmation links to the current and new email addresses
+func (h *HTTPHandler) RequestEmailChange(c *gin.Context) {
+	claims, ok := h.getClaimsFromContext(c)
+	if !ok {
+		h.respondWithError(c, http.StatusUnauthorized, proto.ErrorCode_ERROR_CODE_UNAUTHORIZED, "Unauthorized")
+		return
+	}
+
+	var req ChangeEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondWithError(c, http.StatusBadRequest, proto.ErrorCode_ERROR_CODE_INVALID_REQUEST, "Invalid request format")
+		return
+	}
+
+	if err := h.authService.RequestEmailChange(c.Request.Context(), claims.UserID, req.Email, req.Password); err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"success": true})
+}
+
+// ConfirmEmailChange confirms one address of a pending email change
+func (h *HTTPHandler) ConfirmEmailChange(c *gin.Context) {
+	var req ConfirmEmailChangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondWithError(c, http.StatusBadRequest, proto.ErrorCode_ERROR_CODE_INVALID_REQUEST, "Invalid request format")
+		return
+	}
+
+	completed, err := h.authService.ConfirmEmailChange(c.Request.Context(), req.Token)
+	if err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":   true,
+		"completed": completed,
+	})
+}
+
+// ListIdentityHistory returns the current user's username and email changes
+func (h *HTTPHandler) ListIdentityHistory(c *gin.Context) {
+	claims, ok := h.getClaimsFromContext(c)
+	if !ok {
+		h.respondWithError(c, http.StatusUnauthorized, proto.ErrorCode_ERROR_CODE_UNAUTHORIZED, "Unauthorized")
+		return
+	}
+
+	changes, err := h.authService.ListIdentityHistory(c.Request.Context(), claims.UserID)
+	if err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	resp := make([]IdentityChangeResponse, 0, len(changes))
+	for _, change := range changes {
+		resp = append(resp, IdentityChangeResponse{
+			Field:     string(change.Field),
+			OldValue:  change.OldValue,
+			NewValue:  change.NewValue,
+			HoldUntil: change.HoldUntil,
+			ChangedAt: change.ChangedAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"changes": resp,
+	})
+}
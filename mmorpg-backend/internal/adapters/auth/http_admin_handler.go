@@ -0,0 +1,183 @@
+package auth
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+	"github.com/mmorpg-template/backend/pkg/proto"
+)
+
+// Roles allowed to use the support/admin endpoints
+const (
+	RoleAdmin   = "admin"
+	RoleSupport = "support"
+)
+
+// UpdateRolesRequest is the body for PUT /admin/users/:id/roles
+type UpdateRolesRequest struct {
+	Roles  []string `json:"roles" binding:"required"`
+	Reason string   `json:"reason"`
+}
+
+// SetAccountStatusRequest is the body for PUT /admin/users/:id/status
+type SetAccountStatusRequest struct {
+	Status string `json:"status" binding:"required"`
+	Reason string `json:"reason"`
+}
+
+// SecurityEventResponse is the JSON representation of a security event
+type SecurityEventResponse struct {
+	ID           string            `json:"id"`
+	EventType    string            `json:"event_type"`
+	Outcome      string            `json:"outcome"`
+	ActorID      string            `json:"actor_id,omitempty"`
+	TargetUserID string            `json:"target_user_id,omitempty"`
+	SessionID    string            `json:"session_id,omitempty"`
+	IPAddress    string            `json:"ip_address,omitempty"`
+	DeviceID     string            `json:"device_id,omitempty"`
+	UserAgent    string            `json:"user_agent,omitempty"`
+	Reason       string            `json:"reason,omitempty"`
+	Metadata     map[string]string `json:"metadata,omitempty"`
+	CreatedAt    time.Time         `json:"created_at"`
+}
+
+// RequireAdmin restricts a route group to administrators
+func (h *HTTPHandler) RequireAdmin() gin.HandlerFunc {
+	return h.RequireRole(RoleAdmin)
+}
+
+// RequireSupport restricts a route group to support staff and administrators
+func (h *HTTPHandler) RequireSupport() gin.HandlerFunc {
+	return h.RequireRole(RoleAdmin, RoleSupport)
+}
+
+// ListSecurityEvents returns audit records filtered by user, actor, IP, device, type and time range
+func (h *HTTPHandler) ListSecurityEvents(c *gin.Context) {
+	filter := &auth.SecurityEventFilter{
+		UserID:    c.Query("user_id"),
+		ActorID:   c.Query("actor_id"),
+		IPAddress: c.Query("ip"),
+		DeviceID:  c.Query("device_id"),
+	}
+
+	if types := c.Query("type"); types != "" {
+		for _, t := range strings.Split(types, ",") {
+			filter.Types = append(filter.Types, auth.SecurityEventType(strings.TrimSpace(t)))
+		}
+	}
+
+	var err error
+	if filter.Since, err = parseTimeQuery(c, "since"); err != nil {
+		h.respondWithError(c, http.StatusBadRequest, proto.ErrorCode_ERROR_CODE_INVALID_REQUEST, "Invalid since parameter, expected RFC3339")
+		return
+	}
+	if filter.Until, err = parseTimeQuery(c, "until"); err != nil {
+		h.respondWithError(c, http.StatusBadRequest, proto.ErrorCode_ERROR_CODE_INVALID_REQUEST, "Invalid until parameter, expected RFC3339")
+		return
+	}
+
+	filter.Limit, _ = strconv.Atoi(c.Query("limit"))
+	filter.Offset, _ = strconv.Atoi(c.Query("offset"))
+
+	events, err := h.authService.QuerySecurityEvents(c.Request.Context(), filter)
+	if err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	resp := make([]SecurityEventResponse, 0, len(events))
+	for _, e := range events {
+		resp = append(resp, SecurityEventResponse{
+			ID:           e.ID.String(),
+			EventType:    string(e.EventType),
+			Outcome:      string(e.Outcome),
+			ActorID:      e.ActorID,
+			TargetUserID: e.TargetUserID,
+			SessionID:    e.SessionID,
+			IPAddress:    e.IPAddress,
+			DeviceID:     e.DeviceID,
+			UserAgent:    e.UserAgent,
+			Reason:       e.Reason,
+			Metadata:     e.Metadata,
+			CreatedAt:    e.CreatedAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"events":  resp,
+		"limit":   filter.Limit,
+		"offset":  filter.Offset,
+	})
+}
+
+// UpdateUserRoles replaces the roles of a user
+func (h *HTTPHandler) UpdateUserRoles(c *gin.Context) {
+	var req UpdateRolesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondWithError(c, http.StatusBadRequest, proto.ErrorCode_ERROR_CODE_INVALID_REQUEST, "Invalid request format")
+		return
+	}
+
+	if err := h.authService.UpdateUserRoles(c.Request.Context(), c.Param("id"), req.Roles, req.Reason); err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true})
+}
+
+// SetAccountStatus suspends, bans or reactivates a user
+func (h *HTTPHandler) SetAccountStatus(c *gin.Context) {
+	var req SetAccountStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondWithError(c, http.StatusBadRequest, proto.ErrorCode_ERROR_CODE_INVALID_REQUEST, "Invalid request format")
+		return
+	}
+
+	status, ok := parseAccountStatus(req.Status)
+	if !ok {
+		h.handleAuthError(c, auth.ErrInvalidAccountStatus)
+		return
+	}
+
+	if err := h.authService.SetAccountStatus(c.Request.Context(), c.Param("id"), status, req.Reason); err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true})
+}
+
+func parseAccountStatus(status string) (auth.AccountStatus, bool) {
+	switch strings.ToLower(status) {
+	case "active":
+		return auth.AccountStatusActive, true
+	case "suspended":
+		return auth.AccountStatusSuspended, true
+	case "banned":
+		return auth.AccountStatusBanned, true
+	case "pending_verification":
+		return auth.AccountStatusPendingVerification, true
+	case "deleted":
+		return auth.AccountStatusDeleted, true
+	default:
+		return 0, false
+	}
+}
+
+func parseTimeQuery(c *gin.Context, key string) (*time.Time, error) {
+	value := c.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
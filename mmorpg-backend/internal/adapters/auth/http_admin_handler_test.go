@@ -0,0 +1,186 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+	portsAuth "github.com/mmorpg-template/backend/internal/ports/auth"
+	"github.com/mmorpg-template/backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// adminServiceStub resolves bearer tokens to fixed claims and records admin calls
+type adminServiceStub struct {
+	portsAuth.AuthService
+	tokens map[string]*auth.Claims
+	err    error
+
+	rolesCalls  []rolesCall
+	statusCalls []statusCall
+}
+
+type rolesCall struct {
+	userID string
+	roles  []string
+	reason string
+	actor  string
+}
+
+type statusCall struct {
+	userID string
+	status auth.AccountStatus
+	reason string
+	actor  string
+}
+
+func (s *adminServiceStub) ValidateToken(ctx context.Context, token string) (*auth.Claims, error) {
+	claims, ok := s.tokens[token]
+	if !ok {
+		return nil, auth.ErrInvalidToken
+	}
+	return claims, nil
+}
+
+func (s *adminServiceStub) UpdateUserRoles(ctx context.Context, userID string, roles []string, reason string) error {
+	s.rolesCalls = append(s.rolesCalls, rolesCall{userID, roles, reason, auth.ClientFromContext(ctx).ActorID})
+	return s.err
+}
+
+func (s *adminServiceStub) SetAccountStatus(ctx context.Context, userID string, status auth.AccountStatus, reason string) error {
+	s.statusCalls = append(s.statusCalls, statusCall{userID, status, reason, auth.ClientFromContext(ctx).ActorID})
+	return s.err
+}
+
+func setupAdminRouter(service *adminServiceStub) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := NewHTTPHandler(service, logger.NewNoop())
+
+	router := gin.New()
+	admin := router.Group("/api/v1/auth/admin")
+	admin.Use(handler.Middleware(), handler.RequireAdmin())
+	admin.PUT("/users/:id/roles", handler.UpdateUserRoles)
+	admin.PUT("/users/:id/status", handler.SetAccountStatus)
+	return router
+}
+
+func newAdminServiceStub() *adminServiceStub {
+	return &adminServiceStub{tokens: map[string]*auth.Claims{
+		"admin-token":   {UserID: "admin-1", Roles: []string{RoleAdmin}},
+		"support-token": {UserID: "support-1", Roles: []string{RoleSupport}},
+		"player-token":  {UserID: "player-1", Roles: []string{"player"}},
+	}}
+}
+
+func doAdminRequest(router *gin.Engine, path, token, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPut, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestUpdateUserRolesHandler(t *testing.T) {
+	const path = "/api/v1/auth/admin/users/user-1/roles"
+
+	t.Run("admin updates roles", func(t *testing.T) {
+		service := newAdminServiceStub()
+		w := doAdminRequest(setupAdminRouter(service), path, "admin-token", `{"roles":["player","moderator"],"reason":"promoted"}`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		require.Len(t, service.rolesCalls, 1)
+		assert.Equal(t, rolesCall{"user-1", []string{"player", "moderator"}, "promoted", "admin-1"}, service.rolesCalls[0])
+	})
+
+	t.Run("missing roles", func(t *testing.T) {
+		service := newAdminServiceStub()
+		w := doAdminRequest(setupAdminRouter(service), path, "admin-token", `{"reason":"promoted"}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Empty(t, service.rolesCalls)
+	})
+
+	t.Run("service failure", func(t *testing.T) {
+		service := newAdminServiceStub()
+		service.err = errors.New("db down")
+		w := doAdminRequest(setupAdminRouter(service), path, "admin-token", `{"roles":["player"]}`)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Len(t, service.rolesCalls, 1)
+	})
+
+	for _, tt := range []struct {
+		name  string
+		token string
+		code  int
+	}{
+		{"no token", "", http.StatusUnauthorized},
+		{"invalid token", "forged-token", http.StatusUnauthorized},
+		{"player", "player-token", http.StatusForbidden},
+		{"support staff", "support-token", http.StatusForbidden},
+	} {
+		t.Run(tt.name+" is rejected", func(t *testing.T) {
+			service := newAdminServiceStub()
+			w := doAdminRequest(setupAdminRouter(service), path, tt.token, `{"roles":["admin"]}`)
+
+			assert.Equal(t, tt.code, w.Code)
+			assert.Empty(t, service.rolesCalls)
+		})
+	}
+}
+
+func TestSetAccountStatusHandler(t *testing.T) {
+	const path = "/api/v1/auth/admin/users/user-1/status"
+
+	t.Run("admin bans user", func(t *testing.T) {
+		service := newAdminServiceStub()
+		w := doAdminRequest(setupAdminRouter(service), path, "admin-token", `{"status":"Banned","reason":"cheating"}`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		require.Len(t, service.statusCalls, 1)
+		assert.Equal(t, statusCall{"user-1", auth.AccountStatusBanned, "cheating", "admin-1"}, service.statusCalls[0])
+	})
+
+	t.Run("unknown status", func(t *testing.T) {
+		service := newAdminServiceStub()
+		w := doAdminRequest(setupAdminRouter(service), path, "admin-token", `{"status":"frozen"}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Empty(t, service.statusCalls)
+	})
+
+	t.Run("malformed body", func(t *testing.T) {
+		service := newAdminServiceStub()
+		w := doAdminRequest(setupAdminRouter(service), path, "admin-token", `{"status":`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Empty(t, service.statusCalls)
+	})
+
+	for _, tt := range []struct {
+		name  string
+		token string
+		code  int
+	}{
+		{"no token", "", http.StatusUnauthorized},
+		{"player", "player-token", http.StatusForbidden},
+		{"support staff", "support-token", http.StatusForbidden},
+	} {
+		t.Run(tt.name+" is rejected", func(t *testing.T) {
+			service := newAdminServiceStub()
+			w := doAdminRequest(setupAdminRouter(service), path, tt.token, `{"status":"active"}`)
+
+			assert.Equal(t, tt.code, w.Code)
+			assert.Empty(t, service.statusCalls)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+	"github.com/mmorpg-template/backend/pkg/proto"
+)
+
+// PowChallengeResponse describes a registration proof-of-work puzzle. The client must find
+// a nonce such that SHA-256(challenge + ":" + nonce) starts with difficulty zero bits, then
+// retry registration with the X-PoW-Challenge and X-PoW-Nonce headers.
+type PowChallengeResponse struct {
+	Challenge  string    `json:"challenge"`
+	Difficulty int       `json:"difficulty"`
+	Algorithm  string    `json:"algorithm"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// RegistrationChallenge issues a proof-of-work challenge for registering from the caller's IP
+func (h *HTTPHandler) RegistrationChallenge(c *gin.Context) {
+	challenge, err := h.authService.RegistrationChallenge(c.Request.Context(), c.ClientIP())
+	if err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":   true,
+		"challenge": h.powChallengeResponse(challenge),
+	})
+}
+
+// respondWithChallenge rejects a registration that needs proof of work and attaches a fresh challenge
+func (h *HTTPHandler) respondWithChallenge(c *gin.Context, cause error) {
+	challenge, err := h.authService.RegistrationChallenge(c.Request.Context(), c.ClientIP())
+	if err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusPreconditionRequired, gin.H{
+		"success":       false,
+		"error_code":    proto.ErrorCode_ERROR_CODE_RATE_LIMITED,
+		"error_message": cause.Error(),
+		"challenge":     h.powChallengeResponse(challenge),
+	})
+}
+
+func (h *HTTPHandler) powChallengeResponse(challenge *auth.PowChallenge) PowChallengeResponse {
+	return PowChallengeResponse{
+		Challenge:  challenge.Challenge,
+		Difficulty: challenge.Difficulty,
+		Algorithm:  "sha256",
+		ExpiresAt:  challenge.ExpiresAt,
+	}
+}
@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mmorpg-template/backend/pkg/proto"
+)
+
+// ConfirmDeviceRequest is the body for POST /auth/devices/confirm
+type ConfirmDeviceRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// KnownDeviceResponse is the JSON representation of a known device
+type KnownDeviceResponse struct {
+	ID            string    `json:"id"`
+	UserAgent     string    `json:"user_agent,omitempty"`
+	LastIPAddress string    `json:"last_ip_address,omitempty"`
+	Confirmed     bool      `json:"confirmed"`
+	FirstSeenAt   time.Time `json:"first_seen_at"`
+	LastSeenAt    time.Time `json:"last_seen_at"`
+}
+
+// ConfirmDevice trusts a new device using the token from the confirmation email
+func (h *HTTPHandler) ConfirmDevice(c *gin.Context) {
+	var req ConfirmDeviceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondWithError(c, http.StatusBadRequest, proto.ErrorCode_ERROR_CODE_INVALID_REQUEST, "Invalid request format")
+		return
+	}
+
+	if err := h.authService.ConfirmDevice(c.Request.Context(), req.Token); err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true})
+}
+
+// ListDevices returns the devices the current user has logged in from
+func (h *HTTPHandler) ListDevices(c *gin.Context) {
+	claims, ok := h.getClaimsFromContext(c)
+	if !ok {
+		h.respondWithError(c, http.StatusUnauthorized, proto.ErrorCode_ERROR_CODE_UNAUTHORIZED, "Unauthorized")
+		return
+	}
+
+	devices, err := h.authService.ListKnownDevices(c.Request.Context(), claims.UserID)
+	if err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	resp := make([]KnownDeviceResponse, 0, len(devices))
+	for _, d := range devices {
+		resp = append(resp, KnownDeviceResponse{
+			ID:            d.ID.String(),
+			UserAgent:     d.UserAgent,
+			LastIPAddress: d.LastIPAddress,
+			Confirmed:     d.Confirmed,
+			FirstSeenAt:   d.FirstSeenAt,
+			LastSeenAt:    d.LastSeenAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"devices": resp,
+	})
+}
+
+// RemoveDevice forgets one of the current user's devices
+func (h *HTTPHandler) RemoveDevice(c *gin.Context) {
+	claims, ok := h.getClaimsFromContext(c)
+	if !ok {
+		h.respondWithError(c, http.StatusUnauthorized, proto.ErrorCode_ERROR_CODE_UNAUTHORIZED, "Unauthorized")
+		return
+	}
+
+	if err := h.authService.RemoveKnownDevice(c.Request.Context(), claims.UserID, c.Param("id")); err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true})
+}
@@ -0,0 +1,313 @@
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+	"github.com/mmorpg-template/backend/pkg/proto"
+)
+
+// weekdayNames maps the day names accepted in play windows to weekdays
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// AddWardRequest is the body for POST /auth/guardian/wards
+type AddWardRequest struct {
+	Email string `json:"email" binding:"required"`
+}
+
+// ConfirmGuardianLinkRequest is the body for POST /auth/guardian/confirm
+type ConfirmGuardianLinkRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// PlayWindowJSON is a play window with days as "mon".."sun" and times as "HH:MM" local time
+type PlayWindowJSON struct {
+	Days  []string `json:"days,omitempty"`
+	Start string   `json:"start"`
+	End   string   `json:"end"`
+}
+
+// PlaytimeLimitsRequest is the body for PUT /auth/guardian/wards/:id/limits
+type PlaytimeLimitsRequest struct {
+	DailyMinutes  int              `json:"daily_minutes"`
+	WeeklyMinutes int              `json:"weekly_minutes"`
+	Windows       []PlayWindowJSON `json:"windows"`
+	Timezone      string           `json:"timezone"`
+	Enabled       *bool            `json:"enabled"`
+}
+
+// PlaytimeLimitsResponse is the JSON representation of an account's parental controls
+type PlaytimeLimitsResponse struct {
+	UserID        string           `json:"user_id"`
+	GuardianID    string           `json:"guardian_id"`
+	DailyMinutes  int              `json:"daily_minutes"`
+	WeeklyMinutes int              `json:"weekly_minutes"`
+	Windows       []PlayWindowJSON `json:"windows"`
+	Timezone      string           `json:"timezone"`
+	Enabled       bool             `json:"enabled"`
+	UpdatedAt     time.Time        `json:"updated_at"`
+}
+
+// PlaytimeStatusResponse is the JSON representation of an account's current play time status
+type PlaytimeStatusResponse struct {
+	Allowed          bool   `json:"allowed"`
+	Limited          bool   `json:"limited"`
+	Reason           string `json:"reason,omitempty"`
+	RemainingMinutes *int   `json:"remaining_minutes,omitempty"`
+	UsedTodayMinutes int    `json:"used_today_minutes"`
+	UsedWeekMinutes  int    `json:"used_week_minutes"`
+}
+
+// AddWard emails an account a link to place it under the current user's parental controls
+func (h *HTTPHandler) AddWard(c *gin.Context) {
+	claims, ok := h.getClaimsFromContext(c)
+	if !ok {
+		h.respondWithError(c, http.StatusUnauthorized, proto.ErrorCode_ERROR_CODE_UNAUTHORIZED, "Unauthorized")
+		return
+	}
+
+	var req AddWardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondWithError(c, http.StatusBadRequest, proto.ErrorCode_ERROR_CODE_INVALID_REQUEST, "Invalid request format")
+		return
+	}
+
+	if err := h.authService.RequestGuardianLink(c.Request.Context(), claims.UserID, req.Email); err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	// Same response whether or not the address belongs to an account
+	c.JSON(http.StatusAccepted, gin.H{"success": true})
+}
+
+// ConfirmGuardianLink accepts a guardian link from the ward's email
+func (h *HTTPHandler) ConfirmGuardianLink(c *gin.Context) {
+	var req ConfirmGuardianLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondWithError(c, http.StatusBadRequest, proto.ErrorCode_ERROR_CODE_INVALID_REQUEST, "Invalid request format")
+		return
+	}
+
+	if err := h.authService.ConfirmGuardianLink(c.Request.Context(), req.Token); err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true})
+}
+
+// ListWards returns the accounts the current user is guardian of
+func (h *HTTPHandler) ListWards(c *gin.Context) {
+	claims, ok := h.getClaimsFromContext(c)
+	if !ok {
+		h.respondWithError(c, http.StatusUnauthorized, proto.ErrorCode_ERROR_CODE_UNAUTHORIZED, "Unauthorized")
+		return
+	}
+
+	wards, err := h.authService.ListWards(c.Request.Context(), claims.UserID)
+	if err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	resp := make([]PlaytimeLimitsResponse, 0, len(wards))
+	for _, ward := range wards {
+		resp = append(resp, playtimeLimitsResponse(ward))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"wards":   resp,
+	})
+}
+
+// GetWard returns a ward's limits and current play time
+func (h *HTTPHandler) GetWard(c *gin.Context) {
+	claims, ok := h.getClaimsFromContext(c)
+	if !ok {
+		h.respondWithError(c, http.StatusUnauthorized, proto.ErrorCode_ERROR_CODE_UNAUTHORIZED, "Unauthorized")
+		return
+	}
+
+	controls, status, err := h.authService.GetWardPlaytime(c.Request.Context(), claims.UserID, c.Param("id"))
+	if err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":  true,
+		"limits":   playtimeLimitsResponse(controls),
+		"playtime": playtimeStatusResponse(status),
+	})
+}
+
+// SetWardLimits replaces a ward's play time limits
+func (h *HTTPHandler) SetWardLimits(c *gin.Context) {
+	claims, ok := h.getClaimsFromContext(c)
+	if !ok {
+		h.respondWithError(c, http.StatusUnauthorized, proto.ErrorCode_ERROR_CODE_UNAUTHORIZED, "Unauthorized")
+		return
+	}
+
+	var req PlaytimeLimitsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondWithError(c, http.StatusBadRequest, proto.ErrorCode_ERROR_CODE_INVALID_REQUEST, "Invalid request format")
+		return
+	}
+
+	limits := &auth.ParentalControls{
+		DailyMinutes:  req.DailyMinutes,
+		WeeklyMinutes: req.WeeklyMinutes,
+		Timezone:      req.Timezone,
+		Enabled:       req.Enabled == nil || *req.Enabled,
+	}
+	if limits.Timezone == "" {
+		limits.Timezone = "UTC"
+	}
+	for _, w := range req.Windows {
+		window, err := parsePlayWindow(w)
+		if err != nil {
+			h.respondWithError(c, http.StatusBadRequest, proto.ErrorCode_ERROR_CODE_INVALID_REQUEST, err.Error())
+			return
+		}
+		limits.Windows = append(limits.Windows, window)
+	}
+
+	controls, err := h.authService.SetPlaytimeLimits(c.Request.Context(), claims.UserID, c.Param("id"), limits)
+	if err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"limits":  playtimeLimitsResponse(controls),
+	})
+}
+
+// RemoveWard ends the guardian link with a ward and lifts its limits
+func (h *HTTPHandler) RemoveWard(c *gin.Context) {
+	claims, ok := h.getClaimsFromContext(c)
+	if !ok {
+		h.respondWithError(c, http.StatusUnauthorized, proto.ErrorCode_ERROR_CODE_UNAUTHORIZED, "Unauthorized")
+		return
+	}
+
+	if err := h.authService.RemoveWard(c.Request.Context(), claims.UserID, c.Param("id")); err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true})
+}
+
+// GetMyPlaytime returns the current user's limits, if any, and play time
+func (h *HTTPHandler) GetMyPlaytime(c *gin.Context) {
+	claims, ok := h.getClaimsFromContext(c)
+	if !ok {
+		h.respondWithError(c, http.StatusUnauthorized, proto.ErrorCode_ERROR_CODE_UNAUTHORIZED, "Unauthorized")
+		return
+	}
+
+	controls, status, err := h.authService.GetPlaytime(c.Request.Context(), claims.UserID)
+	if err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	resp := gin.H{
+		"success":  true,
+		"playtime": playtimeStatusResponse(status),
+	}
+	if controls != nil {
+		resp["limits"] = playtimeLimitsResponse(controls)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// parsePlayWindow converts a JSON play window to the domain form
+func parsePlayWindow(w PlayWindowJSON) (auth.PlayWindow, error) {
+	var window auth.PlayWindow
+	for _, name := range w.Days {
+		day, ok := weekdayNames[strings.ToLower(name)]
+		if !ok {
+			return window, fmt.Errorf("unknown day %q", name)
+		}
+		window.Days = append(window.Days, day)
+	}
+
+	var err error
+	if window.Start, err = parseClock(w.Start); err != nil {
+		return window, err
+	}
+	if window.End, err = parseClock(w.End); err != nil {
+		return window, err
+	}
+	return window, nil
+}
+
+// parseClock converts "HH:MM" to minutes after midnight; "24:00" is accepted as an end time
+func parseClock(value string) (int, error) {
+	hours, minutes, ok := strings.Cut(value, ":")
+	h, errH := strconv.Atoi(hours)
+	m, errM := strconv.Atoi(minutes)
+	if !ok || errH != nil || errM != nil || h < 0 || h > 24 || m < 0 || m > 59 || (h == 24 && m != 0) {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", value)
+	}
+	return h*60 + m, nil
+}
+
+func formatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+func playtimeLimitsResponse(p *auth.ParentalControls) PlaytimeLimitsResponse {
+	resp := PlaytimeLimitsResponse{
+		UserID:        p.UserID.String(),
+		GuardianID:    p.GuardianID.String(),
+		DailyMinutes:  p.DailyMinutes,
+		WeeklyMinutes: p.WeeklyMinutes,
+		Windows:       make([]PlayWindowJSON, 0, len(p.Windows)),
+		Timezone:      p.Timezone,
+		Enabled:       p.Enabled,
+		UpdatedAt:     p.UpdatedAt,
+	}
+	for _, w := range p.Windows {
+		window := PlayWindowJSON{Start: formatClock(w.Start), End: formatClock(w.End)}
+		for _, d := range w.Days {
+			window.Days = append(window.Days, strings.ToLower(d.String()[:3]))
+		}
+		resp.Windows = append(resp.Windows, window)
+	}
+	return resp
+}
+
+func playtimeStatusResponse(s *auth.PlaytimeStatus) PlaytimeStatusResponse {
+	resp := PlaytimeStatusResponse{
+		Allowed:          s.Allowed,
+		Limited:          s.Limited,
+		Reason:           s.Reason,
+		UsedTodayMinutes: int(s.UsedToday / time.Minute),
+		UsedWeekMinutes:  int(s.UsedWeek / time.Minute),
+	}
+	if s.Limited {
+		remaining := int(s.Remaining / time.Minute)
+		resp.RemainingMinutes = &remaining
+	}
+	return resp
+}
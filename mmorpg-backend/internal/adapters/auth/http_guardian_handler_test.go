@@ -0,0 +1,40 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePlayWindow(t *testing.T) {
+	window, err := parsePlayWindow(PlayWindowJSON{Days: []string{"Mon", "fri"}, Start: "15:30", End: "24:00"})
+	require.NoError(t, err)
+	assert.Equal(t, auth.PlayWindow{Days: []time.Weekday{time.Monday, time.Friday}, Start: 15*60 + 30, End: 24 * 60}, window)
+
+	for _, bad := range []PlayWindowJSON{
+		{Days: []string{"monday"}, Start: "10:00", End: "11:00"},
+		{Start: "10", End: "11:00"},
+		{Start: "10:60", End: "11:00"},
+		{Start: "10:00", End: "24:30"},
+	} {
+		_, err := parsePlayWindow(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestPlaytimeLimitsResponse_RoundTripsWindows(t *testing.T) {
+	controls := auth.NewParentalControls(uuid.New(), uuid.New())
+	controls.Windows = []auth.PlayWindow{{Days: []time.Weekday{time.Saturday}, Start: 22 * 60, End: 90}}
+
+	resp := playtimeLimitsResponse(controls)
+	require.Len(t, resp.Windows, 1)
+	assert.Equal(t, PlayWindowJSON{Days: []string{"sat"}, Start: "22:00", End: "01:30"}, resp.Windows[0])
+
+	window, err := parsePlayWindow(resp.Windows[0])
+	require.NoError(t, err)
+	assert.Equal(t, controls.Windows[0], window)
+}
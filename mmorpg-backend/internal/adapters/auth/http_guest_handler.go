@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+	"github.com/mmorpg-template/backend/pkg/proto"
+)
+
+// GuestLoginRequest is the body for POST /auth/guest
+type GuestLoginRequest struct {
+	DeviceID     string `json:"device_id"`
+	DeviceSecret string `json:"device_secret"`
+}
+
+// GuestLoginResponse is a login response carrying the device secret when one was issued
+type GuestLoginResponse struct {
+	*proto.LoginResponse
+	DeviceSecret string `json:"device_secret,omitempty"`
+}
+
+// GuestLogin logs in (or creates) the guest account bound to the caller's device
+func (h *HTTPHandler) GuestLogin(c *gin.Context) {
+	var req GuestLoginRequest
+	// The body is optional when the device ID is sent as a header
+	_ = c.ShouldBindJSON(&req)
+
+	deviceID := req.DeviceID
+	if deviceID == "" {
+		deviceID = c.GetHeader("X-Device-ID")
+	}
+
+	deviceSecret := req.DeviceSecret
+	if deviceSecret == "" {
+		deviceSecret = c.GetHeader("X-Device-Secret")
+	}
+
+	tokenPair, user, issuedSecret, err := h.authService.GuestLogin(
+		c.Request.Context(),
+		deviceID,
+		deviceSecret,
+		c.ClientIP(),
+		c.GetHeader("User-Agent"),
+	)
+	if err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GuestLoginResponse{
+		LoginResponse: h.loginResponse(tokenPair, user),
+		DeviceSecret:  issuedSecret,
+	})
+}
+
+// UpgradeGuest adds email and password to the current guest account
+func (h *HTTPHandler) UpgradeGuest(c *gin.Context) {
+	claims, ok := h.getClaimsFromContext(c)
+	if !ok {
+		h.respondWithError(c, http.StatusUnauthorized, proto.ErrorCode_ERROR_CODE_UNAUTHORIZED, "Unauthorized")
+		return
+	}
+
+	var req proto.RegisterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondWithError(c, http.StatusBadRequest, proto.ErrorCode_ERROR_CODE_INVALID_REQUEST, "Invalid request format")
+		return
+	}
+
+	user, err := h.authService.UpgradeGuest(c.Request.Context(), claims.UserID, &auth.RegisterRequest{
+		Email:        req.Email,
+		Password:     req.Password,
+		Username:     req.Username,
+		AcceptTerms:  req.AcceptTerms,
+		ReferralCode: req.GetReferralCode(),
+	})
+	if err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	// Existing tokens still carry the guest role; clients should refresh them
+	c.JSON(http.StatusOK, gin.H{
+		"success":   true,
+		"user_info": h.userInfo(user),
+	})
+}
@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 	"time"
@@ -42,11 +43,18 @@ func (h *HTTPHandler) Register(c *gin.Context) {
 		Username:     req.Username,
 		AcceptTerms:  req.AcceptTerms,
 		ReferralCode: req.GetReferralCode(),
+		IPAddress:    c.ClientIP(),
+		PowChallenge: c.GetHeader("X-PoW-Challenge"),
+		PowNonce:     c.GetHeader("X-PoW-Nonce"),
 	}
 
 	// Call service
 	user, err := h.authService.Register(c.Request.Context(), domainReq)
 	if err != nil {
+		if err == auth.ErrProofOfWorkRequired || err == auth.ErrProofOfWorkInvalid {
+			h.respondWithChallenge(c, err)
+			return
+		}
 		h.handleAuthError(c, err)
 		return
 	}
@@ -90,34 +98,7 @@ func (h *HTTPHandler) Login(c *gin.Context) {
 		return
 	}
 
-	// Build user info
-	userInfo := &proto.UserInfo{
-		UserId:         user.ID.String(),
-		Email:          user.Email,
-		Username:       user.Username,
-		EmailVerified:  user.EmailVerified,
-		AccountStatus:  h.mapAccountStatus(user.AccountStatus),
-		Roles:          user.Roles,
-		MaxCharacters:  int32(user.MaxCharacters),
-		CharacterCount: int32(user.CharacterCount),
-		IsPremium:      user.IsPremium,
-	}
-
-	if user.CreatedAt.Unix() > 0 {
-		userInfo.CreatedAt = h.timeToProto(user.CreatedAt)
-	}
-
-	// Build response
-	resp := &proto.LoginResponse{
-		Success:      true,
-		AccessToken:  tokenPair.AccessToken,
-		RefreshToken: tokenPair.RefreshToken,
-		SessionId:    "", // Session ID is embedded in the token
-		ExpiresIn:    int32(tokenPair.ExpiresIn),
-		UserInfo:     userInfo,
-	}
-
-	c.JSON(http.StatusOK, resp)
+	c.JSON(http.StatusOK, h.loginResponse(tokenPair, user))
 }
 
 // Logout handles user logout
@@ -242,6 +223,49 @@ func (h *HTTPHandler) VerifyToken(c *gin.Context) {
 	})
 }
 
+// UnlockRequest is the body for POST /auth/unlock/request
+type UnlockRequest struct {
+	Email string `json:"email" binding:"required"`
+}
+
+// UnlockAccountRequest is the body for POST /auth/unlock
+type UnlockAccountRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// RequestAccountUnlock emails an unlock link to the owner of a locked account
+func (h *HTTPHandler) RequestAccountUnlock(c *gin.Context) {
+	var req UnlockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondWithError(c, http.StatusBadRequest, proto.ErrorCode_ERROR_CODE_INVALID_REQUEST, "Invalid request format")
+		return
+	}
+
+	if err := h.authService.RequestAccountUnlock(c.Request.Context(), req.Email); err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	// Always accept so the endpoint can't be used to probe accounts
+	c.JSON(http.StatusAccepted, gin.H{"success": true})
+}
+
+// UnlockAccount clears a lockout using an emailed unlock token
+func (h *HTTPHandler) UnlockAccount(c *gin.Context) {
+	var req UnlockAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondWithError(c, http.StatusBadRequest, proto.ErrorCode_ERROR_CODE_INVALID_REQUEST, "Invalid request format")
+		return
+	}
+
+	if err := h.authService.UnlockAccount(c.Request.Context(), req.Token); err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true})
+}
+
 // Middleware provides JWT authentication middleware
 func (h *HTTPHandler) Middleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -272,13 +296,51 @@ func (h *HTTPHandler) Middleware() gin.HandlerFunc {
 		// Store claims in context
 		c.Set("claims", claims)
 		c.Set("user_id", claims.UserID)
+		c.Request = c.Request.WithContext(auth.ContextWithClient(c.Request.Context(), h.clientInfo(c)))
 		c.Next()
 	}
 }
 
+// ClientContext attaches caller details (IP, device, user agent) to the request context
+// so the service can attribute audit records
+func (h *HTTPHandler) ClientContext() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(auth.ContextWithClient(c.Request.Context(), h.clientInfo(c)))
+		c.Next()
+	}
+}
+
+// RequireRole rejects requests whose token carries none of the given roles.
+// Must run after Middleware.
+func (h *HTTPHandler) RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, ok := h.getClaimsFromContext(c)
+		if !ok {
+			h.respondWithError(c, http.StatusUnauthorized, proto.ErrorCode_ERROR_CODE_UNAUTHORIZED, "Unauthorized")
+			c.Abort()
+			return
+		}
+
+		for _, role := range roles {
+			if claims.HasRole(role) {
+				c.Next()
+				return
+			}
+		}
+
+		h.respondWithError(c, http.StatusForbidden, proto.ErrorCode_ERROR_CODE_FORBIDDEN, "Insufficient permissions")
+		c.Abort()
+	}
+}
+
 // Helper methods
 
 func (h *HTTPHandler) handleAuthError(c *gin.Context, err error) {
+	if errors.Is(err, auth.ErrInvalidPlaytimeLimits) {
+		h.respondWithError(c, http.StatusBadRequest, proto.ErrorCode_ERROR_CODE_INVALID_REQUEST, err.Error())
+		return
+	}
+
 	switch err {
 	case auth.ErrUserNotFound, auth.ErrInvalidCredentials:
 		h.respondWithError(c, http.StatusUnauthorized, proto.ErrorCode_ERROR_CODE_INVALID_CREDENTIALS, "Invalid credentials")
@@ -298,14 +360,58 @@ func (h *HTTPHandler) handleAuthError(c *gin.Context, err error) {
 		h.respondWithError(c, http.StatusUnauthorized, proto.ErrorCode_ERROR_CODE_UNAUTHORIZED, "Invalid token")
 	case auth.ErrTooManyAttempts:
 		h.respondWithError(c, http.StatusTooManyRequests, proto.ErrorCode_ERROR_CODE_RATE_LIMITED, "Too many login attempts")
+	case auth.ErrDeviceNotConfirmed:
+		h.respondWithError(c, http.StatusForbidden, proto.ErrorCode_ERROR_CODE_FORBIDDEN, "New device must be confirmed, check your email")
+	case auth.ErrDeviceNotFound:
+		h.respondWithError(c, http.StatusNotFound, proto.ErrorCode_ERROR_CODE_NOT_FOUND, "Device not found")
+	case auth.ErrNotGuestAccount:
+		h.respondWithError(c, http.StatusConflict, proto.ErrorCode_ERROR_CODE_INVALID_REQUEST, "Account is not a guest account")
+	case auth.ErrGuestDeviceRequired:
+		h.respondWithError(c, http.StatusBadRequest, proto.ErrorCode_ERROR_CODE_INVALID_REQUEST, "Device ID is required")
+	case auth.ErrAccountNotActive:
+		h.respondWithError(c, http.StatusForbidden, proto.ErrorCode_ERROR_CODE_FORBIDDEN, "Account not active")
+	case auth.ErrGuestNotAllowed:
+		h.respondWithError(c, http.StatusForbidden, proto.ErrorCode_ERROR_CODE_FORBIDDEN, "Not available for guest accounts")
+	case auth.ErrPasswordMismatch:
+		h.respondWithError(c, http.StatusUnauthorized, proto.ErrorCode_ERROR_CODE_INVALID_CREDENTIALS, "Current password is incorrect")
+	case auth.ErrUsernameChangeTooSoon:
+		h.respondWithError(c, http.StatusTooManyRequests, proto.ErrorCode_ERROR_CODE_RATE_LIMITED, "Username was changed too recently")
+	case auth.ErrUsernameUnchanged, auth.ErrEmailUnchanged:
+		h.respondWithError(c, http.StatusBadRequest, proto.ErrorCode_ERROR_CODE_INVALID_REQUEST, "New value is the same as the current one")
+	case auth.ErrInvalidClientCredentials:
+		h.respondWithError(c, http.StatusUnauthorized, proto.ErrorCode_ERROR_CODE_INVALID_CREDENTIALS, "Invalid client credentials")
+	case auth.ErrInsufficientScope:
+		h.respondWithError(c, http.StatusForbidden, proto.ErrorCode_ERROR_CODE_FORBIDDEN, "Requested scope not allowed")
+	case auth.ErrServiceAccountNotFound:
+		h.respondWithError(c, http.StatusNotFound, proto.ErrorCode_ERROR_CODE_NOT_FOUND, "Service account not found")
+	case auth.ErrServiceAccountExists:
+		h.respondWithError(c, http.StatusConflict, proto.ErrorCode_ERROR_CODE_ALREADY_EXISTS, "Service account already exists")
+	case auth.ErrInvalidScope, auth.ErrInvalidServiceName:
+		h.respondWithError(c, http.StatusBadRequest, proto.ErrorCode_ERROR_CODE_INVALID_REQUEST, err.Error())
+	case auth.ErrSessionInvalid, auth.ErrSessionExpired, auth.ErrSessionNotFound:
+		h.respondWithError(c, http.StatusUnauthorized, proto.ErrorCode_ERROR_CODE_UNAUTHORIZED, "Session has ended")
+	case auth.ErrAccountLocked:
+		h.respondWithError(c, http.StatusLocked, proto.ErrorCode_ERROR_CODE_ACCOUNT_LOCKED, "Account temporarily locked")
 	case auth.ErrPasswordTooWeak:
 		h.respondWithError(c, http.StatusBadRequest, proto.ErrorCode_ERROR_CODE_INVALID_REQUEST, "Password too weak")
+	case auth.ErrPasswordBreached:
+		h.respondWithError(c, http.StatusBadRequest, proto.ErrorCode_ERROR_CODE_INVALID_REQUEST, "Password has appeared in a data breach or is too common, choose another")
 	case auth.ErrInvalidEmail:
 		h.respondWithError(c, http.StatusBadRequest, proto.ErrorCode_ERROR_CODE_INVALID_REQUEST, "Invalid email format")
 	case auth.ErrInvalidUsername:
 		h.respondWithError(c, http.StatusBadRequest, proto.ErrorCode_ERROR_CODE_INVALID_REQUEST, "Invalid username format")
 	case auth.ErrTermsNotAccepted:
 		h.respondWithError(c, http.StatusBadRequest, proto.ErrorCode_ERROR_CODE_INVALID_REQUEST, "Terms of service must be accepted")
+	case auth.ErrInvalidAccountStatus:
+		h.respondWithError(c, http.StatusBadRequest, proto.ErrorCode_ERROR_CODE_INVALID_REQUEST, "Invalid account status")
+	case auth.ErrParentalControlsNotFound:
+		h.respondWithError(c, http.StatusNotFound, proto.ErrorCode_ERROR_CODE_NOT_FOUND, "Account has no parental controls")
+	case auth.ErrNotGuardian:
+		h.respondWithError(c, http.StatusForbidden, proto.ErrorCode_ERROR_CODE_FORBIDDEN, "Not the guardian of this account")
+	case auth.ErrGuardianAlreadyLinked:
+		h.respondWithError(c, http.StatusConflict, proto.ErrorCode_ERROR_CODE_ALREADY_EXISTS, "Account already has a guardian")
+	case auth.ErrCannotGuardSelf:
+		h.respondWithError(c, http.StatusBadRequest, proto.ErrorCode_ERROR_CODE_INVALID_REQUEST, "Cannot become your own guardian")
 	default:
 		h.logger.WithError(err).Error("Unhandled auth error")
 		h.respondWithError(c, http.StatusInternalServerError, proto.ErrorCode_ERROR_CODE_SERVER_ERROR, "Internal server error")
@@ -358,6 +464,54 @@ func (h *HTTPHandler) getClaimsFromContext(c *gin.Context) (*auth.Claims, bool)
 	return authClaims, ok
 }
 
+func (h *HTTPHandler) clientInfo(c *gin.Context) auth.ClientInfo {
+	client := auth.ClientInfo{
+		IPAddress: c.ClientIP(),
+		DeviceID:  c.GetHeader("X-Device-ID"),
+		UserAgent: c.GetHeader("User-Agent"),
+	}
+	if claims, ok := h.getClaimsFromContext(c); ok {
+		client.ActorID = claims.UserID
+		if client.DeviceID == "" {
+			client.DeviceID = claims.DeviceID
+		}
+	}
+	return client
+}
+
+// loginResponse builds the response returned after a successful login
+func (h *HTTPHandler) loginResponse(tokenPair *auth.TokenPair, user *auth.User) *proto.LoginResponse {
+	return &proto.LoginResponse{
+		Success:      true,
+		AccessToken:  tokenPair.AccessToken,
+		RefreshToken: tokenPair.RefreshToken,
+		SessionId:    "", // Session ID is embedded in the token
+		ExpiresIn:    int32(tokenPair.ExpiresIn),
+		UserInfo:     h.userInfo(user),
+	}
+}
+
+// userInfo converts a user into its API representation
+func (h *HTTPHandler) userInfo(user *auth.User) *proto.UserInfo {
+	userInfo := &proto.UserInfo{
+		UserId:         user.ID.String(),
+		Email:          user.Email,
+		Username:       user.Username,
+		EmailVerified:  user.EmailVerified,
+		AccountStatus:  h.mapAccountStatus(user.AccountStatus),
+		Roles:          user.Roles,
+		MaxCharacters:  int32(user.MaxCharacters),
+		CharacterCount: int32(user.CharacterCount),
+		IsPremium:      user.IsPremium,
+	}
+
+	if user.CreatedAt.Unix() > 0 {
+		userInfo.CreatedAt = h.timeToProto(user.CreatedAt)
+	}
+
+	return userInfo
+}
+
 func (h *HTTPHandler) mapAccountStatus(status auth.AccountStatus) proto.AccountStatus {
 	switch status {
 	case auth.AccountStatusActive:
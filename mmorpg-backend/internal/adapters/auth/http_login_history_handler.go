@@ -0,0 +1,91 @@
+package auth
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+	"github.com/mmorpg-template/backend/pkg/proto"
+)
+
+// LoginRecordResponse is the JSON representation of a login history entry
+type LoginRecordResponse struct {
+	ID          string    `json:"id"`
+	SessionID   string    `json:"session_id,omitempty"`
+	Kind        string    `json:"kind"`
+	Result      string    `json:"result"`
+	Success     bool      `json:"success"`
+	IPAddress   string    `json:"ip_address,omitempty"`
+	CountryCode string    `json:"country_code,omitempty"`
+	Region      string    `json:"region,omitempty"`
+	City        string    `json:"city,omitempty"`
+	DeviceID    string    `json:"device_id,omitempty"`
+	UserAgent   string    `json:"user_agent,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ListMyLogins returns the current user's login history
+func (h *HTTPHandler) ListMyLogins(c *gin.Context) {
+	claims, ok := h.getClaimsFromContext(c)
+	if !ok {
+		h.respondWithError(c, http.StatusUnauthorized, proto.ErrorCode_ERROR_CODE_UNAUTHORIZED, "Unauthorized")
+		return
+	}
+
+	h.listLogins(c, claims.UserID)
+}
+
+// ListUserLogins returns the login history of any user (support/admin)
+func (h *HTTPHandler) ListUserLogins(c *gin.Context) {
+	h.listLogins(c, c.Param("id"))
+}
+
+func (h *HTTPHandler) listLogins(c *gin.Context, userID string) {
+	filter := &auth.LoginHistoryFilter{UserID: userID}
+
+	var err error
+	if filter.Since, err = parseTimeQuery(c, "since"); err != nil {
+		h.respondWithError(c, http.StatusBadRequest, proto.ErrorCode_ERROR_CODE_INVALID_REQUEST, "Invalid since parameter, expected RFC3339")
+		return
+	}
+	if filter.Until, err = parseTimeQuery(c, "until"); err != nil {
+		h.respondWithError(c, http.StatusBadRequest, proto.ErrorCode_ERROR_CODE_INVALID_REQUEST, "Invalid until parameter, expected RFC3339")
+		return
+	}
+
+	filter.Limit, _ = strconv.Atoi(c.Query("limit"))
+	filter.Offset, _ = strconv.Atoi(c.Query("offset"))
+
+	records, err := h.authService.ListLoginHistory(c.Request.Context(), filter)
+	if err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	resp := make([]LoginRecordResponse, 0, len(records))
+	for _, r := range records {
+		resp = append(resp, LoginRecordResponse{
+			ID:          r.ID.String(),
+			SessionID:   r.SessionID,
+			Kind:        string(r.Kind),
+			Result:      r.Result,
+			Success:     r.Succeeded(),
+			IPAddress:   r.IPAddress,
+			CountryCode: r.Location.CountryCode,
+			Region:      r.Location.Region,
+			City:        r.Location.City,
+			DeviceID:    r.DeviceID,
+			UserAgent:   r.UserAgent,
+			CreatedAt:   r.CreatedAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"logins":  resp,
+		"limit":   filter.Limit,
+		"offset":  filter.Offset,
+	})
+}
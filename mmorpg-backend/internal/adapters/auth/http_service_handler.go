@@ -0,0 +1,157 @@
+package auth
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+	"github.com/mmorpg-template/backend/pkg/proto"
+)
+
+// ServiceTokenRequest is the body for POST /auth/service/token (OAuth2 client credentials grant)
+type ServiceTokenRequest struct {
+	GrantType    string `json:"grant_type" binding:"required"`
+	ClientID     string `json:"client_id" binding:"required"`
+	ClientSecret string `json:"client_secret" binding:"required"`
+	// Scope is a space-separated list; empty requests every scope of the account
+	Scope string `json:"scope"`
+}
+
+// CreateServiceAccountRequest is the body for POST /admin/service-accounts
+type CreateServiceAccountRequest struct {
+	Name   string   `json:"name" binding:"required"`
+	Scopes []string `json:"scopes" binding:"required"`
+}
+
+// UpdateServiceAccountRequest is the body for PUT /admin/service-accounts/:client_id
+type UpdateServiceAccountRequest struct {
+	Scopes []string `json:"scopes" binding:"required"`
+	Active bool     `json:"active"`
+}
+
+// ServiceAccountResponse is the JSON representation of a service account
+type ServiceAccountResponse struct {
+	ClientID   string     `json:"client_id"`
+	Name       string     `json:"name"`
+	Scopes     []string   `json:"scopes"`
+	Active     bool       `json:"active"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
+
+// IssueServiceToken exchanges service client credentials for a scoped token
+func (h *HTTPHandler) IssueServiceToken(c *gin.Context) {
+	var req ServiceTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.GrantType != "client_credentials" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_request"})
+		return
+	}
+
+	token, err := h.authService.IssueServiceToken(c.Request.Context(), req.ClientID, req.ClientSecret, strings.Fields(req.Scope))
+	if err != nil {
+		switch err {
+		case auth.ErrInvalidClientCredentials:
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid_client"})
+		case auth.ErrInsufficientScope:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_scope"})
+		default:
+			h.logger.WithError(err).Error("Failed to issue service token")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "server_error"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"access_token": token.AccessToken,
+		"token_type":   "Bearer",
+		"expires_in":   token.ExpiresIn,
+		"scope":        strings.Join(token.Scopes, " "),
+	})
+}
+
+// CreateServiceAccount registers a service account and returns its client secret once
+func (h *HTTPHandler) CreateServiceAccount(c *gin.Context) {
+	var req CreateServiceAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondWithError(c, http.StatusBadRequest, proto.ErrorCode_ERROR_CODE_INVALID_REQUEST, "Invalid request format")
+		return
+	}
+
+	account, secret, err := h.authService.CreateServiceAccount(c.Request.Context(), req.Name, req.Scopes)
+	if err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success":         true,
+		"service_account": serviceAccountResponse(account),
+		"client_secret":   secret,
+	})
+}
+
+// ListServiceAccounts returns all service accounts
+func (h *HTTPHandler) ListServiceAccounts(c *gin.Context) {
+	accounts, err := h.authService.ListServiceAccounts(c.Request.Context())
+	if err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	resp := make([]ServiceAccountResponse, 0, len(accounts))
+	for _, account := range accounts {
+		resp = append(resp, serviceAccountResponse(account))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":          true,
+		"service_accounts": resp,
+	})
+}
+
+// UpdateServiceAccount changes a service account's scopes or disables it
+func (h *HTTPHandler) UpdateServiceAccount(c *gin.Context) {
+	var req UpdateServiceAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondWithError(c, http.StatusBadRequest, proto.ErrorCode_ERROR_CODE_INVALID_REQUEST, "Invalid request format")
+		return
+	}
+
+	account, err := h.authService.UpdateServiceAccount(c.Request.Context(), c.Param("client_id"), req.Scopes, req.Active)
+	if err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":         true,
+		"service_account": serviceAccountResponse(account),
+	})
+}
+
+// RotateServiceAccountSecret issues a new client secret, invalidating the old one
+func (h *HTTPHandler) RotateServiceAccountSecret(c *gin.Context) {
+	secret, err := h.authService.RotateServiceAccountSecret(c.Request.Context(), c.Param("client_id"))
+	if err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":       true,
+		"client_secret": secret,
+	})
+}
+
+func serviceAccountResponse(account *auth.ServiceAccount) ServiceAccountResponse {
+	return ServiceAccountResponse{
+		ClientID:   account.ClientID,
+		Name:       account.Name,
+		Scopes:     account.Scopes,
+		Active:     account.IsActive,
+		CreatedAt:  account.CreatedAt,
+		LastUsedAt: account.LastUsedAt,
+	}
+}
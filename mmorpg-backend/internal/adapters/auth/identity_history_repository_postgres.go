@@ -0,0 +1,152 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+	portsAuth "github.com/mmorpg-template/backend/internal/ports/auth"
+)
+
+// PostgresIdentityHistoryRepository implements IdentityHistoryRepository using PostgreSQL
+type PostgresIdentityHistoryRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresIdentityHistoryRepository creates a new PostgreSQL identity history repository
+func NewPostgresIdentityHistoryRepository(db *sql.DB) portsAuth.IdentityHistoryRepository {
+	return &PostgresIdentityHistoryRepository{db: db}
+}
+
+const identityChangeColumns = `id, user_id, field, old_value, new_value, hold_until, changed_at`
+
+// Record stores an identity change
+func (r *PostgresIdentityHistoryRepository) Record(ctx context.Context, change *auth.IdentityChange) error {
+	query := `
+		INSERT INTO user_identity_history (
+			id, user_id, field, old_value, new_value, hold_until, changed_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		change.ID,
+		change.UserID,
+		string(change.Field),
+		change.OldValue,
+		change.NewValue,
+		change.HoldUntil,
+		change.ChangedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record identity change: %w", err)
+	}
+
+	return nil
+}
+
+// LastChange returns the most recent change of a field for a user, or nil if none
+func (r *PostgresIdentityHistoryRepository) LastChange(ctx context.Context, userID string, field auth.IdentityField) (*auth.IdentityChange, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	query := `
+		SELECT ` + identityChangeColumns + `
+		FROM user_identity_history
+		WHERE user_id = $1 AND field = $2
+		ORDER BY changed_at DESC
+		LIMIT 1
+	`
+
+	change, err := scanIdentityChange(r.db.QueryRowContext(ctx, query, uid, string(field)))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get last identity change: %w", err)
+	}
+
+	return change, nil
+}
+
+// ListByUserID retrieves a user's identity changes, newest first
+func (r *PostgresIdentityHistoryRepository) ListByUserID(ctx context.Context, userID string) ([]*auth.IdentityChange, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	query := `
+		SELECT ` + identityChangeColumns + `
+		FROM user_identity_history
+		WHERE user_id = $1
+		ORDER BY changed_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, uid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list identity changes: %w", err)
+	}
+	defer rows.Close()
+
+	var changes []*auth.IdentityChange
+	for rows.Next() {
+		change, err := scanIdentityChange(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan identity change: %w", err)
+		}
+		changes = append(changes, change)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating identity changes: %w", err)
+	}
+
+	return changes, nil
+}
+
+// IsUsernameHeld reports whether a released username is still reserved for another account
+func (r *PostgresIdentityHistoryRepository) IsUsernameHeld(ctx context.Context, username, exceptUserID string, at time.Time) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM user_identity_history
+			WHERE field = 'username'
+				AND LOWER(old_value) = LOWER($1)
+				AND hold_until > $2
+				AND ($3::uuid IS NULL OR user_id <> $3)
+		)
+	`
+
+	var held bool
+	if err := r.db.QueryRowContext(ctx, query, username, at, nullUUID(exceptUserID)).Scan(&held); err != nil {
+		return false, fmt.Errorf("failed to check username hold: %w", err)
+	}
+
+	return held, nil
+}
+
+func scanIdentityChange(row rowScanner) (*auth.IdentityChange, error) {
+	var (
+		change auth.IdentityChange
+		field  string
+	)
+	err := row.Scan(
+		&change.ID,
+		&change.UserID,
+		&field,
+		&change.OldValue,
+		&change.NewValue,
+		&change.HoldUntil,
+		&change.ChangedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	change.Field = auth.IdentityField(field)
+	return &change, nil
+}
@@ -17,14 +17,16 @@
 type JWTGenerator struct {
 	accessSecret  string
 	refreshSecret string
+	serviceSecret string
 	issuer        string
 }
 
 // NewJWTGenerator creates a new JWT generator
-func NewJWTGenerator(accessSecret, refreshSecret, issuer string) portsAuth.TokenGenerator {
+func NewJWTGenerator(accessSecret, refreshSecret, serviceSecret, issuer string) portsAuth.TokenGenerator {
 	return &JWTGenerator{
 		accessSecret:  accessSecret,
 		refreshSecret: refreshSecret,
+		serviceSecret: serviceSecret,
 		issuer:        issuer,
 	}
 }
@@ -79,6 +81,38 @@ func (j *JWTGenerator) GenerateTokenPair(ctx context.Context, user *auth.User, s
 	return auth.NewTokenPair(accessTokenString, refreshTokenString), nil
 }
 
+// GenerateServiceToken generates a client-credential token for a service account.
+// Service tokens are signed with their own secret so a user token can never pass as one.
+func (j *JWTGenerator) GenerateServiceToken(ctx context.Context, account *auth.ServiceAccount, scopes []string) (*auth.ServiceToken, error) {
+	claims := &auth.ServiceClaims{
+		ServiceID: account.ID.String(),
+		ClientID:  account.ClientID,
+		Name:      account.Name,
+		Scopes:    scopes,
+		TokenType: auth.ServiceTokenType,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(auth.ServiceTokenDuration)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			NotBefore: jwt.NewNumericDate(time.Now()),
+			Issuer:    j.issuer,
+			Subject:   account.ClientID,
+			ID:        uuid.New().String(),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	tokenString, err := token.SignedString([]byte(j.serviceSecret))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign service token: %w", err)
+	}
+
+	return &auth.ServiceToken{
+		AccessToken: tokenString,
+		Scopes:      scopes,
+		ExpiresIn:   int(auth.ServiceTokenDuration.Seconds()),
+	}, nil
+}
+
 // ValidateAccessToken validates an access token and returns the claims
 func (j *JWTGenerator) ValidateAccessToken(ctx context.Context, tokenString string) (*auth.Claims, error) {
 	claims := &auth.Claims{}
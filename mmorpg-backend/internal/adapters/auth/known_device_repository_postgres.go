@@ -0,0 +1,206 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+	portsAuth "github.com/mmorpg-template/backend/internal/ports/auth"
+)
+
+// PostgresKnownDeviceRepository implements KnownDeviceRepository using PostgreSQL
+type PostgresKnownDeviceRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresKnownDeviceRepository creates a new PostgreSQL known device repository
+func NewPostgresKnownDeviceRepository(db *sql.DB) portsAuth.KnownDeviceRepository {
+	return &PostgresKnownDeviceRepository{db: db}
+}
+
+const knownDeviceColumns = `
+	id, user_id, device_key, user_agent, host(last_ip_address), last_network,
+	confirmed, first_seen_at, last_seen_at
+`
+
+// Get retrieves a device by user and device key
+func (r *PostgresKnownDeviceRepository) Get(ctx context.Context, userID, deviceKey string) (*auth.KnownDevice, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	query := `SELECT ` + knownDeviceColumns + ` FROM known_devices WHERE user_id = $1 AND device_key = $2`
+
+	device, err := scanKnownDevice(r.db.QueryRowContext(ctx, query, uid, deviceKey))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, auth.ErrDeviceNotFound
+		}
+		return nil, fmt.Errorf("failed to get known device: %w", err)
+	}
+
+	return device, nil
+}
+
+// Save creates or updates a known device and remembers its network in the same statement
+func (r *PostgresKnownDeviceRepository) Save(ctx context.Context, device *auth.KnownDevice) error {
+	query := `
+		WITH device AS (
+			INSERT INTO known_devices (
+				id, user_id, device_key, user_agent, last_ip_address, last_network,
+				confirmed, first_seen_at, last_seen_at
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			ON CONFLICT (user_id, device_key) DO UPDATE SET
+				user_agent = EXCLUDED.user_agent,
+				last_ip_address = EXCLUDED.last_ip_address,
+				last_network = EXCLUDED.last_network,
+				confirmed = known_devices.confirmed OR EXCLUDED.confirmed,
+				last_seen_at = EXCLUDED.last_seen_at
+			RETURNING user_id, last_network, last_seen_at
+		)
+		INSERT INTO known_networks (user_id, network, first_seen_at, last_seen_at)
+		SELECT user_id, last_network, last_seen_at, last_seen_at FROM device WHERE last_network IS NOT NULL
+		ON CONFLICT (user_id, network) DO UPDATE SET
+			last_seen_at = GREATEST(known_networks.last_seen_at, EXCLUDED.last_seen_at)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		device.ID,
+		device.UserID,
+		device.DeviceKey,
+		nullString(device.UserAgent),
+		nullString(device.LastIPAddress),
+		nullString(device.LastNetwork),
+		device.Confirmed,
+		device.FirstSeenAt,
+		device.LastSeenAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save known device: %w", err)
+	}
+
+	return nil
+}
+
+// ListByUserID retrieves all known devices for a user, most recently seen first
+func (r *PostgresKnownDeviceRepository) ListByUserID(ctx context.Context, userID string) ([]*auth.KnownDevice, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	query := `SELECT ` + knownDeviceColumns + ` FROM known_devices WHERE user_id = $1 ORDER BY last_seen_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, uid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list known devices: %w", err)
+	}
+	defer rows.Close()
+
+	var devices []*auth.KnownDevice
+	for rows.Next() {
+		device, err := scanKnownDevice(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan known device: %w", err)
+		}
+		devices = append(devices, device)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating known devices: %w", err)
+	}
+
+	return devices, nil
+}
+
+// CountByUserID returns the number of known devices for a user
+func (r *PostgresKnownDeviceRepository) CountByUserID(ctx context.Context, userID string) (int, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return 0, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	var count int
+	query := `SELECT COUNT(*) FROM known_devices WHERE user_id = $1`
+	if err := r.db.QueryRowContext(ctx, query, uid).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count known devices: %w", err)
+	}
+
+	return count, nil
+}
+
+// HasNetwork reports whether the user has ever logged in from a network
+func (r *PostgresKnownDeviceRepository) HasNetwork(ctx context.Context, userID, network string) (bool, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return false, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	var exists bool
+	query := `SELECT EXISTS(SELECT 1 FROM known_networks WHERE user_id = $1 AND network = $2)`
+	if err := r.db.QueryRowContext(ctx, query, uid, network).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check known network: %w", err)
+	}
+
+	return exists, nil
+}
+
+// Delete forgets a device
+func (r *PostgresKnownDeviceRepository) Delete(ctx context.Context, userID, deviceID string) error {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+	id, err := uuid.Parse(deviceID)
+	if err != nil {
+		return auth.ErrDeviceNotFound
+	}
+
+	result, err := r.db.ExecContext(ctx, `DELETE FROM known_devices WHERE id = $1 AND user_id = $2`, id, uid)
+	if err != nil {
+		return fmt.Errorf("failed to delete known device: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return auth.ErrDeviceNotFound
+	}
+
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanKnownDevice(row rowScanner) (*auth.KnownDevice, error) {
+	var (
+		device                        auth.KnownDevice
+		userAgent, ipAddress, network sql.NullString
+	)
+	err := row.Scan(
+		&device.ID,
+		&device.UserID,
+		&device.DeviceKey,
+		&userAgent,
+		&ipAddress,
+		&network,
+		&device.Confirmed,
+		&device.FirstSeenAt,
+		&device.LastSeenAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	device.UserAgent = userAgent.String
+	device.LastIPAddress = ipAddress.String
+	device.LastNetwork = network.String
+	return &device, nil
+}
@@ -0,0 +1,151 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+	portsAuth "github.com/mmorpg-template/backend/internal/ports/auth"
+)
+
+// PostgresLoginHistoryRepository implements LoginHistoryRepository using PostgreSQL
+type PostgresLoginHistoryRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresLoginHistoryRepository creates a new PostgreSQL login history repository
+func NewPostgresLoginHistoryRepository(db *sql.DB) portsAuth.LoginHistoryRepository {
+	return &PostgresLoginHistoryRepository{db: db}
+}
+
+// Append stores a login record
+func (r *PostgresLoginHistoryRepository) Append(ctx context.Context, record *auth.LoginRecord) error {
+	query := `
+		INSERT INTO login_history (
+			id, user_id, session_id, kind, result, ip_address,
+			country_code, region, city, device_id, user_agent, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		record.ID,
+		record.UserID,
+		nullUUID(record.SessionID),
+		string(record.Kind),
+		record.Result,
+		nullString(record.IPAddress),
+		nullString(record.Location.CountryCode),
+		nullString(record.Location.Region),
+		nullString(record.Location.City),
+		nullString(record.DeviceID),
+		nullString(record.UserAgent),
+		record.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to append login record: %w", err)
+	}
+
+	return nil
+}
+
+// List retrieves a user's login records matching a filter, newest first
+func (r *PostgresLoginHistoryRepository) List(ctx context.Context, filter *auth.LoginHistoryFilter) ([]*auth.LoginRecord, error) {
+	filter.Normalize()
+
+	uid, err := uuid.Parse(filter.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	conditions := []string{"user_id = $1"}
+	args := []interface{}{uid}
+	if filter.Since != nil {
+		args = append(args, *filter.Since)
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", len(args)))
+	}
+	if filter.Until != nil {
+		args = append(args, *filter.Until)
+		conditions = append(conditions, fmt.Sprintf("created_at < $%d", len(args)))
+	}
+
+	query := `
+		SELECT
+			id, user_id, session_id, kind, result, ip_address,
+			country_code, region, city, device_id, user_agent, created_at
+		FROM login_history
+		WHERE ` + strings.Join(conditions, " AND ")
+	args = append(args, filter.Limit, filter.Offset)
+	query += fmt.Sprintf(" ORDER BY created_at DESC LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list login history: %w", err)
+	}
+	defer rows.Close()
+
+	var records []*auth.LoginRecord
+	for rows.Next() {
+		var (
+			record                         auth.LoginRecord
+			kind                           string
+			sessionID                      uuid.NullUUID
+			ipAddress, deviceID, userAgent sql.NullString
+			country, region, city          sql.NullString
+		)
+		err := rows.Scan(
+			&record.ID,
+			&record.UserID,
+			&sessionID,
+			&kind,
+			&record.Result,
+			&ipAddress,
+			&country,
+			&region,
+			&city,
+			&deviceID,
+			&userAgent,
+			&record.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan login record: %w", err)
+		}
+
+		record.Kind = auth.LoginKind(kind)
+		record.SessionID = uuidString(sessionID)
+		record.IPAddress = ipAddress.String
+		record.Location = auth.GeoLocation{
+			CountryCode: strings.TrimSpace(country.String),
+			Region:      region.String,
+			City:        city.String,
+		}
+		record.DeviceID = deviceID.String
+		record.UserAgent = userAgent.String
+
+		records = append(records, &record)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating login history: %w", err)
+	}
+
+	return records, nil
+}
+
+// DeleteBefore removes records older than the given time
+func (r *PostgresLoginHistoryRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM login_history WHERE created_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete login history: %w", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get deleted login history count: %w", err)
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,74 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mmorpg-template/backend/internal/domain/character"
+	"github.com/mmorpg-template/backend/internal/ports"
+	portsAuth "github.com/mmorpg-template/backend/internal/ports/auth"
+	"github.com/mmorpg-template/backend/pkg/logger"
+)
+
+// CharacterCountConsumer keeps account character counts in step with character
+// lifecycle events from the character service
+type CharacterCountConsumer struct {
+	mq      ports.MessageQueue
+	tracker portsAuth.CharacterCountTracker
+	logger  logger.Logger
+}
+
+// NewCharacterCountConsumer creates a consumer for character lifecycle events
+func NewCharacterCountConsumer(mq ports.MessageQueue, tracker portsAuth.CharacterCountTracker, logger logger.Logger) *CharacterCountConsumer {
+	return &CharacterCountConsumer{
+		mq:      mq,
+		tracker: tracker,
+		logger:  logger,
+	}
+}
+
+// Start subscribes to the events that change how many characters an account has
+func (c *CharacterCountConsumer) Start(ctx context.Context) error {
+	subjects := []character.EventType{
+		character.EventCharacterCreated,
+		character.EventCharacterDeleted,
+		character.EventCharacterRestored,
+		character.EventCharacterPurged,
+		character.EventCharacterTransferred,
+	}
+
+	for _, subject := range subjects {
+		if _, err := c.mq.QueueSubscribe(ctx, string(subject), responderQueue, c.handle); err != nil {
+			return fmt.Errorf("failed to subscribe to %s: %w", subject, err)
+		}
+	}
+
+	c.logger.Info("Character count consumer started")
+	return nil
+}
+
+// characterCountEvent holds the fields of any lifecycle event that name affected accounts.
+// Transfers also carry the account the character left.
+type characterCountEvent struct {
+	character.BaseEvent
+	FromUserID string `json:"from_user_id,omitempty"`
+}
+
+func (c *CharacterCountConsumer) handle(msg *ports.QueueMessage) error {
+	var event characterCountEvent
+	if err := json.Unmarshal(msg.Data, &event); err != nil || event.UserID == "" {
+		c.logger.WithField("subject", msg.Subject).Warn("Dropping malformed character event")
+		return nil
+	}
+
+	for _, userID := range []string{event.FromUserID, event.UserID} {
+		if userID == "" {
+			continue
+		}
+		if err := c.tracker.SyncCharacterCount(context.Background(), userID); err != nil {
+			c.logger.WithError(err).WithField("userID", userID).Warn("Failed to sync character count")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mmorpg-template/backend/internal/domain/character"
+	"github.com/mmorpg-template/backend/internal/ports"
+	"github.com/mmorpg-template/backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countTracker records which users had their character count synced
+type countTracker struct {
+	synced []string
+}
+
+func (t *countTracker) SyncCharacterCount(ctx context.Context, userID string) error {
+	t.synced = append(t.synced, userID)
+	return nil
+}
+
+func TestCharacterCountConsumer(t *testing.T) {
+	tracker := &countTracker{}
+	consumer := NewCharacterCountConsumer(nil, tracker, logger.NewNoop())
+
+	events := []interface{}{
+		&character.CharacterCreatedEvent{BaseEvent: character.BaseEvent{EventType: character.EventCharacterCreated, UserID: "user-1"}},
+		&character.CharacterDeletedEvent{BaseEvent: character.BaseEvent{EventType: character.EventCharacterDeleted, UserID: "user-1"}, SoftDelete: true},
+		&character.CharacterPurgedEvent{BaseEvent: character.BaseEvent{EventType: character.EventCharacterPurged, UserID: "user-2"}},
+		&character.CharacterTransferredEvent{BaseEvent: character.BaseEvent{EventType: character.EventCharacterTransferred, UserID: "user-3"}, FromUserID: "user-1"},
+	}
+	for _, event := range events {
+		data, err := json.Marshal(event)
+		require.NoError(t, err)
+		require.NoError(t, consumer.handle(&ports.QueueMessage{Data: data}))
+	}
+	require.NoError(t, consumer.handle(&ports.QueueMessage{Data: []byte("not json")}))
+	require.NoError(t, consumer.handle(&ports.QueueMessage{Data: []byte(`{"event_type": "character.created"}`)}))
+
+	assert.Equal(t, []string{"user-1", "user-1", "user-2", "user-1", "user-3"}, tracker.synced)
+}
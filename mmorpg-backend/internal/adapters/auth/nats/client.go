@@ -0,0 +1,258 @@
+package nats
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/mmorpg-template/backend/internal/adapters/serviceauth"
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+	"github.com/mmorpg-template/backend/internal/ports"
+)
+
+// Client defaults
+const (
+	DefaultValidationCacheTTL = 5 * time.Second
+	defaultRequestTimeout     = 2 * time.Second
+	maxCacheEntries           = 10000
+)
+
+// remoteErrors maps error strings in replies back to domain errors
+var remoteErrors = map[string]error{}
+
+func init() {
+	for _, err := range []error{
+		auth.ErrInvalidToken, auth.ErrTokenExpired, auth.ErrTokenMalformed,
+		auth.ErrTokenSignatureInvalid, auth.ErrSessionInvalid, auth.ErrSessionExpired,
+		auth.ErrSessionNotFound, auth.ErrInsufficientScope,
+	} {
+		remoteErrors[err.Error()] = err
+	}
+}
+
+// ValidationClient checks tokens and sessions against the auth service over NATS.
+// Results, including rejections, are cached for a short TTL so a revoked token is
+// rejected everywhere within that TTL without a round trip per request. A full cache
+// evicts its least recently used entries, so busy tokens stay cached.
+type ValidationClient struct {
+	mq         ports.MessageQueue
+	tokens     *serviceauth.TokenSource
+	cacheTTL   time.Duration
+	timeout    time.Duration
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	// recent orders entries from most to least recently used
+	recent *list.List
+}
+
+type cacheEntry struct {
+	key       string
+	claims    *auth.Claims
+	session   *auth.Session
+	err       error
+	expiresAt time.Time
+}
+
+// NewValidationClient creates a client that authenticates its requests with tokens from the token source
+func NewValidationClient(mq ports.MessageQueue, tokens *serviceauth.TokenSource, cacheTTL time.Duration) *ValidationClient {
+	if cacheTTL <= 0 {
+		cacheTTL = DefaultValidationCacheTTL
+	}
+	return &ValidationClient{
+		mq:         mq,
+		tokens:     tokens,
+		cacheTTL:   cacheTTL,
+		timeout:    defaultRequestTimeout,
+		maxEntries: maxCacheEntries,
+		entries:    make(map[string]*list.Element),
+		recent:     list.New(),
+	}
+}
+
+// ValidateToken returns the claims of a valid access token
+func (c *ValidationClient) ValidateToken(ctx context.Context, token string) (*auth.Claims, error) {
+	sum := sha256.Sum256([]byte(token))
+	key := "token:" + hex.EncodeToString(sum[:])
+	if entry, ok := c.lookup(key); ok {
+		return entry.claims, entry.err
+	}
+
+	var resp TokenValidateResponse
+	if err := c.request(ctx, SubjectTokenValidate, &TokenValidateRequest{Token: token}, &resp); err != nil {
+		return nil, err
+	}
+
+	entry := cacheEntry{expiresAt: time.Now().Add(c.cacheTTL)}
+	if resp.Valid {
+		entry.claims = &auth.Claims{
+			UserID:    resp.UserID,
+			SessionID: resp.SessionID,
+			Email:     resp.Email,
+			Username:  resp.Username,
+			Roles:     resp.Roles,
+			DeviceID:  resp.DeviceID,
+			RegisteredClaims: jwt.RegisteredClaims{
+				Subject:   resp.UserID,
+				ExpiresAt: jwt.NewNumericDate(resp.ExpiresAt),
+			},
+		}
+		// Never serve a token from the cache past its own expiry
+		if !resp.ExpiresAt.IsZero() && resp.ExpiresAt.Before(entry.expiresAt) {
+			entry.expiresAt = resp.ExpiresAt
+		}
+	} else {
+		entry.err = remoteError(resp.Error, auth.ErrInvalidToken)
+	}
+
+	c.store(key, entry)
+	return entry.claims, entry.err
+}
+
+// GetSession returns an active session
+func (c *ValidationClient) GetSession(ctx context.Context, sessionID string) (*auth.Session, error) {
+	key := "session:" + sessionID
+	if entry, ok := c.lookup(key); ok {
+		return entry.session, entry.err
+	}
+
+	var resp SessionGetResponse
+	if err := c.request(ctx, SubjectSessionGet, &SessionGetRequest{SessionID: sessionID}, &resp); err != nil {
+		return nil, err
+	}
+
+	entry := cacheEntry{expiresAt: time.Now().Add(c.cacheTTL)}
+	if resp.Found {
+		id, err := uuid.Parse(resp.SessionID)
+		if err != nil {
+			return nil, fmt.Errorf("malformed session ID in reply: %w", err)
+		}
+		userID, err := uuid.Parse(resp.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("malformed user ID in reply: %w", err)
+		}
+		entry.session = &auth.Session{
+			ID:         id,
+			UserID:     userID,
+			DeviceID:   resp.DeviceID,
+			IPAddress:  resp.IPAddress,
+			ExpiresAt:  resp.ExpiresAt,
+			CreatedAt:  resp.CreatedAt,
+			LastActive: resp.LastActive,
+		}
+	} else {
+		entry.err = remoteError(resp.Error, auth.ErrSessionNotFound)
+	}
+
+	c.store(key, entry)
+	return entry.session, entry.err
+}
+
+// CheckPlaytime returns an account's parental controls status. It is not cached,
+// since the answer changes as the account plays.
+func (c *ValidationClient) CheckPlaytime(ctx context.Context, userID string) (*auth.PlaytimeStatus, error) {
+	var resp PlaytimeCheckResponse
+	if err := c.request(ctx, SubjectPlaytimeCheck, &PlaytimeCheckRequest{UserID: userID}, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Error != "" {
+		return nil, remoteError(resp.Error, auth.ErrAuthUnavailable)
+	}
+
+	return &auth.PlaytimeStatus{
+		Allowed:   resp.Allowed,
+		Limited:   resp.Limited,
+		Reason:    resp.Reason,
+		Remaining: time.Duration(resp.RemainingSeconds) * time.Second,
+	}, nil
+}
+
+// PlaytimeAllowed reports whether an account may start playing now
+func (c *ValidationClient) PlaytimeAllowed(ctx context.Context, userID string) (bool, error) {
+	status, err := c.CheckPlaytime(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return status.Allowed, nil
+}
+
+func (c *ValidationClient) request(ctx context.Context, subject string, req, resp interface{}) error {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	var headers map[string]string
+	if c.tokens != nil {
+		if headers, err = c.tokens.Headers(ctx); err != nil {
+			return fmt.Errorf("%w: failed to get service token: %v", auth.ErrAuthUnavailable, err)
+		}
+	}
+
+	reply, err := c.mq.RequestWithHeaders(ctx, subject, data, headers, c.timeout)
+	if err != nil {
+		return fmt.Errorf("%w: %s request failed: %v", auth.ErrAuthUnavailable, subject, err)
+	}
+
+	if err := json.Unmarshal(reply, resp); err != nil {
+		return fmt.Errorf("failed to unmarshal %s reply: %w", subject, err)
+	}
+	return nil
+}
+
+func (c *ValidationClient) lookup(key string) (cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return cacheEntry{}, false
+	}
+	entry := elem.Value.(cacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.recent.Remove(elem)
+		delete(c.entries, key)
+		return cacheEntry{}, false
+	}
+	c.recent.MoveToFront(elem)
+	return entry, true
+}
+
+func (c *ValidationClient) store(key string, entry cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry.key = key
+	if elem, ok := c.entries[key]; ok {
+		elem.Value = entry
+		c.recent.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.recent.PushFront(entry)
+	for c.recent.Len() > c.maxEntries {
+		oldest := c.recent.Back()
+		c.recent.Remove(oldest)
+		delete(c.entries, oldest.Value.(cacheEntry).key)
+	}
+}
+
+// remoteError converts a reply error string back into a domain error where possible
+func remoteError(message string, fallback error) error {
+	if err, ok := remoteErrors[message]; ok {
+		return err
+	}
+	if message == "" {
+		return fallback
+	}
+	return errors.Join(fallback, errors.New(message))
+}
@@ -0,0 +1,131 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+	"github.com/mmorpg-template/backend/internal/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeQueue answers validation requests with a fixed reply and counts round trips
+type fakeQueue struct {
+	ports.MessageQueue
+	reply    interface{}
+	err      error
+	requests int
+}
+
+func (q *fakeQueue) RequestWithHeaders(ctx context.Context, subject string, data []byte, headers map[string]string, timeout time.Duration) ([]byte, error) {
+	q.requests++
+	if q.err != nil {
+		return nil, q.err
+	}
+	return json.Marshal(q.reply)
+}
+
+func TestValidationClient_ValidateToken_CachesResult(t *testing.T) {
+	mq := &fakeQueue{reply: &TokenValidateResponse{
+		Valid:     true,
+		UserID:    "user-1",
+		SessionID: "session-1",
+		Roles:     []string{"player"},
+		ExpiresAt: time.Now().Add(time.Minute),
+	}}
+	client := NewValidationClient(mq, nil, time.Minute)
+
+	claims, err := client.ValidateToken(context.Background(), "token")
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", claims.UserID)
+	assert.Equal(t, "session-1", claims.SessionID)
+
+	_, err = client.ValidateToken(context.Background(), "token")
+	require.NoError(t, err)
+	assert.Equal(t, 1, mq.requests)
+
+	_, err = client.ValidateToken(context.Background(), "other-token")
+	require.NoError(t, err)
+	assert.Equal(t, 2, mq.requests)
+}
+
+func TestValidationClient_ValidateToken_CachesRejection(t *testing.T) {
+	mq := &fakeQueue{reply: &TokenValidateResponse{Error: auth.ErrSessionInvalid.Error()}}
+	client := NewValidationClient(mq, nil, time.Minute)
+
+	_, err := client.ValidateToken(context.Background(), "token")
+	assert.Equal(t, auth.ErrSessionInvalid, err)
+
+	_, err = client.ValidateToken(context.Background(), "token")
+	assert.Equal(t, auth.ErrSessionInvalid, err)
+	assert.Equal(t, 1, mq.requests)
+}
+
+func TestValidationClient_ValidateToken_ExpiresWithCacheTTL(t *testing.T) {
+	mq := &fakeQueue{reply: &TokenValidateResponse{Valid: true, UserID: "user-1", SessionID: "session-1"}}
+	client := NewValidationClient(mq, nil, 10*time.Millisecond)
+
+	_, err := client.ValidateToken(context.Background(), "token")
+	require.NoError(t, err)
+
+	time.Sleep(20 * time.Millisecond)
+	mq.reply = &TokenValidateResponse{Error: auth.ErrInvalidToken.Error()}
+
+	_, err = client.ValidateToken(context.Background(), "token")
+	assert.Equal(t, auth.ErrInvalidToken, err)
+	assert.Equal(t, 2, mq.requests)
+}
+
+func TestValidationClient_Unavailable(t *testing.T) {
+	mq := &fakeQueue{err: ports.ErrMQTimeout}
+	client := NewValidationClient(mq, nil, time.Minute)
+
+	_, err := client.ValidateToken(context.Background(), "token")
+	assert.True(t, errors.Is(err, auth.ErrAuthUnavailable))
+
+	// Transport failures are not cached
+	_, _ = client.ValidateToken(context.Background(), "token")
+	assert.Equal(t, 2, mq.requests)
+}
+
+func TestValidationClient_GetSession(t *testing.T) {
+	mq := &fakeQueue{reply: &SessionGetResponse{
+		Found:     true,
+		SessionID: "5b8e3a52-8f4c-4d8c-9a55-1c1f5c1b2a10",
+		UserID:    "0d4c7f0e-3a5b-4c9e-8f7a-2b6d1e9c4a33",
+		DeviceID:  "device-1",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}}
+	client := NewValidationClient(mq, nil, time.Minute)
+
+	session, err := client.GetSession(context.Background(), "5b8e3a52-8f4c-4d8c-9a55-1c1f5c1b2a10")
+	require.NoError(t, err)
+	assert.Equal(t, "device-1", session.DeviceID)
+
+	mq.reply = &SessionGetResponse{Error: auth.ErrSessionNotFound.Error()}
+	_, err = client.GetSession(context.Background(), "missing")
+	assert.Equal(t, auth.ErrSessionNotFound, err)
+}
+
+func TestValidationClient_EvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	mq := &fakeQueue{reply: &TokenValidateResponse{Valid: true, UserID: "user-1", ExpiresAt: time.Now().Add(time.Minute)}}
+	client := NewValidationClient(mq, nil, time.Minute)
+	client.maxEntries = 2
+
+	_, _ = client.ValidateToken(ctx, "busy")
+	_, _ = client.ValidateToken(ctx, "idle")
+	_, _ = client.ValidateToken(ctx, "busy")
+	_, _ = client.ValidateToken(ctx, "new")
+	assert.Equal(t, 3, mq.requests)
+
+	// The busy token stays cached while the idle one was evicted
+	_, _ = client.ValidateToken(ctx, "busy")
+	assert.Equal(t, 3, mq.requests)
+	_, _ = client.ValidateToken(ctx, "idle")
+	assert.Equal(t, 4, mq.requests)
+}
@@ -0,0 +1,81 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mmorpg-template/backend/internal/domain/character"
+	"github.com/mmorpg-template/backend/internal/ports"
+	portsAuth "github.com/mmorpg-template/backend/internal/ports/auth"
+	"github.com/mmorpg-template/backend/pkg/logger"
+)
+
+// PresenceConsumer feeds character online/offline events from the character
+// service into play time tracking
+type PresenceConsumer struct {
+	mq      ports.MessageQueue
+	tracker portsAuth.PlaytimeTracker
+	logger  logger.Logger
+}
+
+// NewPresenceConsumer creates a consumer for character presence events
+func NewPresenceConsumer(mq ports.MessageQueue, tracker portsAuth.PlaytimeTracker, logger logger.Logger) *PresenceConsumer {
+	return &PresenceConsumer{
+		mq:      mq,
+		tracker: tracker,
+		logger:  logger,
+	}
+}
+
+// Start subscribes to character presence events
+func (p *PresenceConsumer) Start(ctx context.Context) error {
+	handlers := map[string]ports.MessageHandler{
+		string(character.EventCharacterOnline):  p.handleOnline,
+		string(character.EventCharacterOffline): p.handleOffline,
+	}
+
+	for subject, handler := range handlers {
+		if _, err := p.mq.QueueSubscribe(ctx, subject, responderQueue, handler); err != nil {
+			return fmt.Errorf("failed to subscribe to %s: %w", subject, err)
+		}
+	}
+
+	p.logger.Info("Character presence consumer started")
+	return nil
+}
+
+func (p *PresenceConsumer) handleOnline(msg *ports.QueueMessage) error {
+	var event character.CharacterOnlineEvent
+	if err := json.Unmarshal(msg.Data, &event); err != nil {
+		p.logger.WithError(err).Warn("Dropping malformed character online event")
+		return nil
+	}
+
+	if err := p.tracker.CharacterOnline(context.Background(), event.UserID, event.CharacterID, event.SessionID, eventTime(event.Timestamp)); err != nil {
+		p.logger.WithError(err).WithField("characterID", event.CharacterID).Warn("Failed to track character online")
+	}
+	return nil
+}
+
+func (p *PresenceConsumer) handleOffline(msg *ports.QueueMessage) error {
+	var event character.CharacterOfflineEvent
+	if err := json.Unmarshal(msg.Data, &event); err != nil {
+		p.logger.WithError(err).Warn("Dropping malformed character offline event")
+		return nil
+	}
+
+	if err := p.tracker.CharacterOffline(context.Background(), event.CharacterID, eventTime(event.Timestamp)); err != nil {
+		p.logger.WithError(err).WithField("characterID", event.CharacterID).Warn("Failed to track character offline")
+	}
+	return nil
+}
+
+// eventTime guards against events without a timestamp
+func eventTime(t time.Time) time.Time {
+	if t.IsZero() {
+		return time.Now()
+	}
+	return t
+}
@@ -0,0 +1,122 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+	"github.com/mmorpg-template/backend/internal/ports"
+	"github.com/mmorpg-template/backend/pkg/logger"
+)
+
+// EventPublisher implements the account event publisher using NATS
+type EventPublisher struct {
+	mq         ports.MessageQueue
+	logger     logger.Logger
+	streamName string
+}
+
+// NewEventPublisher creates a new NATS account event publisher
+func NewEventPublisher(mq ports.MessageQueue, logger logger.Logger) *EventPublisher {
+	return &EventPublisher{
+		mq:         mq,
+		logger:     logger,
+		streamName: "USER_EVENTS",
+	}
+}
+
+// Initialize creates the account events stream
+func (p *EventPublisher) Initialize(ctx context.Context) error {
+	streamConfig := ports.StreamConfig{
+		Name: p.streamName,
+		Subjects: []string{
+			"user.>", // All account events
+		},
+		Retention: ports.LimitsPolicy,
+		MaxAge:    30 * 24 * time.Hour,    // 30 days retention
+		MaxBytes:  1 * 1024 * 1024 * 1024, // 1GB max size
+		MaxMsgs:   1000000,
+		Replicas:  1,
+	}
+
+	if err := p.mq.CreateStream(ctx, streamConfig); err != nil {
+		// Check if stream already exists
+		if _, getErr := p.mq.GetStreamInfo(ctx, p.streamName); getErr != nil {
+			return fmt.Errorf("failed to create user events stream: %w", err)
+		}
+		p.logger.Info("User events stream already exists")
+	} else {
+		p.logger.Info("Created user events stream")
+	}
+
+	return nil
+}
+
+// PublishUsernameChanged publishes a username changed event
+func (p *EventPublisher) PublishUsernameChanged(ctx context.Context, event *auth.UsernameChangedEvent) error {
+	p.stamp(&event.BaseEvent, auth.EventUsernameChanged)
+	return p.publishEvent(ctx, string(auth.EventUsernameChanged), event)
+}
+
+// PublishEmailChanged publishes an email changed event
+func (p *EventPublisher) PublishEmailChanged(ctx context.Context, event *auth.EmailChangedEvent) error {
+	p.stamp(&event.BaseEvent, auth.EventEmailChanged)
+	return p.publishEvent(ctx, string(auth.EventEmailChanged), event)
+}
+
+// PublishPlaytimeWarning publishes a play time warning event
+func (p *EventPublisher) PublishPlaytimeWarning(ctx context.Context, event *auth.PlaytimeWarningEvent) error {
+	p.stamp(&event.BaseEvent, auth.EventPlaytimeWarning)
+	return p.publishEvent(ctx, string(auth.EventPlaytimeWarning), event)
+}
+
+// PublishPlaytimeLogout publishes a play time forced logout event
+func (p *EventPublisher) PublishPlaytimeLogout(ctx context.Context, event *auth.PlaytimeLogoutEvent) error {
+	p.stamp(&event.BaseEvent, auth.EventPlaytimeLogout)
+	return p.publishEvent(ctx, string(auth.EventPlaytimeLogout), event)
+}
+
+// PublishSessionEnded publishes a session ended event
+func (p *EventPublisher) PublishSessionEnded(ctx context.Context, event *auth.SessionEndedEvent) error {
+	p.stamp(&event.BaseEvent, auth.EventSessionEnded)
+	return p.publishEvent(ctx, string(auth.EventSessionEnded), event)
+}
+
+// PublishGuestDeleted publishes a guest deleted event
+func (p *EventPublisher) PublishGuestDeleted(ctx context.Context, event *auth.GuestDeletedEvent) error {
+	p.stamp(&event.BaseEvent, auth.EventGuestDeleted)
+	return p.publishEvent(ctx, string(auth.EventGuestDeleted), event)
+}
+
+func (p *EventPublisher) stamp(event *auth.BaseEvent, eventType auth.EventType) {
+	event.EventID = uuid.New().String()
+	event.EventType = eventType
+	event.Timestamp = time.Now().UTC()
+	event.Version = "1.0"
+}
+
+// publishEvent marshals and publishes an event with a few retries
+func (p *EventPublisher) publishEvent(ctx context.Context, subject string, event interface{}) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	const maxRetries = 3
+	var lastErr error
+	for i := 0; i < maxRetries; i++ {
+		if lastErr = p.mq.Publish(ctx, subject, data); lastErr == nil {
+			p.logger.Debugf("Successfully published event to %s", subject)
+			return nil
+		}
+		p.logger.Warnf("Failed to publish event (attempt %d/%d): %v", i+1, maxRetries, lastErr)
+		if i < maxRetries-1 {
+			time.Sleep(time.Duration(i+1) * 100 * time.Millisecond)
+		}
+	}
+
+	return fmt.Errorf("failed to publish event after %d attempts: %w", maxRetries, lastErr)
+}
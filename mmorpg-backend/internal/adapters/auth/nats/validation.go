@@ -0,0 +1,192 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mmorpg-template/backend/internal/adapters/serviceauth"
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+	"github.com/mmorpg-template/backend/internal/ports"
+	portsAuth "github.com/mmorpg-template/backend/internal/ports/auth"
+	"github.com/mmorpg-template/backend/pkg/logger"
+)
+
+// Request/reply subjects served by the auth service
+const (
+	SubjectTokenValidate = "auth.token.validate"
+	SubjectSessionGet    = "auth.session.get"
+	SubjectPlaytimeCheck = "auth.playtime.check"
+
+	// responderQueue spreads requests across auth service replicas
+	responderQueue = "auth-service"
+)
+
+// TokenValidateRequest asks whether an access token is currently valid
+type TokenValidateRequest struct {
+	Token string `json:"token"`
+}
+
+// TokenValidateResponse carries the claims of a valid token, or the reason it is not
+type TokenValidateResponse struct {
+	Valid     bool      `json:"valid"`
+	UserID    string    `json:"user_id,omitempty"`
+	SessionID string    `json:"session_id,omitempty"`
+	Email     string    `json:"email,omitempty"`
+	Username  string    `json:"username,omitempty"`
+	Roles     []string  `json:"roles,omitempty"`
+	DeviceID  string    `json:"device_id,omitempty"`
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// SessionGetRequest asks for an active session
+type SessionGetRequest struct {
+	SessionID string `json:"session_id"`
+}
+
+// SessionGetResponse describes an active session, or why it was not found
+type SessionGetResponse struct {
+	Found      bool      `json:"found"`
+	SessionID  string    `json:"session_id,omitempty"`
+	UserID     string    `json:"user_id,omitempty"`
+	DeviceID   string    `json:"device_id,omitempty"`
+	IPAddress  string    `json:"ip_address,omitempty"`
+	ExpiresAt  time.Time `json:"expires_at,omitempty"`
+	CreatedAt  time.Time `json:"created_at,omitempty"`
+	LastActive time.Time `json:"last_active,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// PlaytimeCheckRequest asks whether an account may start playing now
+type PlaytimeCheckRequest struct {
+	UserID string `json:"user_id"`
+}
+
+// PlaytimeCheckResponse reports an account's parental controls status
+type PlaytimeCheckResponse struct {
+	Allowed          bool   `json:"allowed"`
+	Limited          bool   `json:"limited"`
+	Reason           string `json:"reason,omitempty"`
+	RemainingSeconds int64  `json:"remaining_seconds,omitempty"`
+	Error            string `json:"error,omitempty"`
+}
+
+// ValidationResponder serves token validation, session lookups and play time checks to other services
+type ValidationResponder struct {
+	mq          ports.MessageQueue
+	authService portsAuth.AuthService
+	validator   *serviceauth.Validator
+	logger      logger.Logger
+}
+
+// NewValidationResponder creates a responder; callers must present a service token with auth:validate
+func NewValidationResponder(mq ports.MessageQueue, authService portsAuth.AuthService, validator *serviceauth.Validator, logger logger.Logger) *ValidationResponder {
+	return &ValidationResponder{
+		mq:          mq,
+		authService: authService,
+		validator:   validator,
+		logger:      logger,
+	}
+}
+
+// Start subscribes to the validation subjects
+func (r *ValidationResponder) Start(ctx context.Context) error {
+	handlers := map[string]ports.MessageHandler{
+		SubjectTokenValidate: r.handleTokenValidate,
+		SubjectSessionGet:    r.handleSessionGet,
+		SubjectPlaytimeCheck: r.handlePlaytimeCheck,
+	}
+
+	for subject, handler := range handlers {
+		guarded := r.validator.RequireScopeNATS(r.mq, handler, auth.ScopeAuthValidate)
+		if _, err := r.mq.QueueSubscribe(ctx, subject, responderQueue, guarded); err != nil {
+			return fmt.Errorf("failed to subscribe to %s: %w", subject, err)
+		}
+	}
+
+	r.logger.Info("Token validation responders started")
+	return nil
+}
+
+func (r *ValidationResponder) handleTokenValidate(msg *ports.QueueMessage) error {
+	var req TokenValidateRequest
+	if err := json.Unmarshal(msg.Data, &req); err != nil || req.Token == "" {
+		return r.reply(msg, &TokenValidateResponse{Error: auth.ErrTokenMalformed.Error()})
+	}
+
+	claims, err := r.authService.ValidateToken(context.Background(), req.Token)
+	if err != nil {
+		return r.reply(msg, &TokenValidateResponse{Error: err.Error()})
+	}
+
+	resp := &TokenValidateResponse{
+		Valid:     true,
+		UserID:    claims.UserID,
+		SessionID: claims.SessionID,
+		Email:     claims.Email,
+		Username:  claims.Username,
+		Roles:     claims.Roles,
+		DeviceID:  claims.DeviceID,
+	}
+	if claims.ExpiresAt != nil {
+		resp.ExpiresAt = claims.ExpiresAt.Time
+	}
+	return r.reply(msg, resp)
+}
+
+func (r *ValidationResponder) handleSessionGet(msg *ports.QueueMessage) error {
+	var req SessionGetRequest
+	if err := json.Unmarshal(msg.Data, &req); err != nil || req.SessionID == "" {
+		return r.reply(msg, &SessionGetResponse{Error: auth.ErrSessionInvalid.Error()})
+	}
+
+	session, err := r.authService.GetSession(context.Background(), req.SessionID)
+	if err != nil {
+		return r.reply(msg, &SessionGetResponse{Error: err.Error()})
+	}
+
+	return r.reply(msg, &SessionGetResponse{
+		Found:      true,
+		SessionID:  session.ID.String(),
+		UserID:     session.UserID.String(),
+		DeviceID:   session.DeviceID,
+		IPAddress:  session.IPAddress,
+		ExpiresAt:  session.ExpiresAt,
+		CreatedAt:  session.CreatedAt,
+		LastActive: session.LastActive,
+	})
+}
+
+func (r *ValidationResponder) handlePlaytimeCheck(msg *ports.QueueMessage) error {
+	var req PlaytimeCheckRequest
+	if err := json.Unmarshal(msg.Data, &req); err != nil || req.UserID == "" {
+		return r.reply(msg, &PlaytimeCheckResponse{Error: auth.ErrUserNotFound.Error()})
+	}
+
+	status, err := r.authService.CheckPlaytime(context.Background(), req.UserID)
+	if err != nil {
+		return r.reply(msg, &PlaytimeCheckResponse{Error: err.Error()})
+	}
+
+	return r.reply(msg, &PlaytimeCheckResponse{
+		Allowed:          status.Allowed,
+		Limited:          status.Limited,
+		Reason:           status.Reason,
+		RemainingSeconds: int64(status.Remaining / time.Second),
+	})
+}
+
+func (r *ValidationResponder) reply(msg *ports.QueueMessage, resp interface{}) error {
+	if msg.ReplyTo == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return fmt.Errorf("failed to marshal reply: %w", err)
+	}
+
+	return r.mq.Publish(context.Background(), msg.ReplyTo, data)
+}
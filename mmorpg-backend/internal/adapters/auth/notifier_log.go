@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+	portsAuth "github.com/mmorpg-template/backend/internal/ports/auth"
+	"github.com/mmorpg-template/backend/pkg/logger"
+)
+
+// LogNotifier implements Notifier by writing notifications to the service log.
+// It stands in for an email/push provider in development and never delivers
+// anything; one-time tokens and links are redacted from the log.
+type LogNotifier struct {
+	logger logger.Logger
+}
+
+// redactedNotificationData are the notification data keys that grant access to the account
+var redactedNotificationData = map[string]bool{
+	"token": true,
+	"link":  true,
+}
+
+// NewLogNotifier creates a notifier that logs instead of delivering
+func NewLogNotifier(logger logger.Logger) portsAuth.Notifier {
+	return &LogNotifier{logger: logger}
+}
+
+// Notify logs the notification
+func (n *LogNotifier) Notify(ctx context.Context, notification *auth.Notification) error {
+	fields := map[string]interface{}{
+		"kind":   notification.Kind,
+		"userID": notification.UserID,
+		"email":  notification.Email,
+	}
+	for k, v := range notification.Data {
+		if redactedNotificationData[k] {
+			v = "[redacted]"
+		}
+		fields[k] = v
+	}
+	n.logger.WithFields(fields).Info("Notification")
+	return nil
+}
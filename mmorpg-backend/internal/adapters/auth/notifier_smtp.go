@@ -0,0 +1,202 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"net/url"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+	portsAuth "github.com/mmorpg-template/backend/internal/ports/auth"
+)
+
+// SMTPConfig holds the mail server and sender used by SMTPNotifier
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	// LinkBaseURL is the account page that one-time tokens are sent to,
+	// e.g. https://play.example.com/account; a token for kind K links to <LinkBaseURL>/K?token=...
+	LinkBaseURL string
+	// Timeout bounds one delivery, connection included; defaults to defaultSMTPTimeout
+	Timeout time.Duration
+}
+
+// defaultSMTPTimeout keeps a slow mail server from holding up the request that sends the mail
+const defaultSMTPTimeout = 10 * time.Second
+
+// notificationTemplate is the subject and plain-text body for one kind of notification
+type notificationTemplate struct {
+	subject string
+	body    *template.Template
+}
+
+var notificationTemplates = map[auth.NotificationKind]notificationTemplate{
+	auth.NotificationNewDeviceLogin: {"New sign-in to your account", template.Must(template.New("").Parse(
+		"Hi {{.Username}},\n\nYour account was signed in to from a new device.\n\nIP address: {{.Data.ip_address}}\nDevice: {{.Data.user_agent}}\n\nIf this wasn't you, change your password now.\n"))},
+	auth.NotificationNewNetworkLogin: {"Sign-in from a new location", template.Must(template.New("").Parse(
+		"Hi {{.Username}},\n\nYour account was signed in to from a new network.\n\nIP address: {{.Data.ip_address}}\nDevice: {{.Data.user_agent}}\n\nIf this wasn't you, change your password now.\n"))},
+	auth.NotificationDeviceConfirmation: {"Confirm your new device", template.Must(template.New("").Parse(
+		"Hi {{.Username}},\n\nA sign-in from a new device is waiting for your confirmation.\n\nIP address: {{.Data.ip_address}}\nDevice: {{.Data.user_agent}}\n\nConfirm it here:\n{{.Link}}\n\nIf this wasn't you, ignore this email and change your password.\n"))},
+	auth.NotificationAccountUnlock: {"Unlock your account", template.Must(template.New("").Parse(
+		"Hi {{.Username}},\n\nYour account was locked after too many failed sign-ins until {{.Data.locked_until}}.\n\nUnlock it now:\n{{.Link}}\n"))},
+	auth.NotificationPasswordReset: {"Reset your password", template.Must(template.New("").Parse(
+		"Hi {{.Username}},\n\nReset your password here:\n{{.Link}}\n\nIf you didn't ask for this, ignore this email.\n"))},
+	auth.NotificationEmailChangeOld: {"Confirm your email change", template.Must(template.New("").Parse(
+		"Hi {{.Username}},\n\nA change of your account email to {{.Data.new_email}} was requested.\n\nConfirm it here:\n{{.Link}}\n\nIf this wasn't you, change your password now.\n"))},
+	auth.NotificationEmailChangeNew: {"Confirm your new email address", template.Must(template.New("").Parse(
+		"Hi {{.Username}},\n\nConfirm this address for your account:\n{{.Link}}\n"))},
+	auth.NotificationEmailChanged: {"Your email address was changed", template.Must(template.New("").Parse(
+		"Hi {{.Username}},\n\nYour account email was changed and this address will no longer receive account mail.\n\nIf this wasn't you, contact support.\n"))},
+	auth.NotificationUsernameChanged: {"Your username was changed", template.Must(template.New("").Parse(
+		"Hi {{.Username}},\n\nYour username was changed from {{.Data.old_username}} to {{.Username}}.\n\nIf this wasn't you, contact support.\n"))},
+	auth.NotificationGuardianLink: {"Parental controls request", template.Must(template.New("").Parse(
+		"Hi {{.Username}},\n\n{{.Data.guardian_username}} asked to manage parental controls for your account.\n\nAccept here:\n{{.Link}}\n"))},
+}
+
+// SMTPNotifier implements Notifier by sending plain-text emails through an SMTP server
+type SMTPNotifier struct {
+	config SMTPConfig
+	auth   smtp.Auth
+	send   func(ctx context.Context, addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewSMTPNotifier creates a notifier that emails users
+func NewSMTPNotifier(config SMTPConfig) (portsAuth.Notifier, error) {
+	if config.Host == "" || config.From == "" {
+		return nil, fmt.Errorf("SMTP host and sender are required")
+	}
+	if config.Port == 0 {
+		config.Port = 587
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = defaultSMTPTimeout
+	}
+
+	notifier := &SMTPNotifier{config: config}
+	notifier.send = notifier.sendMail
+	if config.Username != "" {
+		notifier.auth = smtp.PlainAuth("", config.Username, config.Password, config.Host)
+	}
+	return notifier, nil
+}
+
+// Notify renders the notification and sends it to the user's email address
+func (n *SMTPNotifier) Notify(ctx context.Context, notification *auth.Notification) error {
+	if notification.Email == "" {
+		return nil
+	}
+
+	msg, err := n.render(notification)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, n.config.Timeout)
+	defer cancel()
+
+	addr := net.JoinHostPort(n.config.Host, strconv.Itoa(n.config.Port))
+	if err := n.send(ctx, addr, n.auth, n.config.From, []string{notification.Email}, msg); err != nil {
+		return fmt.Errorf("failed to send %s email: %w", notification.Kind, err)
+	}
+	return nil
+}
+
+// render builds the full message, headers included
+func (n *SMTPNotifier) render(notification *auth.Notification) ([]byte, error) {
+	tmpl, ok := notificationTemplates[notification.Kind]
+	if !ok {
+		return nil, fmt.Errorf("no email template for notification %q", notification.Kind)
+	}
+
+	view := struct {
+		*auth.Notification
+		Link string
+	}{Notification: notification}
+	if token := notification.Data["token"]; token != "" {
+		view.Link = fmt.Sprintf("%s/%s?token=%s", strings.TrimRight(n.config.LinkBaseURL, "/"), notification.Kind, url.QueryEscape(token))
+	}
+
+	var body bytes.Buffer
+	if err := tmpl.body.Execute(&body, view); err != nil {
+		return nil, fmt.Errorf("failed to render %s email: %w", notification.Kind, err)
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", n.config.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", notification.Email)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", tmpl.subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(body.String(), "\n", "\r\n"))
+	return msg.Bytes(), nil
+}
+
+// sendMail does what smtp.SendMail does, but gives up when ctx is done instead of
+// waiting on the server indefinitely
+func (n *SMTPNotifier) sendMail(ctx context.Context, addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	// Unblocks any read or write in progress when ctx is cancelled early
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	client, err := smtp.NewClient(conn, n.config.Host)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: n.config.Host}); err != nil {
+			return err
+		}
+	}
+	if a != nil {
+		if ok, _ := client.Extension("AUTH"); !ok {
+			return errors.New("smtp: server doesn't support AUTH")
+		}
+		if err := client.Auth(a); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(from); err != nil {
+		return err
+	}
+	for _, rcpt := range to {
+		if err := client.Rcpt(rcpt); err != nil {
+			return err
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
@@ -0,0 +1,143 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/smtp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+	"github.com/mmorpg-template/backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fieldsLogger captures the fields of the last WithFields call
+type fieldsLogger struct {
+	logger.Logger
+	fields map[string]interface{}
+}
+
+func (l *fieldsLogger) WithFields(fields map[string]interface{}) logger.Logger {
+	l.fields = fields
+	return l
+}
+
+func (l *fieldsLogger) Info(args ...interface{}) {}
+
+func testNotification(kind auth.NotificationKind) *auth.Notification {
+	return auth.NewNotification(kind, &auth.User{ID: uuid.New(), Email: "player@example.com", Username: "player1"})
+}
+
+func TestLogNotifier_RedactsTokens(t *testing.T) {
+	log := &fieldsLogger{}
+	notification := testNotification(auth.NotificationAccountUnlock)
+	notification.Data["token"] = "secret-token"
+	notification.Data["locked_until"] = "2026-01-01T00:00:00Z"
+
+	require.NoError(t, NewLogNotifier(log).Notify(context.Background(), notification))
+
+	assert.Equal(t, "[redacted]", log.fields["token"])
+	assert.Equal(t, "2026-01-01T00:00:00Z", log.fields["locked_until"])
+	assert.Equal(t, auth.NotificationAccountUnlock, log.fields["kind"])
+}
+
+func TestSMTPNotifier(t *testing.T) {
+	_, err := NewSMTPNotifier(SMTPConfig{Host: "smtp.example.com"})
+	assert.Error(t, err, "sender is required")
+
+	newNotifier := func(t *testing.T, send func(context.Context, string, smtp.Auth, string, []string, []byte) error) *SMTPNotifier {
+		notifier, err := NewSMTPNotifier(SMTPConfig{
+			Host:        "smtp.example.com",
+			Username:    "mailer",
+			Password:    "secret",
+			From:        "noreply@example.com",
+			LinkBaseURL: "https://play.example.com/account/",
+		})
+		require.NoError(t, err)
+		smtpNotifier := notifier.(*SMTPNotifier)
+		smtpNotifier.send = send
+		return smtpNotifier
+	}
+
+	t.Run("sends link with token", func(t *testing.T) {
+		var addr, from string
+		var to []string
+		var msg []byte
+		notifier := newNotifier(t, func(_ context.Context, a string, _ smtp.Auth, f string, recipients []string, m []byte) error {
+			addr, from, to, msg = a, f, recipients, m
+			return nil
+		})
+
+		notification := testNotification(auth.NotificationPasswordReset)
+		notification.Data["token"] = "abc+/="
+		require.NoError(t, notifier.Notify(context.Background(), notification))
+
+		assert.Equal(t, "smtp.example.com:587", addr)
+		assert.Equal(t, "noreply@example.com", from)
+		assert.Equal(t, []string{"player@example.com"}, to)
+		assert.Contains(t, string(msg), "To: player@example.com\r\n")
+		assert.Contains(t, string(msg), "Subject: Reset your password\r\n")
+		assert.Contains(t, string(msg), "https://play.example.com/account/password_reset?token=abc%2B%2F%3D\r\n")
+	})
+
+	t.Run("every kind has a template", func(t *testing.T) {
+		notifier := newNotifier(t, nil)
+		for _, kind := range []auth.NotificationKind{
+			auth.NotificationNewDeviceLogin,
+			auth.NotificationNewNetworkLogin,
+			auth.NotificationDeviceConfirmation,
+			auth.NotificationAccountUnlock,
+			auth.NotificationPasswordReset,
+			auth.NotificationEmailChangeOld,
+			auth.NotificationEmailChangeNew,
+			auth.NotificationEmailChanged,
+			auth.NotificationUsernameChanged,
+			auth.NotificationGuardianLink,
+		} {
+			_, err := notifier.render(testNotification(kind))
+			assert.NoError(t, err, kind)
+		}
+
+		_, err := notifier.render(testNotification("unknown"))
+		assert.Error(t, err)
+	})
+
+	t.Run("send failure is returned", func(t *testing.T) {
+		notifier := newNotifier(t, func(context.Context, string, smtp.Auth, string, []string, []byte) error {
+			return errors.New("connection refused")
+		})
+		assert.Error(t, notifier.Notify(context.Background(), testNotification(auth.NotificationEmailChanged)))
+	})
+
+	t.Run("unresponsive server times out", func(t *testing.T) {
+		// Accepts connections but never sends the SMTP greeting
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer listener.Close()
+		go func() {
+			for {
+				conn, err := listener.Accept()
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+			}
+		}()
+
+		host, port, err := net.SplitHostPort(listener.Addr().String())
+		require.NoError(t, err)
+		portNumber, err := strconv.Atoi(port)
+		require.NoError(t, err)
+		notifier, err := NewSMTPNotifier(SMTPConfig{Host: host, Port: portNumber, From: "noreply@example.com", Timeout: 100 * time.Millisecond})
+		require.NoError(t, err)
+
+		start := time.Now()
+		assert.Error(t, notifier.Notify(context.Background(), testNotification(auth.NotificationEmailChanged)))
+		assert.Less(t, time.Since(start), 5*time.Second)
+	})
+}
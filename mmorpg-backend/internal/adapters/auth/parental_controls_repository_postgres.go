@@ -0,0 +1,170 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+	portsAuth "github.com/mmorpg-template/backend/internal/ports/auth"
+)
+
+// PostgresParentalControlsRepository implements ParentalControlsRepository using PostgreSQL
+type PostgresParentalControlsRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresParentalControlsRepository creates a new PostgreSQL parental controls repository
+func NewPostgresParentalControlsRepository(db *sql.DB) portsAuth.ParentalControlsRepository {
+	return &PostgresParentalControlsRepository{db: db}
+}
+
+const parentalControlsColumns = `user_id, guardian_id, daily_minutes, weekly_minutes, allowed_windows,
+	timezone, enabled, created_at, updated_at`
+
+// Get retrieves the controls on an account
+func (r *PostgresParentalControlsRepository) Get(ctx context.Context, userID string) (*auth.ParentalControls, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, auth.ErrParentalControlsNotFound
+	}
+
+	query := `SELECT ` + parentalControlsColumns + ` FROM parental_controls WHERE user_id = $1`
+
+	controls, err := scanParentalControls(r.db.QueryRowContext(ctx, query, uid))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, auth.ErrParentalControlsNotFound
+		}
+		return nil, fmt.Errorf("failed to get parental controls: %w", err)
+	}
+
+	return controls, nil
+}
+
+// ListByGuardian retrieves the controls a guardian manages
+func (r *PostgresParentalControlsRepository) ListByGuardian(ctx context.Context, guardianID string) ([]*auth.ParentalControls, error) {
+	gid, err := uuid.Parse(guardianID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid guardian ID: %w", err)
+	}
+
+	query := `
+		SELECT ` + parentalControlsColumns + `
+		FROM parental_controls
+		WHERE guardian_id = $1
+		ORDER BY created_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, gid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list parental controls: %w", err)
+	}
+	defer rows.Close()
+
+	var list []*auth.ParentalControls
+	for rows.Next() {
+		controls, err := scanParentalControls(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan parental controls: %w", err)
+		}
+		list = append(list, controls)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating parental controls: %w", err)
+	}
+
+	return list, nil
+}
+
+// Save creates or replaces the controls on an account
+func (r *PostgresParentalControlsRepository) Save(ctx context.Context, controls *auth.ParentalControls) error {
+	windows, err := json.Marshal(controls.Windows)
+	if err != nil {
+		return fmt.Errorf("failed to marshal play windows: %w", err)
+	}
+
+	query := `
+		INSERT INTO parental_controls (
+			user_id, guardian_id, daily_minutes, weekly_minutes, allowed_windows,
+			timezone, enabled, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (user_id) DO UPDATE SET
+			guardian_id = EXCLUDED.guardian_id,
+			daily_minutes = EXCLUDED.daily_minutes,
+			weekly_minutes = EXCLUDED.weekly_minutes,
+			allowed_windows = EXCLUDED.allowed_windows,
+			timezone = EXCLUDED.timezone,
+			enabled = EXCLUDED.enabled
+	`
+
+	_, err = r.db.ExecContext(ctx, query,
+		controls.UserID,
+		controls.GuardianID,
+		controls.DailyMinutes,
+		controls.WeeklyMinutes,
+		windows,
+		controls.Timezone,
+		controls.Enabled,
+		controls.CreatedAt,
+		controls.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save parental controls: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes the controls on an account
+func (r *PostgresParentalControlsRepository) Delete(ctx context.Context, userID string) error {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return auth.ErrParentalControlsNotFound
+	}
+
+	result, err := r.db.ExecContext(ctx, `DELETE FROM parental_controls WHERE user_id = $1`, uid)
+	if err != nil {
+		return fmt.Errorf("failed to delete parental controls: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return auth.ErrParentalControlsNotFound
+	}
+
+	return nil
+}
+
+func scanParentalControls(row rowScanner) (*auth.ParentalControls, error) {
+	var (
+		controls auth.ParentalControls
+		windows  []byte
+	)
+	err := row.Scan(
+		&controls.UserID,
+		&controls.GuardianID,
+		&controls.DailyMinutes,
+		&controls.WeeklyMinutes,
+		&windows,
+		&controls.Timezone,
+		&controls.Enabled,
+		&controls.CreatedAt,
+		&controls.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(windows, &controls.Windows); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal play windows: %w", err)
+	}
+
+	return &controls, nil
+}
@@ -0,0 +1,151 @@
+package auth
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+	portsAuth "github.com/mmorpg-template/backend/internal/ports/auth"
+)
+
+// sha1PrefixLength is the hash prefix length used to name range files
+const sha1PrefixLength = 5
+
+// FilePasswordScreener implements PasswordScreener using local files only.
+//
+// Breached passwords are read from a directory in the k-anonymity range layout:
+// one file per 5-character upper-case SHA-1 prefix (e.g. "21BD1" or "21BD1.txt"),
+// each line holding the remaining 35 hash characters and a breach count
+// ("SUFFIX:COUNT"). Only the file for the password's prefix is read per check.
+//
+// Common passwords come from a denylist file with one password per line;
+// blank lines and lines starting with '#' are ignored. Matching is case-insensitive.
+type FilePasswordScreener struct {
+	rangeDir string
+	minCount int
+	denylist map[string]struct{}
+}
+
+// NewFilePasswordScreener creates a screener. Either path may be empty to disable that check.
+// Passwords seen fewer than minCount times in the breach corpus are allowed.
+func NewFilePasswordScreener(rangeDir, denylistPath string, minCount int) (portsAuth.PasswordScreener, error) {
+	screener := &FilePasswordScreener{
+		rangeDir: rangeDir,
+		minCount: minCount,
+		denylist: map[string]struct{}{},
+	}
+
+	if rangeDir != "" {
+		info, err := os.Stat(rangeDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open breached password directory: %w", err)
+		}
+		if !info.IsDir() {
+			return nil, fmt.Errorf("breached password path %s is not a directory", rangeDir)
+		}
+	}
+
+	if denylistPath != "" {
+		if err := screener.loadDenylist(denylistPath); err != nil {
+			return nil, err
+		}
+	}
+
+	return screener, nil
+}
+
+// Screen returns ErrPasswordBreached if the password is denylisted or breached
+func (s *FilePasswordScreener) Screen(ctx context.Context, password string) error {
+	if _, ok := s.denylist[strings.ToLower(password)]; ok {
+		return auth.ErrPasswordBreached
+	}
+
+	if s.rangeDir == "" {
+		return nil
+	}
+
+	breached, err := s.isBreached(password)
+	if err != nil {
+		return err
+	}
+	if breached {
+		return auth.ErrPasswordBreached
+	}
+
+	return nil
+}
+
+func (s *FilePasswordScreener) isBreached(password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:sha1PrefixLength], hash[sha1PrefixLength:]
+
+	file, err := s.openRange(prefix)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to open breached password range %s: %w", prefix, err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		candidate, countStr, _ := strings.Cut(line, ":")
+		if !strings.EqualFold(candidate, suffix) {
+			continue
+		}
+
+		count, err := strconv.Atoi(countStr)
+		if err != nil {
+			// No usable count, treat any listing as breached
+			return true, nil
+		}
+		return count >= s.minCount, nil
+	}
+
+	if err := scanner.Err(); err != nil {
+		return false, fmt.Errorf("failed to read breached password range %s: %w", prefix, err)
+	}
+
+	return false, nil
+}
+
+func (s *FilePasswordScreener) openRange(prefix string) (*os.File, error) {
+	file, err := os.Open(filepath.Join(s.rangeDir, prefix))
+	if err == nil || !os.IsNotExist(err) {
+		return file, err
+	}
+	return os.Open(filepath.Join(s.rangeDir, prefix+".txt"))
+}
+
+func (s *FilePasswordScreener) loadDenylist(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open password denylist: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		s.denylist[strings.ToLower(line)] = struct{}{}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read password denylist: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilePasswordScreener(t *testing.T) {
+	dir := t.TempDir()
+
+	// SHA-1("password") = 5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8
+	// SHA-1("P@ssw0rd") = 21BD12DC183F740EE76F27B78EB39C8AD972A757
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "5BAA6"),
+		[]byte("003D68EB55068C33ACE09247EE4C639306B:3\r\n1E4C9B93F3F0682250B6CF8331B7EE68FD8:9545824\r\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "21BD1.txt"),
+		[]byte("2DC183F740EE76F27B78EB39C8AD972A757:1\n"), 0o644))
+
+	denylist := filepath.Join(dir, "denylist.txt")
+	require.NoError(t, os.WriteFile(denylist, []byte("# common\nDragon2024!\n\n"), 0o644))
+
+	ctx := context.Background()
+
+	t.Run("breached password is rejected", func(t *testing.T) {
+		screener, err := NewFilePasswordScreener(dir, "", 1)
+		require.NoError(t, err)
+		assert.Equal(t, auth.ErrPasswordBreached, screener.Screen(ctx, "password"))
+		assert.Equal(t, auth.ErrPasswordBreached, screener.Screen(ctx, "P@ssw0rd"))
+	})
+
+	t.Run("min count allows rare breaches", func(t *testing.T) {
+		screener, err := NewFilePasswordScreener(dir, "", 2)
+		require.NoError(t, err)
+		assert.Equal(t, auth.ErrPasswordBreached, screener.Screen(ctx, "password"))
+		assert.NoError(t, screener.Screen(ctx, "P@ssw0rd"))
+	})
+
+	t.Run("unlisted password is allowed", func(t *testing.T) {
+		screener, err := NewFilePasswordScreener(dir, denylist, 1)
+		require.NoError(t, err)
+		assert.NoError(t, screener.Screen(ctx, "Correct-Horse-Battery-9"))
+	})
+
+	t.Run("denylist is case-insensitive", func(t *testing.T) {
+		screener, err := NewFilePasswordScreener("", denylist, 1)
+		require.NoError(t, err)
+		assert.Equal(t, auth.ErrPasswordBreached, screener.Screen(ctx, "dragon2024!"))
+	})
+
+	t.Run("missing directory fails at startup", func(t *testing.T) {
+		_, err := NewFilePasswordScreener(filepath.Join(dir, "missing"), "", 1)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,182 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+	portsAuth "github.com/mmorpg-template/backend/internal/ports/auth"
+)
+
+// PostgresPlaytimeRepository implements PlaytimeRepository using PostgreSQL
+type PostgresPlaytimeRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresPlaytimeRepository creates a new PostgreSQL play time repository
+func NewPostgresPlaytimeRepository(db *sql.DB) portsAuth.PlaytimeRepository {
+	return &PostgresPlaytimeRepository{db: db}
+}
+
+const playSessionColumns = `character_id, user_id, session_id, started_at, accounted_until, last_warning`
+
+// AddUsage adds play time to an account's total for a local calendar day
+func (r *PostgresPlaytimeRepository) AddUsage(ctx context.Context, userID string, day time.Time, played time.Duration) error {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	query := `
+		INSERT INTO playtime_usage (user_id, day, seconds)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, day) DO UPDATE SET seconds = playtime_usage.seconds + EXCLUDED.seconds
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, uid, day.Format("2006-01-02"), int64(played.Seconds())); err != nil {
+		return fmt.Errorf("failed to add play time: %w", err)
+	}
+	return nil
+}
+
+// SumUsage returns an account's play time over the local calendar days from..to inclusive
+func (r *PostgresPlaytimeRepository) SumUsage(ctx context.Context, userID string, from, to time.Time) (time.Duration, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return 0, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	query := `
+		SELECT COALESCE(SUM(seconds), 0)
+		FROM playtime_usage
+		WHERE user_id = $1 AND day BETWEEN $2 AND $3
+	`
+
+	var seconds int64
+	err = r.db.QueryRowContext(ctx, query, uid, from.Format("2006-01-02"), to.Format("2006-01-02")).Scan(&seconds)
+	if err != nil {
+		return 0, fmt.Errorf("failed to sum play time: %w", err)
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// OpenSession starts tracking an online character
+func (r *PostgresPlaytimeRepository) OpenSession(ctx context.Context, session *auth.PlaySession) error {
+	query := `
+		INSERT INTO playtime_sessions (` + playSessionColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (character_id) DO UPDATE SET
+			user_id = EXCLUDED.user_id,
+			session_id = EXCLUDED.session_id,
+			started_at = EXCLUDED.started_at,
+			accounted_until = EXCLUDED.accounted_until,
+			last_warning = EXCLUDED.last_warning
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		session.CharacterID,
+		session.UserID,
+		nullUUID(session.SessionID),
+		session.StartedAt,
+		session.AccountedUntil,
+		session.LastWarning,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to open play session: %w", err)
+	}
+	return nil
+}
+
+// UpdateSession stores accounting progress and warnings for an online character
+func (r *PostgresPlaytimeRepository) UpdateSession(ctx context.Context, session *auth.PlaySession) error {
+	query := `
+		UPDATE playtime_sessions
+		SET accounted_until = $2, last_warning = $3
+		WHERE character_id = $1
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, session.CharacterID, session.AccountedUntil, session.LastWarning); err != nil {
+		return fmt.Errorf("failed to update play session: %w", err)
+	}
+	return nil
+}
+
+// CloseSession stops tracking a character and returns its entry
+func (r *PostgresPlaytimeRepository) CloseSession(ctx context.Context, characterID string) (*auth.PlaySession, error) {
+	cid, err := uuid.Parse(characterID)
+	if err != nil {
+		return nil, nil
+	}
+
+	query := `DELETE FROM playtime_sessions WHERE character_id = $1 RETURNING ` + playSessionColumns
+
+	session, err := scanPlaySession(r.db.QueryRowContext(ctx, query, cid))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to close play session: %w", err)
+	}
+	return session, nil
+}
+
+// ListSessions retrieves all tracked online characters
+func (r *PostgresPlaytimeRepository) ListSessions(ctx context.Context) ([]*auth.PlaySession, error) {
+	return r.listSessions(ctx, `SELECT `+playSessionColumns+` FROM playtime_sessions ORDER BY user_id`)
+}
+
+// ListSessionsByUser retrieves the tracked online characters of an account
+func (r *PostgresPlaytimeRepository) ListSessionsByUser(ctx context.Context, userID string) ([]*auth.PlaySession, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+	return r.listSessions(ctx, `SELECT `+playSessionColumns+` FROM playtime_sessions WHERE user_id = $1`, uid)
+}
+
+func (r *PostgresPlaytimeRepository) listSessions(ctx context.Context, query string, args ...interface{}) ([]*auth.PlaySession, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list play sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []*auth.PlaySession
+	for rows.Next() {
+		session, err := scanPlaySession(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan play session: %w", err)
+		}
+		sessions = append(sessions, session)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating play sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+func scanPlaySession(row rowScanner) (*auth.PlaySession, error) {
+	var (
+		session   auth.PlaySession
+		sessionID uuid.NullUUID
+	)
+	err := row.Scan(
+		&session.CharacterID,
+		&session.UserID,
+		&sessionID,
+		&session.StartedAt,
+		&session.AccountedUntil,
+		&session.LastWarning,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	session.SessionID = uuidString(sessionID)
+	return &session, nil
+}
@@ -0,0 +1,209 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+	portsAuth "github.com/mmorpg-template/backend/internal/ports/auth"
+)
+
+// PostgresSecurityEventRepository implements SecurityEventRepository using PostgreSQL
+type PostgresSecurityEventRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresSecurityEventRepository creates a new PostgreSQL security event repository
+func NewPostgresSecurityEventRepository(db *sql.DB) portsAuth.SecurityEventRepository {
+	return &PostgresSecurityEventRepository{db: db}
+}
+
+// Append stores a new security event
+func (r *PostgresSecurityEventRepository) Append(ctx context.Context, event *auth.SecurityEvent) error {
+	metadata, err := json.Marshal(event.Metadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal security event metadata: %w", err)
+	}
+
+	query := `
+		INSERT INTO security_events (
+			id, event_type, outcome, actor_id, target_user_id, session_id,
+			ip_address, device_id, user_agent, reason, metadata, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+
+	_, err = r.db.ExecContext(ctx, query,
+		event.ID,
+		string(event.EventType),
+		string(event.Outcome),
+		nullUUID(event.ActorID),
+		nullUUID(event.TargetUserID),
+		nullUUID(event.SessionID),
+		nullString(event.IPAddress),
+		nullString(event.DeviceID),
+		nullString(event.UserAgent),
+		nullString(event.Reason),
+		metadata,
+		event.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to append security event: %w", err)
+	}
+
+	return nil
+}
+
+// Query retrieves security events matching a filter, newest first
+func (r *PostgresSecurityEventRepository) Query(ctx context.Context, filter *auth.SecurityEventFilter) ([]*auth.SecurityEvent, error) {
+	filter.Normalize()
+
+	var (
+		conditions []string
+		args       []interface{}
+	)
+	addCondition := func(clause string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(clause, len(args)))
+	}
+
+	if filter.UserID != "" {
+		addCondition("target_user_id = $%d", nullUUID(filter.UserID))
+	}
+	if filter.ActorID != "" {
+		addCondition("actor_id = $%d", nullUUID(filter.ActorID))
+	}
+	if filter.IPAddress != "" {
+		addCondition("ip_address = $%d", filter.IPAddress)
+	}
+	if filter.DeviceID != "" {
+		addCondition("device_id = $%d", filter.DeviceID)
+	}
+	if len(filter.Types) > 0 {
+		types := make([]string, len(filter.Types))
+		for i, t := range filter.Types {
+			types[i] = string(t)
+		}
+		addCondition("event_type = ANY($%d)", pq.Array(types))
+	}
+	if filter.Since != nil {
+		addCondition("created_at >= $%d", *filter.Since)
+	}
+	if filter.Until != nil {
+		addCondition("created_at < $%d", *filter.Until)
+	}
+
+	query := `
+		SELECT
+			id, event_type, outcome, actor_id, target_user_id, session_id,
+			ip_address, device_id, user_agent, reason, metadata, created_at
+		FROM security_events
+	`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	args = append(args, filter.Limit, filter.Offset)
+	query += fmt.Sprintf(" ORDER BY created_at DESC LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query security events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*auth.SecurityEvent
+	for rows.Next() {
+		var (
+			event                          auth.SecurityEvent
+			eventType, outcome             string
+			actorID, targetUserID, session uuid.NullUUID
+			ipAddress, deviceID, userAgent sql.NullString
+			reason                         sql.NullString
+			metadata                       []byte
+		)
+		err := rows.Scan(
+			&event.ID,
+			&eventType,
+			&outcome,
+			&actorID,
+			&targetUserID,
+			&session,
+			&ipAddress,
+			&deviceID,
+			&userAgent,
+			&reason,
+			&metadata,
+			&event.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan security event: %w", err)
+		}
+
+		event.EventType = auth.SecurityEventType(eventType)
+		event.Outcome = auth.SecurityEventOutcome(outcome)
+		event.ActorID = uuidString(actorID)
+		event.TargetUserID = uuidString(targetUserID)
+		event.SessionID = uuidString(session)
+		event.IPAddress = ipAddress.String
+		event.DeviceID = deviceID.String
+		event.UserAgent = userAgent.String
+		event.Reason = reason.String
+		if err := json.Unmarshal(metadata, &event.Metadata); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal security event metadata: %w", err)
+		}
+
+		events = append(events, &event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating security events: %w", err)
+	}
+
+	return events, nil
+}
+
+// EnsurePartitions creates monthly partitions covering events up to the given time
+func (r *PostgresSecurityEventRepository) EnsurePartitions(ctx context.Context, until time.Time) error {
+	query := `SELECT create_security_event_partitions(NOW(), $1)`
+	if _, err := r.db.ExecContext(ctx, query, until); err != nil {
+		return fmt.Errorf("failed to create security event partitions: %w", err)
+	}
+	return nil
+}
+
+// DropPartitionsBefore removes monthly partitions that only hold events older than the given time
+func (r *PostgresSecurityEventRepository) DropPartitionsBefore(ctx context.Context, before time.Time) (int, error) {
+	var dropped int
+	query := `SELECT drop_security_event_partitions($1)`
+	if err := r.db.QueryRowContext(ctx, query, before).Scan(&dropped); err != nil {
+		return 0, fmt.Errorf("failed to drop security event partitions: %w", err)
+	}
+	return dropped, nil
+}
+
+// nullUUID converts an optional UUID string into a nullable query argument
+func nullUUID(value string) uuid.NullUUID {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: id, Valid: true}
+}
+
+// nullString converts an optional string into a nullable query argument
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
+
+// uuidString converts a nullable UUID into a string, empty when NULL
+func uuidString(value uuid.NullUUID) string {
+	if !value.Valid {
+		return ""
+	}
+	return value.UUID.String()
+}
@@ -0,0 +1,157 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+	portsAuth "github.com/mmorpg-template/backend/internal/ports/auth"
+)
+
+// PostgresServiceAccountRepository implements ServiceAccountRepository using PostgreSQL
+type PostgresServiceAccountRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresServiceAccountRepository creates a new PostgreSQL service account repository
+func NewPostgresServiceAccountRepository(db *sql.DB) portsAuth.ServiceAccountRepository {
+	return &PostgresServiceAccountRepository{db: db}
+}
+
+const serviceAccountColumns = `
+	id, name, client_id, secret_hash, scopes, is_active, created_at, updated_at, last_used_at
+`
+
+// Create stores a new service account
+func (r *PostgresServiceAccountRepository) Create(ctx context.Context, account *auth.ServiceAccount) error {
+	query := `
+		INSERT INTO service_accounts (
+			id, name, client_id, secret_hash, scopes, is_active, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		account.ID,
+		account.Name,
+		account.ClientID,
+		account.SecretHash,
+		pq.Array(account.Scopes),
+		account.IsActive,
+		account.CreatedAt,
+		account.UpdatedAt,
+	)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return auth.ErrServiceAccountExists
+		}
+		return fmt.Errorf("failed to create service account: %w", err)
+	}
+
+	return nil
+}
+
+// GetByClientID retrieves a service account by its client ID
+func (r *PostgresServiceAccountRepository) GetByClientID(ctx context.Context, clientID string) (*auth.ServiceAccount, error) {
+	query := `SELECT ` + serviceAccountColumns + ` FROM service_accounts WHERE client_id = $1`
+
+	account, err := scanServiceAccount(r.db.QueryRowContext(ctx, query, clientID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, auth.ErrServiceAccountNotFound
+		}
+		return nil, fmt.Errorf("failed to get service account: %w", err)
+	}
+
+	return account, nil
+}
+
+// List retrieves all service accounts
+func (r *PostgresServiceAccountRepository) List(ctx context.Context) ([]*auth.ServiceAccount, error) {
+	query := `SELECT ` + serviceAccountColumns + ` FROM service_accounts ORDER BY name`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service accounts: %w", err)
+	}
+	defer rows.Close()
+
+	var accounts []*auth.ServiceAccount
+	for rows.Next() {
+		account, err := scanServiceAccount(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan service account: %w", err)
+		}
+		accounts = append(accounts, account)
+	}
+
+	return accounts, rows.Err()
+}
+
+// Update saves the secret, scopes and active flag of a service account
+func (r *PostgresServiceAccountRepository) Update(ctx context.Context, account *auth.ServiceAccount) error {
+	query := `
+		UPDATE service_accounts
+		SET secret_hash = $2, scopes = $3, is_active = $4
+		WHERE client_id = $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		account.ClientID,
+		account.SecretHash,
+		pq.Array(account.Scopes),
+		account.IsActive,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update service account: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return auth.ErrServiceAccountNotFound
+	}
+
+	return nil
+}
+
+// TouchLastUsed records when the account last obtained a token
+func (r *PostgresServiceAccountRepository) TouchLastUsed(ctx context.Context, clientID string, at time.Time) error {
+	query := `UPDATE service_accounts SET last_used_at = $2 WHERE client_id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, clientID, at); err != nil {
+		return fmt.Errorf("failed to touch service account: %w", err)
+	}
+
+	return nil
+}
+
+func scanServiceAccount(row rowScanner) (*auth.ServiceAccount, error) {
+	var (
+		account    auth.ServiceAccount
+		lastUsedAt sql.NullTime
+	)
+	err := row.Scan(
+		&account.ID,
+		&account.Name,
+		&account.ClientID,
+		&account.SecretHash,
+		pq.Array(&account.Scopes),
+		&account.IsActive,
+		&account.CreatedAt,
+		&account.UpdatedAt,
+		&lastUsedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if lastUsedAt.Valid {
+		account.LastUsedAt = &lastUsedAt.Time
+	}
+	return &account, nil
+}
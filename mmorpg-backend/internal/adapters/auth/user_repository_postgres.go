@@ -28,8 +28,9 @@ func (r *PostgresUserRepository) Create(ctx context.Context, user *auth.User) er
 		INSERT INTO users (
 			id, email, username, password_hash, email_verified, 
 			account_status, roles, max_characters, character_count,
-			is_premium, premium_expires_at, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+			is_premium, premium_expires_at, is_guest, guest_device_id,
+			last_login_at, created_at, updated_at, guest_secret_hash
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
 	`
 
 	_, err := r.db.ExecContext(ctx, query,
@@ -44,8 +45,12 @@ func (r *PostgresUserRepository) Create(ctx context.Context, user *auth.User) er
 		user.CharacterCount,
 		user.IsPremium,
 		user.PremiumExpiresAt,
+		user.IsGuest,
+		nullString(user.GuestDeviceID),
+		user.LastLoginAt,
 		user.CreatedAt,
 		user.UpdatedAt,
+		nullString(user.GuestSecretHash),
 	)
 
 	if err != nil {
@@ -76,7 +81,8 @@ func (r *PostgresUserRepository) GetByID(ctx context.Context, id string) (*auth.
 		SELECT 
 			id, email, username, password_hash, email_verified,
 			account_status, roles, max_characters, character_count,
-			is_premium, premium_expires_at, created_at, updated_at
+			is_premium, premium_expires_at, is_guest, COALESCE(guest_device_id, ''),
+			last_login_at, created_at, updated_at
 		FROM users
 		WHERE id = $1
 	`
@@ -94,6 +100,9 @@ func (r *PostgresUserRepository) GetByID(ctx context.Context, id string) (*auth.
 		&user.CharacterCount,
 		&user.IsPremium,
 		&user.PremiumExpiresAt,
+		&user.IsGuest,
+		&user.GuestDeviceID,
+		&user.LastLoginAt,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -114,7 +123,8 @@ func (r *PostgresUserRepository) GetByEmail(ctx context.Context, email string) (
 		SELECT 
 			id, email, username, password_hash, email_verified,
 			account_status, roles, max_characters, character_count,
-			is_premium, premium_expires_at, created_at, updated_at
+			is_premium, premium_expires_at, is_guest, COALESCE(guest_device_id, ''),
+			last_login_at, created_at, updated_at
 		FROM users
 		WHERE LOWER(email) = LOWER($1)
 	`
@@ -132,6 +142,9 @@ func (r *PostgresUserRepository) GetByEmail(ctx context.Context, email string) (
 		&user.CharacterCount,
 		&user.IsPremium,
 		&user.PremiumExpiresAt,
+		&user.IsGuest,
+		&user.GuestDeviceID,
+		&user.LastLoginAt,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -152,7 +165,8 @@ func (r *PostgresUserRepository) GetByUsername(ctx context.Context, username str
 		SELECT 
 			id, email, username, password_hash, email_verified,
 			account_status, roles, max_characters, character_count,
-			is_premium, premium_expires_at, created_at, updated_at
+			is_premium, premium_expires_at, is_guest, COALESCE(guest_device_id, ''),
+			last_login_at, created_at, updated_at
 		FROM users
 		WHERE LOWER(username) = LOWER($1)
 	`
@@ -170,6 +184,9 @@ func (r *PostgresUserRepository) GetByUsername(ctx context.Context, username str
 		&user.CharacterCount,
 		&user.IsPremium,
 		&user.PremiumExpiresAt,
+		&user.IsGuest,
+		&user.GuestDeviceID,
+		&user.LastLoginAt,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -198,7 +215,11 @@ func (r *PostgresUserRepository) Update(ctx context.Context, user *auth.User) er
 			character_count = $9,
 			is_premium = $10,
 			premium_expires_at = $11,
-			updated_at = $12
+			is_guest = $12,
+			guest_device_id = $13,
+			last_login_at = $14,
+			updated_at = $15,
+			guest_secret_hash = CASE WHEN $12 THEN COALESCE($16, guest_secret_hash) ELSE NULL END
 		WHERE id = $1
 	`
 
@@ -216,7 +237,11 @@ func (r *PostgresUserRepository) Update(ctx context.Context, user *auth.User) er
 		user.CharacterCount,
 		user.IsPremium,
 		user.PremiumExpiresAt,
+		user.IsGuest,
+		nullString(user.GuestDeviceID),
+		user.LastLoginAt,
 		user.UpdatedAt,
+		nullString(user.GuestSecretHash),
 	)
 
 	if err != nil {
@@ -344,4 +369,196 @@ func (r *PostgresUserRepository) DecrementCharacterCount(ctx context.Context, us
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// RecountCharacters sets a user's character count from their characters that are not deleted.
+// Unlike Increment/DecrementCharacterCount it is idempotent, so replayed events can't skew it.
+func (r *PostgresUserRepository) RecountCharacters(ctx context.Context, userID string) (int, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return 0, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	query := `
+		UPDATE users
+		SET character_count = (
+			SELECT COUNT(*) FROM characters WHERE user_id = $1 AND is_deleted = FALSE
+		), updated_at = $2
+		WHERE id = $1
+		RETURNING character_count
+	`
+
+	var count int
+	err = r.db.QueryRowContext(ctx, query, id, time.Now()).Scan(&count)
+	if err == sql.ErrNoRows {
+		return 0, auth.ErrUserNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to recount characters: %w", err)
+	}
+
+	return count, nil
+}
+
+// ReconcileCharacterCounts recounts the characters of up to limit users with IDs after
+// afterID. Counts are capped at max_characters so an account holding more characters than
+// it allows can't violate check_character_count and abort the batch; such accounts are
+// still returned so they can be reported.
+func (r *PostgresUserRepository) ReconcileCharacterCounts(ctx context.Context, afterID string, limit int) ([]*auth.CharacterCountCorrection, string, error) {
+	after := uuid.Nil
+	if afterID != "" {
+		var err error
+		if after, err = uuid.Parse(afterID); err != nil {
+			return nil, "", fmt.Errorf("invalid user ID: %w", err)
+		}
+	}
+
+	query := `
+		WITH batch AS (
+			SELECT id, character_count, max_characters
+			FROM users
+			WHERE id > $1
+			ORDER BY id
+			LIMIT $2
+		), actual AS (
+			SELECT b.id, b.character_count, b.max_characters, COUNT(c.id)::int AS count
+			FROM batch b
+			LEFT JOIN characters c ON c.user_id = b.id AND c.is_deleted = FALSE
+			GROUP BY b.id, b.character_count, b.max_characters
+		), updated AS (
+			UPDATE users u
+			SET character_count = LEAST(a.count, a.max_characters), updated_at = $3
+			FROM actual a
+			WHERE u.id = a.id AND u.character_count <> LEAST(a.count, a.max_characters)
+		)
+		SELECT id, character_count, count, LEAST(count, max_characters), max_characters
+		FROM actual
+		ORDER BY id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, after, limit, time.Now())
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to reconcile character counts: %w", err)
+	}
+	defer rows.Close()
+
+	var corrections []*auth.CharacterCountCorrection
+	lastID := ""
+	for rows.Next() {
+		var id uuid.UUID
+		correction := &auth.CharacterCountCorrection{}
+		if err := rows.Scan(&id, &correction.Previous, &correction.Actual, &correction.Count, &correction.MaxCharacters); err != nil {
+			return nil, "", fmt.Errorf("failed to scan character count: %w", err)
+		}
+		lastID = id.String()
+		if correction.Previous != correction.Actual {
+			correction.UserID = lastID
+			corrections = append(corrections, correction)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("failed to reconcile character counts: %w", err)
+	}
+
+	return corrections, lastID, nil
+}
+
+// GetGuestByDeviceID retrieves the guest account bound to a device
+func (r *PostgresUserRepository) GetGuestByDeviceID(ctx context.Context, deviceID string) (*auth.User, error) {
+	query := `
+		SELECT 
+			id, email, username, password_hash, email_verified,
+			account_status, roles, max_characters, character_count,
+			is_premium, premium_expires_at, is_guest, COALESCE(guest_device_id, ''),
+			last_login_at, created_at, updated_at, COALESCE(guest_secret_hash, '')
+		FROM users
+		WHERE is_guest = TRUE AND guest_device_id = $1
+	`
+
+	user := &auth.User{}
+	err := r.db.QueryRowContext(ctx, query, deviceID).Scan(
+		&user.ID,
+		&user.Email,
+		&user.Username,
+		&user.PasswordHash,
+		&user.EmailVerified,
+		&user.AccountStatus,
+		pq.Array(&user.Roles),
+		&user.MaxCharacters,
+		&user.CharacterCount,
+		&user.IsPremium,
+		&user.PremiumExpiresAt,
+		&user.IsGuest,
+		&user.GuestDeviceID,
+		&user.LastLoginAt,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+		&user.GuestSecretHash,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, auth.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get guest by device ID: %w", err)
+	}
+
+	return user, nil
+}
+
+// DeleteIdleGuests deletes up to limit guest accounts with no login since the given time,
+// returning them with their characters. Sessions and characters cascade; the characters are
+// read from the statement's snapshot, so they are the ones the cascade removed.
+func (r *PostgresUserRepository) DeleteIdleGuests(ctx context.Context, idleSince time.Time, limit int) ([]*auth.DeletedGuest, error) {
+	query := `
+		WITH deleted AS (
+			DELETE FROM users
+			WHERE id IN (
+				SELECT id FROM users
+				WHERE is_guest = TRUE AND COALESCE(last_login_at, created_at) < $1
+				ORDER BY COALESCE(last_login_at, created_at)
+				LIMIT $2
+			)
+			RETURNING id
+		)
+		SELECT d.id, c.id, c.name
+		FROM deleted d
+		LEFT JOIN characters c ON c.user_id = d.id
+		ORDER BY d.id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, idleSince, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete idle guests: %w", err)
+	}
+	defer rows.Close()
+
+	var guests []*auth.DeletedGuest
+	for rows.Next() {
+		var (
+			id            uuid.UUID
+			characterID   uuid.NullUUID
+			characterName sql.NullString
+		)
+		if err := rows.Scan(&id, &characterID, &characterName); err != nil {
+			return nil, fmt.Errorf("failed to scan deleted guest: %w", err)
+		}
+
+		if len(guests) == 0 || guests[len(guests)-1].UserID != id.String() {
+			guests = append(guests, &auth.DeletedGuest{UserID: id.String()})
+		}
+		if characterID.Valid {
+			guest := guests[len(guests)-1]
+			guest.Characters = append(guest.Characters, auth.DeletedCharacter{
+				ID:   characterID.UUID.String(),
+				Name: characterName.String,
+			})
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating deleted guests: %w", err)
+	}
+
+	return guests, nil
+}
@@ -0,0 +1,43 @@
+package character
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+	"github.com/mmorpg-template/backend/internal/domain/character"
+)
+
+// PostgresCharacterImporter implements CharacterImporter using a PostgreSQL transaction
+type PostgresCharacterImporter struct {
+	transactions *TransactionManager
+}
+
+// NewPostgresCharacterImporter creates a new PostgreSQL character importer
+func NewPostgresCharacterImporter(db *sql.DB) *PostgresCharacterImporter {
+	return &PostgresCharacterImporter{transactions: NewTransactionManager(db)}
+}
+
+// ImportCharacter inserts the character with its appearance, stats and position in one transaction
+func (i *PostgresCharacterImporter) ImportCharacter(ctx context.Context, imported *character.ImportedCharacter) error {
+	return i.transactions.ExecuteInTransaction(ctx, func(tx *sql.Tx) error {
+		if err := NewTransactionalCharacterRepository(tx).Create(ctx, imported.Character); err != nil {
+			var pqErr *pq.Error
+			if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+				if pqErr.Constraint == "unique_user_slot" {
+					return character.ErrSlotOccupied
+				}
+				return character.ErrCharacterNameTaken
+			}
+			return err
+		}
+		if err := NewTransactionalAppearanceRepository(tx).Create(ctx, imported.Appearance); err != nil {
+			return err
+		}
+		if err := NewTransactionalStatsRepository(tx).Create(ctx, imported.Stats); err != nil {
+			return err
+		}
+		return NewTransactionalPositionRepository(tx).Create(ctx, imported.Position)
+	})
+}
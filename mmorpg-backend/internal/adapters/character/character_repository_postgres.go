@@ -3,11 +3,13 @@
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/mmorpg-template/backend/internal/domain/character"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
 // PostgresCharacterRepository implements the CharacterRepository interface using PostgreSQL
@@ -29,6 +31,25 @@ func NewTransactionalCharacterRepository(tx *sql.Tx) *PostgresCharacterRepositor
 	}
 }
 
+// total_play_time is an INTERVAL, which database/sql cannot scan into a duration.
+// It is read as whole seconds through playTimeColumn and written with make_interval.
+const playTimeColumn = `COALESCE(EXTRACT(EPOCH FROM total_play_time), 0)::BIGINT`
+
+// playTimeScanner scans playTimeColumn into a duration
+type playTimeScanner struct {
+	d *time.Duration
+}
+
+// Scan implements sql.Scanner
+func (p playTimeScanner) Scan(src interface{}) error {
+	seconds, ok := src.(int64)
+	if !ok {
+		return fmt.Errorf("unexpected play time type %T", src)
+	}
+	*p.d = time.Duration(seconds) * time.Second
+	return nil
+}
+
 // Create creates a new character in the database
 func (r *PostgresCharacterRepository) Create(ctx context.Context, char *character.Character) error {
 	query := `
@@ -39,7 +60,7 @@ func (r *PostgresCharacterRepository) Create(ctx context.Context, char *characte
 			last_played_at, total_play_time
 		) VALUES (
 			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11,
-			$12, $13, $14, $15, $16
+			$12, $13, $14, $15, make_interval(secs => $16)
 		)`
 
 	_, err := r.db.ExecContext(ctx, query,
@@ -58,7 +79,7 @@ func (r *PostgresCharacterRepository) Create(ctx context.Context, char *characte
 		char.CreatedAt,
 		char.UpdatedAt,
 		char.LastPlayedAt,
-		char.TotalPlayTime,
+		char.TotalPlayTime.Seconds(),
 	)
 
 	if err != nil {
@@ -75,7 +96,7 @@ func (r *PostgresCharacterRepository) GetByID(ctx context.Context, id uuid.UUID)
 			id, user_id, name, slot_number, level, experience,
 			class_type, race, gender, is_deleted, deleted_at,
 			deletion_scheduled_at, created_at, updated_at,
-			last_played_at, total_play_time
+			last_played_at, ` + playTimeColumn + `
 		FROM characters
 		WHERE id = $1`
 
@@ -96,7 +117,7 @@ func (r *PostgresCharacterRepository) GetByID(ctx context.Context, id uuid.UUID)
 		&char.CreatedAt,
 		&char.UpdatedAt,
 		&char.LastPlayedAt,
-		&char.TotalPlayTime,
+		playTimeScanner{&char.TotalPlayTime},
 	)
 
 	if err == sql.ErrNoRows {
@@ -116,7 +137,7 @@ func (r *PostgresCharacterRepository) GetByName(ctx context.Context, name string
 			id, user_id, name, slot_number, level, experience,
 			class_type, race, gender, is_deleted, deleted_at,
 			deletion_scheduled_at, created_at, updated_at,
-			last_played_at, total_play_time
+			last_played_at, ` + playTimeColumn + `
 		FROM characters
 		WHERE LOWER(name) = LOWER($1)`
 
@@ -137,7 +158,7 @@ func (r *PostgresCharacterRepository) GetByName(ctx context.Context, name string
 		&char.CreatedAt,
 		&char.UpdatedAt,
 		&char.LastPlayedAt,
-		&char.TotalPlayTime,
+		playTimeScanner{&char.TotalPlayTime},
 	)
 
 	if err == sql.ErrNoRows {
@@ -157,7 +178,7 @@ func (r *PostgresCharacterRepository) GetByUserID(ctx context.Context, userID uu
 			id, user_id, name, slot_number, level, experience,
 			class_type, race, gender, is_deleted, deleted_at,
 			deletion_scheduled_at, created_at, updated_at,
-			last_played_at, total_play_time
+			last_played_at, ` + playTimeColumn + `
 		FROM characters
 		WHERE user_id = $1
 		ORDER BY slot_number`
@@ -187,7 +208,7 @@ func (r *PostgresCharacterRepository) GetByUserID(ctx context.Context, userID uu
 			&char.CreatedAt,
 			&char.UpdatedAt,
 			&char.LastPlayedAt,
-			&char.TotalPlayTime,
+			playTimeScanner{&char.TotalPlayTime},
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan character: %w", err)
@@ -209,7 +230,7 @@ func (r *PostgresCharacterRepository) GetByUserIDAndSlot(ctx context.Context, us
 			id, user_id, name, slot_number, level, experience,
 			class_type, race, gender, is_deleted, deleted_at,
 			deletion_scheduled_at, created_at, updated_at,
-			last_played_at, total_play_time
+			last_played_at, ` + playTimeColumn + `
 		FROM characters
 		WHERE user_id = $1 AND slot_number = $2`
 
@@ -230,7 +251,7 @@ func (r *PostgresCharacterRepository) GetByUserIDAndSlot(ctx context.Context, us
 		&char.CreatedAt,
 		&char.UpdatedAt,
 		&char.LastPlayedAt,
-		&char.TotalPlayTime,
+		playTimeScanner{&char.TotalPlayTime},
 	)
 
 	if err == sql.ErrNoRows {
@@ -243,7 +264,7 @@ func (r *PostgresCharacterRepository) GetByUserIDAndSlot(ctx context.Context, us
 	return &char, nil
 }
 
-// Update updates a character in the database
+// Update updates a character in the database. Play time is only added by ending play sessions.
 func (r *PostgresCharacterRepository) Update(ctx context.Context, char *character.Character) error {
 	query := `
 		UPDATE characters SET
@@ -257,9 +278,7 @@ func (r *PostgresCharacterRepository) Update(ctx context.Context, char *characte
 			is_deleted = $9,
 			deleted_at = $10,
 			deletion_scheduled_at = $11,
-			updated_at = $12,
-			last_played_at = $13,
-			total_play_time = $14
+			updated_at = $12
 		WHERE id = $1`
 
 	result, err := r.db.ExecContext(ctx, query,
@@ -275,8 +294,6 @@ func (r *PostgresCharacterRepository) Update(ctx context.Context, char *characte
 		char.DeletedAt,
 		char.DeletionScheduledAt,
 		char.UpdatedAt,
-		char.LastPlayedAt,
-		char.TotalPlayTime,
 	)
 
 	if err != nil {
@@ -295,6 +312,74 @@ func (r *PostgresCharacterRepository) Update(ctx context.Context, char *characte
 	return nil
 }
 
+// AddExperience atomically adds experience to a character that is not deleted and records
+// the grant ID in the same statement, so a grant is applied at most once
+func (r *PostgresCharacterRepository) AddExperience(ctx context.Context, id uuid.UUID, grantID string, amount, max int64) (int64, error) {
+	// Two concurrent requests for the same grant can both pass the NOT EXISTS check; the
+	// second then fails on the primary key and its update is rolled back with it
+	query := `
+		WITH updated AS (
+			UPDATE characters SET
+				experience = LEAST(experience + $2, GREATEST(experience, $3)),
+				updated_at = NOW()
+			WHERE id = $1 AND is_deleted = false
+				AND NOT EXISTS (SELECT 1 FROM character_experience_grants WHERE grant_id = $4)
+			RETURNING experience
+		), recorded AS (
+			INSERT INTO character_experience_grants (grant_id, character_id, amount, granted_at)
+			SELECT $4, $1, $2, NOW() FROM updated
+		)
+		SELECT experience FROM updated`
+
+	var experience int64
+	err := r.db.QueryRowContext(ctx, query, id, amount, max, grantID).Scan(&experience)
+	if err == sql.ErrNoRows {
+		var granted bool
+		if err := r.db.QueryRowContext(ctx,
+			`SELECT EXISTS(SELECT 1 FROM character_experience_grants WHERE grant_id = $1)`, grantID).Scan(&granted); err != nil {
+			return 0, fmt.Errorf("failed to check experience grant: %w", err)
+		}
+		if granted {
+			return 0, character.ErrDuplicateGrant
+		}
+		return 0, character.ErrCharacterNotFound
+	}
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+		return 0, character.ErrDuplicateGrant
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to add experience: %w", err)
+	}
+
+	return experience, nil
+}
+
+// RaiseLevel raises a character's level, returning the level it had before
+func (r *PostgresCharacterRepository) RaiseLevel(ctx context.Context, id uuid.UUID, level int) (int, error) {
+	// The locked subquery reads the level being replaced, so two concurrent raises
+	// each see the other's result rather than the same starting level
+	query := `
+		UPDATE characters c SET
+			level = $2,
+			updated_at = NOW()
+		FROM (SELECT id, level FROM characters WHERE id = $1 FOR UPDATE) prev
+		WHERE c.id = prev.id AND prev.level < $2
+		RETURNING prev.level`
+
+	var previous int
+	err := r.db.QueryRowContext(ctx, query, id, level).Scan(&previous)
+	if err == sql.ErrNoRows {
+		// Already at or above the level
+		return level, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to raise level: %w", err)
+	}
+
+	return previous, nil
+}
+
 // Delete permanently deletes a character
 func (r *PostgresCharacterRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	query := `DELETE FROM characters WHERE id = $1`
@@ -352,6 +437,45 @@ func (r *PostgresCharacterRepository) CleanupDeleted(ctx context.Context) error
 	return nil
 }
 
+// PurgeDeleted permanently removes up to limit characters whose recovery period ended before
+// the given time. Rows locked by a concurrent restore are skipped until the next run.
+func (r *PostgresCharacterRepository) PurgeDeleted(ctx context.Context, before time.Time, limit int) ([]*character.Character, error) {
+	query := `
+		DELETE FROM characters
+		WHERE id IN (
+			SELECT id FROM characters
+			WHERE is_deleted = TRUE
+				AND deletion_scheduled_at IS NOT NULL
+				AND deletion_scheduled_at <= $1
+			ORDER BY deletion_scheduled_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, user_id, name, slot_number, deleted_at, deletion_scheduled_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, before, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to purge deleted characters: %w", err)
+	}
+	defer rows.Close()
+
+	var purged []*character.Character
+	for rows.Next() {
+		char := &character.Character{IsDeleted: true}
+		if err := rows.Scan(&char.ID, &char.UserID, &char.Name, &char.SlotNumber, &char.DeletedAt, &char.DeletionScheduledAt); err != nil {
+			return nil, fmt.Errorf("failed to scan purged character: %w", err)
+		}
+		purged = append(purged, char)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating purged characters: %w", err)
+	}
+
+	return purged, nil
+}
+
 // NameExists checks if a character name already exists
 func (r *PostgresCharacterRepository) NameExists(ctx context.Context, name string) (bool, error) {
 	query := `SELECT EXISTS(SELECT 1 FROM characters WHERE LOWER(name) = LOWER($1) AND is_deleted = false)`
@@ -376,4 +500,21 @@ func (r *PostgresCharacterRepository) CountByUserID(ctx context.Context, userID
 	}
 
 	return count, nil
+}
+
+// GetAccountLimit returns how many characters the account allows and whether it is a guest
+func (r *PostgresCharacterRepository) GetAccountLimit(ctx context.Context, userID uuid.UUID) (int, bool, error) {
+	query := `SELECT max_characters, is_guest FROM users WHERE id = $1`
+
+	var maxCharacters int
+	var isGuest bool
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&maxCharacters, &isGuest)
+	if err == sql.ErrNoRows {
+		return 0, false, character.ErrInvalidUserID
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get account limit: %w", err)
+	}
+
+	return maxCharacters, isGuest, nil
 }
\ No newline at end of file
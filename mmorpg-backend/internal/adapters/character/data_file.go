@@ -0,0 +1,90 @@
+package character
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/mmorpg-template/backend/pkg/logger"
+)
+
+// dataFile holds game data loaded from a file and reloads it when the file changes
+type dataFile[T any] struct {
+	path string
+	// kind names the data in errors and logs
+	kind    string
+	load    func(path string) (*T, error)
+	version func(*T) string
+	logger  logger.Logger
+	current atomic.Pointer[T]
+
+	mu      sync.Mutex
+	modTime time.Time
+}
+
+// get returns the data currently in effect
+func (f *dataFile[T]) get() *T {
+	return f.current.Load()
+}
+
+// reload reads the file again if it changed since the last load and reports whether the data was replaced
+func (f *dataFile[T]) reload() (bool, error) {
+	if f.path == "" {
+		return false, nil
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return false, fmt.Errorf("failed to stat %s file: %w", f.kind, err)
+	}
+	if f.current.Load() != nil && info.ModTime().Equal(f.modTime) {
+		return false, nil
+	}
+
+	data, err := f.load(f.path)
+	if err != nil {
+		return false, err
+	}
+
+	f.current.Store(data)
+	f.modTime = info.ModTime()
+	return true, nil
+}
+
+// watch checks the file for changes every interval until ctx is done.
+// Data that fails to load is logged and the previous data stays in effect.
+func (f *dataFile[T]) watch(ctx context.Context, interval time.Duration) {
+	if f.path == "" || interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		previous := f.get()
+		reloaded, err := f.reload()
+		if err != nil {
+			f.logger.WithError(err).Errorf("Failed to reload %s file, keeping the current data", f.kind)
+			continue
+		}
+		if reloaded {
+			f.logger.WithFields(map[string]interface{}{
+				"previous_version": f.version(previous),
+				"version":          f.version(f.get()),
+			}).Infof("Reloaded %s file", f.kind)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package character
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/mmorpg-template/backend/internal/domain/character"
+	"github.com/mmorpg-template/backend/pkg/logger"
+)
+
+// definitionsFile is the JSON layout of a class and race definitions file
+type definitionsFile struct {
+	Version  string                        `json:"version"`
+	Formulas map[string]map[string]float64 `json:"formulas"`
+	Classes  []definitionsFileClass        `json:"classes"`
+	Races    []definitionsFileRace         `json:"races"`
+}
+
+type definitionsFileClass struct {
+	ID             string                        `json:"id"`
+	Name           string                        `json:"name"`
+	BaseAttributes map[string]int                `json:"base_attributes"`
+	Formulas       map[string]map[string]float64 `json:"formulas,omitempty"`
+	Start          definitionsFileLocation       `json:"start"`
+}
+
+type definitionsFileRace struct {
+	ID             string                   `json:"id"`
+	Name           string                   `json:"name"`
+	Modifiers      map[string]int           `json:"modifiers,omitempty"`
+	AllowedClasses []string                 `json:"allowed_classes,omitempty"`
+	Start          *definitionsFileLocation `json:"start,omitempty"`
+}
+
+type definitionsFileLocation struct {
+	WorldID string  `json:"world_id"`
+	ZoneID  string  `json:"zone_id"`
+	X       float64 `json:"x"`
+	Y       float64 `json:"y"`
+	Z       float64 `json:"z"`
+}
+
+// FileDefinitionsProvider implements DefinitionsProvider from a versioned JSON data file.
+//
+// Formulas are written as {"base": 100, "constitution": 10, "strength": 2}: a base
+// value plus a weight per primary attribute. Top-level formulas apply to every
+// class and must cover all derived stats; a class may override any of them.
+// A race's allowed_classes limits its classes, and its start overrides the class
+// starting location. The file is validated on load, and Watch reloads it when it
+// changes, keeping the previous definitions if the new ones are invalid.
+type FileDefinitionsProvider struct {
+	file *dataFile[character.Definitions]
+}
+
+// NewFileDefinitionsProvider loads a definitions file. An empty path serves the built-in classes and races.
+func NewFileDefinitionsProvider(path string, logger logger.Logger) (*FileDefinitionsProvider, error) {
+	p := &FileDefinitionsProvider{file: &dataFile[character.Definitions]{
+		path:    path,
+		kind:    "class and race definitions",
+		load:    loadDefinitionsFile,
+		version: func(d *character.Definitions) string { return d.Version },
+		logger:  logger,
+	}}
+	if path == "" {
+		p.file.current.Store(character.DefaultDefinitions())
+		return p, nil
+	}
+
+	if _, err := p.Reload(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Definitions returns the definitions currently in effect
+func (p *FileDefinitionsProvider) Definitions() *character.Definitions {
+	return p.file.get()
+}
+
+// Reload reads the file again if it changed since the last load and reports whether the definitions were replaced
+func (p *FileDefinitionsProvider) Reload() (bool, error) {
+	return p.file.reload()
+}
+
+// Watch checks the file for changes every interval until ctx is done
+func (p *FileDefinitionsProvider) Watch(ctx context.Context, interval time.Duration) {
+	p.file.watch(ctx, interval)
+}
+
+func loadDefinitionsFile(path string) (*character.Definitions, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read definitions file: %w", err)
+	}
+
+	var file definitionsFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse definitions file: %w", err)
+	}
+
+	defs, err := file.toDomain()
+	if err == nil {
+		err = defs.Validate()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("definitions file %s: %w", path, err)
+	}
+	return defs, nil
+}
+
+func (f *definitionsFile) toDomain() (*character.Definitions, error) {
+	defs := &character.Definitions{
+		Version:  f.Version,
+		Formulas: toFormulas(f.Formulas),
+		Classes:  make(map[character.ClassType]*character.ClassDefinition, len(f.Classes)),
+		Races:    make(map[character.Race]*character.RaceDefinition, len(f.Races)),
+	}
+
+	for _, c := range f.Classes {
+		id := character.ClassType(c.ID)
+		if _, ok := defs.Classes[id]; ok {
+			return nil, fmt.Errorf("%w: class %s is defined twice", character.ErrInvalidDefinitions, id)
+		}
+		base, err := toAttributes(c.BaseAttributes)
+		if err != nil {
+			return nil, fmt.Errorf("class %s: %w", id, err)
+		}
+		defs.Classes[id] = &character.ClassDefinition{
+			ID:             id,
+			Name:           c.Name,
+			BaseAttributes: base,
+			Formulas:       toFormulas(c.Formulas),
+			Start:          c.Start.toDomain(),
+		}
+	}
+
+	for _, r := range f.Races {
+		id := character.Race(r.ID)
+		if _, ok := defs.Races[id]; ok {
+			return nil, fmt.Errorf("%w: race %s is defined twice", character.ErrInvalidDefinitions, id)
+		}
+		modifiers, err := toAttributes(r.Modifiers)
+		if err != nil {
+			return nil, fmt.Errorf("race %s: %w", id, err)
+		}
+		race := &character.RaceDefinition{
+			ID:        id,
+			Name:      r.Name,
+			Modifiers: modifiers,
+		}
+		for _, class := range r.AllowedClasses {
+			race.AllowedClasses = append(race.AllowedClasses, character.ClassType(class))
+		}
+		if r.Start != nil {
+			start := r.Start.toDomain()
+			race.Start = &start
+		}
+		defs.Races[id] = race
+	}
+
+	return defs, nil
+}
+
+func (l definitionsFileLocation) toDomain() character.StartingLocation {
+	return character.StartingLocation{WorldID: l.WorldID, ZoneID: l.ZoneID, X: l.X, Y: l.Y, Z: l.Z}
+}
+
+// toFormulas converts {"base": n, "<attribute>": weight} objects into formulas
+func toFormulas(raw map[string]map[string]float64) map[string]character.Formula {
+	formulas := make(map[string]character.Formula, len(raw))
+	for stat, terms := range raw {
+		formula := character.Formula{Weights: make(map[string]float64, len(terms))}
+		for name, value := range terms {
+			if name == "base" {
+				formula.Base = value
+				continue
+			}
+			formula.Weights[name] = value
+		}
+		formulas[stat] = formula
+	}
+	return formulas
+}
+
+func toAttributes(raw map[string]int) (character.Attributes, error) {
+	var attributes character.Attributes
+	for name, value := range raw {
+		if !attributes.Set(name, value) {
+			return attributes, fmt.Errorf("%w: unknown attribute %s", character.ErrInvalidDefinitions, name)
+		}
+	}
+	return attributes, nil
+}
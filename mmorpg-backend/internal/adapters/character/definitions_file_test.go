@@ -0,0 +1,122 @@
+package character
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mmorpg-template/backend/internal/domain/character"
+	"github.com/mmorpg-template/backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDefinitionsFile = `{
+	"version": "2026.1",
+	"formulas": {
+		"health_max": {"base": 100, "constitution": 8},
+		"mana_max": {"base": 50, "intelligence": 2},
+		"stamina_max": {"base": 100, "constitution": 5},
+		"attack_power": {"strength": 1, "dexterity": 1},
+		"spell_power": {"base": 0},
+		"defense": {"constitution": 2},
+		"critical_chance": {"base": 5},
+		"dodge_chance": {"base": 5},
+		"health_regen": {"base": 1},
+		"mana_regen": {"base": 1},
+		"stamina_regen": {"base": 5}
+	},
+	"classes": [
+		{
+			"id": "monk",
+			"name": "Monk",
+			"base_attributes": {"strength": 10, "dexterity": 14, "intelligence": 8, "wisdom": 12, "constitution": 10, "charisma": 8},
+			"formulas": {"attack_power": {"dexterity": 2, "wisdom": 1}},
+			"start": {"world_id": "starter_zone", "zone_id": "mountain_temple", "x": 10, "y": 20, "z": 30}
+		},
+		{
+			"id": "warrior",
+			"name": "Warrior",
+			"base_attributes": {"strength": 15, "dexterity": 8, "intelligence": 6, "wisdom": 6, "constitution": 13, "charisma": 8},
+			"start": {"world_id": "starter_zone", "zone_id": "warrior_training_grounds"}
+		}
+	],
+	"races": [
+		{"id": "human", "name": "Human"},
+		{
+			"id": "dwarf",
+			"name": "Dwarf",
+			"modifiers": {"constitution": 2, "dexterity": -1},
+			"allowed_classes": ["warrior"],
+			"start": {"world_id": "eastern_kingdoms", "zone_id": "dun_morogh"}
+		}
+	]
+}`
+
+func TestFileDefinitionsProvider(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "classes.json")
+	require.NoError(t, os.WriteFile(path, []byte(testDefinitionsFile), 0o644))
+
+	provider, err := NewFileDefinitionsProvider(path, logger.NewNoop())
+	require.NoError(t, err)
+
+	t.Run("new class without code changes", func(t *testing.T) {
+		defs := provider.Definitions()
+		require.NoError(t, defs.CheckCombination("monk", character.RaceHuman))
+
+		stats := defs.NewStats(uuid.New(), "monk", character.RaceHuman)
+		assert.Equal(t, 14, stats.Dexterity)
+		assert.Equal(t, 14*2+12, stats.AttackPower)
+		assert.Equal(t, 100+10*8, stats.HealthMax)
+
+		position := defs.StartingPosition(uuid.New(), "monk", character.RaceHuman)
+		assert.Equal(t, "mountain_temple", position.ZoneID)
+	})
+
+	t.Run("race restrictions and modifiers", func(t *testing.T) {
+		defs := provider.Definitions()
+		assert.Equal(t, character.ErrClassRaceNotAllowed, defs.CheckCombination("monk", character.RaceDwarf))
+		assert.Equal(t, character.ErrInvalidClass, defs.CheckCombination(character.ClassMage, character.RaceHuman))
+
+		stats := defs.NewStats(uuid.New(), character.ClassWarrior, character.RaceDwarf)
+		assert.Equal(t, 15, stats.Constitution)
+		assert.Equal(t, 7, stats.Dexterity)
+		assert.Equal(t, "dun_morogh", defs.StartingPosition(uuid.New(), character.ClassWarrior, character.RaceDwarf).ZoneID)
+	})
+
+	t.Run("invalid reload keeps the current definitions", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(path, []byte(`{"version": "broken", "classes": [], "races": []}`), 0o644))
+		touch(t, path, time.Now().Add(time.Minute))
+
+		_, err := provider.Reload()
+		assert.True(t, errors.Is(err, character.ErrInvalidDefinitions))
+		assert.Contains(t, provider.Definitions().Classes, character.ClassType("monk"))
+	})
+
+	t.Run("unknown attribute is rejected", func(t *testing.T) {
+		bad := filepath.Join(dir, "bad.json")
+		require.NoError(t, os.WriteFile(bad, []byte(`{"version": "x", "classes": [{"id": "bard", "base_attributes": {"luck": 5}}]}`), 0o644))
+		_, err := NewFileDefinitionsProvider(bad, logger.NewNoop())
+		assert.True(t, errors.Is(err, character.ErrInvalidDefinitions))
+	})
+
+	t.Run("shipped data matches the built-in definitions", func(t *testing.T) {
+		shipped, err := NewFileDefinitionsProvider(filepath.Join("..", "..", "..", "data", "classes.json"), logger.NewNoop())
+		require.NoError(t, err)
+
+		builtin := character.DefaultDefinitions()
+		assert.Equal(t, builtin.ClassIDs(), shipped.Definitions().ClassIDs())
+		assert.Equal(t, builtin.RaceIDs(), shipped.Definitions().RaceIDs())
+		for _, class := range builtin.ClassIDs() {
+			id := uuid.New()
+			want := builtin.NewStats(id, class, character.RaceHuman)
+			got := shipped.Definitions().NewStats(id, class, character.RaceHuman)
+			got.CreatedAt, got.UpdatedAt, got.ID = want.CreatedAt, want.UpdatedAt, want.ID
+			assert.Equal(t, want, got, class)
+		}
+	})
+}
@@ -1,6 +1,7 @@
 package character
 
 import (
+	"errors"
 	"net/http"
 	"time"
 
@@ -24,11 +25,26 @@
 	ErrorCodeCharacterBelongsToOther ErrorCode = "CHARACTER_BELONGS_TO_OTHER"
 	ErrorCodeCharacterOnline         ErrorCode = "CHARACTER_ONLINE"
 	ErrorCodeCharacterInCombat       ErrorCode = "CHARACTER_IN_COMBAT"
+	ErrorCodePlaytimeLimitReached    ErrorCode = "PLAYTIME_LIMIT_REACHED"
+	ErrorCodeRenameTooSoon           ErrorCode = "RENAME_TOO_SOON"
+	ErrorCodeCharacterNameUnchanged  ErrorCode = "CHARACTER_NAME_UNCHANGED"
+	ErrorCodeTransferSameAccount     ErrorCode = "TRANSFER_SAME_ACCOUNT"
+	ErrorCodeTransferReasonRequired  ErrorCode = "TRANSFER_REASON_REQUIRED"
+	ErrorCodeTransferBlocked         ErrorCode = "TRANSFER_BLOCKED"
+	ErrorCodeInvalidSnapshot         ErrorCode = "INVALID_SNAPSHOT"
+	ErrorCodeRestorePointNotFound    ErrorCode = "RESTORE_POINT_NOT_FOUND"
+	ErrorCodeRollbackReasonRequired  ErrorCode = "ROLLBACK_REASON_REQUIRED"
+	ErrorCodeSelectionConflict       ErrorCode = "SELECTION_CONFLICT"
+	ErrorCodeNoCharacterSelected     ErrorCode = "NO_CHARACTER_SELECTED"
+	ErrorCodeInvalidPlayTimeRange    ErrorCode = "INVALID_PLAY_TIME_RANGE"
+	ErrorCodeSelectedElsewhere       ErrorCode = "SELECTED_ELSEWHERE"
+	ErrorCodeSessionIDRequired       ErrorCode = "SESSION_ID_REQUIRED"
 	
 	// Class/Race/Gender errors
-	ErrorCodeInvalidClass  ErrorCode = "INVALID_CLASS"
-	ErrorCodeInvalidRace   ErrorCode = "INVALID_RACE"
-	ErrorCodeInvalidGender ErrorCode = "INVALID_GENDER"
+	ErrorCodeInvalidClass        ErrorCode = "INVALID_CLASS"
+	ErrorCodeInvalidRace         ErrorCode = "INVALID_RACE"
+	ErrorCodeInvalidGender       ErrorCode = "INVALID_GENDER"
+	ErrorCodeClassRaceNotAllowed ErrorCode = "CLASS_RACE_NOT_ALLOWED"
 	
 	// Appearance errors
 	ErrorCodeInvalidAppearance ErrorCode = "INVALID_APPEARANCE"
@@ -41,9 +57,16 @@
 	ErrorCodeInvalidHeight     ErrorCode = "INVALID_HEIGHT"
 	
 	// Stats errors
-	ErrorCodeNoStatPoints    ErrorCode = "NO_STAT_POINTS"
-	ErrorCodeStatMaxReached  ErrorCode = "STAT_MAX_REACHED"
-	ErrorCodeInvalidStatType ErrorCode = "INVALID_STAT_TYPE"
+	ErrorCodeNoStatPoints      ErrorCode = "NO_STAT_POINTS"
+	ErrorCodeStatMaxReached    ErrorCode = "STAT_MAX_REACHED"
+	ErrorCodeInvalidStatType   ErrorCode = "INVALID_STAT_TYPE"
+	ErrorCodeRespecOnCooldown  ErrorCode = "RESPEC_ON_COOLDOWN"
+	ErrorCodeNothingToRespec   ErrorCode = "NOTHING_TO_RESPEC"
+	ErrorCodeNothingToUndo     ErrorCode = "NOTHING_TO_UNDO"
+	ErrorCodeInvalidUndoCount  ErrorCode = "INVALID_UNDO_COUNT"
+	ErrorCodeInvalidAllocation ErrorCode = "INVALID_ALLOCATION"
+	ErrorCodeNotEnoughPoints   ErrorCode = "NOT_ENOUGH_STAT_POINTS"
+	ErrorCodeStatBelowBaseline ErrorCode = "STAT_BELOW_BASELINE"
 	
 	// General errors
 	ErrorCodeUnauthorized     ErrorCode = "UNAUTHORIZED"
@@ -82,11 +105,27 @@ type ErrorDetail struct {
 	character.ErrInvalidSlotNumber:         {http.StatusBadRequest, ErrorCodeInvalidSlotNumber},
 	character.ErrSlotOccupied:              {http.StatusConflict, ErrorCodeSlotOccupied},
 	character.ErrCharacterBelongsToOther:   {http.StatusForbidden, ErrorCodeCharacterBelongsToOther},
+	character.ErrPlaytimeLimitReached:      {http.StatusForbidden, ErrorCodePlaytimeLimitReached},
+	character.ErrPlaytimeUnavailable:       {http.StatusServiceUnavailable, ErrorCodeServiceUnavailable},
+	character.ErrRenameTooSoon:             {http.StatusTooManyRequests, ErrorCodeRenameTooSoon},
+	character.ErrCharacterNameUnchanged:    {http.StatusBadRequest, ErrorCodeCharacterNameUnchanged},
+	character.ErrTransferSameAccount:       {http.StatusBadRequest, ErrorCodeTransferSameAccount},
+	character.ErrTransferReasonRequired:    {http.StatusBadRequest, ErrorCodeTransferReasonRequired},
+	character.ErrTransferBlocked:           {http.StatusConflict, ErrorCodeTransferBlocked},
+	character.ErrRestorePointNotFound:      {http.StatusNotFound, ErrorCodeRestorePointNotFound},
+	character.ErrRollbackReasonRequired:    {http.StatusBadRequest, ErrorCodeRollbackReasonRequired},
+	character.ErrSelectionConflict:         {http.StatusConflict, ErrorCodeSelectionConflict},
+	character.ErrNoCharacterSelected:       {http.StatusConflict, ErrorCodeNoCharacterSelected},
+	character.ErrInvalidPlayTimeRange:      {http.StatusBadRequest, ErrorCodeInvalidPlayTimeRange},
+	character.ErrSelectedElsewhere:         {http.StatusConflict, ErrorCodeSelectedElsewhere},
+	character.ErrSessionIDRequired:         {http.StatusBadRequest, ErrorCodeSessionIDRequired},
+	character.ErrCharacterOnline:           {http.StatusConflict, ErrorCodeCharacterOnline},
 	
 	// Class/Race/Gender errors
-	character.ErrInvalidClass:  {http.StatusBadRequest, ErrorCodeInvalidClass},
-	character.ErrInvalidRace:   {http.StatusBadRequest, ErrorCodeInvalidRace},
-	character.ErrInvalidGender: {http.StatusBadRequest, ErrorCodeInvalidGender},
+	character.ErrInvalidClass:        {http.StatusBadRequest, ErrorCodeInvalidClass},
+	character.ErrInvalidRace:         {http.StatusBadRequest, ErrorCodeInvalidRace},
+	character.ErrInvalidGender:       {http.StatusBadRequest, ErrorCodeInvalidGender},
+	character.ErrClassRaceNotAllowed: {http.StatusBadRequest, ErrorCodeClassRaceNotAllowed},
 	
 	// Appearance errors
 	character.ErrInvalidFaceType:        {http.StatusBadRequest, ErrorCodeInvalidFaceType},
@@ -103,6 +142,13 @@ type ErrorDetail struct {
 	character.ErrNoStatPointsAvailable: {http.StatusBadRequest, ErrorCodeNoStatPoints},
 	character.ErrStatMaxReached:        {http.StatusBadRequest, ErrorCodeStatMaxReached},
 	character.ErrInvalidStatType:       {http.StatusBadRequest, ErrorCodeInvalidStatType},
+	character.ErrRespecOnCooldown:      {http.StatusConflict, ErrorCodeRespecOnCooldown},
+	character.ErrNothingToRespec:       {http.StatusBadRequest, ErrorCodeNothingToRespec},
+	character.ErrNothingToUndo:         {http.StatusBadRequest, ErrorCodeNothingToUndo},
+	character.ErrInvalidUndoCount:      {http.StatusBadRequest, ErrorCodeInvalidUndoCount},
+	character.ErrInvalidAllocation:     {http.StatusBadRequest, ErrorCodeInvalidAllocation},
+	character.ErrNotEnoughStatPoints:   {http.StatusBadRequest, ErrorCodeNotEnoughPoints},
+	character.ErrStatBelowBaseline:     {http.StatusConflict, ErrorCodeStatBelowBaseline},
 	
 	// General errors
 	character.ErrUnauthorized: {http.StatusUnauthorized, ErrorCodeUnauthorized},
@@ -110,6 +156,21 @@ type ErrorDetail struct {
 
 // HandleError processes errors and returns appropriate HTTP responses
 func (h *HTTPHandler) HandleError(c *gin.Context, err error) {
+	var violation *character.NameViolation
+	if errors.As(err, &violation) {
+		h.respondWithError(c, http.StatusBadRequest, ErrorCodeInvalidCharacterName, err.Error(), map[string]interface{}{"reason": violation.Reason})
+		return
+	}
+	var blocked *character.TransferBlocked
+	if errors.As(err, &blocked) {
+		h.respondWithError(c, http.StatusConflict, ErrorCodeTransferBlocked, err.Error(), map[string]interface{}{"pending": blocked.Pending})
+		return
+	}
+	if errors.Is(err, character.ErrInvalidSnapshot) {
+		h.respondWithError(c, http.StatusBadRequest, ErrorCodeInvalidSnapshot, err.Error(), nil)
+		return
+	}
+
 	if mapping, ok := errorMapping[err]; ok {
 		h.respondWithError(c, mapping.status, mapping.code, err.Error(), nil)
 		return
@@ -0,0 +1,351 @@
+package character
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mmorpg-template/backend/internal/domain/character"
+	portsCharacter "github.com/mmorpg-template/backend/internal/ports/character"
+)
+
+// Roles allowed to use the support endpoints
+const (
+	RoleAdmin   = "admin"
+	RoleSupport = "support"
+)
+
+// TransferCharacterRequest is the body for POST /:id/transfer
+type TransferCharacterRequest struct {
+	ToUserID string `json:"to_user_id" binding:"required"`
+	// SlotNumber on the target account; omitted picks the lowest free slot
+	SlotNumber int    `json:"slot_number"`
+	Reason     string `json:"reason" binding:"required"`
+}
+
+// ImportCharacterRequest is the body for POST /import
+type ImportCharacterRequest struct {
+	UserID   string                       `json:"user_id" binding:"required"`
+	Snapshot *character.CharacterSnapshot `json:"snapshot" binding:"required"`
+	// Name replaces the snapshot's name; omitted keeps it, numbered if it is taken
+	Name string `json:"name"`
+	// SlotNumber on the account; omitted picks the lowest free slot
+	SlotNumber int `json:"slot_number"`
+}
+
+// CharacterTransferResponse is the JSON representation of a character transfer
+type CharacterTransferResponse struct {
+	ID          string    `json:"id"`
+	CharacterID string    `json:"character_id"`
+	FromUserID  string    `json:"from_user_id"`
+	ToUserID    string    `json:"to_user_id"`
+	FromSlot    int       `json:"from_slot"`
+	ToSlot      int       `json:"to_slot"`
+	PerformedBy string    `json:"performed_by"`
+	Reason      string    `json:"reason"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// RollbackCharacterRequest is the body for POST /:id/rollback
+type RollbackCharacterRequest struct {
+	RestorePointID string `json:"restore_point_id" binding:"required"`
+	Reason         string `json:"reason" binding:"required"`
+}
+
+// RestorePointResponse is the JSON representation of a restore point
+type RestorePointResponse struct {
+	ID          string                     `json:"id"`
+	CharacterID string                     `json:"character_id"`
+	Trigger     string                     `json:"trigger"`
+	Level       int                        `json:"level"`
+	Experience  int64                      `json:"experience"`
+	Stats       character.SnapshotStats    `json:"stats"`
+	Position    character.SnapshotPosition `json:"position"`
+	CreatedAt   time.Time                  `json:"created_at"`
+}
+
+// CharacterRollbackResponse is the JSON representation of a character rollback
+type CharacterRollbackResponse struct {
+	ID             string    `json:"id"`
+	CharacterID    string    `json:"character_id"`
+	RestorePointID string    `json:"restore_point_id"`
+	BackupID       string    `json:"backup_id"`
+	PerformedBy    string    `json:"performed_by"`
+	Reason         string    `json:"reason"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// DailyPlayTimeResponse is the JSON representation of a character's play time on one day
+type DailyPlayTimeResponse struct {
+	Day             string `json:"day"`
+	PlayTimeSeconds int64  `json:"play_time_seconds"`
+	Sessions        int    `json:"sessions"`
+}
+
+// defaultPlayTimeDays is how many days of play time are listed when no range is given
+const defaultPlayTimeDays = 30
+
+// RequireSupport restricts a route group to support staff and administrators
+func (h *HTTPHandler) RequireSupport() gin.HandlerFunc {
+	return h.jwtMiddleware.RequireRole(RoleAdmin, RoleSupport)
+}
+
+// TransferCharacter moves a character to another account
+func (h *HTTPHandler) TransferCharacter(c *gin.Context) {
+	staffID, ok := GetUserIDFromContext(c)
+	if !ok {
+		h.respondWithError(c, http.StatusUnauthorized, ErrorCodeUnauthorized, "user ID not found in context", nil)
+		return
+	}
+
+	var req TransferCharacterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondWithValidationError(c, map[string]string{
+			"body": err.Error(),
+		})
+		return
+	}
+
+	transfer, err := h.service.TransferCharacter(c.Request.Context(), &portsCharacter.TransferCharacterRequest{
+		CharacterID: c.Param("id"),
+		ToUserID:    req.ToUserID,
+		SlotNumber:  req.SlotNumber,
+		PerformedBy: staffID,
+		Reason:      req.Reason,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toCharacterTransferResponse(transfer))
+}
+
+// GetTransferHistory lists a character's transfers between accounts
+func (h *HTTPHandler) GetTransferHistory(c *gin.Context) {
+	transfers, err := h.service.GetTransferHistory(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	response := make([]CharacterTransferResponse, 0, len(transfers))
+	for _, transfer := range transfers {
+		response = append(response, toCharacterTransferResponse(transfer))
+	}
+	c.JSON(http.StatusOK, gin.H{"transfers": response})
+}
+
+// ExportCharacter returns a versioned snapshot of a character for backup or import elsewhere
+func (h *HTTPHandler) ExportCharacter(c *gin.Context) {
+	snapshot, err := h.service.ExportCharacter(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, snapshot)
+}
+
+// ImportCharacter recreates a character from a snapshot on an account
+func (h *HTTPHandler) ImportCharacter(c *gin.Context) {
+	staffID, ok := GetUserIDFromContext(c)
+	if !ok {
+		h.respondWithError(c, http.StatusUnauthorized, ErrorCodeUnauthorized, "user ID not found in context", nil)
+		return
+	}
+
+	var req ImportCharacterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondWithValidationError(c, map[string]string{
+			"body": err.Error(),
+		})
+		return
+	}
+
+	char, err := h.service.ImportCharacter(c.Request.Context(), &portsCharacter.ImportCharacterRequest{
+		UserID:      req.UserID,
+		Snapshot:    req.Snapshot,
+		Name:        req.Name,
+		SlotNumber:  req.SlotNumber,
+		PerformedBy: staffID,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, CharacterResponse{
+		ID:            char.ID.String(),
+		Name:          char.Name,
+		SlotNumber:    char.SlotNumber,
+		Level:         char.Level,
+		Experience:    char.Experience,
+		ClassType:     string(char.ClassType),
+		Race:          string(char.Race),
+		Gender:        string(char.Gender),
+		CreatedAt:     char.CreatedAt,
+		LastPlayedAt:  char.LastPlayedAt,
+		TotalPlayTime: int64(char.TotalPlayTime.Seconds()),
+	})
+}
+
+// CreateRestorePoint saves a character's current state so it can be rolled back to later
+func (h *HTTPHandler) CreateRestorePoint(c *gin.Context) {
+	point, err := h.service.CreateRestorePoint(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toRestorePointResponse(point))
+}
+
+// ListRestorePoints lists a character's most recent restore points
+func (h *HTTPHandler) ListRestorePoints(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	points, err := h.service.ListRestorePoints(c.Request.Context(), c.Param("id"), limit)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	response := make([]RestorePointResponse, 0, len(points))
+	for _, point := range points {
+		response = append(response, toRestorePointResponse(point))
+	}
+	c.JSON(http.StatusOK, gin.H{"restore_points": response})
+}
+
+// RollbackCharacter restores a character to one of its restore points
+func (h *HTTPHandler) RollbackCharacter(c *gin.Context) {
+	staffID, ok := GetUserIDFromContext(c)
+	if !ok {
+		h.respondWithError(c, http.StatusUnauthorized, ErrorCodeUnauthorized, "user ID not found in context", nil)
+		return
+	}
+
+	var req RollbackCharacterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondWithValidationError(c, map[string]string{
+			"body": err.Error(),
+		})
+		return
+	}
+
+	rollback, err := h.service.RollbackCharacter(c.Request.Context(), &portsCharacter.RollbackCharacterRequest{
+		CharacterID:    c.Param("id"),
+		RestorePointID: req.RestorePointID,
+		PerformedBy:    staffID,
+		Reason:         req.Reason,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toCharacterRollbackResponse(rollback))
+}
+
+// GetRollbackHistory lists a character's rollbacks
+func (h *HTTPHandler) GetRollbackHistory(c *gin.Context) {
+	rollbacks, err := h.service.GetRollbackHistory(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	response := make([]CharacterRollbackResponse, 0, len(rollbacks))
+	for _, rollback := range rollbacks {
+		response = append(response, toCharacterRollbackResponse(rollback))
+	}
+	c.JSON(http.StatusOK, gin.H{"rollbacks": response})
+}
+
+// GetDailyPlayTime lists a character's play time per UTC day. The optional from and to query
+// parameters are dates (YYYY-MM-DD), to being exclusive; the default is the last 30 days.
+func (h *HTTPHandler) GetDailyPlayTime(c *gin.Context) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	to := today.AddDate(0, 0, 1)
+	if value := c.Query("to"); value != "" {
+		parsed, err := time.Parse(time.DateOnly, value)
+		if err != nil {
+			h.handleError(c, character.ErrInvalidPlayTimeRange)
+			return
+		}
+		to = parsed
+	}
+	from := to.AddDate(0, 0, -defaultPlayTimeDays)
+	if value := c.Query("from"); value != "" {
+		parsed, err := time.Parse(time.DateOnly, value)
+		if err != nil {
+			h.handleError(c, character.ErrInvalidPlayTimeRange)
+			return
+		}
+		from = parsed
+	}
+
+	days, err := h.service.GetDailyPlayTime(c.Request.Context(), c.Param("id"), from, to)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	var total int64
+	response := make([]DailyPlayTimeResponse, 0, len(days))
+	for _, day := range days {
+		seconds := int64(day.PlayTime / time.Second)
+		total += seconds
+		response = append(response, DailyPlayTimeResponse{
+			Day:             day.Day.Format(time.DateOnly),
+			PlayTimeSeconds: seconds,
+			Sessions:        day.Sessions,
+		})
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"from":              from.Format(time.DateOnly),
+		"to":                to.Format(time.DateOnly),
+		"play_time_seconds": total,
+		"days":              response,
+	})
+}
+
+func toRestorePointResponse(point *character.RestorePoint) RestorePointResponse {
+	return RestorePointResponse{
+		ID:          point.ID.String(),
+		CharacterID: point.CharacterID.String(),
+		Trigger:     point.Trigger,
+		Level:       point.Level,
+		Experience:  point.Experience,
+		Stats:       point.Stats,
+		Position:    point.Position,
+		CreatedAt:   point.CreatedAt,
+	}
+}
+
+func toCharacterRollbackResponse(rollback *character.CharacterRollback) CharacterRollbackResponse {
+	return CharacterRollbackResponse{
+		ID:             rollback.ID.String(),
+		CharacterID:    rollback.CharacterID.String(),
+		RestorePointID: rollback.RestorePointID.String(),
+		BackupID:       rollback.BackupID.String(),
+		PerformedBy:    rollback.PerformedBy.String(),
+		Reason:         rollback.Reason,
+		CreatedAt:      rollback.CreatedAt,
+	}
+}
+
+func toCharacterTransferResponse(transfer *character.CharacterTransfer) CharacterTransferResponse {
+	return CharacterTransferResponse{
+		ID:          transfer.ID.String(),
+		CharacterID: transfer.CharacterID.String(),
+		FromUserID:  transfer.FromUserID.String(),
+		ToUserID:    transfer.ToUserID.String(),
+		FromSlot:    transfer.FromSlot,
+		ToSlot:      transfer.ToSlot,
+		PerformedBy: transfer.PerformedBy.String(),
+		Reason:      transfer.Reason,
+		CreatedAt:   transfer.CreatedAt,
+	}
+}
@@ -1,11 +1,14 @@
 package character
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/mmorpg-template/backend/internal/domain/auth"
 	"github.com/mmorpg-template/backend/internal/domain/character"
 	portsCharacter "github.com/mmorpg-template/backend/internal/ports/character"
 	"github.com/mmorpg-template/backend/pkg/logger"
@@ -54,6 +57,7 @@ func (h *HTTPHandler) CreateCharacter(c *gin.Context) {
 		ClassType:  character.ClassType(req.ClassType),
 		Race:       character.Race(req.Race),
 		Gender:     character.Gender(req.Gender),
+		IsGuest:    HasRoleInContext(c, auth.RoleGuest),
 	}
 
 	// Handle appearance options if provided
@@ -165,6 +169,75 @@ func (h *HTTPHandler) GetCharacter(c *gin.Context) {
 	})
 }
 
+// RenameCharacter changes a character's name
+func (h *HTTPHandler) RenameCharacter(c *gin.Context) {
+	userID, ok := GetUserIDFromContext(c)
+	if !ok {
+		h.respondWithError(c, http.StatusUnauthorized, ErrorCodeUnauthorized, "user ID not found in context", nil)
+		return
+	}
+	characterID := c.Param("id")
+
+	var req RenameCharacterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondWithValidationError(c, map[string]string{
+			"name": "Name is required",
+		})
+		return
+	}
+
+	char, err := h.service.RenameCharacter(c.Request.Context(), characterID, userID, req.Name)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, CharacterResponse{
+		ID:            char.ID.String(),
+		Name:          char.Name,
+		SlotNumber:    char.SlotNumber,
+		Level:         char.Level,
+		Experience:    char.Experience,
+		ClassType:     string(char.ClassType),
+		Race:          string(char.Race),
+		Gender:        string(char.Gender),
+		CreatedAt:     char.CreatedAt,
+		LastPlayedAt:  char.LastPlayedAt,
+		TotalPlayTime: int64(char.TotalPlayTime.Seconds()),
+	})
+}
+
+// GetNameHistory lists a character's previous names
+func (h *HTTPHandler) GetNameHistory(c *gin.Context) {
+	userID, ok := GetUserIDFromContext(c)
+	if !ok {
+		h.respondWithError(c, http.StatusUnauthorized, ErrorCodeUnauthorized, "user ID not found in context", nil)
+		return
+	}
+	characterID := c.Param("id")
+
+	if err := h.service.ValidateCharacterOwnership(c.Request.Context(), characterID, userID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	changes, err := h.service.GetNameHistory(c.Request.Context(), characterID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	names := make([]NameChangeResponse, 0, len(changes))
+	for _, change := range changes {
+		names = append(names, NameChangeResponse{
+			OldName:   change.OldName,
+			NewName:   change.NewName,
+			ChangedAt: change.ChangedAt,
+		})
+	}
+	c.JSON(http.StatusOK, gin.H{"names": names})
+}
+
 // DeleteCharacter soft deletes a character
 func (h *HTTPHandler) DeleteCharacter(c *gin.Context) {
 	userID, ok := GetUserIDFromContext(c)
@@ -323,35 +396,7 @@ func (h *HTTPHandler) GetStats(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, StatsResponse{
-		Strength:             stats.Strength,
-		Dexterity:            stats.Dexterity,
-		Intelligence:         stats.Intelligence,
-		Wisdom:               stats.Wisdom,
-		Constitution:         stats.Constitution,
-		Charisma:             stats.Charisma,
-		HealthCurrent:        stats.HealthCurrent,
-		HealthMax:            stats.HealthMax,
-		ManaCurrent:          stats.ManaCurrent,
-		ManaMax:              stats.ManaMax,
-		StaminaCurrent:       stats.StaminaCurrent,
-		StaminaMax:           stats.StaminaMax,
-		AttackPower:          stats.AttackPower,
-		SpellPower:           stats.SpellPower,
-		Defense:              stats.Defense,
-		CriticalChance:       stats.CriticalChance,
-		CriticalDamage:       stats.CriticalDamage,
-		DodgeChance:          stats.DodgeChance,
-		BlockChance:          stats.BlockChance,
-		MovementSpeed:        stats.MovementSpeed,
-		AttackSpeed:          stats.AttackSpeed,
-		CastSpeed:            stats.CastSpeed,
-		HealthRegen:          stats.HealthRegen,
-		ManaRegen:            stats.ManaRegen,
-		StaminaRegen:         stats.StaminaRegen,
-		StatPointsAvailable:  stats.StatPointsAvailable,
-		SkillPointsAvailable: stats.SkillPointsAvailable,
-	})
+	c.JSON(http.StatusOK, toStatsResponse(stats))
 }
 
 // GetPosition retrieves character position
@@ -436,9 +481,26 @@ func (h *HTTPHandler) UpdatePosition(c *gin.Context) {
 
 // handleError handles errors and returns appropriate HTTP responses
 func (h *HTTPHandler) handleError(c *gin.Context, err error) {
+	var violation *character.NameViolation
+	if errors.As(err, &violation) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid character name", "reason": violation.Reason})
+		return
+	}
+	var blocked *character.TransferBlocked
+	if errors.As(err, &blocked) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "pending": blocked.Pending})
+		return
+	}
+	if errors.Is(err, character.ErrInvalidSnapshot) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	switch err {
 	case character.ErrCharacterNotFound:
 		c.JSON(http.StatusNotFound, gin.H{"error": "character not found"})
+	case character.ErrRestorePointNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 	case character.ErrCharacterNameTaken:
 		c.JSON(http.StatusConflict, gin.H{"error": "character name is already taken"})
 	case character.ErrCharacterLimitReached:
@@ -451,7 +513,26 @@ func (h *HTTPHandler) handleError(c *gin.Context, err error) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "character cannot be restored"})
 	case character.ErrCharacterBelongsToOther:
 		c.JSON(http.StatusForbidden, gin.H{"error": "character belongs to another user"})
-	case character.ErrInvalidClass, character.ErrInvalidRace, character.ErrInvalidGender:
+	case character.ErrPlaytimeLimitReached:
+		c.JSON(http.StatusForbidden, gin.H{"error": "play time limit reached"})
+	case character.ErrPlaytimeUnavailable:
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+	case character.ErrRenameTooSoon:
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": "character was renamed too recently"})
+	case character.ErrCharacterNameUnchanged:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case character.ErrSlotOccupied, character.ErrSelectionConflict, character.ErrNoCharacterSelected, character.ErrSelectedElsewhere,
+		character.ErrCharacterOnline:
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case character.ErrInvalidSlotNumber, character.ErrInvalidCharacterID, character.ErrInvalidUserID, character.ErrTransferSameAccount, character.ErrTransferReasonRequired,
+		character.ErrRollbackReasonRequired, character.ErrInvalidPlayTimeRange, character.ErrSessionIDRequired:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case character.ErrInvalidClass, character.ErrInvalidRace, character.ErrInvalidGender, character.ErrClassRaceNotAllowed:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case character.ErrRespecOnCooldown, character.ErrStatBelowBaseline:
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case character.ErrNothingToRespec, character.ErrNothingToUndo, character.ErrInvalidUndoCount,
+		character.ErrInvalidAllocation, character.ErrNotEnoughStatPoints, character.ErrStatMaxReached, character.ErrInvalidStatType:
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 	case character.ErrUnauthorized:
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
@@ -471,28 +552,36 @@ func (h *HTTPHandler) CheckNameAvailability(c *gin.Context) {
 		return
 	}
 	
-	// For now, we'll check if a character with this name exists
-	_, err := h.service.GetCharacterByName(c.Request.Context(), name)
-	if err != nil {
-		if err == character.ErrCharacterNotFound {
-			// Name is available
-			c.JSON(http.StatusOK, gin.H{
-				"available": true,
-				"name":      name,
-			})
-			return
-		}
-		// Error checking name
+	err := h.service.CheckCharacterName(c.Request.Context(), name)
+	var violation *character.NameViolation
+	switch {
+	case err == nil:
+		c.JSON(http.StatusOK, gin.H{
+			"available": true,
+			"name":      name,
+		})
+	case errors.As(err, &violation):
+		c.JSON(http.StatusOK, gin.H{
+			"available": false,
+			"name":      name,
+			"reason":    violation.Reason,
+		})
+	case err == character.ErrInvalidCharacterName:
+		c.JSON(http.StatusOK, gin.H{
+			"available": false,
+			"name":      name,
+			"reason":    character.NameReasonLength,
+		})
+	case err == character.ErrCharacterNameTaken:
+		c.JSON(http.StatusOK, gin.H{
+			"available":   false,
+			"name":        name,
+			"reason":      "taken",
+			"suggestions": h.generateNameSuggestions(c.Request.Context(), name),
+		})
+	default:
 		h.handleError(c, err)
-		return
 	}
-	
-	// Name is taken
-	c.JSON(http.StatusOK, gin.H{
-		"available": false,
-		"name":      name,
-		"suggestions": h.generateNameSuggestions(name),
-	})
 }
 
 // ListDeletedCharacters lists soft-deleted characters for the authenticated user
@@ -557,6 +646,39 @@ func (h *HTTPHandler) SelectCharacter(c *gin.Context) {
 	})
 }
 
+// DeselectCharacter ends the caller's play session, returning them to character selection
+func (h *HTTPHandler) DeselectCharacter(c *gin.Context) {
+	userID, ok := GetUserIDFromContext(c)
+	if !ok {
+		h.respondWithError(c, http.StatusUnauthorized, ErrorCodeUnauthorized, "user ID not found in context", nil)
+		return
+	}
+
+	if err := h.service.DeselectCharacter(c.Request.Context(), userID, c.GetString("sessionID")); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// HeartbeatCharacter renews the caller's lease on their selection of a character; clients send
+// it while in the world
+func (h *HTTPHandler) HeartbeatCharacter(c *gin.Context) {
+	userID, ok := GetUserIDFromContext(c)
+	if !ok {
+		h.respondWithError(c, http.StatusUnauthorized, ErrorCodeUnauthorized, "user ID not found in context", nil)
+		return
+	}
+
+	if err := h.service.HeartbeatCharacter(c.Request.Context(), c.Param("id"), userID, c.GetString("sessionID")); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
 // PermanentlyDeleteCharacter permanently deletes a character
 func (h *HTTPHandler) PermanentlyDeleteCharacter(c *gin.Context) {
 	userID, ok := GetUserIDFromContext(c)
@@ -665,8 +787,149 @@ func (h *HTTPHandler) AllocateStatPoints(c *gin.Context) {
 	})
 }
 
+// AllocateStatPointsBulk spends points on several stats at once, or previews the result
+func (h *HTTPHandler) AllocateStatPointsBulk(c *gin.Context) {
+	userID, ok := GetUserIDFromContext(c)
+	if !ok {
+		h.respondWithError(c, http.StatusUnauthorized, ErrorCodeUnauthorized, "user ID not found in context", nil)
+		return
+	}
+	characterID := c.Param("id")
+
+	if err := h.service.ValidateCharacterOwnership(c.Request.Context(), characterID, userID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	var req BulkAllocateStatsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondWithValidationError(c, map[string]string{
+			"body": "Invalid request body",
+		})
+		return
+	}
+
+	stats, err := h.service.AllocateStatPoints(c.Request.Context(), characterID, req.Points, req.Preview)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, BulkAllocateStatsResponse{
+		Preview:         req.Preview,
+		Allocated:       req.Points,
+		PointsRemaining: stats.StatPointsAvailable,
+		Stats:           toStatsResponse(stats),
+	})
+}
+
+// GetRespecQuote returns the cost and availability of the character's next stat respec
+func (h *HTTPHandler) GetRespecQuote(c *gin.Context) {
+	userID, ok := GetUserIDFromContext(c)
+	if !ok {
+		h.respondWithError(c, http.StatusUnauthorized, ErrorCodeUnauthorized, "user ID not found in context", nil)
+		return
+	}
+	characterID := c.Param("id")
+
+	if err := h.service.ValidateCharacterOwnership(c.Request.Context(), characterID, userID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	quote, err := h.service.GetRespecQuote(c.Request.Context(), characterID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	response := RespecQuoteResponse{
+		Number:           quote.Number,
+		Cost:             quote.Cost,
+		Available:        quote.Available(time.Now()),
+		RefundablePoints: quote.RefundablePoints,
+	}
+	if !quote.AvailableAt.IsZero() {
+		response.AvailableAt = &quote.AvailableAt
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// RespecStats returns all allocated stat points and restores the class baseline
+func (h *HTTPHandler) RespecStats(c *gin.Context) {
+	userID, ok := GetUserIDFromContext(c)
+	if !ok {
+		h.respondWithError(c, http.StatusUnauthorized, ErrorCodeUnauthorized, "user ID not found in context", nil)
+		return
+	}
+	characterID := c.Param("id")
+
+	if err := h.service.ValidateCharacterOwnership(c.Request.Context(), characterID, userID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	result, err := h.service.RespecStats(c.Request.Context(), characterID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, RespecResponse{
+		Number:          result.Respec.Number,
+		RefundedPoints:  result.Respec.RefundedPoints,
+		Cost:            result.Respec.Cost,
+		NextAvailableAt: result.NextAvailableAt,
+		Stats:           toStatsResponse(result.Stats),
+	})
+}
+
+// UndoStatAllocations reverts the character's most recent stat point allocations
+func (h *HTTPHandler) UndoStatAllocations(c *gin.Context) {
+	userID, ok := GetUserIDFromContext(c)
+	if !ok {
+		h.respondWithError(c, http.StatusUnauthorized, ErrorCodeUnauthorized, "user ID not found in context", nil)
+		return
+	}
+	characterID := c.Param("id")
+
+	if err := h.service.ValidateCharacterOwnership(c.Request.Context(), characterID, userID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	req := UndoStatAllocationsRequest{Count: 1}
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.respondWithValidationError(c, map[string]string{
+				"body": "Invalid request body",
+			})
+			return
+		}
+	}
+
+	result, err := h.service.UndoStatAllocations(c.Request.Context(), characterID, req.Count)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	undone := make([]UndoneAllocation, 0, len(result.Undone))
+	for _, allocation := range result.Undone {
+		undone = append(undone, UndoneAllocation{
+			Stat:        allocation.Stat,
+			Points:      allocation.Points,
+			AllocatedAt: allocation.AllocatedAt,
+		})
+	}
+	c.JSON(http.StatusOK, UndoStatAllocationsResponse{
+		Undone: undone,
+		Stats:  toStatsResponse(result.Stats),
+	})
+}
+
 // generateNameSuggestions generates name suggestions when a name is taken
-func (h *HTTPHandler) generateNameSuggestions(name string) []string {
+func (h *HTTPHandler) generateNameSuggestions(ctx context.Context, name string) []string {
 	suggestions := []string{}
 	
 	// Add number suffixes
@@ -684,10 +947,52 @@ func (h *HTTPHandler) generateNameSuggestions(name string) []string {
 	suggestions = append(suggestions, "Lord"+name)
 	suggestions = append(suggestions, "Lady"+name)
 	
+	// Only suggest names the name policy accepts
+	valid := suggestions[:0]
+	for _, suggestion := range suggestions {
+		if h.service.ValidateName(ctx, character.NameKindCharacter, suggestion) == nil {
+			valid = append(valid, suggestion)
+		}
+	}
+	suggestions = valid
+	
 	// Limit to 5 suggestions
 	if len(suggestions) > 5 {
 		suggestions = suggestions[:5]
 	}
 	
 	return suggestions
-}
\ No newline at end of file
+}
+
+// toStatsResponse converts domain stats to the HTTP response
+func toStatsResponse(stats *character.Stats) StatsResponse {
+	return StatsResponse{
+	Strength:             stats.Strength,
+	Dexterity:            stats.Dexterity,
+	Intelligence:         stats.Intelligence,
+	Wisdom:               stats.Wisdom,
+	Constitution:         stats.Constitution,
+	Charisma:             stats.Charisma,
+	HealthCurrent:        stats.HealthCurrent,
+	HealthMax:            stats.HealthMax,
+	ManaCurrent:          stats.ManaCurrent,
+	ManaMax:              stats.ManaMax,
+	StaminaCurrent:       stats.StaminaCurrent,
+	StaminaMax:           stats.StaminaMax,
+	AttackPower:          stats.AttackPower,
+	SpellPower:           stats.SpellPower,
+	Defense:              stats.Defense,
+	CriticalChance:       stats.CriticalChance,
+	CriticalDamage:       stats.CriticalDamage,
+	DodgeChance:          stats.DodgeChance,
+	BlockChance:          stats.BlockChance,
+	MovementSpeed:        stats.MovementSpeed,
+	AttackSpeed:          stats.AttackSpeed,
+	CastSpeed:            stats.CastSpeed,
+	HealthRegen:          stats.HealthRegen,
+	ManaRegen:            stats.ManaRegen,
+	StaminaRegen:         stats.StaminaRegen,
+	StatPointsAvailable:  stats.StatPointsAvailable,
+	SkillPointsAvailable: stats.SkillPointsAvailable,
+	}
+}
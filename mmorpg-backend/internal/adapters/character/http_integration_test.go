@@ -100,6 +100,72 @@ func (m *MockCharacterService) AllocateStatPoint(ctx context.Context, characterI
 	return args.Get(0).(*character.Stats), args.Error(1)
 }
 
+func (m *MockCharacterService) AllocateStatPoints(ctx context.Context, characterID string, points map[string]int, preview bool) (*character.Stats, error) {
+	args := m.Called(ctx, characterID, points, preview)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*character.Stats), args.Error(1)
+}
+
+func (m *MockCharacterService) RenameCharacter(ctx context.Context, characterID, userID, newName string) (*character.Character, error) {
+	args := m.Called(ctx, characterID, userID, newName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*character.Character), args.Error(1)
+}
+
+func (m *MockCharacterService) GetNameHistory(ctx context.Context, characterID string) ([]*character.NameChange, error) {
+	args := m.Called(ctx, characterID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*character.NameChange), args.Error(1)
+}
+
+func (m *MockCharacterService) CheckCharacterName(ctx context.Context, name string) error {
+	args := m.Called(ctx, name)
+	return args.Error(0)
+}
+
+func (m *MockCharacterService) ValidateName(ctx context.Context, kind character.NameKind, name string) error {
+	args := m.Called(ctx, kind, name)
+	return args.Error(0)
+}
+
+func (m *MockCharacterService) GetRespecQuote(ctx context.Context, characterID string) (*character.RespecQuote, error) {
+	args := m.Called(ctx, characterID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*character.RespecQuote), args.Error(1)
+}
+
+func (m *MockCharacterService) RespecStats(ctx context.Context, characterID string) (*character.RespecResult, error) {
+	args := m.Called(ctx, characterID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*character.RespecResult), args.Error(1)
+}
+
+func (m *MockCharacterService) UndoStatAllocations(ctx context.Context, characterID string, count int) (*character.StatUndoResult, error) {
+	args := m.Called(ctx, characterID, count)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*character.StatUndoResult), args.Error(1)
+}
+
+func (m *MockCharacterService) GrantExperience(ctx context.Context, characterID, grantID string, amount int64, source string) (*character.ExperienceGain, error) {
+	args := m.Called(ctx, characterID, grantID, amount, source)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*character.ExperienceGain), args.Error(1)
+}
+
 func (m *MockCharacterService) GetPosition(ctx context.Context, characterID string) (*character.Position, error) {
 	args := m.Called(ctx, characterID)
 	if args.Get(0) == nil {
@@ -139,6 +205,103 @@ func (m *MockCharacterService) SelectCharacter(ctx context.Context, characterID
 	return args.Error(0)
 }
 
+func (m *MockCharacterService) TransferCharacter(ctx context.Context, req *portsCharacter.TransferCharacterRequest) (*character.CharacterTransfer, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*character.CharacterTransfer), args.Error(1)
+}
+
+func (m *MockCharacterService) GetTransferHistory(ctx context.Context, characterID string) ([]*character.CharacterTransfer, error) {
+	args := m.Called(ctx, characterID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*character.CharacterTransfer), args.Error(1)
+}
+
+func (m *MockCharacterService) ExportCharacter(ctx context.Context, characterID string) (*character.CharacterSnapshot, error) {
+	args := m.Called(ctx, characterID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*character.CharacterSnapshot), args.Error(1)
+}
+
+func (m *MockCharacterService) ImportCharacter(ctx context.Context, req *portsCharacter.ImportCharacterRequest) (*character.Character, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*character.Character), args.Error(1)
+}
+
+func (m *MockCharacterService) CreateRestorePoint(ctx context.Context, characterID string) (*character.RestorePoint, error) {
+	args := m.Called(ctx, characterID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*character.RestorePoint), args.Error(1)
+}
+
+func (m *MockCharacterService) ListRestorePoints(ctx context.Context, characterID string, limit int) ([]*character.RestorePoint, error) {
+	args := m.Called(ctx, characterID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*character.RestorePoint), args.Error(1)
+}
+
+func (m *MockCharacterService) RollbackCharacter(ctx context.Context, req *portsCharacter.RollbackCharacterRequest) (*character.CharacterRollback, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*character.CharacterRollback), args.Error(1)
+}
+
+func (m *MockCharacterService) GetRollbackHistory(ctx context.Context, characterID string) ([]*character.CharacterRollback, error) {
+	args := m.Called(ctx, characterID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*character.CharacterRollback), args.Error(1)
+}
+
+func (m *MockCharacterService) DeselectCharacter(ctx context.Context, userID string, sessionID string) error {
+	args := m.Called(ctx, userID, sessionID)
+	return args.Error(0)
+}
+
+func (m *MockCharacterService) HeartbeatCharacter(ctx context.Context, characterID string, userID string, sessionID string) error {
+	args := m.Called(ctx, characterID, userID, sessionID)
+	return args.Error(0)
+}
+
+func (m *MockCharacterService) EndPlaySession(ctx context.Context, userID string, sessionID string, reason string) error {
+	args := m.Called(ctx, userID, sessionID, reason)
+	return args.Error(0)
+}
+
+func (m *MockCharacterService) EndLoginSession(ctx context.Context, userID string, sessionID string) error {
+	args := m.Called(ctx, userID, sessionID)
+	return args.Error(0)
+}
+
+func (m *MockCharacterService) ForgetDeletedAccount(ctx context.Context, userID string, characters []*character.Character) error {
+	args := m.Called(ctx, userID, characters)
+	return args.Error(0)
+}
+
+func (m *MockCharacterService) GetDailyPlayTime(ctx context.Context, characterID string, from, to time.Time) ([]*character.DailyPlayTime, error) {
+	args := m.Called(ctx, characterID, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*character.DailyPlayTime), args.Error(1)
+}
+
 func setupTestRouter(t *testing.T) (*gin.Engine, *MockCharacterService, string) {
 	gin.SetMode(gin.TestMode)
 	
@@ -323,15 +486,15 @@ func TestCharacterAPI_CheckNameAvailability(t *testing.T) {
 		name          string
 		queryName     string
 		mockSetup     func()
-		expectedCode  int
-		expectedAvail bool
+		expectedCode   int
+		expectedAvail  bool
+		expectedReason string
 	}{
 		{
 			name:      "name available",
 			queryName: "NewHero",
 			mockSetup: func() {
-				mockService.On("GetCharacterByName", mock.Anything, "NewHero").
-					Return(nil, character.ErrCharacterNotFound)
+				mockService.On("CheckCharacterName", mock.Anything, "NewHero").Return(nil)
 			},
 			expectedCode:  http.StatusOK,
 			expectedAvail: true,
@@ -340,11 +503,25 @@ func TestCharacterAPI_CheckNameAvailability(t *testing.T) {
 			name:      "name taken",
 			queryName: "ExistingHero",
 			mockSetup: func() {
-				mockService.On("GetCharacterByName", mock.Anything, "ExistingHero").
-					Return(&character.Character{Name: "ExistingHero"}, nil)
+				mockService.On("CheckCharacterName", mock.Anything, "ExistingHero").Return(character.ErrCharacterNameTaken)
+				mockService.On("ValidateName", mock.Anything, character.NameKindCharacter, "ExistingHero_01").
+					Return(&character.NameViolation{Kind: character.NameKindCharacter, Reason: character.NameReasonCharacters})
+				mockService.On("ValidateName", mock.Anything, character.NameKindCharacter, mock.Anything).Return(nil)
 			},
-			expectedCode:  http.StatusOK,
-			expectedAvail: false,
+			expectedCode:   http.StatusOK,
+			expectedAvail:  false,
+			expectedReason: "taken",
+		},
+		{
+			name:      "name rejected by policy",
+			queryName: "GMBob",
+			mockSetup: func() {
+				mockService.On("CheckCharacterName", mock.Anything, "GMBob").
+					Return(&character.NameViolation{Kind: character.NameKindCharacter, Reason: character.NameReasonReservedPrefix})
+			},
+			expectedCode:   http.StatusOK,
+			expectedAvail:  false,
+			expectedReason: character.NameReasonReservedPrefix,
 		},
 		{
 			name:         "missing name parameter",
@@ -385,13 +562,116 @@ func TestCharacterAPI_CheckNameAvailability(t *testing.T) {
 				assert.Equal(t, tt.expectedAvail, response["available"])
 				assert.Equal(t, tt.queryName, response["name"])
 				
-				if !tt.expectedAvail {
+				if tt.expectedReason == "taken" {
 					suggestions := response["suggestions"].([]interface{})
 					assert.NotEmpty(t, suggestions)
+					assert.NotContains(t, suggestions, tt.queryName+"_01")
+				}
+				if tt.expectedReason != "" {
+					assert.Equal(t, tt.expectedReason, response["reason"])
 				}
 			}
 			
 			mockService.AssertExpectations(t)
 		})
 	}
-}
\ No newline at end of file
+}
+func TestCharacterAPI_RenameCharacter(t *testing.T) {
+	router, mockService, token := setupTestRouter(t)
+
+	charID := uuid.New()
+	renamed := &character.Character{
+		ID:        charID,
+		Name:      "NewName",
+		Level:     10,
+		ClassType: character.ClassMage,
+		Race:      character.RaceElf,
+		Gender:    character.GenderFemale,
+	}
+	mockService.On("RenameCharacter", mock.Anything, charID.String(), "test-user-123", "NewName").Return(renamed, nil).Once()
+	mockService.On("RenameCharacter", mock.Anything, charID.String(), "test-user-123", "Again").Return(nil, character.ErrRenameTooSoon).Once()
+
+	rename := func(name string) *httptest.ResponseRecorder {
+		body, err := json.Marshal(RenameCharacterRequest{Name: name})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/characters/"+charID.String()+"/name", bytes.NewBuffer(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := rename("NewName")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response CharacterResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "NewName", response.Name)
+
+	w = rename("Again")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestCharacterAPI_TransferCharacter(t *testing.T) {
+	router, mockService, playerToken := setupTestRouter(t)
+
+	claims := &auth.Claims{
+		UserID:    "support-user-1",
+		SessionID: "session-789",
+		Roles:     []string{"player", RoleSupport},
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(15 * time.Minute)),
+			Issuer:    "mmorpg-auth",
+			Subject:   "support-user-1",
+		},
+	}
+	supportToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("test-secret"))
+	require.NoError(t, err)
+
+	charID := uuid.New()
+	toUserID := uuid.New()
+	transfer := &character.CharacterTransfer{
+		ID:          uuid.New(),
+		CharacterID: charID,
+		FromUserID:  uuid.New(),
+		ToUserID:    toUserID,
+		FromSlot:    1,
+		ToSlot:      3,
+		Reason:      "account merge",
+		CreatedAt:   time.Now(),
+	}
+	mockService.On("TransferCharacter", mock.Anything, mock.MatchedBy(func(req *portsCharacter.TransferCharacterRequest) bool {
+		return req.CharacterID == charID.String() && req.PerformedBy == "support-user-1" && req.Reason == "account merge"
+	})).Return(transfer, nil).Once()
+	mockService.On("TransferCharacter", mock.Anything, mock.Anything).
+		Return(nil, &character.TransferBlocked{Pending: []string{character.PendingActivityMail}}).Once()
+
+	send := func(token string) *httptest.ResponseRecorder {
+		body, err := json.Marshal(TransferCharacterRequest{ToUserID: toUserID.String(), Reason: "account merge"})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/characters/"+charID.String()+"/transfer", bytes.NewBuffer(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := send(playerToken)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = send(supportToken)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response CharacterTransferResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, toUserID.String(), response.ToUserID)
+	assert.Equal(t, 3, response.ToSlot)
+
+	w = send(supportToken)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), character.PendingActivityMail)
+
+	mockService.AssertExpectations(t)
+}
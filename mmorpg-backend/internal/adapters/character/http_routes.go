@@ -20,12 +20,16 @@ func (h *HTTPHandler) RegisterRoutes(router *gin.Engine) {
 		protected.GET("", h.ListCharacters)
 		protected.POST("", h.CreateCharacter)
 		protected.GET("/deleted", h.ListDeletedCharacters)
+		protected.POST("/deselect", h.DeselectCharacter)
 		
 		// Single character operations
 		protected.GET("/:id", h.GetCharacter)
 		protected.DELETE("/:id", h.DeleteCharacter)
 		protected.POST("/:id/restore", h.RestoreCharacter)
 		protected.POST("/:id/select", h.SelectCharacter)
+		protected.POST("/:id/heartbeat", h.HeartbeatCharacter)
+		protected.PUT("/:id/name", h.RenameCharacter)
+		protected.GET("/:id/names", h.GetNameHistory)
 		protected.DELETE("/:id/permanent", h.PermanentlyDeleteCharacter)
 		
 		// Character appearance
@@ -35,10 +39,29 @@ func (h *HTTPHandler) RegisterRoutes(router *gin.Engine) {
 		// Character stats
 		protected.GET("/:id/stats", h.GetStats)
 		protected.POST("/:id/stats/allocate", h.AllocateStatPoints)
+		protected.POST("/:id/stats/allocate/bulk", h.AllocateStatPointsBulk)
+		protected.GET("/:id/stats/respec", h.GetRespecQuote)
+		protected.POST("/:id/stats/respec", h.RespecStats)
+		protected.POST("/:id/stats/undo", h.UndoStatAllocations)
 		
 		// Character position
 		protected.GET("/:id/position", h.GetPosition)
 		protected.PUT("/:id/position", h.UpdatePosition)
 	}
+	
+	// Support staff routes
+	support := characterGroup.Group("")
+	support.Use(h.AuthMiddleware(), h.RequireSupport())
+	{
+		support.POST("/:id/transfer", h.TransferCharacter)
+		support.GET("/:id/transfers", h.GetTransferHistory)
+		support.GET("/:id/snapshot", h.ExportCharacter)
+		support.POST("/import", h.ImportCharacter)
+		support.GET("/:id/restore-points", h.ListRestorePoints)
+		support.POST("/:id/restore-points", h.CreateRestorePoint)
+		support.POST("/:id/rollback", h.RollbackCharacter)
+		support.GET("/:id/rollbacks", h.GetRollbackHistory)
+		support.GET("/:id/playtime", h.GetDailyPlayTime)
+	}
 }
 
@@ -44,6 +44,18 @@ type CharacterResponse struct {
 	TotalPlayTime int64     `json:"total_play_time"` // in seconds
 }
 
+// RenameCharacterRequest represents the HTTP request for renaming a character
+type RenameCharacterRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// NameChangeResponse represents a previous character name
+type NameChangeResponse struct {
+	OldName   string    `json:"old_name"`
+	NewName   string    `json:"new_name"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
 // UpdateAppearanceRequest represents the HTTP request for updating appearance
 type UpdateAppearanceRequest struct {
 	FaceType         *int                        `json:"face_type,omitempty"`
@@ -189,4 +201,55 @@ type AllocateStatResponse struct {
 	NewValue        int                    `json:"new_value"`
 	PointsRemaining int                    `json:"points_remaining"`
 	AffectedStats   map[string]interface{} `json:"affected_stats"`
-}
\ No newline at end of file
+}
+
+// BulkAllocateStatsRequest represents the HTTP request for allocating points to several stats
+type BulkAllocateStatsRequest struct {
+	Points map[string]int `json:"points" binding:"required"`
+	// Preview returns the resulting stats without saving them
+	Preview bool `json:"preview"`
+}
+
+// BulkAllocateStatsResponse represents the response for a bulk stat allocation
+type BulkAllocateStatsResponse struct {
+	Preview         bool           `json:"preview"`
+	Allocated       map[string]int `json:"allocated"`
+	PointsRemaining int            `json:"points_remaining"`
+	Stats           StatsResponse  `json:"stats"`
+}
+
+// RespecQuoteResponse describes the character's next stat respec
+type RespecQuoteResponse struct {
+	Number           int        `json:"number"`
+	Cost             int64      `json:"cost"`
+	Available        bool       `json:"available"`
+	AvailableAt      *time.Time `json:"available_at,omitempty"`
+	RefundablePoints int        `json:"refundable_points"`
+}
+
+// RespecResponse represents the response for a stat respec
+type RespecResponse struct {
+	Number          int           `json:"number"`
+	RefundedPoints  int           `json:"refunded_points"`
+	Cost            int64         `json:"cost"`
+	NextAvailableAt time.Time     `json:"next_available_at"`
+	Stats           StatsResponse `json:"stats"`
+}
+
+// UndoStatAllocationsRequest represents the request for undoing recent stat allocations
+type UndoStatAllocationsRequest struct {
+	Count int `json:"count" binding:"min=1"`
+}
+
+// UndoneAllocation is a reverted stat allocation
+type UndoneAllocation struct {
+	Stat        string    `json:"stat"`
+	Points      int       `json:"points"`
+	AllocatedAt time.Time `json:"allocated_at"`
+}
+
+// UndoStatAllocationsResponse represents the response for undoing stat allocations
+type UndoStatAllocationsResponse struct {
+	Undone []UndoneAllocation `json:"undone"`
+	Stats  StatsResponse      `json:"stats"`
+}
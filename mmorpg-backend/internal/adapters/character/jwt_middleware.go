@@ -2,6 +2,7 @@
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strings"
 	"time"
@@ -18,10 +19,17 @@ type JWTConfig struct {
 	Issuer       string
 }
 
+// TokenValidator checks a token with the auth service, so logged-out and
+// revoked tokens are rejected even though their signature is still valid
+type TokenValidator interface {
+	ValidateToken(ctx context.Context, token string) (*auth.Claims, error)
+}
+
 // JWTMiddleware provides JWT validation middleware
 type JWTMiddleware struct {
-	config *JWTConfig
-	logger logger.Logger
+	config    *JWTConfig
+	validator TokenValidator
+	logger    logger.Logger
 }
 
 // NewJWTMiddleware creates a new JWT middleware instance
@@ -32,6 +40,11 @@ func NewJWTMiddleware(config *JWTConfig, logger logger.Logger) *JWTMiddleware {
 	}
 }
 
+// SetTokenValidator makes the middleware confirm locally valid tokens with the auth service
+func (m *JWTMiddleware) SetTokenValidator(validator TokenValidator) {
+	m.validator = validator
+}
+
 // Validate returns a gin middleware function that validates JWT tokens
 func (m *JWTMiddleware) Validate() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -65,6 +78,7 @@ func (m *JWTMiddleware) Validate() gin.HandlerFunc {
 		c.Set("username", claims.Username)
 		c.Set("roles", claims.Roles)
 		c.Set("deviceID", claims.DeviceID)
+		c.Set("authenticated", true)
 
 		// Add claims to request context for downstream use
 		ctx := context.WithValue(c.Request.Context(), "claims", claims)
@@ -104,11 +118,29 @@ func (m *JWTMiddleware) validateToken(ctx context.Context, tokenString string) (
 		return nil, auth.ErrInvalidToken
 	}
 
+	// The signature is fine; ask the auth service whether the session is still alive
+	if m.validator != nil {
+		return m.validator.ValidateToken(ctx, tokenString)
+	}
+
 	return claims, nil
 }
 
 // handleTokenError handles JWT validation errors and returns appropriate responses
 func (m *JWTMiddleware) handleTokenError(c *gin.Context, err error) {
+	if errors.Is(err, auth.ErrAuthUnavailable) {
+		m.logger.WithError(err).Warn("Token validation unavailable")
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": ErrorDetail{
+				Code:    ErrorCodeServiceUnavailable,
+				Message: "authentication temporarily unavailable",
+			},
+			"timestamp": time.Now().Format(time.RFC3339),
+		})
+		c.Abort()
+		return
+	}
+
 	switch err {
 	case jwt.ErrTokenExpired:
 		c.JSON(http.StatusUnauthorized, gin.H{
@@ -217,8 +249,8 @@ func (m *JWTMiddleware) OptionalAuth() gin.HandlerFunc {
 	}
 }
 
-// RequireRole returns a middleware that requires a specific role
-func (m *JWTMiddleware) RequireRole(role string) gin.HandlerFunc {
+// RequireRole returns a middleware that requires at least one of the given roles
+func (m *JWTMiddleware) RequireRole(roles ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Check if user is authenticated
 		if !c.GetBool("authenticated") {
@@ -227,14 +259,14 @@ func (m *JWTMiddleware) RequireRole(role string) gin.HandlerFunc {
 		}
 
 		// Get roles from context
-		roles, exists := c.Get("roles")
+		userRolesValue, exists := c.Get("roles")
 		if !exists {
 			m.respondWithAuthError(c, "no roles found")
 			return
 		}
 
-		// Check if user has the required role
-		userRoles, ok := roles.([]string)
+		// Check if user has one of the required roles
+		userRoles, ok := userRolesValue.([]string)
 		if !ok {
 			m.respondWithAuthError(c, "invalid roles format")
 			return
@@ -242,9 +274,11 @@ func (m *JWTMiddleware) RequireRole(role string) gin.HandlerFunc {
 
 		hasRole := false
 		for _, r := range userRoles {
-			if r == role {
-				hasRole = true
-				break
+			for _, role := range roles {
+				if r == role {
+					hasRole = true
+					break
+				}
 			}
 		}
 
@@ -254,7 +288,7 @@ func (m *JWTMiddleware) RequireRole(role string) gin.HandlerFunc {
 					Code:    "INSUFFICIENT_PERMISSIONS",
 					Message: "insufficient permissions",
 					Details: map[string]interface{}{
-						"required_role": role,
+						"required_roles": roles,
 					},
 				},
 				"timestamp": time.Now().Format(time.RFC3339),
@@ -282,4 +316,20 @@ func GetUserIDFromContext(c *gin.Context) (string, bool) {
 func GetClaimsFromContext(ctx context.Context) (*auth.Claims, bool) {
 	claims, ok := ctx.Value("claims").(*auth.Claims)
 	return claims, ok
-}
\ No newline at end of file
+}
+
+// HasRoleInContext reports whether the authenticated user has a role
+func HasRoleInContext(c *gin.Context, role string) bool {
+	roles, exists := c.Get("roles")
+	if !exists {
+		return false
+	}
+
+	userRoles, _ := roles.([]string)
+	for _, r := range userRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,196 @@
+package character
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/mmorpg-template/backend/internal/domain/character"
+	portsCharacter "github.com/mmorpg-template/backend/internal/ports/character"
+)
+
+// uniqueViolation is the PostgreSQL error code for a unique constraint violation
+const uniqueViolation = "23505"
+
+// PostgresNameHistoryRepository implements NameHistoryRepository using PostgreSQL
+type PostgresNameHistoryRepository struct {
+	db           *sql.DB
+	transactions *TransactionManager
+}
+
+// NewPostgresNameHistoryRepository creates a new PostgreSQL name history repository
+func NewPostgresNameHistoryRepository(db *sql.DB) portsCharacter.NameHistoryRepository {
+	return &PostgresNameHistoryRepository{db: db, transactions: NewTransactionManager(db)}
+}
+
+const nameChangeColumns = `id, character_id, old_name, new_name, changed_by, change_reason, hold_until, changed_at`
+
+// Rename changes the character's name and records the change atomically. The cooldown is
+// checked with the character locked, so concurrent renames can't both pass it.
+func (r *PostgresNameHistoryRepository) Rename(ctx context.Context, change *character.NameChange, cooldown time.Duration) error {
+	return r.transactions.ExecuteInTransaction(ctx, func(tx *sql.Tx) error {
+		var lastChange sql.NullTime
+		err := tx.QueryRowContext(ctx, `
+			SELECT (SELECT MAX(changed_at) FROM character_name_history WHERE character_id = c.id)
+			FROM characters c
+			WHERE c.id = $1 AND c.is_deleted = false
+			FOR UPDATE`,
+			change.CharacterID).Scan(&lastChange)
+		if err == sql.ErrNoRows {
+			return character.ErrCharacterNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("failed to lock character: %w", err)
+		}
+		if cooldown > 0 && lastChange.Valid && change.ChangedAt.Sub(lastChange.Time) < cooldown {
+			return character.ErrRenameTooSoon
+		}
+
+		result, err := tx.ExecContext(ctx, `
+			UPDATE characters SET name = $2, updated_at = $3
+			WHERE id = $1 AND is_deleted = false`,
+			change.CharacterID, change.NewName, change.ChangedAt)
+		if err != nil {
+			var pqErr *pq.Error
+			if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+				return character.ErrCharacterNameTaken
+			}
+			return fmt.Errorf("failed to rename character: %w", err)
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		if rows == 0 {
+			return character.ErrCharacterNotFound
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO character_name_history (`+nameChangeColumns+`)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+			change.ID,
+			change.CharacterID,
+			change.OldName,
+			change.NewName,
+			change.ChangedBy,
+			change.Reason,
+			change.HoldUntil,
+			change.ChangedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to record name change: %w", err)
+		}
+		return nil
+	})
+}
+
+// LastChange returns the character's most recent rename, or nil if none
+func (r *PostgresNameHistoryRepository) LastChange(ctx context.Context, characterID uuid.UUID) (*character.NameChange, error) {
+	query := `
+		SELECT ` + nameChangeColumns + `
+		FROM character_name_history
+		WHERE character_id = $1
+		ORDER BY changed_at DESC
+		LIMIT 1
+	`
+
+	change, err := scanNameChange(r.db.QueryRowContext(ctx, query, characterID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get last name change: %w", err)
+	}
+
+	return change, nil
+}
+
+// ListByCharacterID retrieves a character's renames, newest first
+func (r *PostgresNameHistoryRepository) ListByCharacterID(ctx context.Context, characterID uuid.UUID) ([]*character.NameChange, error) {
+	query := `
+		SELECT ` + nameChangeColumns + `
+		FROM character_name_history
+		WHERE character_id = $1
+		ORDER BY changed_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, characterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list name changes: %w", err)
+	}
+	defer rows.Close()
+
+	var changes []*character.NameChange
+	for rows.Next() {
+		change, err := scanNameChange(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan name change: %w", err)
+		}
+		changes = append(changes, change)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating name changes: %w", err)
+	}
+
+	return changes, nil
+}
+
+// IsNameHeld reports whether a released name is still reserved for a character of another account
+func (r *PostgresNameHistoryRepository) IsNameHeld(ctx context.Context, name string, exceptUserID uuid.UUID, at time.Time) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM character_name_history h
+			JOIN characters c ON c.id = h.character_id
+			WHERE LOWER(h.old_name) = LOWER($1)
+				AND h.hold_until > $2
+				AND c.user_id <> $3
+		)
+	`
+
+	var held bool
+	if err := r.db.QueryRowContext(ctx, query, name, at, exceptUserID).Scan(&held); err != nil {
+		return false, fmt.Errorf("failed to check name hold: %w", err)
+	}
+
+	return held, nil
+}
+
+type nameChangeScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanNameChange(row nameChangeScanner) (*character.NameChange, error) {
+	var (
+		change    character.NameChange
+		changedBy uuid.NullUUID
+		reason    sql.NullString
+		holdUntil sql.NullTime
+	)
+	err := row.Scan(
+		&change.ID,
+		&change.CharacterID,
+		&change.OldName,
+		&change.NewName,
+		&changedBy,
+		&reason,
+		&holdUntil,
+		&change.ChangedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if changedBy.Valid {
+		change.ChangedBy = &changedBy.UUID
+	}
+	change.Reason = reason.String
+	if holdUntil.Valid {
+		change.HoldUntil = &holdUntil.Time
+	}
+	return &change, nil
+}
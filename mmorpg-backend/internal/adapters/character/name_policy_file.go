@@ -0,0 +1,156 @@
+package character
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mmorpg-template/backend/internal/domain/character"
+	"github.com/mmorpg-template/backend/pkg/logger"
+)
+
+// namePolicyFile is the JSON layout of a name policy file
+type namePolicyFile struct {
+	Version          string                         `json:"version"`
+	Rules            map[string]namePolicyFileRules `json:"rules"`
+	Words            namePolicyFileWords            `json:"words"`
+	Languages        map[string]namePolicyFileWords `json:"languages,omitempty"`
+	ReservedPrefixes []string                       `json:"reserved_prefixes,omitempty"`
+	Leetspeak        map[string]string              `json:"leetspeak,omitempty"`
+	Homoglyphs       map[string]string              `json:"homoglyphs,omitempty"`
+}
+
+type namePolicyFileRules struct {
+	MinLength    int      `json:"min_length"`
+	MaxLength    int      `json:"max_length"`
+	Scripts      []string `json:"scripts"`
+	SingleScript bool     `json:"single_script"`
+	AllowDigits  bool     `json:"allow_digits"`
+	Separators   string   `json:"separators"`
+}
+
+type namePolicyFileWords struct {
+	Profanity []string `json:"profanity,omitempty"`
+	Reserved  []string `json:"reserved,omitempty"`
+	Allowed   []string `json:"allowed,omitempty"`
+}
+
+// FileNamePolicyProvider implements NamePolicyProvider from a versioned JSON data file.
+//
+// Rules are keyed by name kind ("character", "guild", "channel"). Scripts use Unicode
+// script names such as "Latin" or "Cyrillic". Word lists under "words" apply to every
+// name; "languages" adds lists per language code. Leetspeak and homoglyph tables map
+// single characters to the letter they stand for. The file is validated on load, and
+// Watch reloads it when it changes, keeping the previous policy if the new one is invalid.
+type FileNamePolicyProvider struct {
+	file *dataFile[character.NamePolicy]
+}
+
+// NewFileNamePolicyProvider loads a name policy file. An empty path serves the built-in policy.
+func NewFileNamePolicyProvider(path string, logger logger.Logger) (*FileNamePolicyProvider, error) {
+	p := &FileNamePolicyProvider{file: &dataFile[character.NamePolicy]{
+		path:    path,
+		kind:    "name policy",
+		load:    loadNamePolicyFile,
+		version: func(p *character.NamePolicy) string { return p.Version },
+		logger:  logger,
+	}}
+	if path == "" {
+		p.file.current.Store(character.DefaultNamePolicy())
+		return p, nil
+	}
+
+	if _, err := p.Reload(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// NamePolicy returns the policy currently in effect
+func (p *FileNamePolicyProvider) NamePolicy() *character.NamePolicy {
+	return p.file.get()
+}
+
+// Reload reads the file again if it changed since the last load and reports whether the policy was replaced
+func (p *FileNamePolicyProvider) Reload() (bool, error) {
+	return p.file.reload()
+}
+
+// Watch checks the file for changes every interval until ctx is done
+func (p *FileNamePolicyProvider) Watch(ctx context.Context, interval time.Duration) {
+	p.file.watch(ctx, interval)
+}
+
+func loadNamePolicyFile(path string) (*character.NamePolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read name policy file: %w", err)
+	}
+
+	var file namePolicyFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse name policy file: %w", err)
+	}
+
+	policy, err := file.toDomain()
+	if err == nil {
+		err = policy.Compile()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("name policy file %s: %w", path, err)
+	}
+	return policy, nil
+}
+
+func (f *namePolicyFile) toDomain() (*character.NamePolicy, error) {
+	policy := &character.NamePolicy{
+		Version:          f.Version,
+		Rules:            make(map[character.NameKind]character.NameRules, len(f.Rules)),
+		Words:            f.Words.toDomain(),
+		Languages:        make(map[string]character.NameWordList, len(f.Languages)),
+		ReservedPrefixes: f.ReservedPrefixes,
+	}
+	for kind, r := range f.Rules {
+		policy.Rules[character.NameKind(kind)] = character.NameRules{
+			MinLength:    r.MinLength,
+			MaxLength:    r.MaxLength,
+			Scripts:      r.Scripts,
+			SingleScript: r.SingleScript,
+			AllowDigits:  r.AllowDigits,
+			Separators:   r.Separators,
+		}
+	}
+	for language, words := range f.Languages {
+		policy.Languages[language] = words.toDomain()
+	}
+
+	var err error
+	if policy.Leetspeak, err = toRuneMap("leetspeak", f.Leetspeak); err != nil {
+		return nil, err
+	}
+	if policy.Homoglyphs, err = toRuneMap("homoglyphs", f.Homoglyphs); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+func (w namePolicyFileWords) toDomain() character.NameWordList {
+	return character.NameWordList{Profanity: w.Profanity, Reserved: w.Reserved, Allowed: w.Allowed}
+}
+
+// toRuneMap converts {"4": "a"} tables, where every key and value must be a single character
+func toRuneMap(table string, raw map[string]string) (map[rune]rune, error) {
+	runes := make(map[rune]rune, len(raw))
+	for from, to := range raw {
+		if utf8.RuneCountInString(from) != 1 || utf8.RuneCountInString(to) != 1 {
+			return nil, fmt.Errorf("%w: %s entry %q: %q must map one character to one character", character.ErrInvalidNamePolicy, table, from, to)
+		}
+		f, _ := utf8.DecodeRuneInString(from)
+		t, _ := utf8.DecodeRuneInString(to)
+		runes[f] = t
+	}
+	return runes, nil
+}
@@ -0,0 +1,78 @@
+package character
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mmorpg-template/backend/internal/domain/character"
+	"github.com/mmorpg-template/backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testNamePolicyFile = `{
+	"version": "2026.1",
+	"rules": {
+		"character": {"min_length": 3, "max_length": 16, "scripts": ["Latin", "Cyrillic"], "single_script": true, "separators": "-"}
+	},
+	"words": {"reserved": ["admin"], "profanity": ["darn"]},
+	"languages": {"de": {"profanity": ["mist"], "allowed": ["mistral"]}},
+	"reserved_prefixes": ["GM"],
+	"leetspeak": {"4": "a", "1": "i"},
+	"homoglyphs": {"а": "a"}
+}`
+
+func TestFileNamePolicyProvider(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "name_policy.json")
+	require.NoError(t, os.WriteFile(path, []byte(testNamePolicyFile), 0o644))
+
+	provider, err := NewFileNamePolicyProvider(path, logger.NewNoop())
+	require.NoError(t, err)
+
+	t.Run("rules and word lists from the file", func(t *testing.T) {
+		policy := provider.NamePolicy()
+		assert.NoError(t, policy.Check(character.NameKindCharacter, "Борис", false))
+		assert.NoError(t, policy.Check(character.NameKindCharacter, "Mistral", false))
+		assert.Error(t, policy.Check(character.NameKindCharacter, "Mistkerl", false))
+		assert.Error(t, policy.Check(character.NameKindCharacter, "Dar-n", false))
+		assert.Error(t, policy.Check(character.NameKindCharacter, "Аdmin", false))
+		assert.Error(t, policy.Check(character.NameKindCharacter, "GMBob", false))
+		assert.Error(t, policy.Check(character.NameKindCharacter, "Bob Smith", false))
+		assert.Error(t, policy.Check(character.NameKindGuild, "Knights", false))
+	})
+
+	t.Run("invalid reload keeps the current policy", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(path, []byte(`{"version": "broken", "rules": {"character": {"min_length": 3, "max_length": 16, "scripts": ["Elvish"]}}}`), 0o644))
+		touch(t, path, time.Now().Add(time.Minute))
+
+		_, err := provider.Reload()
+		assert.True(t, errors.Is(err, character.ErrInvalidNamePolicy))
+		assert.Equal(t, "2026.1", provider.NamePolicy().Version)
+	})
+
+	t.Run("substitution tables map single characters", func(t *testing.T) {
+		bad := filepath.Join(dir, "bad.json")
+		require.NoError(t, os.WriteFile(bad, []byte(`{"version": "x", "rules": {"character": {"min_length": 3, "max_length": 16, "scripts": ["Latin"]}}, "leetspeak": {"|-|": "h"}}`), 0o644))
+		_, err := NewFileNamePolicyProvider(bad, logger.NewNoop())
+		assert.True(t, errors.Is(err, character.ErrInvalidNamePolicy))
+	})
+
+	t.Run("shipped data file", func(t *testing.T) {
+		shipped, err := NewFileNamePolicyProvider(filepath.Join("..", "..", "..", "data", "name_policy.json"), logger.NewNoop())
+		require.NoError(t, err)
+
+		policy := shipped.NamePolicy()
+		builtin := character.DefaultNamePolicy()
+		assert.Equal(t, builtin.Rules, policy.Rules)
+		for _, name := range []string{"Aragorn", "Reputation", "Computadora", "Scunthorpe"} {
+			assert.NoError(t, policy.Check(character.NameKindCharacter, name, false), name)
+		}
+		for _, name := range []string{"4dm1n", "Hurensohn", "Putain", "GM Bob"} {
+			assert.Error(t, policy.Check(character.NameKindCharacter, name, false), name)
+		}
+	})
+}
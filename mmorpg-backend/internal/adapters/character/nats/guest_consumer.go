@@ -0,0 +1,65 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+	"github.com/mmorpg-template/backend/internal/domain/character"
+	"github.com/mmorpg-template/backend/internal/ports"
+	portsCharacter "github.com/mmorpg-template/backend/internal/ports/character"
+	"github.com/mmorpg-template/backend/pkg/logger"
+)
+
+// GuestDeletedConsumer cleans up after idle guests the auth service deleted. Their
+// characters went with the account, so nothing else tells this service they are gone.
+type GuestDeletedConsumer struct {
+	mq      ports.MessageQueue
+	service portsCharacter.CharacterService
+	logger  logger.Logger
+}
+
+// NewGuestDeletedConsumer creates a consumer for guest deleted events
+func NewGuestDeletedConsumer(mq ports.MessageQueue, service portsCharacter.CharacterService, logger logger.Logger) *GuestDeletedConsumer {
+	return &GuestDeletedConsumer{
+		mq:      mq,
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Start subscribes to deleted guests
+func (c *GuestDeletedConsumer) Start(ctx context.Context) error {
+	subject := string(auth.EventGuestDeleted)
+	if _, err := c.mq.QueueSubscribe(ctx, subject, responderQueue, c.handle); err != nil {
+		return fmt.Errorf("failed to subscribe to %s: %w", subject, err)
+	}
+
+	c.logger.Info("Guest deleted consumer started")
+	return nil
+}
+
+func (c *GuestDeletedConsumer) handle(msg *ports.QueueMessage) error {
+	var event auth.GuestDeletedEvent
+	if err := json.Unmarshal(msg.Data, &event); err != nil || event.UserID == "" {
+		c.logger.WithField("subject", msg.Subject).Warn("Dropping malformed guest deleted event")
+		return nil
+	}
+
+	characters := make([]*character.Character, 0, len(event.Characters))
+	for _, deleted := range event.Characters {
+		id, err := uuid.Parse(deleted.ID)
+		if err != nil {
+			c.logger.WithField("characterID", deleted.ID).Warn("Skipping invalid character in guest deleted event")
+			continue
+		}
+		characters = append(characters, &character.Character{ID: id, Name: deleted.Name})
+	}
+
+	if err := c.service.ForgetDeletedAccount(context.Background(), event.UserID, characters); err != nil {
+		c.logger.WithError(err).WithField("userID", event.UserID).Warn("Failed to clean up after deleted guest")
+	}
+	return nil
+}
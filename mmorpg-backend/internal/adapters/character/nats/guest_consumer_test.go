@@ -0,0 +1,52 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+	"github.com/mmorpg-template/backend/internal/domain/character"
+	"github.com/mmorpg-template/backend/internal/ports"
+	portsCharacter "github.com/mmorpg-template/backend/internal/ports/character"
+	"github.com/mmorpg-template/backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// deletedAccountForgetter records which deleted accounts were forgotten
+type deletedAccountForgetter struct {
+	portsCharacter.CharacterService
+	userIDs    []string
+	characters [][]*character.Character
+}
+
+func (s *deletedAccountForgetter) ForgetDeletedAccount(ctx context.Context, userID string, characters []*character.Character) error {
+	s.userIDs = append(s.userIDs, userID)
+	s.characters = append(s.characters, characters)
+	return nil
+}
+
+func TestGuestDeletedConsumer(t *testing.T) {
+	service := &deletedAccountForgetter{}
+	consumer := NewGuestDeletedConsumer(nil, service, logger.NewNoop())
+
+	characterID := uuid.New()
+	data, err := json.Marshal(&auth.GuestDeletedEvent{
+		BaseEvent: auth.BaseEvent{EventType: auth.EventGuestDeleted, UserID: "guest-1"},
+		Characters: []auth.DeletedCharacter{
+			{ID: characterID.String(), Name: "Hero"},
+			{ID: "not-a-uuid", Name: "Broken"},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, consumer.handle(&ports.QueueMessage{Data: data}))
+	require.NoError(t, consumer.handle(&ports.QueueMessage{Data: []byte("not json")}))
+
+	assert.Equal(t, []string{"guest-1"}, service.userIDs)
+	if assert.Len(t, service.characters, 1) && assert.Len(t, service.characters[0], 1) {
+		assert.Equal(t, characterID, service.characters[0][0].ID)
+		assert.Equal(t, "Hero", service.characters[0][0].Name)
+	}
+}
@@ -0,0 +1,116 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mmorpg-template/backend/internal/adapters/serviceauth"
+	"github.com/mmorpg-template/backend/internal/domain/character"
+	"github.com/mmorpg-template/backend/internal/ports"
+	"github.com/mmorpg-template/backend/pkg/logger"
+)
+
+// Subjects answered by the services that own pending trades and mail
+const (
+	SubjectTradesPending = "trades.pending.check"
+	SubjectMailPending   = "mail.pending.check"
+)
+
+// DefaultPendingActivitySubjects asks the trade and mail services about a character
+var DefaultPendingActivitySubjects = map[string]string{
+	character.PendingActivityTrade: SubjectTradesPending,
+	character.PendingActivityMail:  SubjectMailPending,
+}
+
+const pendingActivityTimeout = 2 * time.Second
+
+// PendingActivityRequest asks whether a character has unsettled activity
+type PendingActivityRequest struct {
+	CharacterID string `json:"character_id"`
+}
+
+// PendingActivityResponse is the reply to a PendingActivityRequest
+type PendingActivityResponse struct {
+	Pending bool   `json:"pending"`
+	Count   int    `json:"count,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// PendingActivityClient asks other services over NATS whether a character has open trades,
+// undelivered mail or other activity that must settle before it changes accounts.
+// Any failure blocks the transfer, including a subject nobody answers, unless its kind
+// was configured as optional for deployments without a trade or mail service.
+type PendingActivityClient struct {
+	mq       ports.MessageQueue
+	tokens   *serviceauth.TokenSource
+	subjects map[string]string
+	optional map[string]bool
+	timeout  time.Duration
+	logger   logger.Logger
+}
+
+// NewPendingActivityClient creates a client that asks each subject about its kind of activity.
+// Kinds listed in optional count as nothing pending when no service answers for them.
+// Requests carry a service token when tokens is set.
+func NewPendingActivityClient(mq ports.MessageQueue, tokens *serviceauth.TokenSource, subjects map[string]string, optional []string, logger logger.Logger) *PendingActivityClient {
+	if len(subjects) == 0 {
+		subjects = DefaultPendingActivitySubjects
+	}
+	optionalKinds := make(map[string]bool, len(optional))
+	for _, kind := range optional {
+		optionalKinds[kind] = true
+	}
+	return &PendingActivityClient{
+		mq:       mq,
+		tokens:   tokens,
+		subjects: subjects,
+		optional: optionalKinds,
+		timeout:  pendingActivityTimeout,
+		logger:   logger,
+	}
+}
+
+// PendingActivity returns the kinds of activity pending for a character, sorted by name
+func (c *PendingActivityClient) PendingActivity(ctx context.Context, characterID string) ([]string, error) {
+	data, err := json.Marshal(&PendingActivityRequest{CharacterID: characterID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	var headers map[string]string
+	if c.tokens != nil {
+		if headers, err = c.tokens.Headers(ctx); err != nil {
+			return nil, fmt.Errorf("failed to get service token: %w", err)
+		}
+	}
+
+	pending := []string{}
+	for kind, subject := range c.subjects {
+		reply, err := c.mq.RequestWithHeaders(ctx, subject, data, headers, c.timeout)
+		if errors.Is(err, ports.ErrMQNoResponders) && c.optional[kind] {
+			c.logger.WithField("subject", subject).Debug("No service answers optional pending activity checks")
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s request failed: %w", subject, err)
+		}
+
+		var resp PendingActivityResponse
+		if err := json.Unmarshal(reply, &resp); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %s reply: %w", subject, err)
+		}
+		if resp.Error != "" {
+			return nil, fmt.Errorf("%s check failed: %s", kind, resp.Error)
+		}
+		if resp.Pending {
+			pending = append(pending, kind)
+		}
+	}
+
+	sort.Strings(pending)
+	return pending, nil
+}
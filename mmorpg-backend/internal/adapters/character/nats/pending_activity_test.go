@@ -0,0 +1,42 @@
+package nats
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mmorpg-template/backend/internal/domain/character"
+	"github.com/mmorpg-template/backend/internal/ports"
+	"github.com/mmorpg-template/backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPendingActivityClient(t *testing.T) {
+	mockMQ := new(MockMessageQueue)
+	mockMQ.On("RequestWithHeaders", mock.Anything, SubjectTradesPending, mock.Anything, mock.Anything, mock.Anything).
+		Return([]byte(`{"pending": true, "count": 1}`), nil).Once()
+	mockMQ.On("RequestWithHeaders", mock.Anything, SubjectMailPending, mock.Anything, mock.Anything, mock.Anything).
+		Return([]byte(nil), ports.ErrMQNoResponders).Once()
+	client := NewPendingActivityClient(mockMQ, nil, nil, []string{character.PendingActivityMail}, logger.NewNoop())
+
+	pending, err := client.PendingActivity(context.Background(), "char-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{character.PendingActivityTrade}, pending)
+
+	// A check that times out blocks the transfer rather than letting it through
+	mockMQ.On("RequestWithHeaders", mock.Anything, SubjectTradesPending, mock.Anything, mock.Anything, mock.Anything).
+		Return([]byte(nil), ports.ErrMQTimeout)
+	mockMQ.On("RequestWithHeaders", mock.Anything, SubjectMailPending, mock.Anything, mock.Anything, mock.Anything).
+		Return([]byte(`{"pending": false}`), nil)
+	_, err = client.PendingActivity(context.Background(), "char-1")
+	assert.ErrorIs(t, err, ports.ErrMQTimeout)
+
+	// Nobody answering a check that isn't optional blocks the transfer too
+	strictMQ := new(MockMessageQueue)
+	strictMQ.On("RequestWithHeaders", mock.Anything, SubjectMailPending, mock.Anything, mock.Anything, mock.Anything).
+		Return([]byte(nil), ports.ErrMQNoResponders)
+	strict := NewPendingActivityClient(strictMQ, nil, map[string]string{character.PendingActivityMail: SubjectMailPending}, nil, logger.NewNoop())
+	_, err = strict.PendingActivity(context.Background(), "char-1")
+	assert.ErrorIs(t, err, ports.ErrMQNoResponders)
+}
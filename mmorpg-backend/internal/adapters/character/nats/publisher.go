@@ -143,6 +143,42 @@ func (p *EventPublisher) PublishCharacterAppearanceUpdated(ctx context.Context,
 	return p.publishEvent(ctx, string(character.EventCharacterAppearanceUpdated), event)
 }
 
+// PublishCharacterPurged publishes a character purged event
+func (p *EventPublisher) PublishCharacterPurged(ctx context.Context, event *character.CharacterPurgedEvent) error {
+	event.EventID = uuid.New().String()
+	event.Timestamp = time.Now().UTC()
+	event.Version = "1.0"
+	
+	return p.publishEvent(ctx, string(character.EventCharacterPurged), event)
+}
+
+// PublishCharacterTransferred publishes a character transferred event
+func (p *EventPublisher) PublishCharacterTransferred(ctx context.Context, event *character.CharacterTransferredEvent) error {
+	event.EventID = uuid.New().String()
+	event.Timestamp = time.Now().UTC()
+	event.Version = "1.0"
+	
+	return p.publishEvent(ctx, string(character.EventCharacterTransferred), event)
+}
+
+// PublishCharacterRolledBack publishes a character rolled back event
+func (p *EventPublisher) PublishCharacterRolledBack(ctx context.Context, event *character.CharacterRolledBackEvent) error {
+	event.EventID = uuid.New().String()
+	event.Timestamp = time.Now().UTC()
+	event.Version = "1.0"
+	
+	return p.publishEvent(ctx, string(character.EventCharacterRolledBack), event)
+}
+
+// PublishCharacterRenamed publishes a character renamed event
+func (p *EventPublisher) PublishCharacterRenamed(ctx context.Context, event *character.CharacterRenamedEvent) error {
+	event.EventID = uuid.New().String()
+	event.Timestamp = time.Now().UTC()
+	event.Version = "1.0"
+	
+	return p.publishEvent(ctx, string(character.EventCharacterRenamed), event)
+}
+
 // PublishCharacterLevelUp publishes a character level up event
 func (p *EventPublisher) PublishCharacterLevelUp(ctx context.Context, event *character.CharacterLevelUpEvent) error {
 	event.EventID = uuid.New().String()
@@ -47,6 +47,16 @@ func (m *MockMessageQueue) PublishWithReply(ctx context.Context, subject string,
 	return args.Get(0).([]byte), args.Error(1)
 }
 
+func (m *MockMessageQueue) PublishWithHeaders(ctx context.Context, subject string, data []byte, headers map[string]string) error {
+	args := m.Called(ctx, subject, data, headers)
+	return args.Error(0)
+}
+
+func (m *MockMessageQueue) RequestWithHeaders(ctx context.Context, subject string, data []byte, headers map[string]string, timeout time.Duration) ([]byte, error) {
+	args := m.Called(ctx, subject, data, headers, timeout)
+	return args.Get(0).([]byte), args.Error(1)
+}
+
 func (m *MockMessageQueue) Subscribe(ctx context.Context, subject string, handler ports.MessageHandler) (ports.QueueSubscription, error) {
 	args := m.Called(ctx, subject, handler)
 	return args.Get(0).(ports.QueueSubscription), args.Error(1)
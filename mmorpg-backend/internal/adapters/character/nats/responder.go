@@ -0,0 +1,207 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/mmorpg-template/backend/internal/adapters/serviceauth"
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+	"github.com/mmorpg-template/backend/internal/domain/character"
+	"github.com/mmorpg-template/backend/internal/ports"
+	portsCharacter "github.com/mmorpg-template/backend/internal/ports/character"
+	"github.com/mmorpg-template/backend/pkg/logger"
+)
+
+// Request/reply subjects served by the character service. They live outside
+// character.> so the CHARACTER_EVENTS stream does not capture the requests and
+// answer them with a publish acknowledgement.
+const (
+	SubjectExperienceGrant = "characters.experience.grant"
+	SubjectNameValidate    = "characters.names.validate"
+	// World servers report play sessions of connected clients
+	SubjectSessionHeartbeat = "characters.sessions.heartbeat"
+	SubjectSessionEnd       = "characters.sessions.end"
+
+	// responderQueue spreads requests across character service replicas
+	responderQueue = "character-service"
+)
+
+// GrantExperienceRequest adds experience to a character. GrantID is chosen by the caller
+// and must be reused when retrying, so a retried grant is applied once.
+type GrantExperienceRequest struct {
+	CharacterID string `json:"character_id"`
+	GrantID     string `json:"grant_id"`
+	Amount      int64  `json:"amount"`
+	Source      string `json:"source"`
+}
+
+// GrantExperienceResponse reports the character's progression after a grant
+type GrantExperienceResponse struct {
+	Success            bool   `json:"success"`
+	Amount             int64  `json:"amount,omitempty"`
+	Experience         int64  `json:"experience,omitempty"`
+	PreviousLevel      int    `json:"previous_level,omitempty"`
+	Level              int    `json:"level,omitempty"`
+	StatPoints         int    `json:"stat_points_gained,omitempty"`
+	SkillPoints        int    `json:"skill_points_gained,omitempty"`
+	ProgressionVersion string `json:"progression_version,omitempty"`
+	// Duplicate reports a grant ID that was already applied; nothing was added
+	Duplicate bool   `json:"duplicate,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// ValidateNameRequest checks a player-chosen name, such as a guild or chat channel name
+type ValidateNameRequest struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+// ValidateNameResponse reports whether the name policy accepts a name
+type ValidateNameResponse struct {
+	Valid  bool   `json:"valid"`
+	Reason string `json:"reason,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// PlaySessionRequest identifies the account whose play session a world server reports on
+type PlaySessionRequest struct {
+	UserID string `json:"user_id"`
+	// CharacterID is the character being played, required for SubjectSessionHeartbeat
+	CharacterID string `json:"character_id,omitempty"`
+	// SessionID is the client's login session; only a selection it holds is renewed or ended.
+	// Heartbeats without one are refused.
+	SessionID string `json:"session_id,omitempty"`
+	// Reason why the session ended, for SubjectSessionEnd; defaults to disconnect
+	Reason string `json:"reason,omitempty"`
+}
+
+// PlaySessionResponse reports whether a play session request succeeded
+type PlaySessionResponse struct {
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// CommandResponder serves character operations requested by world servers
+type CommandResponder struct {
+	mq        ports.MessageQueue
+	service   portsCharacter.CharacterService
+	validator *serviceauth.Validator
+	logger    logger.Logger
+}
+
+// NewCommandResponder creates a responder; callers must present a service token with
+// character:write, or character:read for subjects that only read
+func NewCommandResponder(mq ports.MessageQueue, service portsCharacter.CharacterService, validator *serviceauth.Validator, logger logger.Logger) *CommandResponder {
+	return &CommandResponder{
+		mq:        mq,
+		service:   service,
+		validator: validator,
+		logger:    logger,
+	}
+}
+
+// Start subscribes to the command subjects
+func (r *CommandResponder) Start(ctx context.Context) error {
+	handlers := map[string]struct {
+		handle ports.MessageHandler
+		scope  string
+	}{
+		SubjectExperienceGrant:  {r.handleExperienceGrant, auth.ScopeCharacterWrite},
+		SubjectNameValidate:     {r.handleNameValidate, auth.ScopeCharacterRead},
+		SubjectSessionHeartbeat: {r.handleSessionHeartbeat, auth.ScopeCharacterWrite},
+		SubjectSessionEnd:       {r.handleSessionEnd, auth.ScopeCharacterWrite},
+	}
+
+	for subject, handler := range handlers {
+		guarded := r.validator.RequireScopeNATS(r.mq, handler.handle, handler.scope)
+		if _, err := r.mq.QueueSubscribe(ctx, subject, responderQueue, guarded); err != nil {
+			return fmt.Errorf("failed to subscribe to %s: %w", subject, err)
+		}
+	}
+
+	r.logger.Info("Character command responders started")
+	return nil
+}
+
+func (r *CommandResponder) handleExperienceGrant(msg *ports.QueueMessage) error {
+	var req GrantExperienceRequest
+	if err := json.Unmarshal(msg.Data, &req); err != nil {
+		return r.reply(msg, &GrantExperienceResponse{Error: "invalid request"})
+	}
+
+	gain, err := r.service.GrantExperience(context.Background(), req.CharacterID, req.GrantID, req.Amount, req.Source)
+	if err != nil {
+		return r.reply(msg, &GrantExperienceResponse{Error: err.Error()})
+	}
+
+	return r.reply(msg, &GrantExperienceResponse{
+		Success:            true,
+		Amount:             gain.Amount,
+		Experience:         gain.Experience,
+		PreviousLevel:      gain.PreviousLevel,
+		Level:              gain.Level,
+		StatPoints:         gain.StatPoints,
+		SkillPoints:        gain.SkillPoints,
+		ProgressionVersion: gain.ProgressionVersion,
+		Duplicate:          gain.Duplicate,
+	})
+}
+
+func (r *CommandResponder) handleNameValidate(msg *ports.QueueMessage) error {
+	var req ValidateNameRequest
+	if err := json.Unmarshal(msg.Data, &req); err != nil {
+		return r.reply(msg, &ValidateNameResponse{Error: "invalid request"})
+	}
+
+	err := r.service.ValidateName(context.Background(), character.NameKind(req.Kind), req.Name)
+	var violation *character.NameViolation
+	switch {
+	case err == nil:
+		return r.reply(msg, &ValidateNameResponse{Valid: true})
+	case errors.As(err, &violation):
+		return r.reply(msg, &ValidateNameResponse{Reason: violation.Reason})
+	case errors.Is(err, character.ErrInvalidCharacterName):
+		return r.reply(msg, &ValidateNameResponse{Reason: character.NameReasonLength})
+	default:
+		return r.reply(msg, &ValidateNameResponse{Error: err.Error()})
+	}
+}
+
+func (r *CommandResponder) handleSessionHeartbeat(msg *ports.QueueMessage) error {
+	var req PlaySessionRequest
+	if err := json.Unmarshal(msg.Data, &req); err != nil {
+		return r.reply(msg, &PlaySessionResponse{Error: "invalid request"})
+	}
+
+	if err := r.service.HeartbeatCharacter(context.Background(), req.CharacterID, req.UserID, req.SessionID); err != nil {
+		return r.reply(msg, &PlaySessionResponse{Error: err.Error()})
+	}
+	return r.reply(msg, &PlaySessionResponse{Success: true})
+}
+
+func (r *CommandResponder) handleSessionEnd(msg *ports.QueueMessage) error {
+	var req PlaySessionRequest
+	if err := json.Unmarshal(msg.Data, &req); err != nil {
+		return r.reply(msg, &PlaySessionResponse{Error: "invalid request"})
+	}
+
+	if err := r.service.EndPlaySession(context.Background(), req.UserID, req.SessionID, req.Reason); err != nil {
+		return r.reply(msg, &PlaySessionResponse{Error: err.Error()})
+	}
+	return r.reply(msg, &PlaySessionResponse{Success: true})
+}
+
+func (r *CommandResponder) reply(msg *ports.QueueMessage, resp interface{}) error {
+	if msg.ReplyTo == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return fmt.Errorf("failed to marshal reply: %w", err)
+	}
+
+	return r.mq.Publish(context.Background(), msg.ReplyTo, data)
+}
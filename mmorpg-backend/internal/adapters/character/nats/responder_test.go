@@ -0,0 +1,138 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mmorpg-template/backend/internal/domain/character"
+	"github.com/mmorpg-template/backend/internal/ports"
+	portsCharacter "github.com/mmorpg-template/backend/internal/ports/character"
+	"github.com/mmorpg-template/backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// stubCharacterService answers GrantExperience with a fixed result
+type stubCharacterService struct {
+	portsCharacter.CharacterService
+	gain *character.ExperienceGain
+	err  error
+}
+
+func (s *stubCharacterService) GrantExperience(ctx context.Context, characterID, grantID string, amount int64, source string) (*character.ExperienceGain, error) {
+	return s.gain, s.err
+}
+
+func TestCommandResponder_ExperienceGrant(t *testing.T) {
+	mockMQ := new(MockMessageQueue)
+	mockMQ.On("Publish", mock.Anything, "reply", mock.AnythingOfType("[]uint8")).Return(nil)
+
+	service := &stubCharacterService{gain: &character.ExperienceGain{
+		CharacterID:   uuid.New(),
+		Amount:        500,
+		Experience:    1700,
+		PreviousLevel: 3,
+		Level:         5,
+		StatPoints:    2 * character.DefaultStatPointsPerLevel,
+		SkillPoints:   2 * character.DefaultSkillPointsPerLevel,
+	}}
+	responder := NewCommandResponder(mockMQ, service, nil, logger.New())
+
+	data, _ := json.Marshal(&GrantExperienceRequest{CharacterID: uuid.New().String(), GrantID: "kill-1", Amount: 500, Source: character.ExperienceSourceQuest})
+	require.NoError(t, responder.handleExperienceGrant(&ports.QueueMessage{Data: data, ReplyTo: "reply"}))
+
+	require.Len(t, mockMQ.publishedMessages, 1)
+	var resp GrantExperienceResponse
+	require.NoError(t, json.Unmarshal(mockMQ.publishedMessages[0].Data, &resp))
+	assert.True(t, resp.Success)
+	assert.Equal(t, 5, resp.Level)
+	assert.Equal(t, 3, resp.PreviousLevel)
+	assert.Equal(t, 2*character.DefaultStatPointsPerLevel, resp.StatPoints)
+
+	service.err = character.ErrInvalidExperienceAmount
+	require.NoError(t, responder.handleExperienceGrant(&ports.QueueMessage{Data: data, ReplyTo: "reply"}))
+	require.NoError(t, json.Unmarshal(mockMQ.publishedMessages[1].Data, &resp))
+	assert.False(t, resp.Success)
+	assert.Equal(t, character.ErrInvalidExperienceAmount.Error(), resp.Error)
+}
+
+// nameValidator answers ValidateName with the built-in name policy
+type nameValidator struct {
+	portsCharacter.CharacterService
+}
+
+func (nameValidator) ValidateName(ctx context.Context, kind character.NameKind, name string) error {
+	return character.DefaultNamePolicy().Check(kind, name, false)
+}
+
+func TestCommandResponder_NameValidate(t *testing.T) {
+	mockMQ := new(MockMessageQueue)
+	mockMQ.On("Publish", mock.Anything, "reply", mock.AnythingOfType("[]uint8")).Return(nil)
+	responder := NewCommandResponder(mockMQ, nameValidator{}, nil, logger.New())
+
+	requests := []ValidateNameRequest{
+		{Kind: string(character.NameKindGuild), Name: "Knights of Dawn"},
+		{Kind: string(character.NameKindGuild), Name: "4dm1n Guild"},
+		{Kind: "pet", Name: "Rex"},
+	}
+	for _, req := range requests {
+		data, _ := json.Marshal(&req)
+		require.NoError(t, responder.handleNameValidate(&ports.QueueMessage{Data: data, ReplyTo: "reply"}))
+	}
+
+	require.Len(t, mockMQ.publishedMessages, 3)
+	var resp ValidateNameResponse
+	require.NoError(t, json.Unmarshal(mockMQ.publishedMessages[0].Data, &resp))
+	assert.True(t, resp.Valid)
+
+	resp = ValidateNameResponse{}
+	require.NoError(t, json.Unmarshal(mockMQ.publishedMessages[1].Data, &resp))
+	assert.False(t, resp.Valid)
+	assert.Equal(t, character.NameReasonReserved, resp.Reason)
+
+	resp = ValidateNameResponse{}
+	require.NoError(t, json.Unmarshal(mockMQ.publishedMessages[2].Data, &resp))
+	assert.Equal(t, character.NameReasonUnknownKind, resp.Reason)
+}
+
+// selectionHolder renews only the selection held by one login session
+type selectionHolder struct {
+	portsCharacter.CharacterService
+	sessionID string
+	ended     []string
+}
+
+func (s *selectionHolder) HeartbeatCharacter(ctx context.Context, characterID string, userID string, sessionID string) error {
+	if sessionID != s.sessionID {
+		return character.ErrSelectedElsewhere
+	}
+	return nil
+}
+
+func (s *selectionHolder) EndPlaySession(ctx context.Context, userID string, sessionID string, reason string) error {
+	s.ended = append(s.ended, sessionID+":"+reason)
+	return nil
+}
+
+func TestCommandResponder_PlaySessions(t *testing.T) {
+	mockMQ := new(MockMessageQueue)
+	mockMQ.On("Publish", mock.Anything, "reply", mock.AnythingOfType("[]uint8")).Return(nil)
+	service := &selectionHolder{sessionID: "session-2"}
+	responder := NewCommandResponder(mockMQ, service, nil, logger.New())
+
+	for i, sessionID := range []string{"session-2", "session-1"} {
+		data, _ := json.Marshal(&PlaySessionRequest{UserID: uuid.New().String(), CharacterID: uuid.New().String(), SessionID: sessionID})
+		require.NoError(t, responder.handleSessionHeartbeat(&ports.QueueMessage{Data: data, ReplyTo: "reply"}))
+
+		var resp PlaySessionResponse
+		require.NoError(t, json.Unmarshal(mockMQ.publishedMessages[i].Data, &resp))
+		assert.Equal(t, sessionID == "session-2", resp.Success)
+	}
+
+	data, _ := json.Marshal(&PlaySessionRequest{UserID: uuid.New().String(), SessionID: "session-1", Reason: character.PlaySessionEndDisconnect})
+	require.NoError(t, responder.handleSessionEnd(&ports.QueueMessage{Data: data, ReplyTo: "reply"}))
+	assert.Equal(t, []string{"session-1:disconnect"}, service.ended)
+}
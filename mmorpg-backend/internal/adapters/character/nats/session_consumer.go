@@ -0,0 +1,53 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+	"github.com/mmorpg-template/backend/internal/ports"
+	portsCharacter "github.com/mmorpg-template/backend/internal/ports/character"
+	"github.com/mmorpg-template/backend/pkg/logger"
+)
+
+// LoginSessionConsumer takes an account's character offline when the auth service ends the
+// login session it was selected in
+type LoginSessionConsumer struct {
+	mq      ports.MessageQueue
+	service portsCharacter.CharacterService
+	logger  logger.Logger
+}
+
+// NewLoginSessionConsumer creates a consumer for login session events
+func NewLoginSessionConsumer(mq ports.MessageQueue, service portsCharacter.CharacterService, logger logger.Logger) *LoginSessionConsumer {
+	return &LoginSessionConsumer{
+		mq:      mq,
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Start subscribes to ended login sessions
+func (c *LoginSessionConsumer) Start(ctx context.Context) error {
+	subject := string(auth.EventSessionEnded)
+	if _, err := c.mq.QueueSubscribe(ctx, subject, responderQueue, c.handle); err != nil {
+		return fmt.Errorf("failed to subscribe to %s: %w", subject, err)
+	}
+
+	c.logger.Info("Login session consumer started")
+	return nil
+}
+
+func (c *LoginSessionConsumer) handle(msg *ports.QueueMessage) error {
+	var event auth.SessionEndedEvent
+	if err := json.Unmarshal(msg.Data, &event); err != nil || event.UserID == "" {
+		c.logger.WithField("subject", msg.Subject).Warn("Dropping malformed session event")
+		return nil
+	}
+
+	if err := c.service.EndLoginSession(context.Background(), event.UserID, event.SessionID); err != nil {
+		c.logger.WithError(err).WithField("userID", event.UserID).Warn("Failed to end play session after logout")
+	}
+	return nil
+}
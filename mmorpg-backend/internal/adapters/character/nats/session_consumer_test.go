@@ -0,0 +1,44 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+	"github.com/mmorpg-template/backend/internal/ports"
+	portsCharacter "github.com/mmorpg-template/backend/internal/ports/character"
+	"github.com/mmorpg-template/backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// loginSessionEnder records which login sessions were ended
+type loginSessionEnder struct {
+	portsCharacter.CharacterService
+	ended [][2]string
+}
+
+func (s *loginSessionEnder) EndLoginSession(ctx context.Context, userID string, sessionID string) error {
+	s.ended = append(s.ended, [2]string{userID, sessionID})
+	return nil
+}
+
+func TestLoginSessionConsumer(t *testing.T) {
+	service := &loginSessionEnder{}
+	consumer := NewLoginSessionConsumer(nil, service, logger.NewNoop())
+
+	events := []*auth.SessionEndedEvent{
+		{BaseEvent: auth.BaseEvent{EventType: auth.EventSessionEnded, UserID: "user-1"}, SessionID: "session-1", Reason: "logout"},
+		{BaseEvent: auth.BaseEvent{EventType: auth.EventSessionEnded, UserID: "user-2"}, Reason: "session.revoked_all"},
+	}
+	for _, event := range events {
+		data, err := json.Marshal(event)
+		require.NoError(t, err)
+		require.NoError(t, consumer.handle(&ports.QueueMessage{Data: data}))
+	}
+	require.NoError(t, consumer.handle(&ports.QueueMessage{Data: []byte("not json")}))
+	require.NoError(t, consumer.handle(&ports.QueueMessage{Data: []byte(`{"event_type": "user.session.ended"}`)}))
+
+	assert.Equal(t, [][2]string{{"user-1", "session-1"}, {"user-2", ""}}, service.ended)
+}
@@ -0,0 +1,287 @@
+package character
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/mmorpg-template/backend/internal/domain/character"
+	portsCharacter "github.com/mmorpg-template/backend/internal/ports/character"
+)
+
+// PostgresPlaySessionRepository implements PlaySessionRepository using PostgreSQL
+type PostgresPlaySessionRepository struct {
+	db           *sql.DB
+	transactions *TransactionManager
+}
+
+// NewPostgresPlaySessionRepository creates a new PostgreSQL play session repository
+func NewPostgresPlaySessionRepository(db *sql.DB) portsCharacter.PlaySessionRepository {
+	return &PostgresPlaySessionRepository{db: db, transactions: NewTransactionManager(db)}
+}
+
+const playSessionColumns = `id, character_id, user_id, session_id, started_at, last_heartbeat_at, ended_at, COALESCE(end_reason, '')`
+
+// Start saves a new play session. The character row is share locked, so a session can't start
+// while the character is being deleted, transferred or rolled back.
+func (r *PostgresPlaySessionRepository) Start(ctx context.Context, session *character.PlaySession) error {
+	return r.transactions.ExecuteInTransaction(ctx, func(tx *sql.Tx) error {
+		var id uuid.UUID
+		err := tx.QueryRowContext(ctx, `
+			SELECT id FROM characters
+			WHERE id = $1 AND user_id = $2 AND is_deleted = false
+			FOR SHARE`, session.CharacterID, session.UserID).Scan(&id)
+		if errors.Is(err, sql.ErrNoRows) {
+			return character.ErrCharacterNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("failed to lock character: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO character_play_sessions (id, character_id, user_id, session_id, started_at, last_heartbeat_at)
+			VALUES ($1, $2, $3, $4, $5, $6)`,
+			session.ID,
+			session.CharacterID,
+			session.UserID,
+			session.SessionID,
+			session.StartedAt,
+			session.LastHeartbeatAt,
+		)
+		if err != nil {
+			var pqErr *pq.Error
+			if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+				return character.ErrSelectionConflict
+			}
+			return fmt.Errorf("failed to start play session: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetOpenByUserID retrieves the account's open play session
+func (r *PostgresPlaySessionRepository) GetOpenByUserID(ctx context.Context, userID uuid.UUID) (*character.PlaySession, error) {
+	row := r.db.QueryRowContext(ctx, `
+		SELECT `+playSessionColumns+`
+		FROM character_play_sessions
+		WHERE user_id = $1 AND ended_at IS NULL`, userID)
+	return scanOpenPlaySession(row)
+}
+
+// Heartbeat renews the lease of the account's open play session of the character if sessionID
+// started it. Sessions without a login session ID can't be renewed.
+func (r *PostgresPlaySessionRepository) Heartbeat(ctx context.Context, userID, characterID uuid.UUID, sessionID string, at, expiredBefore time.Time) (*character.PlaySession, error) {
+	if sessionID == "" {
+		return nil, character.ErrNoCharacterSelected
+	}
+
+	row := r.db.QueryRowContext(ctx, `
+		UPDATE character_play_sessions SET last_heartbeat_at = GREATEST(last_heartbeat_at, $4)
+		WHERE user_id = $1 AND character_id = $2 AND session_id = $3 AND ended_at IS NULL
+			AND last_heartbeat_at >= $5
+		RETURNING `+playSessionColumns, userID, characterID, sessionID, at, expiredBefore)
+	return scanOpenPlaySession(row)
+}
+
+// ListStale retrieves open play sessions without a heartbeat since the given time
+func (r *PostgresPlaySessionRepository) ListStale(ctx context.Context, heartbeatBefore time.Time, limit int) ([]*character.PlaySession, error) {
+	query := `
+		SELECT ` + playSessionColumns + `
+		FROM character_play_sessions
+		WHERE ended_at IS NULL AND last_heartbeat_at < $1
+		ORDER BY last_heartbeat_at
+		LIMIT $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, heartbeatBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stale play sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []*character.PlaySession
+	for rows.Next() {
+		session, err := scanPlaySession(rows)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating play sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// End closes the session and adds its time to the character and the daily totals atomically
+func (r *PostgresPlaySessionRepository) End(ctx context.Context, session *character.PlaySession) error {
+	if session.EndedAt == nil {
+		return fmt.Errorf("play session %s has not ended", session.ID)
+	}
+
+	return r.transactions.ExecuteInTransaction(ctx, func(tx *sql.Tx) error {
+		return endPlaySession(ctx, tx, session)
+	})
+}
+
+// DeleteCharacter soft deletes a character and ends its open play session in one transaction
+func (r *PostgresPlaySessionRepository) DeleteCharacter(ctx context.Context, characterID uuid.UUID, at time.Time) (*character.PlaySession, error) {
+	var ended *character.PlaySession
+	err := r.transactions.ExecuteInTransaction(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `SELECT soft_delete_character($1)`, characterID); err != nil {
+			return fmt.Errorf("failed to soft delete character: %w", err)
+		}
+
+		var err error
+		ended, err = endCharacterPlaySession(ctx, tx, characterID, at, character.PlaySessionEndDeleted)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ended, nil
+}
+
+// ListDailyPlayTime retrieves a character's play time per day within [from, to), oldest first
+func (r *PostgresPlaySessionRepository) ListDailyPlayTime(ctx context.Context, characterID uuid.UUID, from, to time.Time) ([]*character.DailyPlayTime, error) {
+	query := `
+		SELECT character_id, user_id, day, play_seconds, sessions
+		FROM character_play_time_daily
+		WHERE character_id = $1 AND day >= $2 AND day < $3
+		ORDER BY day
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, characterID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list daily play time: %w", err)
+	}
+	defer rows.Close()
+
+	var days []*character.DailyPlayTime
+	for rows.Next() {
+		var daily character.DailyPlayTime
+		var seconds int64
+		if err := rows.Scan(&daily.CharacterID, &daily.UserID, &daily.Day, &seconds, &daily.Sessions); err != nil {
+			return nil, fmt.Errorf("failed to scan daily play time: %w", err)
+		}
+		daily.PlayTime = time.Duration(seconds) * time.Second
+		days = append(days, &daily)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating daily play time: %w", err)
+	}
+
+	return days, nil
+}
+
+// endPlaySession closes an ended session and adds its time to the character's total and daily
+// play time. It returns ErrNoCharacterSelected if the session was already closed.
+func endPlaySession(ctx context.Context, tx *sql.Tx, session *character.PlaySession) error {
+	result, err := tx.ExecContext(ctx, `
+		UPDATE character_play_sessions SET ended_at = $2, end_reason = $3
+		WHERE id = $1 AND ended_at IS NULL`,
+		session.ID, *session.EndedAt, session.EndReason)
+	if err != nil {
+		return fmt.Errorf("failed to end play session: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		// Another replica or request closed it first and already counted the time
+		return character.ErrNoCharacterSelected
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE characters SET
+			total_play_time = total_play_time + make_interval(secs => $2),
+			last_played_at = GREATEST(last_played_at, $3)
+		WHERE id = $1`,
+		session.CharacterID, session.Duration().Seconds(), *session.EndedAt)
+	if err != nil {
+		return fmt.Errorf("failed to add play time: %w", err)
+	}
+
+	for _, daily := range session.DailyPlayTime() {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO character_play_time_daily (character_id, user_id, day, play_seconds, sessions)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (character_id, day) DO UPDATE SET
+				play_seconds = character_play_time_daily.play_seconds + EXCLUDED.play_seconds,
+				sessions = character_play_time_daily.sessions + EXCLUDED.sessions`,
+			daily.CharacterID,
+			daily.UserID,
+			daily.Day,
+			int64(daily.PlayTime/time.Second),
+			daily.Sessions,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to add daily play time: %w", err)
+		}
+	}
+	return nil
+}
+
+// endCharacterPlaySession ends the character's open play session, if any, for a change that
+// makes the character unplayable on its account. It returns the ended session or nil.
+func endCharacterPlaySession(ctx context.Context, tx *sql.Tx, characterID uuid.UUID, at time.Time, reason string) (*character.PlaySession, error) {
+	row := tx.QueryRowContext(ctx, `
+		SELECT `+playSessionColumns+`
+		FROM character_play_sessions
+		WHERE character_id = $1 AND ended_at IS NULL
+		FOR UPDATE`, characterID)
+	session, err := scanOpenPlaySession(row)
+	if errors.Is(err, character.ErrNoCharacterSelected) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	session.End(at, reason)
+	if err := endPlaySession(ctx, tx, session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+type playSessionScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanPlaySession(row playSessionScanner) (*character.PlaySession, error) {
+	var session character.PlaySession
+	err := row.Scan(
+		&session.ID,
+		&session.CharacterID,
+		&session.UserID,
+		&session.SessionID,
+		&session.StartedAt,
+		&session.LastHeartbeatAt,
+		&session.EndedAt,
+		&session.EndReason,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func scanOpenPlaySession(row playSessionScanner) (*character.PlaySession, error) {
+	session, err := scanPlaySession(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, character.ErrNoCharacterSelected
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get play session: %w", err)
+	}
+	return session, nil
+}
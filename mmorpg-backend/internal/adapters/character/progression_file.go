@@ -0,0 +1,116 @@
+package character
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/mmorpg-template/backend/internal/domain/character"
+	"github.com/mmorpg-template/backend/pkg/logger"
+)
+
+// progressionFile is the JSON layout of a progression data file
+type progressionFile struct {
+	Version  string `json:"version"`
+	MaxLevel int    `json:"max_level"`
+	// Rewards for levels that don't set their own
+	StatPointsPerLevel  int                    `json:"stat_points_per_level"`
+	SkillPointsPerLevel int                    `json:"skill_points_per_level"`
+	Levels              []progressionFileLevel `json:"levels"`
+}
+
+type progressionFileLevel struct {
+	Level       int   `json:"level"`
+	Experience  int64 `json:"experience"`
+	StatPoints  *int  `json:"stat_points,omitempty"`
+	SkillPoints *int  `json:"skill_points,omitempty"`
+}
+
+// FileProgressionProvider implements ProgressionProvider from a versioned JSON data file.
+//
+// The file lists every level from 1 to max_level with the total experience needed
+// to reach it; level 1 needs 0. Levels may override the per-level stat and skill
+// point defaults. The file is validated on load, and Watch reloads it when it
+// changes, keeping the previous table if the new one is invalid.
+type FileProgressionProvider struct {
+	file *dataFile[character.ProgressionTable]
+}
+
+// NewFileProgressionProvider loads a progression file. An empty path serves the built-in progression.
+func NewFileProgressionProvider(path string, logger logger.Logger) (*FileProgressionProvider, error) {
+	p := &FileProgressionProvider{file: &dataFile[character.ProgressionTable]{
+		path:    path,
+		kind:    "progression",
+		load:    loadProgressionFile,
+		version: func(t *character.ProgressionTable) string { return t.Version },
+		logger:  logger,
+	}}
+	if path == "" {
+		p.file.current.Store(character.DefaultProgression())
+		return p, nil
+	}
+
+	if _, err := p.Reload(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Progression returns the table currently in effect
+func (p *FileProgressionProvider) Progression() *character.ProgressionTable {
+	return p.file.get()
+}
+
+// Reload reads the file again if it changed since the last load and reports whether the table was replaced
+func (p *FileProgressionProvider) Reload() (bool, error) {
+	return p.file.reload()
+}
+
+// Watch checks the file for changes every interval until ctx is done
+func (p *FileProgressionProvider) Watch(ctx context.Context, interval time.Duration) {
+	p.file.watch(ctx, interval)
+}
+
+func loadProgressionFile(path string) (*character.ProgressionTable, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read progression file: %w", err)
+	}
+
+	var file progressionFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse progression file: %w", err)
+	}
+
+	table := &character.ProgressionTable{
+		Version:  file.Version,
+		MaxLevel: file.MaxLevel,
+		Levels:   make([]character.LevelDefinition, 0, len(file.Levels)),
+	}
+	for _, level := range file.Levels {
+		def := character.LevelDefinition{
+			Level:       level.Level,
+			Experience:  level.Experience,
+			StatPoints:  file.StatPointsPerLevel,
+			SkillPoints: file.SkillPointsPerLevel,
+		}
+		if level.Level == 1 {
+			// Nothing is awarded for the starting level
+			def.StatPoints, def.SkillPoints = 0, 0
+		}
+		if level.StatPoints != nil {
+			def.StatPoints = *level.StatPoints
+		}
+		if level.SkillPoints != nil {
+			def.SkillPoints = *level.SkillPoints
+		}
+		table.Levels = append(table.Levels, def)
+	}
+
+	if err := table.Validate(); err != nil {
+		return nil, fmt.Errorf("progression file %s: %w", path, err)
+	}
+	return table, nil
+}
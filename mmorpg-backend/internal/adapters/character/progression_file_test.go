@@ -0,0 +1,92 @@
+package character
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mmorpg-template/backend/internal/domain/character"
+	"github.com/mmorpg-template/backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testProgressionFile = `{
+	"version": "2026.1",
+	"max_level": 4,
+	"stat_points_per_level": 3,
+	"skill_points_per_level": 1,
+	"levels": [
+		{"level": 1, "experience": 0},
+		{"level": 2, "experience": 50},
+		{"level": 3, "experience": 200, "skill_points": 2},
+		{"level": 4, "experience": 500, "stat_points": 10}
+	]
+}`
+
+func TestFileProgressionProvider(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "progression.json")
+	require.NoError(t, os.WriteFile(path, []byte(testProgressionFile), 0o644))
+
+	provider, err := NewFileProgressionProvider(path, logger.NewNoop())
+	require.NoError(t, err)
+
+	t.Run("loads levels and rewards", func(t *testing.T) {
+		table := provider.Progression()
+		assert.Equal(t, "2026.1", table.Version)
+		assert.Equal(t, 4, table.MaxLevel)
+		assert.Equal(t, int64(500), table.MaxExperience())
+		assert.Equal(t, 3, table.LevelForExperience(499))
+
+		stat, skill := table.Rewards(1, 4)
+		assert.Equal(t, 3+3+10, stat)
+		assert.Equal(t, 1+2+1, skill)
+	})
+
+	t.Run("unchanged file is not reloaded", func(t *testing.T) {
+		reloaded, err := provider.Reload()
+		require.NoError(t, err)
+		assert.False(t, reloaded)
+	})
+
+	t.Run("invalid reload keeps the current table", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(path, []byte(`{"version": "broken", "max_level": 2, "levels": [{"level": 1, "experience": 10}]}`), 0o644))
+		touch(t, path, time.Now().Add(time.Minute))
+
+		_, err := provider.Reload()
+		assert.True(t, errors.Is(err, character.ErrInvalidProgression))
+		assert.Equal(t, "2026.1", provider.Progression().Version)
+	})
+
+	t.Run("changed file replaces the table", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(path, []byte(`{"version": "2026.2", "max_level": 2, "levels": [{"level": 1, "experience": 0}, {"level": 2, "experience": 10}]}`), 0o644))
+		touch(t, path, time.Now().Add(2*time.Minute))
+
+		reloaded, err := provider.Reload()
+		require.NoError(t, err)
+		assert.True(t, reloaded)
+		assert.Equal(t, "2026.2", provider.Progression().Version)
+		assert.Equal(t, 2, provider.Progression().MaxLevel)
+	})
+
+	t.Run("empty path serves the built-in table", func(t *testing.T) {
+		builtin, err := NewFileProgressionProvider("", logger.NewNoop())
+		require.NoError(t, err)
+		assert.Equal(t, character.DefaultProgression(), builtin.Progression())
+	})
+
+	t.Run("invalid file fails at startup", func(t *testing.T) {
+		bad := filepath.Join(dir, "bad.json")
+		require.NoError(t, os.WriteFile(bad, []byte(`{"version": "x", "max_level": 3, "levels": []}`), 0o644))
+		_, err := NewFileProgressionProvider(bad, logger.NewNoop())
+		assert.Error(t, err)
+	})
+}
+
+func touch(t *testing.T, path string, at time.Time) {
+	t.Helper()
+	require.NoError(t, os.Chtimes(path, at, at))
+}
@@ -0,0 +1,319 @@
+package character
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mmorpg-template/backend/internal/domain/character"
+	portsCharacter "github.com/mmorpg-template/backend/internal/ports/character"
+)
+
+// PostgresRestorePointRepository implements RestorePointRepository using PostgreSQL
+type PostgresRestorePointRepository struct {
+	db           *sql.DB
+	transactions *TransactionManager
+}
+
+// NewPostgresRestorePointRepository creates a new PostgreSQL restore point repository
+func NewPostgresRestorePointRepository(db *sql.DB) portsCharacter.RestorePointRepository {
+	return &PostgresRestorePointRepository{db: db, transactions: NewTransactionManager(db)}
+}
+
+const (
+	restorePointColumns = `id, character_id, trigger, level, experience, stats, position, inventory, created_at`
+	rollbackColumns     = `id, character_id, restore_point_id, backup_id, performed_by, reason, created_at`
+)
+
+// Create saves a restore point
+func (r *PostgresRestorePointRepository) Create(ctx context.Context, point *character.RestorePoint) error {
+	return insertRestorePoint(ctx, r.db, point)
+}
+
+func insertRestorePoint(ctx context.Context, db DBExecutor, point *character.RestorePoint) error {
+	stats, err := json.Marshal(point.Stats)
+	if err != nil {
+		return fmt.Errorf("failed to marshal stats: %w", err)
+	}
+	position, err := json.Marshal(point.Position)
+	if err != nil {
+		return fmt.Errorf("failed to marshal position: %w", err)
+	}
+	var inventory []byte
+	if len(point.Inventory) > 0 {
+		inventory = point.Inventory
+	}
+
+	_, err = db.ExecContext(ctx, `
+		INSERT INTO character_restore_points (`+restorePointColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		point.ID,
+		point.CharacterID,
+		point.Trigger,
+		point.Level,
+		point.Experience,
+		stats,
+		position,
+		inventory,
+		point.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create restore point: %w", err)
+	}
+	return nil
+}
+
+// CaptureChanged takes a restore point of every live character whose level, stats or position
+// changed since the given time. The JSON keys match character.SnapshotStats and SnapshotPosition.
+func (r *PostgresRestorePointRepository) CaptureChanged(ctx context.Context, trigger string, since time.Time) (int64, error) {
+	query := `
+		INSERT INTO character_restore_points (id, character_id, trigger, level, experience, stats, position, created_at)
+		SELECT
+			gen_random_uuid(), c.id, $1, c.level, c.experience,
+			jsonb_build_object(
+				'strength', s.strength, 'dexterity', s.dexterity, 'intelligence', s.intelligence,
+				'wisdom', s.wisdom, 'constitution', s.constitution, 'charisma', s.charisma,
+				'health_current', s.health_current, 'mana_current', s.mana_current,
+				'stamina_current', s.stamina_current, 'stat_points_available', s.stat_points_available,
+				'skill_points_available', s.skill_points_available
+			),
+			jsonb_build_object(
+				'world_id', p.world_id, 'zone_id', p.zone_id, 'map_id', p.map_id,
+				'position_x', p.position_x, 'position_y', p.position_y, 'position_z', p.position_z,
+				'rotation_pitch', p.rotation_pitch, 'rotation_yaw', p.rotation_yaw, 'rotation_roll', p.rotation_roll,
+				'safe_world_id', p.safe_world_id, 'safe_zone_id', p.safe_zone_id,
+				'safe_position_x', p.safe_position_x, 'safe_position_y', p.safe_position_y, 'safe_position_z', p.safe_position_z
+			),
+			NOW()
+		FROM characters c
+		JOIN character_stats s ON s.character_id = c.id
+		JOIN character_position p ON p.character_id = c.id
+		WHERE c.is_deleted = false
+			AND GREATEST(c.updated_at, s.updated_at, p.updated_at) >= $2`
+
+	result, err := r.db.ExecContext(ctx, query, trigger, since)
+	if err != nil {
+		return 0, fmt.Errorf("failed to capture restore points: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// GetByID retrieves a restore point
+func (r *PostgresRestorePointRepository) GetByID(ctx context.Context, id uuid.UUID) (*character.RestorePoint, error) {
+	row := r.db.QueryRowContext(ctx, `
+		SELECT `+restorePointColumns+`
+		FROM character_restore_points
+		WHERE id = $1`, id)
+
+	point, err := scanRestorePoint(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, character.ErrRestorePointNotFound
+	}
+	return point, err
+}
+
+// ListByCharacterID retrieves a character's most recent restore points, newest first
+func (r *PostgresRestorePointRepository) ListByCharacterID(ctx context.Context, characterID uuid.UUID, limit int) ([]*character.RestorePoint, error) {
+	query := `
+		SELECT ` + restorePointColumns + `
+		FROM character_restore_points
+		WHERE character_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, characterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list restore points: %w", err)
+	}
+	defer rows.Close()
+
+	var points []*character.RestorePoint
+	for rows.Next() {
+		point, err := scanRestorePoint(rows)
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, point)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating restore points: %w", err)
+	}
+
+	return points, nil
+}
+
+// DeleteBefore removes restore points taken before the given time
+func (r *PostgresRestorePointRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM character_restore_points WHERE created_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete restore points: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// Rollback locks the character with its stats and position, applies fn and saves the result,
+// the backup restore point and the rollback record in one transaction
+func (r *PostgresRestorePointRepository) Rollback(ctx context.Context, characterID uuid.UUID, fn func(char *character.Character, stats *character.Stats, position *character.Position, ledger portsCharacter.StatLedger) (*character.RollbackResult, error)) error {
+	return r.transactions.ExecuteInTransaction(ctx, func(tx *sql.Tx) error {
+		// Locks the character and position rows; the stats row is locked below
+		var id uuid.UUID
+		err := tx.QueryRowContext(ctx, `
+			SELECT c.id FROM characters c
+			JOIN character_position p ON p.character_id = c.id
+			WHERE c.id = $1 AND c.is_deleted = false
+			FOR UPDATE`, characterID).Scan(&id)
+		if errors.Is(err, sql.ErrNoRows) {
+			return character.ErrCharacterNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("failed to lock character: %w", err)
+		}
+
+		// Sessions start under a share lock on the character, so none can open until this commits
+		var online bool
+		err = tx.QueryRowContext(ctx, `
+			SELECT EXISTS (SELECT 1 FROM character_play_sessions WHERE character_id = $1 AND ended_at IS NULL)`,
+			characterID).Scan(&online)
+		if err != nil {
+			return fmt.Errorf("failed to check play sessions: %w", err)
+		}
+		if online {
+			return character.ErrCharacterOnline
+		}
+
+		characterRepo := NewTransactionalCharacterRepository(tx)
+		statsRepo := NewTransactionalStatsRepository(tx)
+		positionRepo := NewTransactionalPositionRepository(tx)
+
+		char, err := characterRepo.GetByID(ctx, characterID)
+		if err != nil {
+			return err
+		}
+		stats, err := statsRepo.GetByCharacterIDForUpdate(ctx, characterID)
+		if err != nil {
+			return err
+		}
+		position, err := positionRepo.GetByCharacterID(ctx, characterID)
+		if err != nil {
+			return err
+		}
+
+		result, err := fn(char, stats, position, &PostgresStatLedger{db: tx})
+		if err != nil {
+			return err
+		}
+
+		if err := insertRestorePoint(ctx, tx, result.Backup); err != nil {
+			return err
+		}
+		if err := characterRepo.Update(ctx, char); err != nil {
+			return err
+		}
+		if err := statsRepo.Update(ctx, stats); err != nil {
+			return err
+		}
+		if err := positionRepo.Update(ctx, position); err != nil {
+			return err
+		}
+
+		rollback := result.Rollback
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO character_rollbacks (`+rollbackColumns+`)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			rollback.ID,
+			rollback.CharacterID,
+			rollback.RestorePointID,
+			rollback.BackupID,
+			rollback.PerformedBy,
+			rollback.Reason,
+			rollback.CreatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to record rollback: %w", err)
+		}
+		return nil
+	})
+}
+
+// ListRollbacks retrieves a character's rollbacks, newest first
+func (r *PostgresRestorePointRepository) ListRollbacks(ctx context.Context, characterID uuid.UUID) ([]*character.CharacterRollback, error) {
+	query := `
+		SELECT ` + rollbackColumns + `
+		FROM character_rollbacks
+		WHERE character_id = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, characterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rollbacks: %w", err)
+	}
+	defer rows.Close()
+
+	var rollbacks []*character.CharacterRollback
+	for rows.Next() {
+		var rb character.CharacterRollback
+		err := rows.Scan(
+			&rb.ID,
+			&rb.CharacterID,
+			&rb.RestorePointID,
+			&rb.BackupID,
+			&rb.PerformedBy,
+			&rb.Reason,
+			&rb.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan rollback: %w", err)
+		}
+		rollbacks = append(rollbacks, &rb)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rollbacks: %w", err)
+	}
+
+	return rollbacks, nil
+}
+
+type restorePointScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanRestorePoint(row restorePointScanner) (*character.RestorePoint, error) {
+	var point character.RestorePoint
+	var stats, position, inventory []byte
+	err := row.Scan(
+		&point.ID,
+		&point.CharacterID,
+		&point.Trigger,
+		&point.Level,
+		&point.Experience,
+		&stats,
+		&position,
+		&inventory,
+		&point.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to scan restore point: %w", err)
+	}
+
+	if err := json.Unmarshal(stats, &point.Stats); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal restore point stats: %w", err)
+	}
+	if err := json.Unmarshal(position, &point.Position); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal restore point position: %w", err)
+	}
+	if len(inventory) > 0 {
+		point.Inventory = json.RawMessage(inventory)
+	}
+	return &point, nil
+}
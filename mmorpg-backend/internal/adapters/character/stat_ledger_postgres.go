@@ -0,0 +1,174 @@
+package character
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/mmorpg-template/backend/internal/domain/character"
+	portsCharacter "github.com/mmorpg-template/backend/internal/ports/character"
+)
+
+// PostgresStatLedger implements the StatLedger interface using PostgreSQL
+type PostgresStatLedger struct {
+	db DBExecutor
+}
+
+// NewPostgresStatLedger creates a new PostgreSQL stat ledger
+func NewPostgresStatLedger(db *sql.DB) *PostgresStatLedger {
+	return &PostgresStatLedger{db: db}
+}
+
+// RecordAllocations appends allocations to the character's log
+func (l *PostgresStatLedger) RecordAllocations(ctx context.Context, allocations []*character.StatAllocation) error {
+	query := `
+		INSERT INTO character_stat_allocations (id, character_id, stat, points, allocated_at)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	for _, a := range allocations {
+		if _, err := l.db.ExecContext(ctx, query, a.ID, a.CharacterID, a.Stat, a.Points, a.AllocatedAt); err != nil {
+			return fmt.Errorf("failed to record stat allocation: %w", err)
+		}
+	}
+	return nil
+}
+
+// RecentAllocations returns allocations made since the given time that were not reverted, newest first
+func (l *PostgresStatLedger) RecentAllocations(ctx context.Context, characterID uuid.UUID, since time.Time, limit int) ([]*character.StatAllocation, error) {
+	query := `
+		SELECT id, character_id, stat, points, allocated_at
+		FROM character_stat_allocations
+		WHERE character_id = $1 AND allocated_at >= $2 AND reverted_at IS NULL
+		ORDER BY allocated_at DESC, id DESC
+		LIMIT $3`
+
+	rows, err := l.db.QueryContext(ctx, query, characterID, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stat allocations: %w", err)
+	}
+	defer rows.Close()
+
+	var allocations []*character.StatAllocation
+	for rows.Next() {
+		var a character.StatAllocation
+		if err := rows.Scan(&a.ID, &a.CharacterID, &a.Stat, &a.Points, &a.AllocatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan stat allocation: %w", err)
+		}
+		allocations = append(allocations, &a)
+	}
+	return allocations, rows.Err()
+}
+
+// RevertAllocations marks allocations as reverted so they cannot be undone again
+func (l *PostgresStatLedger) RevertAllocations(ctx context.Context, ids []uuid.UUID, at time.Time) error {
+	values := make([]string, len(ids))
+	for i, id := range ids {
+		values[i] = id.String()
+	}
+
+	query := `UPDATE character_stat_allocations SET reverted_at = $2 WHERE id = ANY($1::uuid[]) AND reverted_at IS NULL`
+	if _, err := l.db.ExecContext(ctx, query, pq.Array(values), at); err != nil {
+		return fmt.Errorf("failed to revert stat allocations: %w", err)
+	}
+	return nil
+}
+
+// RevertAllAllocations marks every allocation of a character as reverted
+func (l *PostgresStatLedger) RevertAllAllocations(ctx context.Context, characterID uuid.UUID, at time.Time) error {
+	query := `UPDATE character_stat_allocations SET reverted_at = $2 WHERE character_id = $1 AND reverted_at IS NULL`
+	if _, err := l.db.ExecContext(ctx, query, characterID, at); err != nil {
+		return fmt.Errorf("failed to revert stat allocations: %w", err)
+	}
+	return nil
+}
+
+// GetRespecHistory summarizes the character's past respecs
+func (l *PostgresStatLedger) GetRespecHistory(ctx context.Context, characterID uuid.UUID) (*character.RespecHistory, error) {
+	query := `SELECT COUNT(*), MAX(created_at) FROM character_respecs WHERE character_id = $1`
+
+	var history character.RespecHistory
+	var last sql.NullTime
+	if err := l.db.QueryRowContext(ctx, query, characterID).Scan(&history.Count, &last); err != nil {
+		return nil, fmt.Errorf("failed to get respec history: %w", err)
+	}
+	history.LastRespecAt = last.Time
+	return &history, nil
+}
+
+// RecordRespec appends a respec to the character's history
+func (l *PostgresStatLedger) RecordRespec(ctx context.Context, respec *character.Respec) error {
+	query := `
+		INSERT INTO character_respecs (id, character_id, number, refunded_points, cost, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+
+	_, err := l.db.ExecContext(ctx, query,
+		respec.ID, respec.CharacterID, respec.Number, respec.RefundedPoints, respec.Cost, respec.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record respec: %w", err)
+	}
+	return nil
+}
+
+// PostgresStatsTransactor implements the StatsTransactor interface using PostgreSQL transactions
+type PostgresStatsTransactor struct {
+	transactions *TransactionManager
+	ledger       *PostgresStatLedger
+}
+
+// NewPostgresStatsTransactor creates a new PostgreSQL stats transactor
+func NewPostgresStatsTransactor(db *sql.DB) *PostgresStatsTransactor {
+	return &PostgresStatsTransactor{
+		transactions: NewTransactionManager(db),
+		ledger:       NewPostgresStatLedger(db),
+	}
+}
+
+// UpdateStats locks the character's stats, applies fn and saves the result in one transaction
+func (t *PostgresStatsTransactor) UpdateStats(ctx context.Context, characterID uuid.UUID, fn func(stats *character.Stats, ledger portsCharacter.StatLedger) error) error {
+	return t.transactions.ExecuteInTransaction(ctx, func(tx *sql.Tx) error {
+		statsRepo := NewTransactionalStatsRepository(tx)
+
+		stats, err := statsRepo.GetByCharacterIDForUpdate(ctx, characterID)
+		if err != nil {
+			return err
+		}
+		if err := fn(stats, &PostgresStatLedger{db: tx}); err != nil {
+			return err
+		}
+		return statsRepo.Update(ctx, stats)
+	})
+}
+
+// RaiseLevel raises the character's level and applies fn to its stats in one transaction
+func (t *PostgresStatsTransactor) RaiseLevel(ctx context.Context, characterID uuid.UUID, level int, fn func(previous int, stats *character.Stats) error) (int, error) {
+	var previous int
+	err := t.transactions.ExecuteInTransaction(ctx, func(tx *sql.Tx) error {
+		var err error
+		previous, err = NewTransactionalCharacterRepository(tx).RaiseLevel(ctx, characterID, level)
+		if err != nil || previous >= level {
+			return err
+		}
+
+		statsRepo := NewTransactionalStatsRepository(tx)
+		stats, err := statsRepo.GetByCharacterIDForUpdate(ctx, characterID)
+		if err != nil {
+			return err
+		}
+		if err := fn(previous, stats); err != nil {
+			return err
+		}
+		return statsRepo.Update(ctx, stats)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return previous, nil
+}
+
+// Ledger returns a ledger for reads outside a transaction
+func (t *PostgresStatsTransactor) Ledger() portsCharacter.StatLedger {
+	return t.ledger
+}
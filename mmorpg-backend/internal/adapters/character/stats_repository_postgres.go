@@ -88,6 +88,15 @@ func (r *PostgresStatsRepository) Create(ctx context.Context, stats *character.S
 
 // GetByCharacterID retrieves stats by character ID
 func (r *PostgresStatsRepository) GetByCharacterID(ctx context.Context, characterID uuid.UUID) (*character.Stats, error) {
+	return r.getByCharacterID(ctx, characterID, "")
+}
+
+// GetByCharacterIDForUpdate retrieves stats and locks the row until the transaction ends
+func (r *PostgresStatsRepository) GetByCharacterIDForUpdate(ctx context.Context, characterID uuid.UUID) (*character.Stats, error) {
+	return r.getByCharacterID(ctx, characterID, " FOR UPDATE")
+}
+
+func (r *PostgresStatsRepository) getByCharacterID(ctx context.Context, characterID uuid.UUID, lock string) (*character.Stats, error) {
 	query := `
 		SELECT 
 			id, character_id, strength, dexterity, intelligence, wisdom,
@@ -98,7 +107,7 @@ func (r *PostgresStatsRepository) GetByCharacterID(ctx context.Context, characte
 			stamina_regen, stat_points_available, skill_points_available,
 			created_at, updated_at
 		FROM character_stats
-		WHERE character_id = $1`
+		WHERE character_id = $1` + lock
 
 	var stats character.Stats
 	err := r.db.QueryRowContext(ctx, query, characterID).Scan(
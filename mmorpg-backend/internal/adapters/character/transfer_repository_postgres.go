@@ -0,0 +1,118 @@
+package character
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/mmorpg-template/backend/internal/domain/character"
+	portsCharacter "github.com/mmorpg-template/backend/internal/ports/character"
+)
+
+// PostgresTransferRepository implements TransferRepository using PostgreSQL
+type PostgresTransferRepository struct {
+	db           *sql.DB
+	transactions *TransactionManager
+}
+
+// NewPostgresTransferRepository creates a new PostgreSQL character transfer repository
+func NewPostgresTransferRepository(db *sql.DB) portsCharacter.TransferRepository {
+	return &PostgresTransferRepository{db: db, transactions: NewTransactionManager(db)}
+}
+
+const transferColumns = `id, character_id, from_user_id, to_user_id, from_slot, to_slot, performed_by, reason, created_at`
+
+// Transfer reassigns the character's account and slot, records the transfer and ends the
+// character's open play session atomically
+func (r *PostgresTransferRepository) Transfer(ctx context.Context, transfer *character.CharacterTransfer) (*character.PlaySession, error) {
+	var ended *character.PlaySession
+	err := r.transactions.ExecuteInTransaction(ctx, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, `
+			UPDATE characters SET user_id = $3, slot_number = $4, updated_at = $5
+			WHERE id = $1 AND user_id = $2 AND is_deleted = false`,
+			transfer.CharacterID, transfer.FromUserID, transfer.ToUserID, transfer.ToSlot, transfer.CreatedAt)
+		if err != nil {
+			var pqErr *pq.Error
+			if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+				return character.ErrSlotOccupied
+			}
+			return fmt.Errorf("failed to transfer character: %w", err)
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		if rows == 0 {
+			return character.ErrCharacterNotFound
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO character_transfers (`+transferColumns+`)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+			transfer.ID,
+			transfer.CharacterID,
+			transfer.FromUserID,
+			transfer.ToUserID,
+			transfer.FromSlot,
+			transfer.ToSlot,
+			transfer.PerformedBy,
+			transfer.Reason,
+			transfer.CreatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to record character transfer: %w", err)
+		}
+
+		ended, err = endCharacterPlaySession(ctx, tx, transfer.CharacterID, transfer.CreatedAt, character.PlaySessionEndTransfer)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ended, nil
+}
+
+// ListByCharacterID retrieves a character's transfers, newest first
+func (r *PostgresTransferRepository) ListByCharacterID(ctx context.Context, characterID uuid.UUID) ([]*character.CharacterTransfer, error) {
+	query := `
+		SELECT ` + transferColumns + `
+		FROM character_transfers
+		WHERE character_id = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, characterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list character transfers: %w", err)
+	}
+	defer rows.Close()
+
+	var transfers []*character.CharacterTransfer
+	for rows.Next() {
+		var t character.CharacterTransfer
+		err := rows.Scan(
+			&t.ID,
+			&t.CharacterID,
+			&t.FromUserID,
+			&t.ToUserID,
+			&t.FromSlot,
+			&t.ToSlot,
+			&t.PerformedBy,
+			&t.Reason,
+			&t.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan character transfer: %w", err)
+		}
+		transfers = append(transfers, &t)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating character transfers: %w", err)
+	}
+
+	return transfers, nil
+}
@@ -99,6 +99,15 @@ func (n *NATSMessageQueue) PublishWithReply(ctx context.Context, subject string,
 	return msg.Data, nil
 }
 
+// PublishWithHeaders publishes a message with headers to a subject
+func (n *NATSMessageQueue) PublishWithHeaders(ctx context.Context, subject string, data []byte, headers map[string]string) error {
+	if n.conn == nil {
+		return ports.ErrMQConnection
+	}
+	
+	return n.conn.PublishMsg(newNATSMsg(subject, data, headers))
+}
+
 // Subscribe creates a subscription to a subject
 func (n *NATSMessageQueue) Subscribe(ctx context.Context, subject string, handler ports.MessageHandler) (ports.QueueSubscription, error) {
 	if n.conn == nil {
@@ -156,6 +165,29 @@ func (n *NATSMessageQueue) Request(ctx context.Context, subject string, data []b
 	return n.PublishWithReply(ctx, subject, data, timeout)
 }
 
+// RequestWithHeaders sends a request with headers and waits for a reply
+func (n *NATSMessageQueue) RequestWithHeaders(ctx context.Context, subject string, data []byte, headers map[string]string, timeout time.Duration) ([]byte, error) {
+	if n.conn == nil {
+		return nil, ports.ErrMQConnection
+	}
+	
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	
+	msg, err := n.conn.RequestMsgWithContext(ctx, newNATSMsg(subject, data, headers))
+	if err != nil {
+		if err == nats.ErrTimeout || err == context.DeadlineExceeded {
+			return nil, ports.ErrMQTimeout
+		}
+		if err == nats.ErrNoResponders {
+			return nil, ports.ErrMQNoResponders
+		}
+		return nil, err
+	}
+	
+	return msg.Data, nil
+}
+
 // CreateStream creates a new JetStream stream
 func (n *NATSMessageQueue) CreateStream(ctx context.Context, config ports.StreamConfig) error {
 	if n.js == nil {
@@ -234,6 +266,15 @@ func (s *natsSubscription) Drain() error {
 
 // Helper functions
 
+func newNATSMsg(subject string, data []byte, headers map[string]string) *nats.Msg {
+	msg := nats.NewMsg(subject)
+	msg.Data = data
+	for k, v := range headers {
+		msg.Header.Set(k, v)
+	}
+	return msg
+}
+
 func natsHeadersToMap(h nats.Header) map[string]string {
 	result := make(map[string]string)
 	for k, v := range h {
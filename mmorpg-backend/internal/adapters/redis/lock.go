@@ -0,0 +1,75 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mmorpg-template/backend/internal/ports"
+	"github.com/redis/go-redis/v9"
+)
+
+// The lock value is a random token so only the holder can refresh or release it
+var (
+	refreshLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`)
+
+	releaseLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+)
+
+// RedisLocker implements ports.Locker with single-instance Redis locks (SET NX PX)
+type RedisLocker struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisLocker creates a locker whose keys are namespaced under prefix
+func NewRedisLocker(client *redis.Client, prefix string) *RedisLocker {
+	return &RedisLocker{client: client, prefix: prefix}
+}
+
+// TryLock acquires key for ttl, returning ports.ErrLockHeld if it is taken
+func (l *RedisLocker) TryLock(ctx context.Context, key string, ttl time.Duration) (ports.Lock, error) {
+	lock := &redisLock{client: l.client, key: fmt.Sprintf("%s:lock:%s", l.prefix, key), token: uuid.NewString()}
+
+	ok, err := l.client.SetNX(ctx, lock.key, lock.token, ttl).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire lock %s: %w", key, err)
+	}
+	if !ok {
+		return nil, ports.ErrLockHeld
+	}
+	return lock, nil
+}
+
+type redisLock struct {
+	client *redis.Client
+	key    string
+	token  string
+}
+
+func (l *redisLock) Refresh(ctx context.Context, ttl time.Duration) error {
+	n, err := refreshLockScript.Run(ctx, l.client, []string{l.key}, l.token, ttl.Milliseconds()).Int()
+	if err != nil {
+		return fmt.Errorf("failed to refresh lock: %w", err)
+	}
+	if n == 0 {
+		return ports.ErrLockLost
+	}
+	return nil
+}
+
+func (l *redisLock) Release(ctx context.Context) error {
+	if err := releaseLockScript.Run(ctx, l.client, []string{l.key}, l.token).Err(); err != nil {
+		return fmt.Errorf("failed to release lock: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/mmorpg-template/backend/internal/ports"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLocker(t *testing.T) (*RedisLocker, *miniredis.Miniredis) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return NewRedisLocker(client, "test"), server
+}
+
+func TestRedisLocker_TryLock(t *testing.T) {
+	ctx := context.Background()
+	locker, server := newTestLocker(t)
+
+	lock, err := locker.TryLock(ctx, "purge", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, server.Exists("test:lock:purge"))
+
+	_, err = locker.TryLock(ctx, "purge", time.Minute)
+	assert.ErrorIs(t, err, ports.ErrLockHeld)
+
+	_, err = locker.TryLock(ctx, "other", time.Minute)
+	assert.NoError(t, err, "keys are locked independently")
+
+	require.NoError(t, lock.Release(ctx))
+	_, err = locker.TryLock(ctx, "purge", time.Minute)
+	assert.NoError(t, err, "released lock can be taken again")
+}
+
+func TestRedisLocker_OnlyHolderRefreshesAndReleases(t *testing.T) {
+	ctx := context.Background()
+	locker, server := newTestLocker(t)
+
+	stale, err := locker.TryLock(ctx, "purge", time.Minute)
+	require.NoError(t, err)
+
+	// The lock expires and another replica takes it
+	server.FastForward(2 * time.Minute)
+	holder, err := locker.TryLock(ctx, "purge", time.Minute)
+	require.NoError(t, err)
+	token, err := server.Get("test:lock:purge")
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, stale.Refresh(ctx, time.Hour), ports.ErrLockLost)
+	assert.Equal(t, time.Minute, server.TTL("test:lock:purge"), "stale refresh leaves the holder's ttl alone")
+
+	require.NoError(t, stale.Release(ctx), "releasing a lost lock is a no-op")
+	value, err := server.Get("test:lock:purge")
+	require.NoError(t, err, "stale release leaves the holder's lock in place")
+	assert.Equal(t, token, value)
+
+	require.NoError(t, holder.Refresh(ctx, time.Hour))
+	assert.Equal(t, time.Hour, server.TTL("test:lock:purge"))
+
+	require.NoError(t, holder.Release(ctx))
+	assert.False(t, server.Exists("test:lock:purge"))
+}
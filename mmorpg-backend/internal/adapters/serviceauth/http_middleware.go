@@ -0,0 +1,48 @@
+package serviceauth
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+)
+
+const serviceClaimsKey = "serviceClaims"
+
+// RequireScope returns a gin middleware that only admits requests carrying a
+// service token with every given scope
+func (v *Validator) RequireScope(scopes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, err := v.Authorize(c.GetHeader(AuthorizationHeader), scopes...)
+		if err != nil {
+			status := http.StatusUnauthorized
+			code := "UNAUTHORIZED"
+			if err == auth.ErrInsufficientScope {
+				status = http.StatusForbidden
+				code = "INSUFFICIENT_SCOPE"
+			}
+			c.AbortWithStatusJSON(status, gin.H{
+				"error": gin.H{
+					"code":    code,
+					"message": err.Error(),
+				},
+				"timestamp": time.Now().Format(time.RFC3339),
+			})
+			return
+		}
+
+		c.Set(serviceClaimsKey, claims)
+		c.Next()
+	}
+}
+
+// ClaimsFromContext returns the service claims set by RequireScope
+func ClaimsFromContext(c *gin.Context) (*auth.ServiceClaims, bool) {
+	value, exists := c.Get(serviceClaimsKey)
+	if !exists {
+		return nil, false
+	}
+	claims, ok := value.(*auth.ServiceClaims)
+	return claims, ok
+}
@@ -0,0 +1,28 @@
+package serviceauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mmorpg-template/backend/internal/ports"
+)
+
+// RequireScopeNATS wraps a message handler so it only runs for messages whose
+// Authorization header carries a service token with every given scope.
+// Rejected requests get an error reply so callers fail fast instead of timing out.
+func (v *Validator) RequireScopeNATS(mq ports.MessageQueue, handler ports.MessageHandler, scopes ...string) ports.MessageHandler {
+	return func(msg *ports.QueueMessage) error {
+		if _, err := v.Authorize(msg.Headers[AuthorizationHeader], scopes...); err != nil {
+			if msg.ReplyTo != "" {
+				reply, _ := json.Marshal(map[string]string{"error": err.Error()})
+				if pubErr := mq.Publish(context.Background(), msg.ReplyTo, reply); pubErr != nil {
+					return fmt.Errorf("failed to reply to rejected request on %s: %w", msg.Subject, pubErr)
+				}
+			}
+			return fmt.Errorf("rejected unauthenticated request on %s: %w", msg.Subject, err)
+		}
+
+		return handler(msg)
+	}
+}
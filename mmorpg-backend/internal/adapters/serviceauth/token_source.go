@@ -0,0 +1,105 @@
+package serviceauth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// refreshMargin renews a cached token this long before it expires
+const refreshMargin = time.Minute
+
+// TokenSource obtains client-credential tokens from the auth service and caches them
+type TokenSource struct {
+	tokenURL     string
+	clientID     string
+	clientSecret string
+	scopes       []string
+	client       *http.Client
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// NewTokenSource creates a token source for the auth service at authURL
+func NewTokenSource(authURL, clientID, clientSecret string, scopes []string) *TokenSource {
+	return &TokenSource{
+		tokenURL:     strings.TrimRight(authURL, "/") + "/api/v1/auth/service/token",
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		scopes:       scopes,
+		client:       &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type tokenRequest struct {
+	GrantType    string `json:"grant_type"`
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	Scope        string `json:"scope,omitempty"`
+}
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+	Error       string `json:"error"`
+}
+
+// Token returns a valid token, fetching a new one when the cached token is about to expire
+func (s *TokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && time.Now().Add(refreshMargin).Before(s.expiresAt) {
+		return s.token, nil
+	}
+
+	body, err := json.Marshal(tokenRequest{
+		GrantType:    "client_credentials",
+		ClientID:     s.clientID,
+		ClientSecret: s.clientSecret,
+		Scope:        strings.Join(s.scopes, " "),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode token request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.tokenURL, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to request service token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var token tokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", fmt.Errorf("failed to decode token response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK || token.AccessToken == "" {
+		return "", fmt.Errorf("service token request failed with status %d: %s", resp.StatusCode, token.Error)
+	}
+
+	s.token = token.AccessToken
+	s.expiresAt = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	return s.token, nil
+}
+
+// Headers returns the headers that authenticate a request or NATS message as this service
+func (s *TokenSource) Headers(ctx context.Context) (map[string]string, error) {
+	token, err := s.Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{AuthorizationHeader: "Bearer " + token}, nil
+}
@@ -0,0 +1,74 @@
+// Package serviceauth validates and obtains service-to-service client-credential tokens.
+// Services that expose internal APIs use Validator to guard HTTP routes and NATS
+// subscriptions; callers use TokenSource to fetch and cache tokens from the auth service.
+package serviceauth
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+)
+
+// AuthorizationHeader carries "Bearer <token>" on HTTP requests and NATS messages
+const AuthorizationHeader = "Authorization"
+
+// Validator checks service tokens signed by the auth service
+type Validator struct {
+	secret []byte
+	issuer string
+}
+
+// NewValidator creates a validator for tokens signed with the shared service secret
+func NewValidator(secret, issuer string) *Validator {
+	return &Validator{
+		secret: []byte(secret),
+		issuer: issuer,
+	}
+}
+
+// Validate parses a service token and returns its claims
+func (v *Validator) Validate(tokenString string) (*auth.ServiceClaims, error) {
+	claims := &auth.ServiceClaims{}
+
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, jwt.ErrSignatureInvalid
+		}
+		return v.secret, nil
+	})
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, auth.ErrTokenExpired
+		}
+		return nil, auth.ErrInvalidToken
+	}
+
+	if !token.Valid || claims.Issuer != v.issuer || !claims.IsValid() {
+		return nil, auth.ErrInvalidToken
+	}
+
+	return claims, nil
+}
+
+// Authorize validates an Authorization header value and checks the token grants every scope
+func (v *Validator) Authorize(header string, scopes ...string) (*auth.ServiceClaims, error) {
+	tokenString, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok || tokenString == "" {
+		return nil, auth.ErrInvalidToken
+	}
+
+	claims, err := v.Validate(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, scope := range scopes {
+		if !claims.HasScope(scope) {
+			return nil, auth.ErrInsufficientScope
+		}
+	}
+
+	return claims, nil
+}
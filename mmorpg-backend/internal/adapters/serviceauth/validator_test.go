@@ -0,0 +1,175 @@
+package serviceauth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+	"github.com/mmorpg-template/backend/internal/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "test-service-secret"
+
+func signServiceToken(t *testing.T, claims jwt.Claims, secret string) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	require.NoError(t, err)
+	return token
+}
+
+func serviceClaims(scopes []string, expiresIn time.Duration) *auth.ServiceClaims {
+	return &auth.ServiceClaims{
+		ServiceID: "8b1f4c1e-0000-0000-0000-000000000001",
+		ClientID:  "svc-world",
+		Name:      "world",
+		Scopes:    scopes,
+		TokenType: auth.ServiceTokenType,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiresIn)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			Issuer:    "mmorpg-auth",
+			Subject:   "svc-world",
+		},
+	}
+}
+
+func TestValidator_Authorize(t *testing.T) {
+	validator := NewValidator(testSecret, "mmorpg-auth")
+
+	tests := []struct {
+		name    string
+		header  string
+		scopes  []string
+		wantErr error
+	}{
+		{
+			name:   "valid token with scope",
+			header: "Bearer " + signServiceToken(t, serviceClaims([]string{auth.ScopeCharacterRead}, time.Minute), testSecret),
+			scopes: []string{auth.ScopeCharacterRead},
+		},
+		{
+			name:    "missing scope",
+			header:  "Bearer " + signServiceToken(t, serviceClaims([]string{auth.ScopeUserRead}, time.Minute), testSecret),
+			scopes:  []string{auth.ScopeCharacterRead},
+			wantErr: auth.ErrInsufficientScope,
+		},
+		{
+			name:    "expired token",
+			header:  "Bearer " + signServiceToken(t, serviceClaims([]string{auth.ScopeCharacterRead}, -time.Minute), testSecret),
+			wantErr: auth.ErrTokenExpired,
+		},
+		{
+			name:    "wrong secret",
+			header:  "Bearer " + signServiceToken(t, serviceClaims(nil, time.Minute), "other-secret"),
+			wantErr: auth.ErrInvalidToken,
+		},
+		{
+			name: "user token",
+			header: "Bearer " + signServiceToken(t, &auth.Claims{
+				UserID:    "user-1",
+				SessionID: "session-1",
+				RegisteredClaims: jwt.RegisteredClaims{
+					ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+					Issuer:    "mmorpg-auth",
+				},
+			}, testSecret),
+			wantErr: auth.ErrInvalidToken,
+		},
+		{
+			name:    "missing header",
+			header:  "",
+			wantErr: auth.ErrInvalidToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := validator.Authorize(tt.header, tt.scopes...)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "svc-world", claims.ClientID)
+		})
+	}
+}
+
+func TestValidator_RequireScope(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	validator := NewValidator(testSecret, "mmorpg-auth")
+
+	router := gin.New()
+	router.GET("/internal", validator.RequireScope(auth.ScopeCharacterRead), func(c *gin.Context) {
+		claims, ok := ClaimsFromContext(c)
+		require.True(t, ok)
+		c.String(http.StatusOK, claims.ClientID)
+	})
+
+	tests := []struct {
+		name   string
+		scopes []string
+		header bool
+		status int
+	}{
+		{"authorized", []string{auth.ScopeCharacterRead}, true, http.StatusOK},
+		{"insufficient scope", []string{auth.ScopeUserRead}, true, http.StatusForbidden},
+		{"unauthenticated", nil, false, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/internal", nil)
+			if tt.header {
+				req.Header.Set(AuthorizationHeader, "Bearer "+signServiceToken(t, serviceClaims(tt.scopes, time.Minute), testSecret))
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tt.status, w.Code)
+		})
+	}
+}
+
+// replyRecorder captures replies published by the NATS middleware
+type replyRecorder struct {
+	ports.MessageQueue
+	subject string
+	data    []byte
+}
+
+func (r *replyRecorder) Publish(ctx context.Context, subject string, data []byte) error {
+	r.subject = subject
+	r.data = data
+	return nil
+}
+
+func TestValidator_RequireScopeNATS(t *testing.T) {
+	validator := NewValidator(testSecret, "mmorpg-auth")
+	mq := &replyRecorder{}
+
+	called := false
+	handler := validator.RequireScopeNATS(mq, func(msg *ports.QueueMessage) error {
+		called = true
+		return nil
+	}, auth.ScopeCharacterRead)
+
+	err := handler(&ports.QueueMessage{Subject: "character.validate", ReplyTo: "_INBOX.1"})
+	assert.Error(t, err)
+	assert.False(t, called)
+	assert.Equal(t, "_INBOX.1", mq.subject)
+	assert.Contains(t, string(mq.data), "invalid token")
+
+	token := signServiceToken(t, serviceClaims([]string{auth.ScopeCharacterRead}, time.Minute), testSecret)
+	err = handler(&ports.QueueMessage{
+		Subject: "character.validate",
+		Headers: map[string]string{AuthorizationHeader: "Bearer " + token},
+	})
+	assert.NoError(t, err)
+	assert.True(t, called)
+}
@@ -2,6 +2,9 @@
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"regexp"
 	"strings"
@@ -10,19 +13,32 @@
 
 	"github.com/google/uuid"
 	"github.com/mmorpg-template/backend/internal/domain/auth"
+	"github.com/mmorpg-template/backend/internal/ports"
 	portsAuth "github.com/mmorpg-template/backend/internal/ports/auth"
 	"github.com/mmorpg-template/backend/pkg/logger"
 )
 
 // AuthServiceImpl implements the auth service business logic
 type AuthServiceImpl struct {
-	userRepo       portsAuth.UserRepository
-	sessionRepo    portsAuth.SessionRepository
-	tokenGenerator portsAuth.TokenGenerator
-	passwordHasher portsAuth.PasswordHasher
-	tokenCache     portsAuth.TokenCache
-	config         *Config
-	logger         logger.Logger
+	userRepo         portsAuth.UserRepository
+	sessionRepo      portsAuth.SessionRepository
+	tokenGenerator   portsAuth.TokenGenerator
+	passwordHasher   portsAuth.PasswordHasher
+	tokenCache       portsAuth.TokenCache
+	securityEvents   portsAuth.SecurityEventRepository
+	knownDevices     portsAuth.KnownDeviceRepository
+	notifier         portsAuth.Notifier
+	screener         portsAuth.PasswordScreener
+	identities       portsAuth.IdentityHistoryRepository
+	eventPublisher   portsAuth.EventPublisher
+	services         portsAuth.ServiceAccountRepository
+	loginHistory     portsAuth.LoginHistoryRepository
+	geoLocator       portsAuth.GeoLocator
+	parentalControls portsAuth.ParentalControlsRepository
+	playtime         portsAuth.PlaytimeRepository
+	locker           ports.Locker
+	config           *Config
+	logger           logger.Logger
 }
 
 // Config holds auth service configuration
@@ -32,6 +48,42 @@ type Config struct {
 	LoginRateLimitWindow time.Duration
 	SessionDuration      time.Duration
 	MaxLoginAttempts     int
+	// SecurityEventRetention is how long audit records are kept before their partition is dropped
+	SecurityEventRetention time.Duration
+	// AccountLockoutThreshold is the number of failed passwords per account before it is locked
+	AccountLockoutThreshold int
+	// AccountLockoutWindow is how long failed attempts against an account are remembered
+	AccountLockoutWindow time.Duration
+	// AccountLockoutBase is the first lock duration; each further failure doubles it
+	AccountLockoutBase time.Duration
+	// AccountLockoutMax caps the lock duration
+	AccountLockoutMax time.Duration
+	// RequireNewDeviceConfirmation withholds tokens from a brand-new device until
+	// the login is confirmed through an emailed link
+	RequireNewDeviceConfirmation bool
+	// GuestCreationLimit is the number of guest accounts one IP may create per GuestCreationWindow
+	GuestCreationLimit  int
+	GuestCreationWindow time.Duration
+	// GuestIdleTimeout is how long a guest may go without logging in before it is deleted
+	GuestIdleTimeout time.Duration
+	// UsernameChangeCooldown is the minimum time between username changes
+	UsernameChangeCooldown time.Duration
+	// UsernameHoldPeriod keeps a released username reserved for its previous owner
+	UsernameHoldPeriod time.Duration
+	// Registrations per RegistrationPowWindow from one IP or subnet above which
+	// a proof-of-work challenge is required (0 disables that bucket)
+	RegistrationPowIPThreshold     int
+	RegistrationPowSubnetThreshold int
+	RegistrationPowWindow          time.Duration
+	// RegistrationPowBaseDifficulty is the difficulty in bits just over the threshold;
+	// each doubling of pressure adds one bit, up to RegistrationPowMaxDifficulty
+	RegistrationPowBaseDifficulty int
+	RegistrationPowMaxDifficulty  int
+	// LoginHistoryRetention is how long login history is kept (0 keeps it forever)
+	LoginHistoryRetention time.Duration
+	// PlaytimeWarnings are the remaining play times at which a ward under parental
+	// controls is warned before being logged out
+	PlaytimeWarnings []time.Duration
 }
 
 // NewAuthService creates a new auth service
@@ -41,17 +93,39 @@ func NewAuthService(
 	tokenGenerator portsAuth.TokenGenerator,
 	passwordHasher portsAuth.PasswordHasher,
 	tokenCache portsAuth.TokenCache,
+	securityEvents portsAuth.SecurityEventRepository,
+	knownDevices portsAuth.KnownDeviceRepository,
+	notifier portsAuth.Notifier,
+	screener portsAuth.PasswordScreener,
+	identities portsAuth.IdentityHistoryRepository,
+	eventPublisher portsAuth.EventPublisher,
+	services portsAuth.ServiceAccountRepository,
+	loginHistory portsAuth.LoginHistoryRepository,
+	geoLocator portsAuth.GeoLocator,
+	parentalControls portsAuth.ParentalControlsRepository,
+	playtime portsAuth.PlaytimeRepository,
 	config *Config,
 	logger logger.Logger,
 ) *AuthServiceImpl {
 	return &AuthServiceImpl{
-		userRepo:       userRepo,
-		sessionRepo:    sessionRepo,
-		tokenGenerator: tokenGenerator,
-		passwordHasher: passwordHasher,
-		tokenCache:     tokenCache,
-		config:         config,
-		logger:         logger,
+		userRepo:         userRepo,
+		sessionRepo:      sessionRepo,
+		tokenGenerator:   tokenGenerator,
+		passwordHasher:   passwordHasher,
+		tokenCache:       tokenCache,
+		securityEvents:   securityEvents,
+		knownDevices:     knownDevices,
+		notifier:         notifier,
+		screener:         screener,
+		identities:       identities,
+		eventPublisher:   eventPublisher,
+		services:         services,
+		loginHistory:     loginHistory,
+		geoLocator:       geoLocator,
+		parentalControls: parentalControls,
+		playtime:         playtime,
+		config:           config,
+		logger:           logger,
 	}
 }
 
@@ -61,6 +135,12 @@ func (s *AuthServiceImpl) Register(ctx context.Context, req *auth.RegisterReques
 	if err := req.Validate(); err != nil {
 		return nil, err
 	}
+	if err := s.checkRegistrationPow(ctx, req); err != nil {
+		return nil, err
+	}
+	if err := s.screenPassword(ctx, req.Password); err != nil {
+		return nil, err
+	}
 
 	// Check if email already exists
 	emailExists, err := s.userRepo.ExistsByEmail(ctx, req.Email)
@@ -78,7 +158,7 @@ func (s *AuthServiceImpl) Register(ctx context.Context, req *auth.RegisterReques
 		s.logger.WithError(err).Error("Failed to check username existence")
 		return nil, fmt.Errorf("failed to check username: %w", err)
 	}
-	if usernameExists {
+	if usernameExists || s.isUsernameHeld(ctx, req.Username, "") {
 		return nil, auth.ErrUsernameAlreadyTaken
 	}
 
@@ -117,6 +197,7 @@ func (s *AuthServiceImpl) Login(ctx context.Context, email, password, deviceID,
 	}
 	
 	if attempts > s.config.MaxLoginAttempts {
+		s.recordLoginFailure(ctx, "", email, deviceID, ipAddress, userAgent, "rate_limited")
 		return nil, nil, auth.ErrTooManyAttempts
 	}
 
@@ -124,29 +205,51 @@ func (s *AuthServiceImpl) Login(ctx context.Context, email, password, deviceID,
 	user, err := s.userRepo.GetByEmail(ctx, email)
 	if err != nil {
 		if err == auth.ErrUserNotFound {
+			s.recordLoginFailure(ctx, "", email, deviceID, ipAddress, userAgent, "unknown_user")
 			return nil, nil, auth.ErrInvalidCredentials
 		}
 		s.logger.WithError(err).Error("Failed to get user by email")
 		return nil, nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
+	// A locked account answers a wrong password exactly like an unknown email, so the
+	// lockout can't be used to find accounts; only the right password learns of it
+	lockedUntil, err := s.tokenCache.GetAccountLock(ctx, user.ID.String())
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to check account lock")
+	}
+	if time.Now().Before(lockedUntil) {
+		s.recordLoginFailure(ctx, user.ID.String(), email, deviceID, ipAddress, userAgent, "account_locked")
+		if err := s.passwordHasher.ComparePassword(user.PasswordHash, password); err != nil {
+			return nil, nil, auth.ErrInvalidCredentials
+		}
+		return nil, nil, auth.ErrAccountLocked
+	}
+
 	// Check password
 	if err := s.passwordHasher.ComparePassword(user.PasswordHash, password); err != nil {
+		s.recordLoginFailure(ctx, user.ID.String(), email, deviceID, ipAddress, userAgent, "invalid_password")
+		s.registerAccountFailure(ctx, user)
 		return nil, nil, auth.ErrInvalidCredentials
 	}
 
 	// Check account status
+	var statusErr error
 	switch user.AccountStatus {
 	case auth.AccountStatusActive:
 		// Continue with login
 	case auth.AccountStatusSuspended:
-		return nil, nil, auth.ErrAccountSuspended
+		statusErr = auth.ErrAccountSuspended
 	case auth.AccountStatusBanned:
-		return nil, nil, auth.ErrAccountBanned
+		statusErr = auth.ErrAccountBanned
 	case auth.AccountStatusPendingVerification:
-		return nil, nil, auth.ErrEmailNotVerified
+		statusErr = auth.ErrEmailNotVerified
 	default:
-		return nil, nil, auth.ErrAccountNotActive
+		statusErr = auth.ErrAccountNotActive
+	}
+	if statusErr != nil {
+		s.recordLoginFailure(ctx, user.ID.String(), email, deviceID, ipAddress, userAgent, statusErr.Error())
+		return nil, nil, statusErr
 	}
 
 	// Check session limit
@@ -158,44 +261,42 @@ func (s *AuthServiceImpl) Login(ctx context.Context, email, password, deviceID,
 	if sessionCount >= s.config.MaxSessionsPerUser {
 		// Optional: Delete oldest session
 		s.logger.WithField("userID", user.ID).Warn("Max sessions reached")
+		s.recordLoginFailure(ctx, user.ID.String(), email, deviceID, ipAddress, userAgent, "too_many_sessions")
 		return nil, nil, auth.ErrTooManySessions
 	}
 
-	// Generate tokens
-	sessionID := uuid.New().String()
-	tokenPair, err := s.tokenGenerator.GenerateTokenPair(ctx, user, sessionID, deviceID)
-	if err != nil {
-		s.logger.WithError(err).Error("Failed to generate token pair")
-		return nil, nil, fmt.Errorf("failed to generate tokens: %w", err)
+	// Recognise the device; a brand-new device may need email confirmation first
+	if err := s.checkLoginDevice(ctx, user, deviceID, ipAddress, userAgent); err != nil {
+		s.recordLoginFailure(ctx, user.ID.String(), email, deviceID, ipAddress, userAgent, "device_not_confirmed")
+		return nil, nil, err
 	}
 
-	// Create session
-	tokenHash := s.tokenGenerator.HashToken(tokenPair.RefreshToken)
-	session := auth.NewSession(
-		user.ID,
-		tokenHash,
-		deviceID,
-		ipAddress,
-		userAgent,
-		time.Now().Add(auth.RefreshTokenDuration),
-	)
-	session.ID = uuid.MustParse(sessionID)
-
-	if err := s.sessionRepo.Create(ctx, session); err != nil {
-		s.logger.WithError(err).Error("Failed to create session")
-		return nil, nil, fmt.Errorf("failed to create session: %w", err)
+	tokenPair, sessionID, err := s.issueSession(ctx, user, deviceID, ipAddress, userAgent)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	// Cache session for faster access
-	// Note: This is a type assertion to a concrete type from adapters layer
-	// In a pure hexagonal architecture, we'd use an interface method instead
-
 	// Clear login attempts on successful login
 	_ = s.tokenCache.DeleteLoginAttempts(ctx, identifier)
-
-	// Update user last login
-	user.UpdatedAt = time.Now()
-	_ = s.userRepo.Update(ctx, user)
+	_ = s.tokenCache.DeleteLoginAttempts(ctx, accountAttemptsKey(user.ID.String()))
+
+	event := auth.NewSecurityEvent(auth.SecurityEventLoginSuccess, auth.SecurityOutcomeSuccess, user.ID.String())
+	event.ActorID = user.ID.String()
+	event.SessionID = sessionID
+	event.DeviceID = deviceID
+	event.IPAddress = ipAddress
+	event.UserAgent = userAgent
+	s.recordSecurityEvent(ctx, event)
+
+	s.recordLogin(ctx, loginAttempt{
+		userID:    user.ID.String(),
+		sessionID: sessionID,
+		kind:      auth.LoginKindPassword,
+		result:    auth.LoginResultSuccess,
+		deviceID:  deviceID,
+		ipAddress: ipAddress,
+		userAgent: userAgent,
+	})
 
 	s.logger.WithFields(map[string]interface{}{
 		"userID":    user.ID,
@@ -208,6 +309,14 @@ func (s *AuthServiceImpl) Login(ctx context.Context, email, password, deviceID,
 
 // Logout invalidates a session
 func (s *AuthServiceImpl) Logout(ctx context.Context, sessionID string) error {
+	return s.endSession(ctx, sessionID, auth.SecurityEventLogout)
+}
+
+// endSession deletes a session and records why it ended
+func (s *AuthServiceImpl) endSession(ctx context.Context, sessionID string, eventType auth.SecurityEventType) error {
+	// Look up the owner for the audit log before the session disappears
+	session, _ := s.sessionRepo.GetByID(ctx, sessionID)
+
 	// Delete session
 	if err := s.sessionRepo.Delete(ctx, sessionID); err != nil {
 		if err == auth.ErrSessionNotFound {
@@ -220,6 +329,17 @@ func (s *AuthServiceImpl) Logout(ctx context.Context, sessionID string) error {
 	// Delete cached session
 	_ = s.tokenCache.DeleteSession(ctx, sessionID)
 
+	event := auth.NewSecurityEvent(eventType, auth.SecurityOutcomeSuccess, "")
+	event.SessionID = sessionID
+	if session != nil {
+		event.TargetUserID = session.UserID.String()
+		event.DeviceID = session.DeviceID
+	}
+	s.recordSecurityEvent(ctx, event)
+	if session != nil {
+		s.publishSessionEnded(ctx, session.UserID.String(), sessionID, eventType)
+	}
+
 	s.logger.WithField("sessionID", sessionID).Info("User logged out successfully")
 	return nil
 }
@@ -243,10 +363,31 @@ func (s *AuthServiceImpl) LogoutAllDevices(ctx context.Context, userID string) e
 		_ = s.tokenCache.DeleteSession(ctx, session.ID.String())
 	}
 
+	event := auth.NewSecurityEvent(auth.SecurityEventAllSessionsEnded, auth.SecurityOutcomeSuccess, userID)
+	event.Metadata["sessions"] = fmt.Sprintf("%d", len(sessions))
+	s.recordSecurityEvent(ctx, event)
+	s.publishSessionEnded(ctx, userID, "", auth.SecurityEventAllSessionsEnded)
+
 	s.logger.WithField("userID", userID).Info("All devices logged out successfully")
 	return nil
 }
 
+// publishSessionEnded tells other services that a session, or with an empty sessionID every
+// session of the user, has ended
+func (s *AuthServiceImpl) publishSessionEnded(ctx context.Context, userID, sessionID string, reason auth.SecurityEventType) {
+	if s.eventPublisher == nil {
+		return
+	}
+	event := &auth.SessionEndedEvent{
+		BaseEvent: auth.BaseEvent{UserID: userID},
+		SessionID: sessionID,
+		Reason:    string(reason),
+	}
+	if err := s.eventPublisher.PublishSessionEnded(ctx, event); err != nil {
+		s.logger.WithError(err).WithField("userID", userID).Warn("Failed to publish session ended event")
+	}
+}
+
 // RefreshToken generates a new token pair from a refresh token
 func (s *AuthServiceImpl) RefreshToken(ctx context.Context, refreshToken, deviceID, ipAddress, userAgent string) (*auth.TokenPair, error) {
 	// Validate refresh token
@@ -267,6 +408,15 @@ func (s *AuthServiceImpl) RefreshToken(ctx context.Context, refreshToken, device
 		return nil, auth.ErrSessionInvalid
 	}
 
+	attempt := loginAttempt{
+		userID:    claims.UserID,
+		sessionID: session.ID.String(),
+		kind:      auth.LoginKindRefresh,
+		deviceID:  deviceID,
+		ipAddress: ipAddress,
+		userAgent: userAgent,
+	}
+
 	// Get user
 	user, err := s.userRepo.GetByID(ctx, claims.UserID)
 	if err != nil {
@@ -275,6 +425,8 @@ func (s *AuthServiceImpl) RefreshToken(ctx context.Context, refreshToken, device
 
 	// Check if user is still active
 	if !user.IsActive() {
+		attempt.result = auth.ErrAccountNotActive.Error()
+		s.recordLogin(ctx, attempt)
 		return nil, auth.ErrAccountNotActive
 	}
 
@@ -293,6 +445,10 @@ func (s *AuthServiceImpl) RefreshToken(ctx context.Context, refreshToken, device
 	if err := s.sessionRepo.Update(ctx, session); err != nil {
 		return nil, fmt.Errorf("failed to update session: %w", err)
 	}
+	_ = s.tokenCache.DeleteSession(ctx, session.ID.String())
+
+	attempt.result = auth.LoginResultSuccess
+	s.recordLogin(ctx, attempt)
 
 	s.logger.WithFields(map[string]interface{}{
 		"userID":    user.ID,
@@ -320,11 +476,18 @@ func (s *AuthServiceImpl) ValidateToken(ctx context.Context, token string) (*aut
 		return nil, err
 	}
 
-	// Update session activity
-	go func() {
-		ctx := context.Background()
-		_ = s.sessionRepo.UpdateLastActive(ctx, claims.SessionID, time.Now())
-	}()
+	// Access tokens die with their session (logout, revocation, ban)
+	session, err := s.GetSession(ctx, claims.SessionID)
+	if err != nil {
+		if err == auth.ErrSessionNotFound || err == auth.ErrSessionExpired {
+			return nil, auth.ErrSessionInvalid
+		}
+		return nil, err
+	}
+
+	if time.Since(session.LastActive) > sessionActivityInterval {
+		s.touchSession(session)
+	}
 
 	return claims, nil
 }
@@ -344,12 +507,15 @@ func (s *AuthServiceImpl) ChangePassword(ctx context.Context, userID, currentPas
 
 	// Verify current password
 	if err := s.passwordHasher.ComparePassword(user.PasswordHash, currentPassword); err != nil {
+		event := auth.NewSecurityEvent(auth.SecurityEventPasswordChanged, auth.SecurityOutcomeFailure, userID)
+		event.Reason = "current_password_mismatch"
+		s.recordSecurityEvent(ctx, event)
 		return auth.ErrPasswordMismatch
 	}
 
 	// Validate new password
-	if !isStrongPassword(newPassword) {
-		return auth.ErrPasswordTooWeak
+	if err := s.validateNewPassword(ctx, newPassword); err != nil {
+		return err
 	}
 
 	// Hash new password
@@ -369,6 +535,8 @@ func (s *AuthServiceImpl) ChangePassword(ctx context.Context, userID, currentPas
 	// Optionally: Invalidate all sessions except current
 	// This is a security measure to log out potential attackers
 
+	s.recordSecurityEvent(ctx, auth.NewSecurityEvent(auth.SecurityEventPasswordChanged, auth.SecurityOutcomeSuccess, userID))
+
 	s.logger.WithField("userID", userID).Info("Password changed successfully")
 	return nil
 }
@@ -394,8 +562,9 @@ func (s *AuthServiceImpl) RequestPasswordReset(ctx context.Context, email string
 		return fmt.Errorf("failed to store reset token: %w", err)
 	}
 
-	// TODO: Send email with reset token
-	// This would integrate with an email service
+	notification := auth.NewNotification(auth.NotificationPasswordReset, user)
+	notification.Data["token"] = resetToken
+	s.notify(ctx, notification)
 
 	s.logger.WithField("userID", user.ID).Info("Password reset requested")
 	return nil
@@ -416,8 +585,8 @@ func (s *AuthServiceImpl) ResetPassword(ctx context.Context, token, newPassword
 	}
 
 	// Validate new password
-	if !isStrongPassword(newPassword) {
-		return auth.ErrPasswordTooWeak
+	if err := s.validateNewPassword(ctx, newPassword); err != nil {
+		return err
 	}
 
 	// Get user
@@ -443,6 +612,8 @@ func (s *AuthServiceImpl) ResetPassword(ctx context.Context, token, newPassword
 	// Delete reset token
 	_ = s.tokenCache.DeletePasswordResetToken(ctx, token)
 
+	s.recordSecurityEvent(ctx, auth.NewSecurityEvent(auth.SecurityEventPasswordReset, auth.SecurityOutcomeSuccess, userID))
+
 	// Invalidate all sessions for security
 	_ = s.LogoutAllDevices(ctx, userID)
 
@@ -450,6 +621,138 @@ func (s *AuthServiceImpl) ResetPassword(ctx context.Context, token, newPassword
 	return nil
 }
 
+// RequestAccountUnlock sends a one-time unlock link to the owner of a locked account
+func (s *AuthServiceImpl) RequestAccountUnlock(ctx context.Context, email string) error {
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		// Don't reveal if email exists or not
+		s.logger.WithField("email", email).Debug("Account unlock requested for non-existent email")
+		return nil
+	}
+
+	lockedUntil, err := s.tokenCache.GetAccountLock(ctx, user.ID.String())
+	if err != nil {
+		return fmt.Errorf("failed to check account lock: %w", err)
+	}
+	if !time.Now().Before(lockedUntil) {
+		// Nothing to unlock; respond the same way to avoid leaking lock state
+		return nil
+	}
+
+	if err := s.sendUnlockLink(ctx, user, lockedUntil); err != nil {
+		return err
+	}
+
+	s.logger.WithField("userID", user.ID).Info("Account unlock requested")
+	return nil
+}
+
+// sendUnlockLink emails the owner of a locked account a one-time unlock link
+func (s *AuthServiceImpl) sendUnlockLink(ctx context.Context, user *auth.User, lockedUntil time.Time) error {
+	unlockToken, err := generateVerificationToken()
+	if err != nil {
+		return fmt.Errorf("failed to generate unlock token: %w", err)
+	}
+
+	tokenHash := s.tokenGenerator.HashToken(unlockToken)
+	if err := s.tokenCache.SetVerificationToken(ctx, auth.TokenPurposeAccountUnlock, tokenHash, user.ID.String(), auth.AccountUnlockTokenDuration); err != nil {
+		return fmt.Errorf("failed to store unlock token: %w", err)
+	}
+
+	notification := auth.NewNotification(auth.NotificationAccountUnlock, user)
+	notification.Data["token"] = unlockToken
+	notification.Data["locked_until"] = lockedUntil.UTC().Format(time.RFC3339)
+	s.notify(ctx, notification)
+	return nil
+}
+
+// UnlockAccount clears a lockout using a token from RequestAccountUnlock
+func (s *AuthServiceImpl) UnlockAccount(ctx context.Context, token string) error {
+	tokenHash := s.tokenGenerator.HashToken(token)
+	userID, err := s.tokenCache.GetVerificationToken(ctx, auth.TokenPurposeAccountUnlock, tokenHash)
+	if err != nil {
+		return auth.ErrInvalidToken
+	}
+
+	// Tokens are single use
+	_ = s.tokenCache.DeleteVerificationToken(ctx, auth.TokenPurposeAccountUnlock, tokenHash)
+
+	if err := s.tokenCache.DeleteAccountLock(ctx, userID); err != nil {
+		return fmt.Errorf("failed to clear account lock: %w", err)
+	}
+	_ = s.tokenCache.DeleteLoginAttempts(ctx, accountAttemptsKey(userID))
+
+	event := auth.NewSecurityEvent(auth.SecurityEventAccountUnlocked, auth.SecurityOutcomeSuccess, userID)
+	event.Reason = "email_link"
+	s.recordSecurityEvent(ctx, event)
+
+	s.logger.WithField("userID", userID).Info("Account unlocked")
+	return nil
+}
+
+// ConfirmDevice trusts a new device using a token from the confirmation email
+func (s *AuthServiceImpl) ConfirmDevice(ctx context.Context, token string) error {
+	if s.knownDevices == nil {
+		return auth.ErrInvalidToken
+	}
+
+	tokenHash := s.tokenGenerator.HashToken(token)
+	payload, err := s.tokenCache.GetVerificationToken(ctx, auth.TokenPurposeDeviceConfirm, tokenHash)
+	if err != nil {
+		return auth.ErrInvalidToken
+	}
+
+	// Tokens are single use
+	_ = s.tokenCache.DeleteVerificationToken(ctx, auth.TokenPurposeDeviceConfirm, tokenHash)
+
+	var confirmation auth.DeviceConfirmation
+	if err := json.Unmarshal([]byte(payload), &confirmation); err != nil {
+		return auth.ErrInvalidToken
+	}
+	userID, err := uuid.Parse(confirmation.UserID)
+	if err != nil {
+		return auth.ErrInvalidToken
+	}
+
+	device := auth.NewKnownDevice(userID, confirmation.DeviceKey, confirmation.IPAddress, confirmation.UserAgent)
+	device.Confirmed = true
+	if err := s.knownDevices.Save(ctx, device); err != nil {
+		return fmt.Errorf("failed to save device: %w", err)
+	}
+
+	event := auth.NewSecurityEvent(auth.SecurityEventDeviceConfirmed, auth.SecurityOutcomeSuccess, confirmation.UserID)
+	event.IPAddress = confirmation.IPAddress
+	event.UserAgent = confirmation.UserAgent
+	event.Metadata["device_key"] = confirmation.DeviceKey
+	s.recordSecurityEvent(ctx, event)
+
+	s.logger.WithField("userID", confirmation.UserID).Info("New device confirmed")
+	return nil
+}
+
+// ListKnownDevices retrieves the devices a user has logged in from
+func (s *AuthServiceImpl) ListKnownDevices(ctx context.Context, userID string) ([]*auth.KnownDevice, error) {
+	if s.knownDevices == nil {
+		return nil, nil
+	}
+	return s.knownDevices.ListByUserID(ctx, userID)
+}
+
+// RemoveKnownDevice forgets a device so its next login is treated as new
+func (s *AuthServiceImpl) RemoveKnownDevice(ctx context.Context, userID, deviceID string) error {
+	if s.knownDevices == nil {
+		return auth.ErrDeviceNotFound
+	}
+	if err := s.knownDevices.Delete(ctx, userID, deviceID); err != nil {
+		return err
+	}
+
+	event := auth.NewSecurityEvent(auth.SecurityEventDeviceRemoved, auth.SecurityOutcomeSuccess, userID)
+	event.Metadata["device"] = deviceID
+	s.recordSecurityEvent(ctx, event)
+	return nil
+}
+
 // GetUserSessions retrieves all active sessions for a user
 func (s *AuthServiceImpl) GetUserSessions(ctx context.Context, userID string) ([]*auth.Session, error) {
 	return s.sessionRepo.GetByUserID(ctx, userID)
@@ -457,7 +760,394 @@ func (s *AuthServiceImpl) GetUserSessions(ctx context.Context, userID string) ([
 
 // RevokeSession revokes a specific session
 func (s *AuthServiceImpl) RevokeSession(ctx context.Context, sessionID string) error {
-	return s.Logout(ctx, sessionID)
+	return s.endSession(ctx, sessionID, auth.SecurityEventSessionRevoked)
+}
+
+// UpdateUserRoles replaces a user's roles and records the change in the audit log
+func (s *AuthServiceImpl) UpdateUserRoles(ctx context.Context, userID string, roles []string, reason string) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	previous := strings.Join(user.Roles, ",")
+	user.Roles = roles
+	user.UpdatedAt = time.Now()
+
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+
+	event := auth.NewSecurityEvent(auth.SecurityEventRolesChanged, auth.SecurityOutcomeSuccess, userID)
+	event.Reason = reason
+	event.Metadata["previous_roles"] = previous
+	event.Metadata["new_roles"] = strings.Join(roles, ",")
+	s.recordSecurityEvent(ctx, event)
+
+	s.logger.WithField("userID", userID).Info("User roles updated")
+	return nil
+}
+
+// SetAccountStatus changes a user's account status (suspend, ban, reactivate)
+func (s *AuthServiceImpl) SetAccountStatus(ctx context.Context, userID string, status auth.AccountStatus, reason string) error {
+	if status < auth.AccountStatusActive || status > auth.AccountStatusDeleted {
+		return auth.ErrInvalidAccountStatus
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	previous := user.AccountStatus
+	user.AccountStatus = status
+	user.UpdatedAt = time.Now()
+
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+
+	// Suspended or banned accounts lose their sessions immediately
+	if status == auth.AccountStatusSuspended || status == auth.AccountStatusBanned {
+		if err := s.LogoutAllDevices(ctx, userID); err != nil {
+			s.logger.WithError(err).Warn("Failed to revoke sessions after status change")
+		}
+	}
+
+	event := auth.NewSecurityEvent(auth.SecurityEventStatusChanged, auth.SecurityOutcomeSuccess, userID)
+	event.Reason = reason
+	event.Metadata["previous_status"] = fmt.Sprintf("%d", previous)
+	event.Metadata["new_status"] = fmt.Sprintf("%d", status)
+	s.recordSecurityEvent(ctx, event)
+
+	s.logger.WithFields(map[string]interface{}{
+		"userID": userID,
+		"status": status,
+	}).Info("Account status changed")
+	return nil
+}
+
+// QuerySecurityEvents retrieves audit records for support investigations
+func (s *AuthServiceImpl) QuerySecurityEvents(ctx context.Context, filter *auth.SecurityEventFilter) ([]*auth.SecurityEvent, error) {
+	if s.securityEvents == nil {
+		return nil, nil
+	}
+	filter.Normalize()
+	return s.securityEvents.Query(ctx, filter)
+}
+
+// MaintainSecurityEvents pre-creates upcoming audit partitions and drops those past retention
+func (s *AuthServiceImpl) MaintainSecurityEvents(ctx context.Context) error {
+	if s.securityEvents == nil {
+		return nil
+	}
+
+	if err := s.securityEvents.EnsurePartitions(ctx, time.Now().AddDate(0, 1, 0)); err != nil {
+		return err
+	}
+
+	if s.config.SecurityEventRetention <= 0 {
+		return nil
+	}
+
+	dropped, err := s.securityEvents.DropPartitionsBefore(ctx, time.Now().Add(-s.config.SecurityEventRetention))
+	if err != nil {
+		return err
+	}
+	if dropped > 0 {
+		s.logger.WithField("partitions", dropped).Info("Dropped expired security event partitions")
+	}
+	return nil
+}
+
+// recordSecurityEvent appends an audit record, filling caller details from the context.
+// Failures are logged and never block the audited operation.
+func (s *AuthServiceImpl) recordSecurityEvent(ctx context.Context, event *auth.SecurityEvent) {
+	if s.securityEvents == nil {
+		return
+	}
+
+	event.WithClient(auth.ClientFromContext(ctx))
+	if err := s.securityEvents.Append(ctx, event); err != nil {
+		s.logger.WithError(err).WithField("eventType", event.EventType).Warn("Failed to record security event")
+	}
+}
+
+// recordLoginFailure records a rejected login attempt
+func (s *AuthServiceImpl) recordLoginFailure(ctx context.Context, userID, email, deviceID, ipAddress, userAgent, reason string) {
+	event := auth.NewSecurityEvent(auth.SecurityEventLoginFailure, auth.SecurityOutcomeFailure, userID)
+	event.Reason = reason
+	event.DeviceID = deviceID
+	event.IPAddress = ipAddress
+	event.UserAgent = userAgent
+	event.Metadata["email"] = email
+	s.recordSecurityEvent(ctx, event)
+
+	s.recordLogin(ctx, loginAttempt{
+		userID:    userID,
+		kind:      auth.LoginKindPassword,
+		result:    reason,
+		deviceID:  deviceID,
+		ipAddress: ipAddress,
+		userAgent: userAgent,
+	})
+}
+
+// issueSession generates tokens for a user and stores the new session
+func (s *AuthServiceImpl) issueSession(ctx context.Context, user *auth.User, deviceID, ipAddress, userAgent string) (*auth.TokenPair, string, error) {
+	// Generate tokens
+	sessionID := uuid.New().String()
+	tokenPair, err := s.tokenGenerator.GenerateTokenPair(ctx, user, sessionID, deviceID)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to generate token pair")
+		return nil, "", fmt.Errorf("failed to generate tokens: %w", err)
+	}
+
+	// Create session
+	tokenHash := s.tokenGenerator.HashToken(tokenPair.RefreshToken)
+	session := auth.NewSession(
+		user.ID,
+		tokenHash,
+		deviceID,
+		ipAddress,
+		userAgent,
+		time.Now().Add(auth.RefreshTokenDuration),
+	)
+	session.ID = uuid.MustParse(sessionID)
+
+	if err := s.sessionRepo.Create(ctx, session); err != nil {
+		s.logger.WithError(err).Error("Failed to create session")
+		return nil, "", fmt.Errorf("failed to create session: %w", err)
+	}
+
+	// Update user last login
+	now := time.Now()
+	user.LastLoginAt = &now
+	user.UpdatedAt = now
+	_ = s.userRepo.Update(ctx, user)
+
+	return tokenPair, sessionID, nil
+}
+
+// checkLoginDevice compares the login with the user's device history, notifying the
+// user about new devices and networks. It returns ErrDeviceNotConfirmed when the
+// device is new or still unconfirmed and the confirmation policy is enabled. Lookup
+// failures never block login.
+func (s *AuthServiceImpl) checkLoginDevice(ctx context.Context, user *auth.User, deviceID, ipAddress, userAgent string) error {
+	if s.knownDevices == nil {
+		return nil
+	}
+
+	userID := user.ID.String()
+	deviceKey := auth.DeviceKey(deviceID, userAgent)
+
+	device, err := s.knownDevices.Get(ctx, userID, deviceKey)
+	switch err {
+	case nil:
+		// Seen before but never confirmed, e.g. from before confirmation was required
+		if s.config.RequireNewDeviceConfirmation && !device.Confirmed {
+			device.Touch(ipAddress, userAgent)
+			return s.requestDeviceConfirmation(ctx, user, device)
+		}
+
+		network := auth.NetworkOf(ipAddress)
+		if network != "" && network != device.LastNetwork {
+			known, err := s.knownDevices.HasNetwork(ctx, userID, network)
+			if err != nil {
+				s.logger.WithError(err).Warn("Failed to check known networks")
+			} else if !known {
+				s.alertNewLogin(ctx, user, auth.SecurityEventNewNetwork, auth.NotificationNewNetworkLogin, deviceKey, ipAddress, userAgent)
+			}
+		}
+
+		device.Touch(ipAddress, userAgent)
+		if err := s.knownDevices.Save(ctx, device); err != nil {
+			s.logger.WithError(err).Warn("Failed to update known device")
+		}
+		return nil
+	case auth.ErrDeviceNotFound:
+		// New device, handled below
+	default:
+		s.logger.WithError(err).Warn("Failed to look up known device")
+		return nil
+	}
+
+	device = auth.NewKnownDevice(user.ID, deviceKey, ipAddress, userAgent)
+
+	count, err := s.knownDevices.CountByUserID(ctx, userID)
+	if err != nil {
+		s.logger.WithError(err).Warn("Failed to count known devices")
+	}
+	if err == nil && count == 0 {
+		// The first device on an account is the one it was created from
+		device.Confirmed = true
+		if err := s.knownDevices.Save(ctx, device); err != nil {
+			s.logger.WithError(err).Warn("Failed to save known device")
+		}
+		return nil
+	}
+
+	if s.config.RequireNewDeviceConfirmation {
+		return s.requestDeviceConfirmation(ctx, user, device)
+	}
+
+	if err := s.knownDevices.Save(ctx, device); err != nil {
+		s.logger.WithError(err).Warn("Failed to save known device")
+	}
+	s.alertNewLogin(ctx, user, auth.SecurityEventNewDevice, auth.NotificationNewDeviceLogin, deviceKey, ipAddress, userAgent)
+	return nil
+}
+
+// requestDeviceConfirmation emails a one-time link that trusts the device
+func (s *AuthServiceImpl) requestDeviceConfirmation(ctx context.Context, user *auth.User, device *auth.KnownDevice) error {
+	confirmToken, err := generateVerificationToken()
+	if err != nil {
+		return fmt.Errorf("failed to generate device confirmation token: %w", err)
+	}
+
+	payload, err := json.Marshal(auth.DeviceConfirmation{
+		UserID:    user.ID.String(),
+		DeviceKey: device.DeviceKey,
+		IPAddress: device.LastIPAddress,
+		UserAgent: device.UserAgent,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode device confirmation: %w", err)
+	}
+
+	tokenHash := s.tokenGenerator.HashToken(confirmToken)
+	if err := s.tokenCache.SetVerificationToken(ctx, auth.TokenPurposeDeviceConfirm, tokenHash, string(payload), auth.DeviceConfirmTokenDuration); err != nil {
+		return fmt.Errorf("failed to store device confirmation token: %w", err)
+	}
+
+	notification := auth.NewNotification(auth.NotificationDeviceConfirmation, user)
+	notification.Data["token"] = confirmToken
+	notification.Data["ip_address"] = device.LastIPAddress
+	notification.Data["user_agent"] = device.UserAgent
+	s.notify(ctx, notification)
+
+	event := auth.NewSecurityEvent(auth.SecurityEventNewDevice, auth.SecurityOutcomeDenied, user.ID.String())
+	event.Reason = "confirmation_required"
+	event.IPAddress = device.LastIPAddress
+	event.UserAgent = device.UserAgent
+	event.Metadata["device_key"] = device.DeviceKey
+	s.recordSecurityEvent(ctx, event)
+
+	return auth.ErrDeviceNotConfirmed
+}
+
+// alertNewLogin notifies the user of a login from an unfamiliar device or network
+func (s *AuthServiceImpl) alertNewLogin(ctx context.Context, user *auth.User, eventType auth.SecurityEventType, kind auth.NotificationKind, deviceKey, ipAddress, userAgent string) {
+	notification := auth.NewNotification(kind, user)
+	notification.Data["ip_address"] = ipAddress
+	notification.Data["user_agent"] = userAgent
+	s.notify(ctx, notification)
+
+	event := auth.NewSecurityEvent(eventType, auth.SecurityOutcomeSuccess, user.ID.String())
+	event.IPAddress = ipAddress
+	event.UserAgent = userAgent
+	event.Metadata["device_key"] = deviceKey
+	s.recordSecurityEvent(ctx, event)
+}
+
+// notify sends a notification. Failures are logged and never block the operation.
+func (s *AuthServiceImpl) notify(ctx context.Context, notification *auth.Notification) {
+	if s.notifier == nil {
+		return
+	}
+	if err := s.notifier.Notify(ctx, notification); err != nil {
+		s.logger.WithError(err).WithField("kind", notification.Kind).Warn("Failed to send notification")
+	}
+}
+
+// validateNewPassword applies the strength rules and breached-password screening
+func (s *AuthServiceImpl) validateNewPassword(ctx context.Context, password string) error {
+	if !isStrongPassword(password) {
+		return auth.ErrPasswordTooWeak
+	}
+	return s.screenPassword(ctx, password)
+}
+
+// screenPassword rejects breached or common passwords. Screening errors other than
+// a match are logged and the password is allowed, so a broken corpus can't block signups.
+func (s *AuthServiceImpl) screenPassword(ctx context.Context, password string) error {
+	if s.screener == nil {
+		return nil
+	}
+	err := s.screener.Screen(ctx, password)
+	if err == nil || err == auth.ErrPasswordBreached {
+		return err
+	}
+	s.logger.WithError(err).Warn("Failed to screen password")
+	return nil
+}
+
+// registerAccountFailure counts a failed password against the account and locks it
+// once the threshold is reached. The owner learns of the lock from an emailed unlock
+// link rather than from the login response.
+func (s *AuthServiceImpl) registerAccountFailure(ctx context.Context, user *auth.User) {
+	if s.config.AccountLockoutThreshold <= 0 {
+		return
+	}
+
+	userID := user.ID.String()
+	failures, err := s.tokenCache.IncrementLoginAttempts(ctx, accountAttemptsKey(userID), s.config.AccountLockoutWindow)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to track account login failures")
+		return
+	}
+	if failures < s.config.AccountLockoutThreshold {
+		return
+	}
+
+	duration := lockoutDuration(failures-s.config.AccountLockoutThreshold, s.config.AccountLockoutBase, s.config.AccountLockoutMax)
+	lockedUntil := time.Now().Add(duration)
+	if err := s.tokenCache.SetAccountLock(ctx, userID, lockedUntil); err != nil {
+		s.logger.WithError(err).Error("Failed to lock account")
+		return
+	}
+
+	event := auth.NewSecurityEvent(auth.SecurityEventAccountLocked, auth.SecurityOutcomeDenied, userID)
+	event.Reason = "too_many_failed_logins"
+	event.Metadata["failures"] = fmt.Sprintf("%d", failures)
+	event.Metadata["locked_until"] = lockedUntil.UTC().Format(time.RFC3339)
+	s.recordSecurityEvent(ctx, event)
+
+	s.logger.WithFields(map[string]interface{}{
+		"userID":   userID,
+		"failures": failures,
+		"duration": duration,
+	}).Warn("Account locked after repeated failed logins")
+
+	if err := s.sendUnlockLink(ctx, user, lockedUntil); err != nil {
+		s.logger.WithError(err).Error("Failed to send account unlock link")
+	}
+}
+
+// lockoutDuration doubles the base duration for every failure past the threshold
+func lockoutDuration(excess int, base, max time.Duration) time.Duration {
+	duration := base
+	for i := 0; i < excess && duration < max; i++ {
+		duration *= 2
+	}
+	if max > 0 && duration > max {
+		duration = max
+	}
+	return duration
+}
+
+// accountAttemptsKey is the login attempt counter identifier for an account
+func accountAttemptsKey(userID string) string {
+	return fmt.Sprintf("account:%s", userID)
+}
+
+// generateVerificationToken creates a random URL-safe one-time token
+func generateVerificationToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
 }
 
 // Helper functions for validation
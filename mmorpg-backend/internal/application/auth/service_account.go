@@ -0,0 +1,154 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+)
+
+// CreateServiceAccount registers an internal caller and returns its client secret.
+// The secret is only ever returned here and by RotateServiceAccountSecret.
+func (s *AuthServiceImpl) CreateServiceAccount(ctx context.Context, name string, scopes []string) (*auth.ServiceAccount, string, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, "", auth.ErrInvalidServiceName
+	}
+	if err := validateScopes(scopes); err != nil {
+		return nil, "", err
+	}
+
+	secret, err := generateVerificationToken()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate client secret: %w", err)
+	}
+
+	account := auth.NewServiceAccount(name, scopes, s.tokenGenerator.HashToken(secret))
+	if err := s.services.Create(ctx, account); err != nil {
+		return nil, "", err
+	}
+
+	event := auth.NewSecurityEvent(auth.SecurityEventServiceAccountCreated, auth.SecurityOutcomeSuccess, "")
+	event.Metadata["client_id"] = account.ClientID
+	event.Metadata["scopes"] = strings.Join(scopes, ",")
+	s.recordSecurityEvent(ctx, event)
+
+	s.logger.WithField("clientID", account.ClientID).Info("Service account created")
+	return account, secret, nil
+}
+
+// ListServiceAccounts returns all service accounts
+func (s *AuthServiceImpl) ListServiceAccounts(ctx context.Context) ([]*auth.ServiceAccount, error) {
+	return s.services.List(ctx)
+}
+
+// UpdateServiceAccount changes the scopes and active flag of a service account.
+// Tokens already issued stay valid until they expire.
+func (s *AuthServiceImpl) UpdateServiceAccount(ctx context.Context, clientID string, scopes []string, active bool) (*auth.ServiceAccount, error) {
+	if err := validateScopes(scopes); err != nil {
+		return nil, err
+	}
+
+	account, err := s.services.GetByClientID(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := strings.Join(account.Scopes, ",")
+	account.Scopes = scopes
+	account.IsActive = active
+	if err := s.services.Update(ctx, account); err != nil {
+		return nil, err
+	}
+
+	event := auth.NewSecurityEvent(auth.SecurityEventServiceAccountUpdated, auth.SecurityOutcomeSuccess, "")
+	event.Metadata["client_id"] = clientID
+	event.Metadata["previous_scopes"] = previous
+	event.Metadata["new_scopes"] = strings.Join(scopes, ",")
+	event.Metadata["active"] = fmt.Sprintf("%t", active)
+	s.recordSecurityEvent(ctx, event)
+
+	return account, nil
+}
+
+// RotateServiceAccountSecret replaces a service account's client secret and returns the new one
+func (s *AuthServiceImpl) RotateServiceAccountSecret(ctx context.Context, clientID string) (string, error) {
+	account, err := s.services.GetByClientID(ctx, clientID)
+	if err != nil {
+		return "", err
+	}
+
+	secret, err := generateVerificationToken()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate client secret: %w", err)
+	}
+
+	account.SecretHash = s.tokenGenerator.HashToken(secret)
+	if err := s.services.Update(ctx, account); err != nil {
+		return "", err
+	}
+
+	event := auth.NewSecurityEvent(auth.SecurityEventServiceAccountUpdated, auth.SecurityOutcomeSuccess, "")
+	event.Reason = "secret_rotated"
+	event.Metadata["client_id"] = clientID
+	s.recordSecurityEvent(ctx, event)
+
+	return secret, nil
+}
+
+// IssueServiceToken exchanges client credentials for a scoped service token.
+// An empty scope list grants every scope the account holds.
+func (s *AuthServiceImpl) IssueServiceToken(ctx context.Context, clientID, clientSecret string, scopes []string) (*auth.ServiceToken, error) {
+	account, err := s.services.GetByClientID(ctx, clientID)
+	if err != nil && err != auth.ErrServiceAccountNotFound {
+		return nil, fmt.Errorf("failed to get service account: %w", err)
+	}
+
+	// Secrets are high-entropy random values, so a SHA-256 hash compared in constant time suffices
+	if account == nil || !account.IsActive ||
+		subtle.ConstantTimeCompare([]byte(account.SecretHash), []byte(s.tokenGenerator.HashToken(clientSecret))) != 1 {
+		s.recordServiceTokenFailure(ctx, clientID, "invalid_credentials")
+		return nil, auth.ErrInvalidClientCredentials
+	}
+
+	granted, err := account.GrantableScopes(scopes)
+	if err != nil {
+		s.recordServiceTokenFailure(ctx, clientID, "insufficient_scope")
+		return nil, err
+	}
+
+	token, err := s.tokenGenerator.GenerateServiceToken(ctx, account, granted)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to generate service token")
+		return nil, fmt.Errorf("failed to generate service token: %w", err)
+	}
+
+	if err := s.services.TouchLastUsed(ctx, clientID, time.Now()); err != nil {
+		s.logger.WithError(err).Warn("Failed to record service account use")
+	}
+
+	event := auth.NewSecurityEvent(auth.SecurityEventServiceTokenIssued, auth.SecurityOutcomeSuccess, "")
+	event.Metadata["client_id"] = clientID
+	event.Metadata["scopes"] = strings.Join(granted, ",")
+	s.recordSecurityEvent(ctx, event)
+
+	return token, nil
+}
+
+func (s *AuthServiceImpl) recordServiceTokenFailure(ctx context.Context, clientID, reason string) {
+	event := auth.NewSecurityEvent(auth.SecurityEventServiceTokenIssued, auth.SecurityOutcomeFailure, "")
+	event.Reason = reason
+	event.Metadata["client_id"] = clientID
+	s.recordSecurityEvent(ctx, event)
+}
+
+func validateScopes(scopes []string) error {
+	for _, scope := range scopes {
+		if !auth.IsValidScope(scope) {
+			return auth.ErrInvalidScope
+		}
+	}
+	return nil
+}
@@ -0,0 +1,251 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	authApp "github.com/mmorpg-template/backend/internal/application/auth"
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+	"github.com/mmorpg-template/backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingSecurityEvents keeps appended audit records in memory
+type recordingSecurityEvents struct {
+	events    []*auth.SecurityEvent
+	appendErr error
+}
+
+func (r *recordingSecurityEvents) Append(ctx context.Context, event *auth.SecurityEvent) error {
+	if r.appendErr != nil {
+		return r.appendErr
+	}
+	r.events = append(r.events, event)
+	return nil
+}
+
+func (r *recordingSecurityEvents) Query(ctx context.Context, filter *auth.SecurityEventFilter) ([]*auth.SecurityEvent, error) {
+	return r.events, nil
+}
+
+func (r *recordingSecurityEvents) EnsurePartitions(ctx context.Context, until time.Time) error {
+	return nil
+}
+
+func (r *recordingSecurityEvents) DropPartitionsBefore(ctx context.Context, before time.Time) (int, error) {
+	return 0, nil
+}
+
+func (r *recordingSecurityEvents) types() []auth.SecurityEventType {
+	types := make([]auth.SecurityEventType, 0, len(r.events))
+	for _, event := range r.events {
+		types = append(types, event.EventType)
+	}
+	return types
+}
+
+type auditFixture struct {
+	service     *authApp.AuthServiceImpl
+	userRepo    *mockUserRepository
+	sessionRepo *mockSessionRepository
+	passHasher  *mockPasswordHasher
+	tokenCache  *mockTokenCache
+	events      *recordingSecurityEvents
+}
+
+func newAuditFixture() *auditFixture {
+	f := &auditFixture{
+		userRepo:    new(mockUserRepository),
+		sessionRepo: new(mockSessionRepository),
+		passHasher:  new(mockPasswordHasher),
+		tokenCache:  new(mockTokenCache),
+		events:      &recordingSecurityEvents{},
+	}
+	config := &authApp.Config{
+		MaxSessionsPerUser:   10,
+		LoginRateLimit:       10,
+		LoginRateLimitWindow: 15 * time.Minute,
+		SessionDuration:      7 * 24 * time.Hour,
+		MaxLoginAttempts:     5,
+	}
+	f.service = authApp.NewAuthService(f.userRepo, f.sessionRepo, new(mockTokenGenerator), f.passHasher, f.tokenCache,
+		f.events, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, config, logger.NewNoop())
+	return f
+}
+
+func adminContext(adminID string) context.Context {
+	return auth.ContextWithClient(context.Background(), auth.ClientInfo{
+		ActorID:   adminID,
+		IPAddress: "10.0.0.1",
+		UserAgent: "AdminConsole",
+	})
+}
+
+func TestUpdateUserRoles_RecordsSecurityEvent(t *testing.T) {
+	f := newAuditFixture()
+	adminID := uuid.New().String()
+	ctx := adminContext(adminID)
+
+	user := &auth.User{ID: uuid.New(), Roles: []string{"player"}}
+	f.userRepo.On("GetByID", ctx, user.ID.String()).Return(user, nil)
+	f.userRepo.On("Update", ctx, user).Return(nil)
+
+	err := f.service.UpdateUserRoles(ctx, user.ID.String(), []string{"player", "moderator"}, "promoted")
+	require.NoError(t, err)
+
+	require.Len(t, f.events.events, 1)
+	event := f.events.events[0]
+	assert.Equal(t, auth.SecurityEventRolesChanged, event.EventType)
+	assert.Equal(t, auth.SecurityOutcomeSuccess, event.Outcome)
+	assert.Equal(t, user.ID.String(), event.TargetUserID)
+	assert.Equal(t, adminID, event.ActorID)
+	assert.Equal(t, "10.0.0.1", event.IPAddress)
+	assert.Equal(t, "promoted", event.Reason)
+	assert.Equal(t, "player", event.Metadata["previous_roles"])
+	assert.Equal(t, "player,moderator", event.Metadata["new_roles"])
+}
+
+func TestUpdateUserRoles_NoEventWhenUpdateFails(t *testing.T) {
+	f := newAuditFixture()
+	ctx := context.Background()
+
+	user := &auth.User{ID: uuid.New(), Roles: []string{"player"}}
+	f.userRepo.On("GetByID", ctx, user.ID.String()).Return(user, nil)
+	f.userRepo.On("Update", ctx, user).Return(errors.New("db down"))
+
+	err := f.service.UpdateUserRoles(ctx, user.ID.String(), []string{"admin"}, "")
+	assert.Error(t, err)
+	assert.Empty(t, f.events.events)
+}
+
+func TestSetAccountStatus_RecordsSecurityEvents(t *testing.T) {
+	t.Run("ban revokes sessions and records both events", func(t *testing.T) {
+		f := newAuditFixture()
+		adminID := uuid.New().String()
+		ctx := adminContext(adminID)
+
+		user := &auth.User{ID: uuid.New(), AccountStatus: auth.AccountStatusActive}
+		userID := user.ID.String()
+		session := &auth.Session{ID: uuid.New(), UserID: user.ID}
+		f.userRepo.On("GetByID", ctx, userID).Return(user, nil)
+		f.userRepo.On("Update", ctx, user).Return(nil)
+		f.sessionRepo.On("GetByUserID", ctx, userID).Return([]*auth.Session{session}, nil)
+		f.sessionRepo.On("DeleteByUserID", ctx, userID).Return(nil)
+		f.tokenCache.On("DeleteSession", ctx, session.ID.String()).Return(nil)
+
+		err := f.service.SetAccountStatus(ctx, userID, auth.AccountStatusBanned, "cheating")
+		require.NoError(t, err)
+
+		assert.Equal(t, []auth.SecurityEventType{
+			auth.SecurityEventAllSessionsEnded,
+			auth.SecurityEventStatusChanged,
+		}, f.events.types())
+
+		revoked := f.events.events[0]
+		assert.Equal(t, userID, revoked.TargetUserID)
+		assert.Equal(t, "1", revoked.Metadata["sessions"])
+
+		changed := f.events.events[1]
+		assert.Equal(t, auth.SecurityOutcomeSuccess, changed.Outcome)
+		assert.Equal(t, userID, changed.TargetUserID)
+		assert.Equal(t, adminID, changed.ActorID)
+		assert.Equal(t, "cheating", changed.Reason)
+		assert.Equal(t, fmt.Sprintf("%d", auth.AccountStatusActive), changed.Metadata["previous_status"])
+		assert.Equal(t, fmt.Sprintf("%d", auth.AccountStatusBanned), changed.Metadata["new_status"])
+		f.sessionRepo.AssertExpectations(t)
+	})
+
+	t.Run("reactivation keeps sessions", func(t *testing.T) {
+		f := newAuditFixture()
+		ctx := context.Background()
+
+		user := &auth.User{ID: uuid.New(), AccountStatus: auth.AccountStatusSuspended}
+		f.userRepo.On("GetByID", ctx, user.ID.String()).Return(user, nil)
+		f.userRepo.On("Update", ctx, user).Return(nil)
+
+		err := f.service.SetAccountStatus(ctx, user.ID.String(), auth.AccountStatusActive, "appeal accepted")
+		require.NoError(t, err)
+
+		assert.Equal(t, []auth.SecurityEventType{auth.SecurityEventStatusChanged}, f.events.types())
+		f.sessionRepo.AssertNotCalled(t, "DeleteByUserID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid status records nothing", func(t *testing.T) {
+		f := newAuditFixture()
+
+		err := f.service.SetAccountStatus(context.Background(), uuid.New().String(), auth.AccountStatus(99), "")
+		assert.Equal(t, auth.ErrInvalidAccountStatus, err)
+		assert.Empty(t, f.events.events)
+	})
+}
+
+func TestSessionEnd_RecordsSecurityEvent(t *testing.T) {
+	tests := []struct {
+		name      string
+		end       func(s *authApp.AuthServiceImpl, ctx context.Context, sessionID string) error
+		eventType auth.SecurityEventType
+	}{
+		{"logout", (*authApp.AuthServiceImpl).Logout, auth.SecurityEventLogout},
+		{"revoke", (*authApp.AuthServiceImpl).RevokeSession, auth.SecurityEventSessionRevoked},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newAuditFixture()
+			ctx := context.Background()
+
+			session := &auth.Session{ID: uuid.New(), UserID: uuid.New(), DeviceID: "device123"}
+			sessionID := session.ID.String()
+			f.sessionRepo.On("GetByID", ctx, sessionID).Return(session, nil)
+			f.sessionRepo.On("Delete", ctx, sessionID).Return(nil)
+			f.tokenCache.On("DeleteSession", ctx, sessionID).Return(nil)
+
+			require.NoError(t, tt.end(f.service, ctx, sessionID))
+
+			require.Len(t, f.events.events, 1)
+			event := f.events.events[0]
+			assert.Equal(t, tt.eventType, event.EventType)
+			assert.Equal(t, sessionID, event.SessionID)
+			assert.Equal(t, session.UserID.String(), event.TargetUserID)
+			assert.Equal(t, "device123", event.DeviceID)
+		})
+	}
+}
+
+func TestChangePassword_RecordsFailedAttempt(t *testing.T) {
+	f := newAuditFixture()
+	ctx := context.Background()
+
+	user := &auth.User{ID: uuid.New(), PasswordHash: "hashed_password"}
+	f.userRepo.On("GetByID", ctx, user.ID.String()).Return(user, nil)
+	f.passHasher.On("ComparePassword", user.PasswordHash, "wrong").Return(auth.ErrPasswordMismatch)
+
+	err := f.service.ChangePassword(ctx, user.ID.String(), "wrong", "NewStrongPass123!")
+	assert.Equal(t, auth.ErrPasswordMismatch, err)
+
+	require.Len(t, f.events.events, 1)
+	event := f.events.events[0]
+	assert.Equal(t, auth.SecurityEventPasswordChanged, event.EventType)
+	assert.Equal(t, auth.SecurityOutcomeFailure, event.Outcome)
+	assert.Equal(t, "current_password_mismatch", event.Reason)
+}
+
+func TestRecordSecurityEvent_FailureDoesNotBlockAction(t *testing.T) {
+	f := newAuditFixture()
+	f.events.appendErr = errors.New("audit store unavailable")
+	ctx := context.Background()
+
+	user := &auth.User{ID: uuid.New(), Roles: []string{"player"}}
+	f.userRepo.On("GetByID", ctx, user.ID.String()).Return(user, nil)
+	f.userRepo.On("Update", ctx, user).Return(nil)
+
+	err := f.service.UpdateUserRoles(ctx, user.ID.String(), []string{"support"}, "")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"support"}, user.Roles)
+}
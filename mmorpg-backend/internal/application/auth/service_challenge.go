@@ -0,0 +1,138 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+)
+
+// RegistrationChallenge issues a proof-of-work challenge for registering from an IP.
+// The difficulty follows the current registration pressure from the IP and its subnet,
+// and is never below the configured base so a client may solve one ahead of time.
+func (s *AuthServiceImpl) RegistrationChallenge(ctx context.Context, ipAddress string) (*auth.PowChallenge, error) {
+	difficulty := s.registrationDifficulty(ctx, ipAddress)
+	if difficulty < s.config.RegistrationPowBaseDifficulty {
+		difficulty = s.config.RegistrationPowBaseDifficulty
+	}
+
+	challenge, err := generateVerificationToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate challenge: %w", err)
+	}
+
+	grant, err := json.Marshal(&auth.PowGrant{Difficulty: difficulty, Network: auth.PowNetworkOf(ipAddress)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode challenge: %w", err)
+	}
+
+	if err := s.tokenCache.SetVerificationToken(ctx, auth.TokenPurposeRegisterPow,
+		s.tokenGenerator.HashToken(challenge), string(grant), auth.PowChallengeDuration); err != nil {
+		s.logger.WithError(err).Error("Failed to store registration challenge")
+		return nil, fmt.Errorf("failed to store challenge: %w", err)
+	}
+
+	return &auth.PowChallenge{
+		Challenge:  challenge,
+		Difficulty: difficulty,
+		ExpiresAt:  time.Now().Add(auth.PowChallengeDuration),
+	}, nil
+}
+
+// checkRegistrationPow counts a registration attempt against the IP and its subnet and,
+// once either is over its threshold, requires a solved challenge issued to the same
+// network at no less than the difficulty the pressure before this attempt calls for
+func (s *AuthServiceImpl) checkRegistrationPow(ctx context.Context, req *auth.RegisterRequest) error {
+	if s.config.RegistrationPowIPThreshold <= 0 && s.config.RegistrationPowSubnetThreshold <= 0 {
+		return nil
+	}
+
+	// Measured before counting so a challenge fetched just now is still hard enough;
+	// challenges solved in bulk while pressure was low stop working as it rises
+	current := s.registrationDifficulty(ctx, req.IPAddress)
+	s.countRegistrationAttempt(ctx, req.IPAddress)
+	required := s.registrationDifficulty(ctx, req.IPAddress)
+	if required == 0 {
+		return nil
+	}
+	if req.PowChallenge == "" || req.PowNonce == "" {
+		return auth.ErrProofOfWorkRequired
+	}
+
+	// Challenges are single use: redeem before verifying so a bad nonce burns it too
+	challengeHash := s.tokenGenerator.HashToken(req.PowChallenge)
+	value, err := s.tokenCache.GetVerificationToken(ctx, auth.TokenPurposeRegisterPow, challengeHash)
+	if err != nil {
+		return auth.ErrProofOfWorkInvalid
+	}
+	if err := s.tokenCache.DeleteVerificationToken(ctx, auth.TokenPurposeRegisterPow, challengeHash); err != nil {
+		s.logger.WithError(err).Warn("Failed to delete registration challenge")
+	}
+
+	var grant auth.PowGrant
+	if err := json.Unmarshal([]byte(value), &grant); err != nil {
+		return auth.ErrProofOfWorkInvalid
+	}
+	if grant.Network != auth.PowNetworkOf(req.IPAddress) || grant.Difficulty < current {
+		return auth.ErrProofOfWorkInvalid
+	}
+	if !auth.VerifyPowSolution(req.PowChallenge, req.PowNonce, grant.Difficulty) {
+		return auth.ErrProofOfWorkInvalid
+	}
+
+	return nil
+}
+
+// registrationDifficulty returns the difficulty currently required from an IP,
+// the harder of the per-IP and per-subnet pressure
+func (s *AuthServiceImpl) registrationDifficulty(ctx context.Context, ipAddress string) int {
+	difficulty := 0
+	for _, bucket := range s.registrationBuckets(ipAddress) {
+		attempts, err := s.tokenCache.GetLoginAttempts(ctx, bucket.key)
+		if err != nil {
+			s.logger.WithError(err).Warn("Failed to read registration attempts")
+			continue
+		}
+		d := auth.PowDifficulty(attempts, bucket.threshold,
+			s.config.RegistrationPowBaseDifficulty, s.config.RegistrationPowMaxDifficulty)
+		if d > difficulty {
+			difficulty = d
+		}
+	}
+	return difficulty
+}
+
+func (s *AuthServiceImpl) countRegistrationAttempt(ctx context.Context, ipAddress string) {
+	for _, bucket := range s.registrationBuckets(ipAddress) {
+		if _, err := s.tokenCache.IncrementLoginAttempts(ctx, bucket.key, s.config.RegistrationPowWindow); err != nil {
+			s.logger.WithError(err).Warn("Failed to count registration attempt")
+		}
+	}
+}
+
+type registrationBucket struct {
+	key       string
+	threshold int
+}
+
+func (s *AuthServiceImpl) registrationBuckets(ipAddress string) []registrationBucket {
+	var buckets []registrationBucket
+	if ipAddress == "" {
+		return buckets
+	}
+	if s.config.RegistrationPowIPThreshold > 0 {
+		buckets = append(buckets, registrationBucket{
+			key:       fmt.Sprintf("register:ip:%s", ipAddress),
+			threshold: s.config.RegistrationPowIPThreshold,
+		})
+	}
+	if network := auth.NetworkOf(ipAddress); network != "" && s.config.RegistrationPowSubnetThreshold > 0 {
+		buckets = append(buckets, registrationBucket{
+			key:       fmt.Sprintf("register:net:%s", network),
+			threshold: s.config.RegistrationPowSubnetThreshold,
+		})
+	}
+	return buckets
+}
@@ -0,0 +1,154 @@
+package auth_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	authApp "github.com/mmorpg-template/backend/internal/application/auth"
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+	"github.com/mmorpg-template/backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryTokenCache keeps attempt counters, account locks, verification tokens and
+// confirmed sides in maps
+type memoryTokenCache struct {
+	*mockTokenCache
+	attempts  map[string]int
+	locks     map[string]time.Time
+	tokens    map[string]string
+	confirmed map[string]bool
+}
+
+func newMemoryTokenCache() *memoryTokenCache {
+	return &memoryTokenCache{
+		mockTokenCache: new(mockTokenCache),
+		attempts:       map[string]int{},
+		locks:          map[string]time.Time{},
+		tokens:         map[string]string{},
+		confirmed:      map[string]bool{},
+	}
+}
+
+func (c *memoryTokenCache) GetLoginAttempts(ctx context.Context, identifier string) (int, error) {
+	return c.attempts[identifier], nil
+}
+
+func (c *memoryTokenCache) IncrementLoginAttempts(ctx context.Context, identifier string, expiration time.Duration) (int, error) {
+	c.attempts[identifier]++
+	return c.attempts[identifier], nil
+}
+
+func (c *memoryTokenCache) SetVerificationToken(ctx context.Context, purpose auth.TokenPurpose, tokenHash string, value string, expiration time.Duration) error {
+	c.tokens[string(purpose)+":"+tokenHash] = value
+	return nil
+}
+
+func (c *memoryTokenCache) GetVerificationToken(ctx context.Context, purpose auth.TokenPurpose, tokenHash string) (string, error) {
+	value, ok := c.tokens[string(purpose)+":"+tokenHash]
+	if !ok {
+		return "", auth.ErrInvalidToken
+	}
+	return value, nil
+}
+
+func (c *memoryTokenCache) DeleteVerificationToken(ctx context.Context, purpose auth.TokenPurpose, tokenHash string) error {
+	delete(c.tokens, string(purpose)+":"+tokenHash)
+	return nil
+}
+
+func solveChallenge(challenge *auth.PowChallenge) string {
+	for i := 0; ; i++ {
+		nonce := strconv.Itoa(i)
+		if auth.VerifyPowSolution(challenge.Challenge, nonce, challenge.Difficulty) {
+			return nonce
+		}
+	}
+}
+
+func TestRegistrationPow(t *testing.T) {
+	const ip = "203.0.113.10"
+
+	setup := func() (*authApp.AuthServiceImpl, *memoryTokenCache, *mockUserRepository) {
+		userRepo := new(mockUserRepository)
+		// Reaching the repository means the proof of work was accepted
+		userRepo.On("ExistsByEmail", mock.Anything, mock.Anything).Return(true, nil)
+
+		tokenGen := new(mockTokenGenerator)
+		tokenGen.On("HashToken", mock.Anything).Return("challenge-hash")
+
+		cache := newMemoryTokenCache()
+		config := &authApp.Config{
+			RegistrationPowIPThreshold:    2,
+			RegistrationPowWindow:         time.Hour,
+			RegistrationPowBaseDifficulty: 4,
+			RegistrationPowMaxDifficulty:  12,
+		}
+		service := authApp.NewAuthService(userRepo, new(mockSessionRepository), tokenGen, new(mockPasswordHasher), cache,
+			nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, config, logger.NewNoop())
+		return service, cache, userRepo
+	}
+
+	register := func(service *authApp.AuthServiceImpl, ip string, challenge *auth.PowChallenge) error {
+		req := &auth.RegisterRequest{
+			Email:       "player@example.com",
+			Username:    "player1",
+			Password:    "StrongPass123!",
+			AcceptTerms: true,
+			IPAddress:   ip,
+		}
+		if challenge != nil {
+			req.PowChallenge = challenge.Challenge
+			req.PowNonce = solveChallenge(challenge)
+		}
+		_, err := service.Register(context.Background(), req)
+		return err
+	}
+
+	t.Run("solved challenge from the same network is accepted", func(t *testing.T) {
+		service, cache, _ := setup()
+		cache.attempts["register:ip:"+ip] = 2
+
+		challenge, err := service.RegistrationChallenge(context.Background(), ip)
+		require.NoError(t, err)
+
+		assert.Equal(t, auth.ErrEmailAlreadyTaken, register(service, ip, challenge))
+		assert.Empty(t, cache.tokens, "challenge is single use")
+	})
+
+	t.Run("no challenge over the threshold", func(t *testing.T) {
+		service, cache, _ := setup()
+		cache.attempts["register:ip:"+ip] = 2
+
+		assert.Equal(t, auth.ErrProofOfWorkRequired, register(service, ip, nil))
+	})
+
+	t.Run("challenge from another network is rejected", func(t *testing.T) {
+		service, cache, userRepo := setup()
+		cache.attempts["register:ip:198.51.100.7"] = 2
+
+		challenge, err := service.RegistrationChallenge(context.Background(), ip)
+		require.NoError(t, err)
+
+		assert.Equal(t, auth.ErrProofOfWorkInvalid, register(service, "198.51.100.7", challenge))
+		userRepo.AssertNotCalled(t, "ExistsByEmail", mock.Anything, mock.Anything)
+	})
+
+	t.Run("challenge easier than current pressure is rejected", func(t *testing.T) {
+		service, cache, userRepo := setup()
+
+		// Issued at the base difficulty while the IP was quiet
+		challenge, err := service.RegistrationChallenge(context.Background(), ip)
+		require.NoError(t, err)
+		require.Equal(t, 4, challenge.Difficulty)
+
+		cache.attempts["register:ip:"+ip] = 8
+
+		assert.Equal(t, auth.ErrProofOfWorkInvalid, register(service, ip, challenge))
+		userRepo.AssertNotCalled(t, "ExistsByEmail", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+	"github.com/mmorpg-template/backend/internal/ports"
+)
+
+const (
+	// reconcileLockKey makes sure only one replica reconciles at a time. The lock is
+	// refreshed after every batch, so reconcileLockTTL only needs to outlast one.
+	reconcileLockKey = "character-count-reconcile"
+	reconcileLockTTL = 10 * time.Minute
+
+	// reconcileBatchSize is how many users are recounted per statement
+	reconcileBatchSize = 1000
+)
+
+// SetLocker enables distributed locks so background jobs run on one replica at a time
+func (s *AuthServiceImpl) SetLocker(locker ports.Locker) {
+	s.locker = locker
+}
+
+// SyncCharacterCount recounts a user's characters after the character service created,
+// deleted, restored or purged one. Recounting instead of adding or subtracting one keeps
+// the count exact when events are redelivered or arrive out of order.
+func (s *AuthServiceImpl) SyncCharacterCount(ctx context.Context, userID string) error {
+	if _, err := uuid.Parse(userID); err != nil {
+		return auth.ErrUserNotFound
+	}
+
+	count, err := s.userRepo.RecountCharacters(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"userID": userID,
+		"count":  count,
+	}).Debug("Synced character count")
+	return nil
+}
+
+// ReconcileCharacterCounts recounts every account's characters in batches, correcting
+// counts that drifted because events were lost while the auth service was down. Accounts
+// holding more characters than they allow are capped at their limit and logged. When
+// another replica holds the reconcile lock it does nothing.
+func (s *AuthServiceImpl) ReconcileCharacterCounts(ctx context.Context) (int, error) {
+	var lock ports.Lock
+	if s.locker != nil {
+		var err error
+		lock, err = s.locker.TryLock(ctx, reconcileLockKey, reconcileLockTTL)
+		if errors.Is(err, ports.ErrLockHeld) {
+			return 0, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to acquire reconcile lock: %w", err)
+		}
+		defer func() {
+			if err := lock.Release(context.Background()); err != nil {
+				s.logger.WithError(err).Warn("Failed to release reconcile lock")
+			}
+		}()
+	}
+
+	corrected := 0
+	afterID := ""
+	for {
+		corrections, lastID, err := s.userRepo.ReconcileCharacterCounts(ctx, afterID, reconcileBatchSize)
+		if err != nil {
+			return corrected, err
+		}
+
+		for _, correction := range corrections {
+			if correction.Previous != correction.Count {
+				corrected++
+			}
+			if correction.OverLimit() {
+				s.logger.WithFields(map[string]interface{}{
+					"userID":        correction.UserID,
+					"characters":    correction.Actual,
+					"maxCharacters": correction.MaxCharacters,
+				}).Warn("Account holds more characters than it allows")
+			}
+		}
+
+		if lastID == "" {
+			break
+		}
+		afterID = lastID
+
+		if lock != nil {
+			if err := lock.Refresh(ctx, reconcileLockTTL); err != nil {
+				// Another replica may have taken over; it starts a full pass of its own
+				s.logger.WithError(err).Warn("Lost reconcile lock, stopping")
+				break
+			}
+		}
+	}
+
+	if corrected > 0 {
+		s.logger.WithField("count", corrected).Warn("Corrected drifted character counts")
+	}
+	return corrected, nil
+}
@@ -0,0 +1,92 @@
+package auth_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	authApp "github.com/mmorpg-template/backend/internal/application/auth"
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+	"github.com/mmorpg-template/backend/internal/ports"
+	"github.com/mmorpg-template/backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// stubLocker hands out a single stubLock, or fails with err
+type stubLocker struct {
+	lock *stubLock
+	err  error
+}
+
+func (l *stubLocker) TryLock(ctx context.Context, key string, ttl time.Duration) (ports.Lock, error) {
+	if l.err != nil {
+		return nil, l.err
+	}
+	return l.lock, nil
+}
+
+type stubLock struct {
+	released bool
+}
+
+func (l *stubLock) Refresh(ctx context.Context, ttl time.Duration) error {
+	return nil
+}
+
+func (l *stubLock) Release(ctx context.Context) error {
+	l.released = true
+	return nil
+}
+
+func TestReconcileCharacterCounts(t *testing.T) {
+	setup := func(locker ports.Locker) (*authApp.AuthServiceImpl, *mockUserRepository) {
+		userRepo := new(mockUserRepository)
+		service := authApp.NewAuthService(userRepo, new(mockSessionRepository), new(mockTokenGenerator), new(mockPasswordHasher), new(mockTokenCache),
+			nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &authApp.Config{}, logger.NewNoop())
+		service.SetLocker(locker)
+		return service, userRepo
+	}
+
+	t.Run("reconciles under the lock", func(t *testing.T) {
+		lock := &stubLock{}
+		service, userRepo := setup(&stubLocker{lock: lock})
+		userRepo.On("ReconcileCharacterCounts", mock.Anything, "", mock.Anything).Return([]*auth.CharacterCountCorrection{
+			{UserID: "user-1", Previous: 1, Actual: 2, Count: 2, MaxCharacters: 5},
+		}, "user-1", nil)
+		userRepo.On("ReconcileCharacterCounts", mock.Anything, "user-1", mock.Anything).Return(nil, "user-2", nil)
+		userRepo.On("ReconcileCharacterCounts", mock.Anything, "user-2", mock.Anything).Return(nil, "", nil)
+
+		corrected, err := service.ReconcileCharacterCounts(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, corrected)
+		assert.True(t, lock.released)
+		userRepo.AssertNumberOfCalls(t, "ReconcileCharacterCounts", 3)
+	})
+
+	t.Run("accounts over their limit are capped, not counted again", func(t *testing.T) {
+		service, userRepo := setup(&stubLocker{lock: &stubLock{}})
+		userRepo.On("ReconcileCharacterCounts", mock.Anything, "", mock.Anything).Return([]*auth.CharacterCountCorrection{
+			{UserID: "user-1", Previous: 3, Actual: 7, Count: 5, MaxCharacters: 5},
+			{UserID: "user-2", Previous: 5, Actual: 7, Count: 5, MaxCharacters: 5},
+		}, "user-2", nil)
+		userRepo.On("ReconcileCharacterCounts", mock.Anything, "user-2", mock.Anything).Return(nil, "", nil)
+
+		corrected, err := service.ReconcileCharacterCounts(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, corrected)
+	})
+
+	t.Run("does nothing while another replica holds the lock", func(t *testing.T) {
+		service, userRepo := setup(&stubLocker{err: ports.ErrLockHeld})
+
+		corrected, err := service.ReconcileCharacterCounts(context.Background())
+		require.NoError(t, err)
+
+		assert.Zero(t, corrected)
+		userRepo.AssertNotCalled(t, "ReconcileCharacterCounts", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
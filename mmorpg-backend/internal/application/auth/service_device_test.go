@@ -0,0 +1,112 @@
+package auth_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	authApp "github.com/mmorpg-template/backend/internal/application/auth"
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+	portsAuth "github.com/mmorpg-template/backend/internal/ports/auth"
+	"github.com/mmorpg-template/backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// memoryKnownDevices keeps devices by key and every network they were saved with
+type memoryKnownDevices struct {
+	portsAuth.KnownDeviceRepository
+	devices  map[string]*auth.KnownDevice
+	networks map[string]bool
+}
+
+func (r *memoryKnownDevices) Get(ctx context.Context, userID, deviceKey string) (*auth.KnownDevice, error) {
+	device, ok := r.devices[deviceKey]
+	if !ok {
+		return nil, auth.ErrDeviceNotFound
+	}
+	copied := *device
+	return &copied, nil
+}
+
+func (r *memoryKnownDevices) Save(ctx context.Context, device *auth.KnownDevice) error {
+	copied := *device
+	r.devices[device.DeviceKey] = &copied
+	r.networks[device.LastNetwork] = true
+	return nil
+}
+
+func (r *memoryKnownDevices) CountByUserID(ctx context.Context, userID string) (int, error) {
+	return len(r.devices), nil
+}
+
+func (r *memoryKnownDevices) HasNetwork(ctx context.Context, userID, network string) (bool, error) {
+	return r.networks[network], nil
+}
+
+func TestLoginDeviceChecks(t *testing.T) {
+	const (
+		email    = "player@example.com"
+		password = "StrongPass123!"
+	)
+
+	setup := func(requireConfirmation bool) (*authApp.AuthServiceImpl, *memoryKnownDevices, *recordingNotifier, *auth.User) {
+		user := &auth.User{ID: uuid.New(), Email: email, Username: "player1", PasswordHash: "hashed", AccountStatus: auth.AccountStatusActive}
+		userRepo := new(mockUserRepository)
+		userRepo.On("GetByEmail", mock.Anything, email).Return(user, nil)
+		userRepo.On("Update", mock.Anything, user).Return(nil)
+
+		passHasher := new(mockPasswordHasher)
+		passHasher.On("ComparePassword", "hashed", password).Return(nil)
+
+		sessionRepo := new(mockSessionRepository)
+		sessionRepo.On("CountByUserID", mock.Anything, user.ID.String()).Return(0, nil)
+		sessionRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+		tokenGen := new(mockTokenGenerator)
+		tokenGen.On("GenerateTokenPair", mock.Anything, user, mock.Anything, mock.Anything).Return(&auth.TokenPair{RefreshToken: "refresh"}, nil)
+		tokenGen.On("HashToken", mock.Anything).Return("hash")
+
+		cache := newMemoryTokenCache()
+		cache.mockTokenCache.On("DeleteLoginAttempts", mock.Anything, mock.Anything).Return(nil)
+
+		devices := &memoryKnownDevices{devices: map[string]*auth.KnownDevice{}, networks: map[string]bool{}}
+		notifier := &recordingNotifier{}
+		config := &authApp.Config{MaxLoginAttempts: 100, MaxSessionsPerUser: 10, RequireNewDeviceConfirmation: requireConfirmation}
+		service := authApp.NewAuthService(userRepo, sessionRepo, tokenGen, passHasher, cache,
+			nil, devices, notifier, nil, nil, nil, nil, nil, nil, nil, nil, config, logger.NewNoop())
+		return service, devices, notifier, user
+	}
+
+	login := func(service *authApp.AuthServiceImpl, deviceID, ip string) error {
+		_, _, err := service.Login(context.Background(), email, password, deviceID, ip, "TestAgent")
+		return err
+	}
+
+	t.Run("unconfirmed device stays untrusted", func(t *testing.T) {
+		service, devices, notifier, user := setup(true)
+		devices.Save(context.Background(), auth.NewKnownDevice(user.ID, "first", "203.0.113.10", "TestAgent"))
+		// Seen before confirmation was required, so never confirmed
+		devices.Save(context.Background(), auth.NewKnownDevice(user.ID, "second", "203.0.113.10", "TestAgent"))
+
+		assert.Equal(t, auth.ErrDeviceNotConfirmed, login(service, "second", "203.0.113.10"))
+		assert.Equal(t, auth.ErrDeviceNotConfirmed, login(service, "second", "203.0.113.10"))
+		if assert.NotEmpty(t, notifier.sent) {
+			assert.Equal(t, auth.NotificationDeviceConfirmation, notifier.sent[0].Kind)
+		}
+	})
+
+	t.Run("alternating between known networks raises no alert", func(t *testing.T) {
+		service, _, notifier, _ := setup(false)
+
+		assert.NoError(t, login(service, "laptop", "203.0.113.10"))
+		assert.NoError(t, login(service, "laptop", "198.51.100.7"))
+		if assert.Len(t, notifier.sent, 1) {
+			assert.Equal(t, auth.NotificationNewNetworkLogin, notifier.sent[0].Kind)
+		}
+
+		assert.NoError(t, login(service, "laptop", "203.0.113.20"))
+		assert.NoError(t, login(service, "laptop", "198.51.100.7"))
+		assert.Len(t, notifier.sent, 1)
+	})
+}
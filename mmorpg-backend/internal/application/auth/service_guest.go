@@ -0,0 +1,238 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"time"
+
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+)
+
+// guestCleanupBatchSize bounds how many idle guests are deleted per statement
+const guestCleanupBatchSize = 500
+
+// GuestLogin logs in the guest account bound to a device, creating it on first use. The
+// device must present the secret it was issued when the guest was created; a newly issued
+// secret is returned and must be stored by the device, as it is never shown again.
+func (s *AuthServiceImpl) GuestLogin(ctx context.Context, deviceID, deviceSecret, ipAddress, userAgent string) (*auth.TokenPair, *auth.User, string, error) {
+	if deviceID == "" {
+		return nil, nil, "", auth.ErrGuestDeviceRequired
+	}
+
+	var issuedSecret string
+	user, err := s.userRepo.GetGuestByDeviceID(ctx, deviceID)
+	switch err {
+	case nil:
+		if user.GuestSecretHash == "" {
+			// Created before device secrets; the first device to log in claims it
+			issuedSecret, err = s.issueGuestSecret(user)
+			if err != nil {
+				return nil, nil, "", err
+			}
+			if err := s.userRepo.Update(ctx, user); err != nil {
+				return nil, nil, "", fmt.Errorf("failed to save guest secret: %w", err)
+			}
+		} else if subtle.ConstantTimeCompare([]byte(user.GuestSecretHash), []byte(s.tokenGenerator.HashToken(deviceSecret))) != 1 {
+			s.recordLoginFailure(ctx, user.ID.String(), "", deviceID, ipAddress, userAgent, "invalid_device_secret")
+			return nil, nil, "", auth.ErrInvalidCredentials
+		}
+		if !user.IsActive() {
+			s.recordLoginFailure(ctx, user.ID.String(), "", deviceID, ipAddress, userAgent, "guest_not_active")
+			return nil, nil, "", auth.ErrAccountNotActive
+		}
+	case auth.ErrUserNotFound:
+		user, issuedSecret, err = s.createGuest(ctx, deviceID, ipAddress)
+		if err != nil {
+			return nil, nil, "", err
+		}
+	default:
+		s.logger.WithError(err).Error("Failed to get guest by device")
+		return nil, nil, "", fmt.Errorf("failed to get guest: %w", err)
+	}
+
+	tokenPair, sessionID, err := s.issueSession(ctx, user, deviceID, ipAddress, userAgent)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	event := auth.NewSecurityEvent(auth.SecurityEventLoginSuccess, auth.SecurityOutcomeSuccess, user.ID.String())
+	event.ActorID = user.ID.String()
+	event.SessionID = sessionID
+	event.DeviceID = deviceID
+	event.IPAddress = ipAddress
+	event.UserAgent = userAgent
+	event.Metadata["guest"] = "true"
+	s.recordSecurityEvent(ctx, event)
+
+	s.recordLogin(ctx, loginAttempt{
+		userID:    user.ID.String(),
+		sessionID: sessionID,
+		kind:      auth.LoginKindGuest,
+		result:    auth.LoginResultSuccess,
+		deviceID:  deviceID,
+		ipAddress: ipAddress,
+		userAgent: userAgent,
+	})
+
+	s.logger.WithFields(map[string]interface{}{
+		"userID":    user.ID,
+		"sessionID": sessionID,
+		"deviceID":  deviceID,
+	}).Info("Guest logged in successfully")
+
+	return tokenPair, user, issuedSecret, nil
+}
+
+// createGuest creates a guest account for a device, rate limited per IP, returning it with
+// the device secret it was issued
+func (s *AuthServiceImpl) createGuest(ctx context.Context, deviceID, ipAddress string) (*auth.User, string, error) {
+	if s.config.GuestCreationLimit > 0 {
+		created, err := s.tokenCache.IncrementLoginAttempts(ctx, fmt.Sprintf("guest:%s", ipAddress), s.config.GuestCreationWindow)
+		if err != nil {
+			s.logger.WithError(err).Error("Failed to check guest creation rate")
+		}
+		if created > s.config.GuestCreationLimit {
+			return nil, "", auth.ErrTooManyAttempts
+		}
+	}
+
+	user := auth.NewGuestUser(deviceID)
+	secret, err := s.issueGuestSecret(user)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := s.userRepo.Create(ctx, user); err != nil {
+		s.logger.WithError(err).Error("Failed to create guest")
+		return nil, "", err
+	}
+
+	event := auth.NewSecurityEvent(auth.SecurityEventGuestCreated, auth.SecurityOutcomeSuccess, user.ID.String())
+	event.DeviceID = deviceID
+	event.IPAddress = ipAddress
+	s.recordSecurityEvent(ctx, event)
+
+	s.logger.WithField("userID", user.ID).Info("Guest account created")
+	return user, secret, nil
+}
+
+// issueGuestSecret generates a new device secret for a guest, keeping only its hash
+func (s *AuthServiceImpl) issueGuestSecret(user *auth.User) (string, error) {
+	secret, err := generateVerificationToken()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate device secret: %w", err)
+	}
+	user.GuestSecretHash = s.tokenGenerator.HashToken(secret)
+	return secret, nil
+}
+
+// UpgradeGuest converts a guest into a full account in place, keeping its user ID and characters
+func (s *AuthServiceImpl) UpgradeGuest(ctx context.Context, userID string, req *auth.RegisterRequest) (*auth.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsGuest {
+		return nil, auth.ErrNotGuestAccount
+	}
+
+	// Same rules as Register
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.screenPassword(ctx, req.Password); err != nil {
+		return nil, err
+	}
+
+	emailExists, err := s.userRepo.ExistsByEmail(ctx, req.Email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check email: %w", err)
+	}
+	if emailExists {
+		return nil, auth.ErrEmailAlreadyTaken
+	}
+
+	usernameExists, err := s.userRepo.ExistsByUsername(ctx, req.Username)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check username: %w", err)
+	}
+	if usernameExists || s.isUsernameHeld(ctx, req.Username, userID) {
+		return nil, auth.ErrUsernameAlreadyTaken
+	}
+
+	passwordHash, err := s.passwordHasher.HashPassword(req.Password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	deviceID := user.GuestDeviceID
+	user.UpgradeFromGuest(req.Email, req.Username, passwordHash)
+
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+
+	// The guest device becomes the account's first trusted device
+	if s.knownDevices != nil && deviceID != "" {
+		device := auth.NewKnownDevice(user.ID, deviceID, "", "")
+		device.Confirmed = true
+		if err := s.knownDevices.Save(ctx, device); err != nil {
+			s.logger.WithError(err).Warn("Failed to save upgraded guest device")
+		}
+	}
+
+	event := auth.NewSecurityEvent(auth.SecurityEventGuestUpgraded, auth.SecurityOutcomeSuccess, userID)
+	event.DeviceID = deviceID
+	s.recordSecurityEvent(ctx, event)
+
+	s.logger.WithField("userID", userID).Info("Guest upgraded to full account")
+	return user, nil
+}
+
+// CleanupIdleGuests deletes guests that haven't logged in within the idle timeout
+func (s *AuthServiceImpl) CleanupIdleGuests(ctx context.Context) (int, error) {
+	if s.config.GuestIdleTimeout <= 0 {
+		return 0, nil
+	}
+
+	idleSince := time.Now().Add(-s.config.GuestIdleTimeout)
+	total := 0
+	for {
+		guests, err := s.userRepo.DeleteIdleGuests(ctx, idleSince, guestCleanupBatchSize)
+		if err != nil {
+			return total, err
+		}
+
+		for _, guest := range guests {
+			event := auth.NewSecurityEvent(auth.SecurityEventGuestDeleted, auth.SecurityOutcomeSuccess, guest.UserID)
+			event.Reason = "idle"
+			s.recordSecurityEvent(ctx, event)
+			s.publishGuestDeleted(ctx, guest)
+		}
+
+		total += len(guests)
+		if len(guests) < guestCleanupBatchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		s.logger.WithField("count", total).Info("Deleted idle guest accounts")
+	}
+	return total, nil
+}
+
+// publishGuestDeleted tells the character service to drop what it holds for the characters
+// that were deleted with a guest
+func (s *AuthServiceImpl) publishGuestDeleted(ctx context.Context, guest *auth.DeletedGuest) {
+	if s.eventPublisher == nil {
+		return
+	}
+	event := &auth.GuestDeletedEvent{
+		BaseEvent:  auth.BaseEvent{UserID: guest.UserID},
+		Characters: guest.Characters,
+	}
+	if err := s.eventPublisher.PublishGuestDeleted(ctx, event); err != nil {
+		s.logger.WithError(err).WithField("userID", guest.UserID).Warn("Failed to publish guest deleted event")
+	}
+}
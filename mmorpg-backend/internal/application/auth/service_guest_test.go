@@ -0,0 +1,117 @@
+package auth_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	authApp "github.com/mmorpg-template/backend/internal/application/auth"
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+	portsAuth "github.com/mmorpg-template/backend/internal/ports/auth"
+	"github.com/mmorpg-template/backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingEventPublisher collects published guest deleted events
+type recordingEventPublisher struct {
+	portsAuth.EventPublisher
+	guestsDeleted []*auth.GuestDeletedEvent
+}
+
+func (p *recordingEventPublisher) PublishGuestDeleted(ctx context.Context, event *auth.GuestDeletedEvent) error {
+	p.guestsDeleted = append(p.guestsDeleted, event)
+	return nil
+}
+
+func TestGuestLogin(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(existing *auth.User) (*authApp.AuthServiceImpl, *mockUserRepository) {
+		userRepo := new(mockUserRepository)
+		if existing != nil {
+			userRepo.On("GetGuestByDeviceID", mock.Anything, existing.GuestDeviceID).Return(existing, nil)
+		}
+		userRepo.On("GetGuestByDeviceID", mock.Anything, mock.Anything).Return(nil, auth.ErrUserNotFound)
+		userRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+		userRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+		sessionRepo := new(mockSessionRepository)
+		sessionRepo.On("CountByUserID", mock.Anything, mock.Anything).Return(0, nil)
+		sessionRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+		tokenGen := new(mockTokenGenerator)
+		tokenGen.On("GenerateTokenPair", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&auth.TokenPair{RefreshToken: "refresh"}, nil)
+		tokenGen.On("HashToken", "device-secret").Return("device-secret-hash")
+		tokenGen.On("HashToken", mock.Anything).Return("other-hash")
+
+		config := &authApp.Config{MaxSessionsPerUser: 10}
+		service := authApp.NewAuthService(userRepo, sessionRepo, tokenGen, new(mockPasswordHasher), newMemoryTokenCache(),
+			nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, config, logger.NewNoop())
+		return service, userRepo
+	}
+
+	t.Run("new device is issued a secret", func(t *testing.T) {
+		service, userRepo := setup(nil)
+
+		_, user, secret, err := service.GuestLogin(ctx, "device-1", "", "203.0.113.10", "TestAgent")
+		require.NoError(t, err)
+
+		assert.NotEmpty(t, secret)
+		assert.Equal(t, "other-hash", user.GuestSecretHash)
+		userRepo.AssertCalled(t, "Create", mock.Anything, user)
+	})
+
+	t.Run("known device must present its secret", func(t *testing.T) {
+		guest := auth.NewGuestUser("device-1")
+		guest.GuestSecretHash = "device-secret-hash"
+		service, _ := setup(guest)
+
+		_, _, _, err := service.GuestLogin(ctx, "device-1", "", "203.0.113.10", "TestAgent")
+		assert.Equal(t, auth.ErrInvalidCredentials, err)
+		_, _, _, err = service.GuestLogin(ctx, "device-1", "guessed", "203.0.113.10", "TestAgent")
+		assert.Equal(t, auth.ErrInvalidCredentials, err)
+
+		_, user, secret, err := service.GuestLogin(ctx, "device-1", "device-secret", "203.0.113.10", "TestAgent")
+		require.NoError(t, err)
+		assert.Equal(t, guest.ID, user.ID)
+		assert.Empty(t, secret)
+	})
+
+	t.Run("guest without a secret is issued one", func(t *testing.T) {
+		guest := auth.NewGuestUser("device-1")
+		service, userRepo := setup(guest)
+
+		_, _, secret, err := service.GuestLogin(ctx, "device-1", "", "203.0.113.10", "TestAgent")
+		require.NoError(t, err)
+
+		assert.NotEmpty(t, secret)
+		assert.Equal(t, "other-hash", guest.GuestSecretHash)
+		userRepo.AssertCalled(t, "Update", mock.Anything, guest)
+	})
+}
+
+func TestCleanupIdleGuests(t *testing.T) {
+	deleted := []*auth.DeletedGuest{
+		{UserID: "guest-1", Characters: []auth.DeletedCharacter{{ID: "character-1", Name: "Hero"}}},
+		{UserID: "guest-2"},
+	}
+	userRepo := new(mockUserRepository)
+	userRepo.On("DeleteIdleGuests", mock.Anything, mock.Anything, mock.Anything).Return(deleted, nil)
+
+	publisher := &recordingEventPublisher{}
+	config := &authApp.Config{GuestIdleTimeout: 24 * time.Hour}
+	service := authApp.NewAuthService(userRepo, new(mockSessionRepository), new(mockTokenGenerator), new(mockPasswordHasher), newMemoryTokenCache(),
+		nil, nil, nil, nil, nil, publisher, nil, nil, nil, nil, nil, config, logger.NewNoop())
+
+	count, err := service.CleanupIdleGuests(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	if assert.Len(t, publisher.guestsDeleted, 2) {
+		assert.Equal(t, "guest-1", publisher.guestsDeleted[0].UserID)
+		assert.Equal(t, deleted[0].Characters, publisher.guestsDeleted[0].Characters)
+		assert.Equal(t, "guest-2", publisher.guestsDeleted[1].UserID)
+	}
+}
@@ -0,0 +1,341 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+)
+
+// ChangeUsername renames a user, subject to a cooldown. The old name stays reserved
+// for the user for the hold period so it can't be sniped by someone else.
+func (s *AuthServiceImpl) ChangeUsername(ctx context.Context, userID, newUsername string) (*auth.User, error) {
+	if !isValidUsername(newUsername) {
+		return nil, auth.ErrInvalidUsername
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsGuest {
+		// Guests get a generated name; they choose one when they upgrade
+		return nil, auth.ErrGuestNotAllowed
+	}
+	if user.Username == newUsername {
+		return nil, auth.ErrUsernameUnchanged
+	}
+
+	if s.identities != nil && s.config.UsernameChangeCooldown > 0 {
+		last, err := s.identities.LastChange(ctx, userID, auth.IdentityFieldUsername)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check username history: %w", err)
+		}
+		if last != nil && time.Since(last.ChangedAt) < s.config.UsernameChangeCooldown {
+			return nil, auth.ErrUsernameChangeTooSoon
+		}
+	}
+
+	// Case-only changes keep the same name and don't need an availability check
+	if !strings.EqualFold(user.Username, newUsername) {
+		exists, err := s.userRepo.ExistsByUsername(ctx, newUsername)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check username: %w", err)
+		}
+		if exists || s.isUsernameHeld(ctx, newUsername, userID) {
+			return nil, auth.ErrUsernameAlreadyTaken
+		}
+	}
+
+	oldUsername := user.Username
+	user.Username = newUsername
+	user.UpdatedAt = time.Now()
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	change := auth.NewIdentityChange(user.ID, auth.IdentityFieldUsername, oldUsername, newUsername)
+	if s.config.UsernameHoldPeriod > 0 {
+		holdUntil := change.ChangedAt.Add(s.config.UsernameHoldPeriod)
+		change.HoldUntil = &holdUntil
+	}
+	s.recordIdentityChange(ctx, change)
+
+	event := auth.NewSecurityEvent(auth.SecurityEventUsernameChanged, auth.SecurityOutcomeSuccess, userID)
+	event.Metadata["old_username"] = oldUsername
+	event.Metadata["new_username"] = newUsername
+	s.recordSecurityEvent(ctx, event)
+
+	notification := auth.NewNotification(auth.NotificationUsernameChanged, user)
+	notification.Data["old_username"] = oldUsername
+	s.notify(ctx, notification)
+
+	if s.eventPublisher != nil {
+		published := &auth.UsernameChangedEvent{
+			BaseEvent:   auth.BaseEvent{UserID: userID},
+			OldUsername: oldUsername,
+			NewUsername: newUsername,
+		}
+		if err := s.eventPublisher.PublishUsernameChanged(ctx, published); err != nil {
+			s.logger.WithError(err).Warn("Failed to publish username changed event")
+		}
+	}
+
+	s.logger.WithField("userID", userID).Info("Username changed")
+	return user, nil
+}
+
+// RequestEmailChange starts an email change. Both the current and the new address
+// receive a confirmation link; the change applies once both have been confirmed.
+func (s *AuthServiceImpl) RequestEmailChange(ctx context.Context, userID, newEmail, currentPassword string) error {
+	if !isValidEmail(newEmail) {
+		return auth.ErrInvalidEmail
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.IsGuest {
+		return auth.ErrGuestNotAllowed
+	}
+	if strings.EqualFold(user.Email, newEmail) {
+		return auth.ErrEmailUnchanged
+	}
+
+	if err := s.passwordHasher.ComparePassword(user.PasswordHash, currentPassword); err != nil {
+		return auth.ErrPasswordMismatch
+	}
+
+	exists, err := s.userRepo.ExistsByEmail(ctx, newEmail)
+	if err != nil {
+		return fmt.Errorf("failed to check email: %w", err)
+	}
+	if exists {
+		return auth.ErrEmailAlreadyTaken
+	}
+
+	pending := &auth.PendingEmailChange{
+		ChangeID:    uuid.New().String(),
+		UserID:      userID,
+		OldEmail:    user.Email,
+		NewEmail:    newEmail,
+		RequestedAt: time.Now(),
+	}
+
+	if err := s.savePendingEmailChange(ctx, pending); err != nil {
+		return err
+	}
+
+	oldToken, err := s.issueEmailChangeToken(ctx, auth.TokenPurposeEmailChangeOld, pending)
+	if err != nil {
+		return err
+	}
+	newToken, err := s.issueEmailChangeToken(ctx, auth.TokenPurposeEmailChangeNew, pending)
+	if err != nil {
+		return err
+	}
+
+	toOld := auth.NewNotification(auth.NotificationEmailChangeOld, user)
+	toOld.Data["token"] = oldToken
+	toOld.Data["new_email"] = newEmail
+	s.notify(ctx, toOld)
+
+	toNew := auth.NewNotification(auth.NotificationEmailChangeNew, user)
+	toNew.Email = newEmail
+	toNew.Data["token"] = newToken
+	s.notify(ctx, toNew)
+
+	event := auth.NewSecurityEvent(auth.SecurityEventEmailChangeReq, auth.SecurityOutcomeSuccess, userID)
+	event.Metadata["new_email"] = newEmail
+	s.recordSecurityEvent(ctx, event)
+
+	s.logger.WithField("userID", userID).Info("Email change requested")
+	return nil
+}
+
+// ConfirmEmailChange confirms one side of a pending email change. It reports
+// whether the change was applied, which happens once both addresses have confirmed.
+func (s *AuthServiceImpl) ConfirmEmailChange(ctx context.Context, token string) (bool, error) {
+	tokenHash := s.tokenGenerator.HashToken(token)
+
+	var (
+		value          string
+		purpose, other auth.TokenPurpose
+	)
+	for _, sides := range [][2]auth.TokenPurpose{
+		{auth.TokenPurposeEmailChangeOld, auth.TokenPurposeEmailChangeNew},
+		{auth.TokenPurposeEmailChangeNew, auth.TokenPurposeEmailChangeOld},
+	} {
+		v, err := s.tokenCache.GetVerificationToken(ctx, sides[0], tokenHash)
+		if err == nil {
+			value, purpose, other = v, sides[0], sides[1]
+			break
+		}
+	}
+	userID, changeID, found := strings.Cut(value, ":")
+	if !found {
+		return false, auth.ErrInvalidToken
+	}
+
+	// Tokens are single use
+	_ = s.tokenCache.DeleteVerificationToken(ctx, purpose, tokenHash)
+
+	pending, err := s.getPendingEmailChange(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	if pending.ChangeID != changeID {
+		// Superseded by a newer request
+		return false, auth.ErrInvalidToken
+	}
+
+	// Both addresses may confirm at the same moment, so the sides are marked atomically and
+	// only the confirmation that completes the pair applies the change
+	ttl := auth.EmailChangeTokenDuration - time.Since(pending.RequestedAt)
+	if ttl <= 0 {
+		return false, auth.ErrTokenExpired
+	}
+	complete, err := s.tokenCache.ConfirmSide(ctx, auth.TokenPurposeEmailChange, pending.ChangeID, string(purpose), string(other), ttl)
+	if err != nil {
+		return false, err
+	}
+	if !complete {
+		return false, nil
+	}
+
+	_ = s.tokenCache.DeleteVerificationToken(ctx, auth.TokenPurposeEmailChange, userID)
+	if err := s.applyEmailChange(ctx, pending); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ListIdentityHistory returns a user's username and email changes
+func (s *AuthServiceImpl) ListIdentityHistory(ctx context.Context, userID string) ([]*auth.IdentityChange, error) {
+	if s.identities == nil {
+		return nil, nil
+	}
+	return s.identities.ListByUserID(ctx, userID)
+}
+
+func (s *AuthServiceImpl) applyEmailChange(ctx context.Context, pending *auth.PendingEmailChange) error {
+	user, err := s.userRepo.GetByID(ctx, pending.UserID)
+	if err != nil {
+		return err
+	}
+
+	// The account may have changed since the request was made
+	if !strings.EqualFold(user.Email, pending.OldEmail) {
+		return auth.ErrInvalidToken
+	}
+	exists, err := s.userRepo.ExistsByEmail(ctx, pending.NewEmail)
+	if err != nil {
+		return fmt.Errorf("failed to check email: %w", err)
+	}
+	if exists {
+		return auth.ErrEmailAlreadyTaken
+	}
+
+	user.Email = pending.NewEmail
+	user.EmailVerified = true // The new address confirmed ownership
+	user.UpdatedAt = time.Now()
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+
+	s.recordIdentityChange(ctx, auth.NewIdentityChange(user.ID, auth.IdentityFieldEmail, pending.OldEmail, pending.NewEmail))
+
+	event := auth.NewSecurityEvent(auth.SecurityEventEmailChanged, auth.SecurityOutcomeSuccess, pending.UserID)
+	event.Metadata["old_email"] = pending.OldEmail
+	event.Metadata["new_email"] = pending.NewEmail
+	s.recordSecurityEvent(ctx, event)
+
+	// Warn the old address in case the change wasn't expected
+	notification := auth.NewNotification(auth.NotificationEmailChanged, user)
+	notification.Email = pending.OldEmail
+	s.notify(ctx, notification)
+
+	if s.eventPublisher != nil {
+		published := &auth.EmailChangedEvent{BaseEvent: auth.BaseEvent{UserID: pending.UserID}}
+		if err := s.eventPublisher.PublishEmailChanged(ctx, published); err != nil {
+			s.logger.WithError(err).Warn("Failed to publish email changed event")
+		}
+	}
+
+	s.logger.WithField("userID", pending.UserID).Info("Email changed")
+	return nil
+}
+
+// issueEmailChangeToken creates a one-time token for one side of an email change
+func (s *AuthServiceImpl) issueEmailChangeToken(ctx context.Context, purpose auth.TokenPurpose, pending *auth.PendingEmailChange) (string, error) {
+	token, err := generateVerificationToken()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate email change token: %w", err)
+	}
+
+	tokenHash := s.tokenGenerator.HashToken(token)
+	value := pending.UserID + ":" + pending.ChangeID
+	if err := s.tokenCache.SetVerificationToken(ctx, purpose, tokenHash, value, auth.EmailChangeTokenDuration); err != nil {
+		return "", fmt.Errorf("failed to store email change token: %w", err)
+	}
+	return token, nil
+}
+
+// savePendingEmailChange stores the pending change keyed by user; a new request
+// replaces the previous one
+func (s *AuthServiceImpl) savePendingEmailChange(ctx context.Context, pending *auth.PendingEmailChange) error {
+	payload, err := json.Marshal(pending)
+	if err != nil {
+		return fmt.Errorf("failed to encode email change: %w", err)
+	}
+
+	ttl := auth.EmailChangeTokenDuration - time.Since(pending.RequestedAt)
+	if ttl <= 0 {
+		return auth.ErrTokenExpired
+	}
+	if err := s.tokenCache.SetVerificationToken(ctx, auth.TokenPurposeEmailChange, pending.UserID, string(payload), ttl); err != nil {
+		return fmt.Errorf("failed to store email change: %w", err)
+	}
+	return nil
+}
+
+func (s *AuthServiceImpl) getPendingEmailChange(ctx context.Context, userID string) (*auth.PendingEmailChange, error) {
+	payload, err := s.tokenCache.GetVerificationToken(ctx, auth.TokenPurposeEmailChange, userID)
+	if err != nil {
+		return nil, auth.ErrInvalidToken
+	}
+
+	var pending auth.PendingEmailChange
+	if err := json.Unmarshal([]byte(payload), &pending); err != nil {
+		return nil, auth.ErrInvalidToken
+	}
+	return &pending, nil
+}
+
+// recordIdentityChange stores a history record. Failures are logged and never block the change.
+func (s *AuthServiceImpl) recordIdentityChange(ctx context.Context, change *auth.IdentityChange) {
+	if s.identities == nil {
+		return
+	}
+	if err := s.identities.Record(ctx, change); err != nil {
+		s.logger.WithError(err).WithField("field", change.Field).Warn("Failed to record identity change")
+	}
+}
+
+// isUsernameHeld reports whether a released username is reserved for another account
+func (s *AuthServiceImpl) isUsernameHeld(ctx context.Context, username, exceptUserID string) bool {
+	if s.identities == nil {
+		return false
+	}
+	held, err := s.identities.IsUsernameHeld(ctx, username, exceptUserID, time.Now())
+	if err != nil {
+		s.logger.WithError(err).Warn("Failed to check username hold")
+		return false
+	}
+	return held
+}
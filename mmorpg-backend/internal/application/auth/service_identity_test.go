@@ -0,0 +1,71 @@
+package auth_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	authApp "github.com/mmorpg-template/backend/internal/application/auth"
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+	"github.com/mmorpg-template/backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func (c *memoryTokenCache) ConfirmSide(ctx context.Context, purpose auth.TokenPurpose, id string, side, otherSide string, expiration time.Duration) (bool, error) {
+	c.confirmed[id+":"+side] = true
+	if !c.confirmed[id+":"+otherSide] {
+		return false, nil
+	}
+	delete(c.confirmed, id+":"+side)
+	delete(c.confirmed, id+":"+otherSide)
+	return true, nil
+}
+
+func TestConfirmEmailChange(t *testing.T) {
+	ctx := context.Background()
+	user := &auth.User{ID: uuid.New(), Email: "old@example.com", Username: "player1", AccountStatus: auth.AccountStatusActive}
+	pending := &auth.PendingEmailChange{
+		ChangeID:    "change-1",
+		UserID:      user.ID.String(),
+		OldEmail:    "old@example.com",
+		NewEmail:    "new@example.com",
+		RequestedAt: time.Now(),
+	}
+
+	userRepo := new(mockUserRepository)
+	userRepo.On("GetByID", mock.Anything, user.ID.String()).Return(user, nil)
+	userRepo.On("ExistsByEmail", mock.Anything, "new@example.com").Return(false, nil)
+	userRepo.On("Update", mock.Anything, user).Return(nil)
+
+	tokenGen := new(mockTokenGenerator)
+	tokenGen.On("HashToken", "old-token").Return("old-hash")
+	tokenGen.On("HashToken", "new-token").Return("new-hash")
+
+	cache := newMemoryTokenCache()
+	payload, err := json.Marshal(pending)
+	require.NoError(t, err)
+	cache.tokens["email_change:"+user.ID.String()] = string(payload)
+	cache.tokens["email_change_old:old-hash"] = user.ID.String() + ":change-1"
+	cache.tokens["email_change_new:new-hash"] = user.ID.String() + ":change-1"
+
+	service := authApp.NewAuthService(userRepo, new(mockSessionRepository), tokenGen, new(mockPasswordHasher), cache,
+		nil, nil, &recordingNotifier{}, nil, nil, nil, nil, nil, nil, nil, nil, &authApp.Config{}, logger.NewNoop())
+
+	applied, err := service.ConfirmEmailChange(ctx, "new-token")
+	require.NoError(t, err)
+	assert.False(t, applied)
+	assert.Equal(t, "old@example.com", user.Email)
+
+	// The new side's token is spent
+	_, err = service.ConfirmEmailChange(ctx, "new-token")
+	assert.Equal(t, auth.ErrInvalidToken, err)
+
+	applied, err = service.ConfirmEmailChange(ctx, "old-token")
+	require.NoError(t, err)
+	assert.True(t, applied)
+	assert.Equal(t, "new@example.com", user.Email)
+}
@@ -0,0 +1,96 @@
+package auth_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	authApp "github.com/mmorpg-template/backend/internal/application/auth"
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+	"github.com/mmorpg-template/backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// recordingNotifier collects sent notifications
+type recordingNotifier struct {
+	sent []*auth.Notification
+}
+
+func (n *recordingNotifier) Notify(ctx context.Context, notification *auth.Notification) error {
+	n.sent = append(n.sent, notification)
+	return nil
+}
+
+func (c *memoryTokenCache) SetAccountLock(ctx context.Context, userID string, lockedUntil time.Time) error {
+	c.locks[userID] = lockedUntil
+	return nil
+}
+
+func (c *memoryTokenCache) GetAccountLock(ctx context.Context, userID string) (time.Time, error) {
+	return c.locks[userID], nil
+}
+
+func TestLoginLockout(t *testing.T) {
+	const (
+		email    = "player@example.com"
+		password = "StrongPass123!"
+		ip       = "203.0.113.10"
+	)
+
+	setup := func() (*authApp.AuthServiceImpl, *memoryTokenCache, *recordingNotifier, *auth.User) {
+		user := &auth.User{ID: uuid.New(), Email: email, Username: "player1", PasswordHash: "hashed", AccountStatus: auth.AccountStatusActive}
+		userRepo := new(mockUserRepository)
+		userRepo.On("GetByEmail", mock.Anything, email).Return(user, nil)
+		userRepo.On("GetByEmail", mock.Anything, mock.Anything).Return(nil, auth.ErrUserNotFound)
+
+		passHasher := new(mockPasswordHasher)
+		passHasher.On("ComparePassword", "hashed", password).Return(nil)
+		passHasher.On("ComparePassword", "hashed", mock.Anything).Return(auth.ErrPasswordMismatch)
+
+		tokenGen := new(mockTokenGenerator)
+		tokenGen.On("HashToken", mock.Anything).Return("unlock-hash")
+
+		cache := newMemoryTokenCache()
+		notifier := &recordingNotifier{}
+		config := &authApp.Config{
+			MaxLoginAttempts:        100,
+			AccountLockoutThreshold: 3,
+			AccountLockoutWindow:    time.Hour,
+			AccountLockoutBase:      time.Minute,
+			AccountLockoutMax:       time.Hour,
+		}
+		service := authApp.NewAuthService(userRepo, new(mockSessionRepository), tokenGen, passHasher, cache,
+			nil, nil, notifier, nil, nil, nil, nil, nil, nil, nil, nil, config, logger.NewNoop())
+		return service, cache, notifier, user
+	}
+
+	login := func(service *authApp.AuthServiceImpl, email, password string) error {
+		_, _, err := service.Login(context.Background(), email, password, "device", ip, "TestAgent")
+		return err
+	}
+
+	t.Run("locking failure looks like any other and emails an unlock link", func(t *testing.T) {
+		service, cache, notifier, user := setup()
+
+		for i := 0; i < 3; i++ {
+			assert.Equal(t, auth.ErrInvalidCredentials, login(service, email, "wrong"))
+		}
+
+		assert.True(t, time.Now().Before(cache.locks[user.ID.String()]))
+		if assert.Len(t, notifier.sent, 1) {
+			assert.Equal(t, auth.NotificationAccountUnlock, notifier.sent[0].Kind)
+			assert.NotEmpty(t, notifier.sent[0].Data["token"])
+		}
+	})
+
+	t.Run("locked account answers a wrong password like an unknown email", func(t *testing.T) {
+		service, cache, _, user := setup()
+		cache.locks[user.ID.String()] = time.Now().Add(time.Hour)
+
+		assert.Equal(t, auth.ErrInvalidCredentials, login(service, "nobody@example.com", "wrong"))
+		assert.Equal(t, auth.ErrInvalidCredentials, login(service, email, "wrong"))
+		assert.Equal(t, auth.ErrAccountLocked, login(service, email, password))
+	})
+}
@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+)
+
+// loginAttempt describes a login or refresh to be written to the user's history
+type loginAttempt struct {
+	userID    string
+	sessionID string
+	kind      auth.LoginKind
+	result    string
+	deviceID  string
+	ipAddress string
+	userAgent string
+}
+
+// ListLoginHistory retrieves a page of a user's login history, newest first
+func (s *AuthServiceImpl) ListLoginHistory(ctx context.Context, filter *auth.LoginHistoryFilter) ([]*auth.LoginRecord, error) {
+	if s.loginHistory == nil {
+		return []*auth.LoginRecord{}, nil
+	}
+	if _, err := uuid.Parse(filter.UserID); err != nil {
+		return nil, auth.ErrUserNotFound
+	}
+	return s.loginHistory.List(ctx, filter)
+}
+
+// PurgeLoginHistory deletes login records older than the configured retention
+func (s *AuthServiceImpl) PurgeLoginHistory(ctx context.Context) (int64, error) {
+	if s.loginHistory == nil || s.config.LoginHistoryRetention <= 0 {
+		return 0, nil
+	}
+
+	deleted, err := s.loginHistory.DeleteBefore(ctx, time.Now().Add(-s.config.LoginHistoryRetention))
+	if err != nil {
+		return 0, err
+	}
+	if deleted > 0 {
+		s.logger.WithField("count", deleted).Info("Purged expired login history")
+	}
+	return deleted, nil
+}
+
+// recordLogin appends a login history entry for a known account.
+// Failures are logged and never block the login itself.
+func (s *AuthServiceImpl) recordLogin(ctx context.Context, attempt loginAttempt) {
+	if s.loginHistory == nil {
+		return
+	}
+
+	userID, err := uuid.Parse(attempt.userID)
+	if err != nil {
+		// Attempts against unknown accounts only go to the security log
+		return
+	}
+
+	record := auth.NewLoginRecord(userID, attempt.kind, attempt.result)
+	record.SessionID = attempt.sessionID
+	record.DeviceID = attempt.deviceID
+	record.IPAddress = attempt.ipAddress
+	record.UserAgent = attempt.userAgent
+	if s.geoLocator != nil {
+		if location := s.geoLocator.Locate(attempt.ipAddress); location != nil {
+			record.Location = *location
+		}
+	}
+
+	if err := s.loginHistory.Append(ctx, record); err != nil {
+		s.logger.WithError(err).WithField("userID", attempt.userID).Warn("Failed to record login history")
+	}
+}
@@ -0,0 +1,527 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+)
+
+// RequestGuardianLink emails the owner of wardEmail a link that places their
+// account under the guardian's parental controls. Unknown addresses are ignored
+// so the endpoint can't be used to probe for accounts.
+func (s *AuthServiceImpl) RequestGuardianLink(ctx context.Context, guardianID, wardEmail string) error {
+	if s.parentalControls == nil {
+		return auth.ErrParentalControlsNotFound
+	}
+
+	guardian, err := s.userRepo.GetByID(ctx, guardianID)
+	if err != nil {
+		return err
+	}
+	if guardian.IsGuest {
+		return auth.ErrGuestNotAllowed
+	}
+	if strings.EqualFold(guardian.Email, wardEmail) {
+		return auth.ErrCannotGuardSelf
+	}
+
+	ward, err := s.userRepo.GetByEmail(ctx, wardEmail)
+	if err != nil {
+		if err == auth.ErrUserNotFound {
+			return nil
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	existing, err := s.parentalControls.Get(ctx, ward.ID.String())
+	if err == nil && existing.GuardianID != guardian.ID {
+		return auth.ErrGuardianAlreadyLinked
+	}
+	if err != nil && err != auth.ErrParentalControlsNotFound {
+		return fmt.Errorf("failed to get parental controls: %w", err)
+	}
+
+	linkToken, err := generateVerificationToken()
+	if err != nil {
+		return fmt.Errorf("failed to generate guardian link token: %w", err)
+	}
+	payload, err := json.Marshal(auth.GuardianLink{GuardianID: guardian.ID.String(), UserID: ward.ID.String()})
+	if err != nil {
+		return fmt.Errorf("failed to encode guardian link: %w", err)
+	}
+
+	tokenHash := s.tokenGenerator.HashToken(linkToken)
+	if err := s.tokenCache.SetVerificationToken(ctx, auth.TokenPurposeGuardianLink, tokenHash, string(payload), auth.GuardianLinkTokenDuration); err != nil {
+		return fmt.Errorf("failed to store guardian link token: %w", err)
+	}
+
+	notification := auth.NewNotification(auth.NotificationGuardianLink, ward)
+	notification.Data["token"] = linkToken
+	notification.Data["guardian_username"] = guardian.Username
+	s.notify(ctx, notification)
+
+	return nil
+}
+
+// ConfirmGuardianLink accepts a guardian link. The ward starts with no limits
+// until the guardian sets them.
+func (s *AuthServiceImpl) ConfirmGuardianLink(ctx context.Context, token string) error {
+	if s.parentalControls == nil {
+		return auth.ErrInvalidToken
+	}
+
+	tokenHash := s.tokenGenerator.HashToken(token)
+	payload, err := s.tokenCache.GetVerificationToken(ctx, auth.TokenPurposeGuardianLink, tokenHash)
+	if err != nil {
+		return auth.ErrInvalidToken
+	}
+
+	// Tokens are single use
+	_ = s.tokenCache.DeleteVerificationToken(ctx, auth.TokenPurposeGuardianLink, tokenHash)
+
+	var link auth.GuardianLink
+	if err := json.Unmarshal([]byte(payload), &link); err != nil {
+		return auth.ErrInvalidToken
+	}
+	userID, err := uuid.Parse(link.UserID)
+	if err != nil {
+		return auth.ErrInvalidToken
+	}
+	guardianID, err := uuid.Parse(link.GuardianID)
+	if err != nil {
+		return auth.ErrInvalidToken
+	}
+
+	existing, err := s.parentalControls.Get(ctx, link.UserID)
+	switch {
+	case err == nil && existing.GuardianID != guardianID:
+		return auth.ErrGuardianAlreadyLinked
+	case err == nil:
+		return nil // Already linked
+	case err != auth.ErrParentalControlsNotFound:
+		return fmt.Errorf("failed to get parental controls: %w", err)
+	}
+
+	if err := s.parentalControls.Save(ctx, auth.NewParentalControls(userID, guardianID)); err != nil {
+		return err
+	}
+
+	event := auth.NewSecurityEvent(auth.SecurityEventGuardianLinked, auth.SecurityOutcomeSuccess, link.UserID)
+	event.Metadata["guardian_id"] = link.GuardianID
+	s.recordSecurityEvent(ctx, event)
+
+	s.logger.WithFields(map[string]interface{}{
+		"userID":     link.UserID,
+		"guardianID": link.GuardianID,
+	}).Info("Guardian linked")
+	return nil
+}
+
+// ListWards retrieves the accounts a guardian manages
+func (s *AuthServiceImpl) ListWards(ctx context.Context, guardianID string) ([]*auth.ParentalControls, error) {
+	if s.parentalControls == nil {
+		return []*auth.ParentalControls{}, nil
+	}
+	return s.parentalControls.ListByGuardian(ctx, guardianID)
+}
+
+// GetWardPlaytime retrieves a ward's limits and current play time status
+func (s *AuthServiceImpl) GetWardPlaytime(ctx context.Context, guardianID, wardID string) (*auth.ParentalControls, *auth.PlaytimeStatus, error) {
+	controls, err := s.wardControls(ctx, guardianID, wardID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	status, err := s.playtimeStatus(ctx, controls, time.Now())
+	if err != nil {
+		return nil, nil, err
+	}
+	return controls, status, nil
+}
+
+// SetPlaytimeLimits replaces a ward's budgets, allowed hours, timezone and enabled flag
+func (s *AuthServiceImpl) SetPlaytimeLimits(ctx context.Context, guardianID, wardID string, limits *auth.ParentalControls) (*auth.ParentalControls, error) {
+	controls, err := s.wardControls(ctx, guardianID, wardID)
+	if err != nil {
+		return nil, err
+	}
+
+	controls.DailyMinutes = limits.DailyMinutes
+	controls.WeeklyMinutes = limits.WeeklyMinutes
+	controls.Windows = limits.Windows
+	controls.Timezone = limits.Timezone
+	controls.Enabled = limits.Enabled
+	controls.UpdatedAt = time.Now()
+	if err := controls.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.parentalControls.Save(ctx, controls); err != nil {
+		return nil, err
+	}
+
+	event := auth.NewSecurityEvent(auth.SecurityEventPlaytimeLimitsChanged, auth.SecurityOutcomeSuccess, wardID)
+	event.ActorID = guardianID
+	event.Metadata["daily_minutes"] = fmt.Sprintf("%d", controls.DailyMinutes)
+	event.Metadata["weekly_minutes"] = fmt.Sprintf("%d", controls.WeeklyMinutes)
+	event.Metadata["windows"] = fmt.Sprintf("%d", len(controls.Windows))
+	event.Metadata["enabled"] = fmt.Sprintf("%t", controls.Enabled)
+	s.recordSecurityEvent(ctx, event)
+
+	return controls, nil
+}
+
+// RemoveWard ends a guardian link and lifts all limits on the ward
+func (s *AuthServiceImpl) RemoveWard(ctx context.Context, guardianID, wardID string) error {
+	if _, err := s.wardControls(ctx, guardianID, wardID); err != nil {
+		return err
+	}
+
+	if err := s.parentalControls.Delete(ctx, wardID); err != nil {
+		return err
+	}
+
+	event := auth.NewSecurityEvent(auth.SecurityEventGuardianRemoved, auth.SecurityOutcomeSuccess, wardID)
+	event.ActorID = guardianID
+	s.recordSecurityEvent(ctx, event)
+	return nil
+}
+
+// GetPlaytime retrieves an account's own limits (nil if it has none) and play time status
+func (s *AuthServiceImpl) GetPlaytime(ctx context.Context, userID string) (*auth.ParentalControls, *auth.PlaytimeStatus, error) {
+	if s.parentalControls == nil {
+		return nil, auth.UnrestrictedPlaytime(), nil
+	}
+
+	controls, err := s.parentalControls.Get(ctx, userID)
+	if err == auth.ErrParentalControlsNotFound {
+		return nil, auth.UnrestrictedPlaytime(), nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	status, err := s.playtimeStatus(ctx, controls, time.Now())
+	if err != nil {
+		return nil, nil, err
+	}
+	return controls, status, nil
+}
+
+// CheckPlaytime reports whether an account may start playing now
+func (s *AuthServiceImpl) CheckPlaytime(ctx context.Context, userID string) (*auth.PlaytimeStatus, error) {
+	_, status, err := s.GetPlaytime(ctx, userID)
+	return status, err
+}
+
+// CharacterOnline starts tracking an online character. Every account is tracked, so
+// controls enabled while a character is online apply to it right away.
+func (s *AuthServiceImpl) CharacterOnline(ctx context.Context, userID, characterID, sessionID string, at time.Time) error {
+	if s.playtime == nil {
+		return nil
+	}
+
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+	charID, err := uuid.Parse(characterID)
+	if err != nil {
+		return fmt.Errorf("invalid character ID: %w", err)
+	}
+
+	session := &auth.PlaySession{
+		CharacterID:    charID,
+		UserID:         uid,
+		SessionID:      sessionID,
+		StartedAt:      at,
+		AccountedUntil: at,
+	}
+
+	// A login plays one character at a time; switching carries the unaccounted time over
+	existing, err := s.playtime.ListSessionsByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, other := range existing {
+		if other.CharacterID == charID || (sessionID != "" && other.SessionID == sessionID) {
+			if _, err := s.playtime.CloseSession(ctx, other.CharacterID.String()); err != nil {
+				return err
+			}
+			if other.AccountedUntil.Before(session.AccountedUntil) {
+				session.AccountedUntil = other.AccountedUntil
+			}
+		}
+	}
+
+	return s.playtime.OpenSession(ctx, session)
+}
+
+// CharacterOffline stops tracking a character and credits the time it played since it
+// was last accounted for
+func (s *AuthServiceImpl) CharacterOffline(ctx context.Context, characterID string, at time.Time) error {
+	if s.playtime == nil {
+		return nil
+	}
+
+	session, err := s.playtime.CloseSession(ctx, characterID)
+	if err != nil || session == nil {
+		return err
+	}
+	if s.parentalControls == nil {
+		return nil
+	}
+
+	controls, err := s.parentalControls.Get(ctx, session.UserID.String())
+	if err == auth.ErrParentalControlsNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !controls.Enabled {
+		return nil
+	}
+
+	// Concurrent characters count once: credit from the account's oldest unaccounted point
+	// and move the characters still online past it, so the sweep doesn't count it again
+	remaining, err := s.playtime.ListSessionsByUser(ctx, session.UserID.String())
+	if err != nil {
+		return err
+	}
+	since := session.AccountedUntil
+	for _, other := range remaining {
+		if other.AccountedUntil.Before(since) {
+			since = other.AccountedUntil
+		}
+	}
+	if err := s.addPlaytime(ctx, controls, since, at); err != nil {
+		return err
+	}
+
+	for _, other := range remaining {
+		if other.AccountedUntil.Before(at) {
+			other.AccountedUntil = at
+			if err := s.playtime.UpdateSession(ctx, other); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// EnforcePlaytime adds elapsed play time for every online character under parental
+// controls, warns players about to run out and logs out those who have
+func (s *AuthServiceImpl) EnforcePlaytime(ctx context.Context) error {
+	if s.parentalControls == nil || s.playtime == nil {
+		return nil
+	}
+
+	sessions, err := s.playtime.ListSessions(ctx)
+	if err != nil {
+		return err
+	}
+
+	byUser := map[string][]*auth.PlaySession{}
+	for _, session := range sessions {
+		userID := session.UserID.String()
+		byUser[userID] = append(byUser[userID], session)
+	}
+
+	now := time.Now()
+	for userID, userSessions := range byUser {
+		if err := s.enforceUserPlaytime(ctx, userID, userSessions, now); err != nil {
+			s.logger.WithError(err).WithField("userID", userID).Warn("Failed to enforce play time")
+		}
+	}
+	return nil
+}
+
+func (s *AuthServiceImpl) enforceUserPlaytime(ctx context.Context, userID string, sessions []*auth.PlaySession, now time.Time) error {
+	controls, err := s.parentalControls.Get(ctx, userID)
+	if err != nil && err != auth.ErrParentalControlsNotFound {
+		return err
+	}
+	// Accounts without limits are still tracked, but their time isn't counted
+	limited := err == nil && controls.Enabled
+
+	var status *auth.PlaytimeStatus
+	if limited {
+		// Concurrent characters count once: the account has played since the oldest unaccounted point
+		since := now
+		for _, session := range sessions {
+			if session.AccountedUntil.Before(since) {
+				since = session.AccountedUntil
+			}
+		}
+		if err := s.addPlaytime(ctx, controls, since, now); err != nil {
+			return err
+		}
+
+		status, err = s.playtimeStatus(ctx, controls, now)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, session := range sessions {
+		session.AccountedUntil = now
+
+		if session.SessionID != "" {
+			if _, err := s.sessionRepo.GetByID(ctx, session.SessionID); err == auth.ErrSessionNotFound {
+				// Logged out without the game reporting the character offline
+				_, _ = s.playtime.CloseSession(ctx, session.CharacterID.String())
+				continue
+			}
+		}
+
+		if !limited {
+			session.LastWarning = 0
+			if err := s.playtime.UpdateSession(ctx, session); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !status.Allowed {
+			s.forcePlaytimeLogout(ctx, session, status.Reason)
+			continue
+		}
+
+		if threshold := s.playtimeWarning(status, session.LastWarning); threshold != session.LastWarning {
+			session.LastWarning = threshold
+			if threshold > 0 {
+				s.publishPlaytimeWarning(ctx, session, status)
+			}
+		}
+
+		if err := s.playtime.UpdateSession(ctx, session); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// playtimeWarning returns the warning threshold (in minutes) the session should be at:
+// the smallest configured threshold not above the remaining time, or 0 if none applies
+func (s *AuthServiceImpl) playtimeWarning(status *auth.PlaytimeStatus, lastWarning int) int {
+	if !status.Limited {
+		return 0
+	}
+
+	thresholds := append([]time.Duration(nil), s.config.PlaytimeWarnings...)
+	sort.Slice(thresholds, func(i, j int) bool { return thresholds[i] < thresholds[j] })
+
+	for _, threshold := range thresholds {
+		if status.Remaining <= threshold {
+			minutes := int(threshold / time.Minute)
+			if lastWarning != 0 && lastWarning <= minutes {
+				// Already warned at this or a closer threshold
+				return lastWarning
+			}
+			return minutes
+		}
+	}
+
+	// More time than any threshold, e.g. after the guardian raised a limit
+	return 0
+}
+
+func (s *AuthServiceImpl) publishPlaytimeWarning(ctx context.Context, session *auth.PlaySession, status *auth.PlaytimeStatus) {
+	if s.eventPublisher == nil {
+		return
+	}
+
+	event := &auth.PlaytimeWarningEvent{
+		BaseEvent:        auth.BaseEvent{UserID: session.UserID.String()},
+		CharacterID:      session.CharacterID.String(),
+		SessionID:        session.SessionID,
+		MinutesRemaining: int((status.Remaining + time.Minute - 1) / time.Minute),
+		Reason:           status.Reason,
+	}
+	if err := s.eventPublisher.PublishPlaytimeWarning(ctx, event); err != nil {
+		s.logger.WithError(err).Warn("Failed to publish play time warning")
+	}
+}
+
+// forcePlaytimeLogout ends the auth session of a character out of play time
+func (s *AuthServiceImpl) forcePlaytimeLogout(ctx context.Context, session *auth.PlaySession, reason string) {
+	_, _ = s.playtime.CloseSession(ctx, session.CharacterID.String())
+
+	if session.SessionID != "" {
+		if err := s.endSession(ctx, session.SessionID, auth.SecurityEventPlaytimeLogout); err != nil {
+			s.logger.WithError(err).WithField("sessionID", session.SessionID).Warn("Failed to end session over play time")
+		}
+	}
+
+	if s.eventPublisher != nil {
+		event := &auth.PlaytimeLogoutEvent{
+			BaseEvent:   auth.BaseEvent{UserID: session.UserID.String()},
+			CharacterID: session.CharacterID.String(),
+			SessionID:   session.SessionID,
+			Reason:      reason,
+		}
+		if err := s.eventPublisher.PublishPlaytimeLogout(ctx, event); err != nil {
+			s.logger.WithError(err).Warn("Failed to publish play time logout")
+		}
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"userID":      session.UserID,
+		"characterID": session.CharacterID,
+		"reason":      reason,
+	}).Info("Logged out character over play time limits")
+}
+
+// wardControls loads a ward's controls, checking that guardianID manages them
+func (s *AuthServiceImpl) wardControls(ctx context.Context, guardianID, wardID string) (*auth.ParentalControls, error) {
+	if s.parentalControls == nil {
+		return nil, auth.ErrParentalControlsNotFound
+	}
+
+	controls, err := s.parentalControls.Get(ctx, wardID)
+	if err != nil {
+		return nil, err
+	}
+	if controls.GuardianID.String() != guardianID {
+		return nil, auth.ErrNotGuardian
+	}
+	return controls, nil
+}
+
+// playtimeStatus evaluates the limits against the play time recorded so far today and this week
+func (s *AuthServiceImpl) playtimeStatus(ctx context.Context, controls *auth.ParentalControls, now time.Time) (*auth.PlaytimeStatus, error) {
+	if s.playtime == nil {
+		return controls.Evaluate(now, 0, 0), nil
+	}
+
+	userID := controls.UserID.String()
+	today := controls.DayStart(now)
+
+	usedToday, err := s.playtime.SumUsage(ctx, userID, today, today)
+	if err != nil {
+		return nil, err
+	}
+	usedWeek, err := s.playtime.SumUsage(ctx, userID, controls.WeekStart(now), today)
+	if err != nil {
+		return nil, err
+	}
+
+	return controls.Evaluate(now, usedToday, usedWeek), nil
+}
+
+// addPlaytime records the span [from, to) against the account, split by local day
+func (s *AuthServiceImpl) addPlaytime(ctx context.Context, controls *auth.ParentalControls, from, to time.Time) error {
+	for _, slice := range controls.SplitByDay(from, to) {
+		if err := s.playtime.AddUsage(ctx, controls.UserID.String(), slice.Day, slice.Duration); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package auth_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	authApp "github.com/mmorpg-template/backend/internal/application/auth"
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+	portsAuth "github.com/mmorpg-template/backend/internal/ports/auth"
+	"github.com/mmorpg-template/backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryPlaytime keeps online characters by ID and the total usage credited
+type memoryPlaytime struct {
+	portsAuth.PlaytimeRepository
+	sessions map[uuid.UUID]*auth.PlaySession
+	usage    time.Duration
+}
+
+func (r *memoryPlaytime) AddUsage(ctx context.Context, userID string, day time.Time, played time.Duration) error {
+	r.usage += played
+	return nil
+}
+
+func (r *memoryPlaytime) OpenSession(ctx context.Context, session *auth.PlaySession) error {
+	copied := *session
+	r.sessions[session.CharacterID] = &copied
+	return nil
+}
+
+func (r *memoryPlaytime) UpdateSession(ctx context.Context, session *auth.PlaySession) error {
+	return r.OpenSession(ctx, session)
+}
+
+func (r *memoryPlaytime) CloseSession(ctx context.Context, characterID string) (*auth.PlaySession, error) {
+	id := uuid.MustParse(characterID)
+	session := r.sessions[id]
+	delete(r.sessions, id)
+	return session, nil
+}
+
+func (r *memoryPlaytime) ListSessionsByUser(ctx context.Context, userID string) ([]*auth.PlaySession, error) {
+	var sessions []*auth.PlaySession
+	for _, session := range r.sessions {
+		if session.UserID.String() == userID {
+			copied := *session
+			sessions = append(sessions, &copied)
+		}
+	}
+	return sessions, nil
+}
+
+// memoryParentalControls holds the controls of a single account
+type memoryParentalControls struct {
+	portsAuth.ParentalControlsRepository
+	controls *auth.ParentalControls
+}
+
+func (r *memoryParentalControls) Get(ctx context.Context, userID string) (*auth.ParentalControls, error) {
+	if r.controls == nil || r.controls.UserID.String() != userID {
+		return nil, auth.ErrParentalControlsNotFound
+	}
+	return r.controls, nil
+}
+
+func TestPlaytimeTracking(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	start := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+
+	newService := func(controls portsAuth.ParentalControlsRepository, playtime *memoryPlaytime) *authApp.AuthServiceImpl {
+		return authApp.NewAuthService(new(mockUserRepository), new(mockSessionRepository), new(mockTokenGenerator), new(mockPasswordHasher), newMemoryTokenCache(),
+			nil, nil, nil, nil, nil, nil, nil, nil, nil, controls, playtime, &authApp.Config{}, logger.NewNoop())
+	}
+
+	t.Run("each closed session is credited once", func(t *testing.T) {
+		playtime := &memoryPlaytime{sessions: map[uuid.UUID]*auth.PlaySession{}}
+		controls := &memoryParentalControls{controls: auth.NewParentalControls(userID, uuid.New())}
+		service := newService(controls, playtime)
+		first, second := uuid.New(), uuid.New()
+
+		require.NoError(t, service.CharacterOnline(ctx, userID.String(), first.String(), "login-1", start))
+		require.NoError(t, service.CharacterOnline(ctx, userID.String(), second.String(), "login-2", start.Add(10*time.Minute)))
+
+		// The second character is still online, but the first one's time counts now
+		require.NoError(t, service.CharacterOffline(ctx, first.String(), start.Add(30*time.Minute)))
+		assert.Equal(t, 30*time.Minute, playtime.usage)
+
+		// Time both played together is not counted again
+		require.NoError(t, service.CharacterOffline(ctx, second.String(), start.Add(40*time.Minute)))
+		assert.Equal(t, 40*time.Minute, playtime.usage)
+	})
+
+	t.Run("accounts without controls are tracked", func(t *testing.T) {
+		playtime := &memoryPlaytime{sessions: map[uuid.UUID]*auth.PlaySession{}}
+		service := newService(&memoryParentalControls{}, playtime)
+		character := uuid.New()
+
+		require.NoError(t, service.CharacterOnline(ctx, userID.String(), character.String(), "login-1", start))
+		assert.Len(t, playtime.sessions, 1)
+
+		require.NoError(t, service.CharacterOffline(ctx, character.String(), start.Add(time.Hour)))
+		assert.Empty(t, playtime.sessions)
+		assert.Zero(t, playtime.usage)
+	})
+
+	t.Run("missing parental controls repository", func(t *testing.T) {
+		playtime := &memoryPlaytime{sessions: map[uuid.UUID]*auth.PlaySession{}}
+		service := newService(nil, playtime)
+		character := uuid.New()
+
+		require.NoError(t, service.CharacterOnline(ctx, userID.String(), character.String(), "", start))
+		assert.NoError(t, service.CharacterOffline(ctx, character.String(), start.Add(time.Hour)))
+		assert.Empty(t, playtime.sessions)
+	})
+}
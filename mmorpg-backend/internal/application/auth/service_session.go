@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+)
+
+const (
+	// sessionCacheTTL bounds how long a session lookup is served from the cache.
+	// Ending a session deletes its cache entry, so this only limits staleness of activity data.
+	sessionCacheTTL = 5 * time.Minute
+
+	// sessionActivityInterval is how stale a session's last activity may get before a
+	// validated token records it again; recording it on every request would mean a
+	// database write per request
+	sessionActivityInterval = time.Minute
+)
+
+// GetSession retrieves an active session, preferring the cache
+func (s *AuthServiceImpl) GetSession(ctx context.Context, sessionID string) (*auth.Session, error) {
+	if data, err := s.tokenCache.GetSession(ctx, sessionID); err == nil {
+		var session auth.Session
+		if err := json.Unmarshal(data, &session); err == nil {
+			if session.IsExpired() {
+				return nil, auth.ErrSessionExpired
+			}
+			return &session, nil
+		}
+	}
+
+	session, err := s.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		if err == auth.ErrSessionNotFound {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	if session.IsExpired() {
+		return nil, auth.ErrSessionExpired
+	}
+
+	s.cacheSession(ctx, session)
+	return session, nil
+}
+
+// cacheSession caches a session for sessionCacheTTL, or until it expires if that is sooner
+func (s *AuthServiceImpl) cacheSession(ctx context.Context, session *auth.Session) {
+	ttl := time.Until(session.ExpiresAt)
+	if ttl > sessionCacheTTL {
+		ttl = sessionCacheTTL
+	}
+	if data, err := json.Marshal(session); err == nil {
+		if err := s.tokenCache.SetSession(ctx, session.ID.String(), data, ttl); err != nil {
+			s.logger.WithError(err).Warn("Failed to cache session")
+		}
+	}
+}
+
+// touchSession records session activity in the background and refreshes the cached copy,
+// so the next validations see it as recent
+func (s *AuthServiceImpl) touchSession(session *auth.Session) {
+	touched := *session
+	touched.LastActive = time.Now()
+	go func() {
+		ctx := context.Background()
+		if err := s.sessionRepo.UpdateLastActive(ctx, touched.ID.String(), touched.LastActive); err != nil {
+			s.logger.WithError(err).Warn("Failed to update session activity")
+			return
+		}
+		s.cacheSession(ctx, &touched)
+	}()
+}
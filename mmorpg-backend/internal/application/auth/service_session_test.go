@@ -0,0 +1,66 @@
+package auth_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	authApp "github.com/mmorpg-template/backend/internal/application/auth"
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+	"github.com/mmorpg-template/backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateToken_SessionActivity(t *testing.T) {
+	setup := func(lastActive time.Time) (*authApp.AuthServiceImpl, *mockSessionRepository, chan struct{}) {
+		session := &auth.Session{ID: uuid.New(), UserID: uuid.New(), ExpiresAt: time.Now().Add(time.Hour), LastActive: lastActive}
+		data, err := json.Marshal(session)
+		require.NoError(t, err)
+
+		tokenGen := new(mockTokenGenerator)
+		tokenGen.On("HashToken", "access").Return("access-hash")
+		tokenGen.On("ValidateAccessToken", mock.Anything, "access").Return(&auth.Claims{SessionID: session.ID.String()}, nil)
+
+		touched := make(chan struct{})
+		cache := new(mockTokenCache)
+		cache.On("IsBlacklisted", mock.Anything, "access-hash").Return(false, nil)
+		cache.On("GetSession", mock.Anything, session.ID.String()).Return(data, nil)
+		cache.On("SetSession", mock.Anything, session.ID.String(), mock.Anything, mock.Anything).
+			Return(nil).Run(func(mock.Arguments) { close(touched) })
+
+		sessionRepo := new(mockSessionRepository)
+		sessionRepo.On("UpdateLastActive", mock.Anything, session.ID.String(), mock.Anything).Return(nil)
+
+		service := authApp.NewAuthService(new(mockUserRepository), sessionRepo, tokenGen, new(mockPasswordHasher), cache,
+			nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &authApp.Config{}, logger.NewNoop())
+		return service, sessionRepo, touched
+	}
+
+	t.Run("recent activity is not written again", func(t *testing.T) {
+		service, sessionRepo, _ := setup(time.Now())
+
+		for i := 0; i < 3; i++ {
+			_, err := service.ValidateToken(context.Background(), "access")
+			require.NoError(t, err)
+		}
+		sessionRepo.AssertNotCalled(t, "UpdateLastActive", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("stale activity is recorded and recached", func(t *testing.T) {
+		service, sessionRepo, touched := setup(time.Now().Add(-time.Hour))
+
+		_, err := service.ValidateToken(context.Background(), "access")
+		require.NoError(t, err)
+
+		select {
+		case <-touched:
+		case <-time.After(time.Second):
+			t.Fatal("session activity was not recorded")
+		}
+		assert.True(t, sessionRepo.AssertNumberOfCalls(t, "UpdateLastActive", 1))
+	})
+}
@@ -6,6 +6,7 @@
 	"time"
 
 	"github.com/google/uuid"
+	authApp "github.com/mmorpg-template/backend/internal/application/auth"
 	"github.com/mmorpg-template/backend/internal/domain/auth"
 	"github.com/mmorpg-template/backend/pkg/logger"
 	"github.com/stretchr/testify/assert"
@@ -72,6 +73,33 @@ func (m *mockUserRepository) IncrementCharacterCount(ctx context.Context, userID
 	return args.Error(0)
 }
 
+func (m *mockUserRepository) RecountCharacters(ctx context.Context, userID string) (int, error) {
+	args := m.Called(ctx, userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *mockUserRepository) ReconcileCharacterCounts(ctx context.Context, afterID string, limit int) ([]*auth.CharacterCountCorrection, string, error) {
+	args := m.Called(ctx, afterID, limit)
+	corrections, _ := args.Get(0).([]*auth.CharacterCountCorrection)
+	return corrections, args.String(1), args.Error(2)
+}
+
+func (m *mockUserRepository) GetGuestByDeviceID(ctx context.Context, deviceID string) (*auth.User, error) {
+	args := m.Called(ctx, deviceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*auth.User), args.Error(1)
+}
+
+func (m *mockUserRepository) DeleteIdleGuests(ctx context.Context, idleSince time.Time, limit int) ([]*auth.DeletedGuest, error) {
+	args := m.Called(ctx, idleSince, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*auth.DeletedGuest), args.Error(1)
+}
+
 func (m *mockUserRepository) DecrementCharacterCount(ctx context.Context, userID string) error {
 	args := m.Called(ctx, userID)
 	return args.Error(0)
@@ -152,6 +180,14 @@ func (m *mockTokenGenerator) GenerateTokenPair(ctx context.Context, user *auth.U
 	return args.Get(0).(*auth.TokenPair), args.Error(1)
 }
 
+func (m *mockTokenGenerator) GenerateServiceToken(ctx context.Context, account *auth.ServiceAccount, scopes []string) (*auth.ServiceToken, error) {
+	args := m.Called(ctx, account, scopes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*auth.ServiceToken), args.Error(1)
+}
+
 func (m *mockTokenGenerator) ValidateAccessToken(ctx context.Context, token string) (*auth.Claims, error) {
 	args := m.Called(ctx, token)
 	if args.Get(0) == nil {
@@ -264,6 +300,41 @@ func (m *mockTokenCache) DeletePasswordResetToken(ctx context.Context, token str
 	return args.Error(0)
 }
 
+func (m *mockTokenCache) SetAccountLock(ctx context.Context, userID string, lockedUntil time.Time) error {
+	args := m.Called(ctx, userID, lockedUntil)
+	return args.Error(0)
+}
+
+func (m *mockTokenCache) GetAccountLock(ctx context.Context, userID string) (time.Time, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(time.Time), args.Error(1)
+}
+
+func (m *mockTokenCache) DeleteAccountLock(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *mockTokenCache) SetVerificationToken(ctx context.Context, purpose auth.TokenPurpose, tokenHash string, value string, expiration time.Duration) error {
+	args := m.Called(ctx, purpose, tokenHash, value, expiration)
+	return args.Error(0)
+}
+
+func (m *mockTokenCache) GetVerificationToken(ctx context.Context, purpose auth.TokenPurpose, tokenHash string) (string, error) {
+	args := m.Called(ctx, purpose, tokenHash)
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockTokenCache) DeleteVerificationToken(ctx context.Context, purpose auth.TokenPurpose, tokenHash string) error {
+	args := m.Called(ctx, purpose, tokenHash)
+	return args.Error(0)
+}
+
+func (m *mockTokenCache) ConfirmSide(ctx context.Context, purpose auth.TokenPurpose, id string, side, otherSide string, expiration time.Duration) (bool, error) {
+	args := m.Called(ctx, purpose, id, side, otherSide, expiration)
+	return args.Bool(0), args.Error(1)
+}
+
 // Tests
 
 func TestRegister(t *testing.T) {
@@ -275,7 +346,7 @@ func TestRegister(t *testing.T) {
 	passHasher := new(mockPasswordHasher)
 	tokenCache := new(mockTokenCache)
 	
-	config := &auth.Config{
+	config := &authApp.Config{
 		MaxSessionsPerUser:   10,
 		LoginRateLimit:       10,
 		LoginRateLimitWindow: 15 * time.Minute,
@@ -285,7 +356,7 @@ func TestRegister(t *testing.T) {
 	
 	logger := logger.NewNoop()
 	
-	service := auth.NewAuthService(userRepo, sessionRepo, tokenGen, passHasher, tokenCache, config, logger)
+	service := authApp.NewAuthService(userRepo, sessionRepo, tokenGen, passHasher, tokenCache, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, config, logger)
 	
 	t.Run("successful registration", func(t *testing.T) {
 		req := &auth.RegisterRequest{
@@ -354,7 +425,7 @@ func TestLogin(t *testing.T) {
 	passHasher := new(mockPasswordHasher)
 	tokenCache := new(mockTokenCache)
 	
-	config := &auth.Config{
+	config := &authApp.Config{
 		MaxSessionsPerUser:   10,
 		LoginRateLimit:       10,
 		LoginRateLimitWindow: 15 * time.Minute,
@@ -364,7 +435,7 @@ func TestLogin(t *testing.T) {
 	
 	logger := logger.NewNoop()
 	
-	service := auth.NewAuthService(userRepo, sessionRepo, tokenGen, passHasher, tokenCache, config, logger)
+	service := authApp.NewAuthService(userRepo, sessionRepo, tokenGen, passHasher, tokenCache, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, config, logger)
 	
 	t.Run("successful login", func(t *testing.T) {
 		email := "test@example.com"
@@ -390,12 +461,14 @@ func TestLogin(t *testing.T) {
 		
 		tokenCache.On("IncrementLoginAttempts", ctx, "login:"+ipAddress, config.LoginRateLimitWindow).Return(1, nil)
 		userRepo.On("GetByEmail", ctx, email).Return(user, nil)
+		tokenCache.On("GetAccountLock", ctx, user.ID.String()).Return(time.Time{}, nil)
 		passHasher.On("ComparePassword", user.PasswordHash, password).Return(nil)
 		sessionRepo.On("CountByUserID", ctx, user.ID.String()).Return(0, nil)
 		tokenGen.On("GenerateTokenPair", ctx, user, mock.AnythingOfType("string"), deviceID).Return(tokenPair, nil)
 		tokenGen.On("HashToken", tokenPair.RefreshToken).Return("hashed_refresh_token")
 		sessionRepo.On("Create", ctx, mock.AnythingOfType("*auth.Session")).Return(nil)
 		tokenCache.On("DeleteLoginAttempts", ctx, "login:"+ipAddress).Return(nil)
+		tokenCache.On("DeleteLoginAttempts", ctx, "account:"+user.ID.String()).Return(nil)
 		userRepo.On("Update", ctx, user).Return(nil)
 		
 		resultTokenPair, resultUser, err := service.Login(ctx, email, password, deviceID, ipAddress, userAgent)
@@ -430,6 +503,7 @@ func TestLogin(t *testing.T) {
 		
 		tokenCache.On("IncrementLoginAttempts", ctx, "login:"+ipAddress, config.LoginRateLimitWindow).Return(1, nil)
 		userRepo.On("GetByEmail", ctx, email).Return(user, nil)
+		tokenCache.On("GetAccountLock", ctx, user.ID.String()).Return(time.Time{}, nil)
 		passHasher.On("ComparePassword", user.PasswordHash, password).Return(auth.ErrPasswordMismatch)
 		
 		resultTokenPair, resultUser, err := service.Login(ctx, email, password, deviceID, ipAddress, userAgent)
@@ -3,12 +3,11 @@
 import (
 	"context"
 	"fmt"
-	"regexp"
-	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/mmorpg-template/backend/internal/domain/character"
+	"github.com/mmorpg-template/backend/internal/ports"
 	portsCharacter "github.com/mmorpg-template/backend/internal/ports/character"
 	"github.com/mmorpg-template/backend/pkg/logger"
 )
@@ -16,10 +15,25 @@
 // Config holds the configuration for the character service
 type Config struct {
 	MaxCharactersPerUser      int
+	MaxCharactersPerGuest     int
 	MaxCharacterNameLength    int
 	MinCharacterNameLength    int
 	DefaultStartingLevel      int
 	DefaultStartingExperience int64
+	// Respec sets respec cost, cooldown and undo rules; zero uses the built-in policy
+	Respec character.RespecPolicy
+	// RenameCooldown is the minimum time between renames of a character
+	RenameCooldown time.Duration
+	// NameHoldPeriod keeps a released name reserved for its previous owner
+	NameHoldPeriod time.Duration
+	// PurgeBatchSize bounds how many expired characters are purged per statement
+	PurgeBatchSize int
+	// RestorePointRetention is how long restore points are kept; zero keeps them forever
+	RestorePointRetention time.Duration
+	// SelectionLease is how long a character selection is held without a heartbeat; zero never expires
+	SelectionLease time.Duration
+	// SelectionPolicy decides whether selecting from a second login session takes over or is refused
+	SelectionPolicy character.SelectionPolicy
 }
 
 // CharacterService implements the character service interface
@@ -31,6 +45,18 @@ type CharacterService struct {
 	cache          portsCharacter.CharacterCache
 	cacheTTL       *portsCharacter.CacheTTL
 	eventPublisher portsCharacter.EventPublisher
+	playtime       portsCharacter.PlaytimeChecker
+	progression    portsCharacter.ProgressionProvider
+	definitions    portsCharacter.DefinitionsProvider
+	statsTx        portsCharacter.StatsTransactor
+	names          portsCharacter.NameHistoryRepository
+	namePolicies   portsCharacter.NamePolicyProvider
+	locker         ports.Locker
+	transfers      portsCharacter.TransferRepository
+	pendingChecker portsCharacter.PendingActivityChecker
+	importer       portsCharacter.CharacterImporter
+	restorePoints  portsCharacter.RestorePointRepository
+	playSessions   portsCharacter.PlaySessionRepository
 	config         *Config
 	logger         logger.Logger
 }
@@ -59,6 +85,21 @@ func NewCharacterService(
 	}
 }
 
+// SetPlaytimeChecker enables parental controls checks when selecting a character
+func (s *CharacterService) SetPlaytimeChecker(checker portsCharacter.PlaytimeChecker) {
+	s.playtime = checker
+}
+
+// SetProgression replaces the built-in progression with a data-driven one
+func (s *CharacterService) SetProgression(provider portsCharacter.ProgressionProvider) {
+	s.progression = provider
+}
+
+// SetDefinitions replaces the built-in classes and races with data-driven ones
+func (s *CharacterService) SetDefinitions(provider portsCharacter.DefinitionsProvider) {
+	s.definitions = provider
+}
+
 // CreateCharacter creates a new character for a user
 func (s *CharacterService) CreateCharacter(ctx context.Context, req *portsCharacter.CreateCharacterRequest) (*character.Character, error) {
 	// Validate user ID
@@ -68,7 +109,7 @@ func (s *CharacterService) CreateCharacter(ctx context.Context, req *portsCharac
 	}
 
 	// Check if user can create more characters
-	canCreate, err := s.CanCreateCharacter(ctx, req.UserID)
+	canCreate, err := s.canCreateCharacter(ctx, req.UserID, s.characterLimit(req.IsGuest))
 	if err != nil {
 		return nil, fmt.Errorf("failed to check character limit: %w", err)
 	}
@@ -81,28 +122,22 @@ func (s *CharacterService) CreateCharacter(ctx context.Context, req *portsCharac
 		return nil, err
 	}
 
-	// Check if name is already taken
-	exists, err := s.characterRepo.NameExists(ctx, req.Name)
-	if err != nil {
-		return nil, fmt.Errorf("failed to check name availability: %w", err)
-	}
-	if exists {
-		return nil, character.ErrCharacterNameTaken
+	// Check if name is already taken or held for another account
+	if err := s.checkNameAvailable(ctx, req.Name, userID); err != nil {
+		return nil, err
 	}
 
 	// Validate class, race, and gender
-	if !character.IsValidClass(req.ClassType) {
-		return nil, character.ErrInvalidClass
-	}
-	if !character.IsValidRace(req.Race) {
-		return nil, character.ErrInvalidRace
+	definitions := s.characterDefinitions()
+	if err := definitions.CheckCombination(req.ClassType, req.Race); err != nil {
+		return nil, err
 	}
 	if !character.IsValidGender(req.Gender) {
 		return nil, character.ErrInvalidGender
 	}
 
 	// Validate slot number
-	if req.SlotNumber < 1 || req.SlotNumber > 100 {
+	if req.SlotNumber < 1 || req.SlotNumber > character.MaxSlotNumber {
 		return nil, character.ErrInvalidSlotNumber
 	}
 
@@ -143,7 +178,7 @@ func (s *CharacterService) CreateCharacter(ctx context.Context, req *portsCharac
 	}
 
 	// Create stats
-	stats := character.NewStats(char.ID, req.ClassType)
+	stats := definitions.NewStats(char.ID, req.ClassType, req.Race)
 	if err := s.statsRepo.Create(ctx, stats); err != nil {
 		// Rollback
 		s.appearanceRepo.Delete(ctx, char.ID)
@@ -152,8 +187,7 @@ func (s *CharacterService) CreateCharacter(ctx context.Context, req *portsCharac
 	}
 
 	// Create position
-	position := character.NewPosition(char.ID)
-	position.ApplyClassStartingPosition(req.ClassType)
+	position := definitions.StartingPosition(char.ID, req.ClassType, req.Race)
 	if err := s.positionRepo.Create(ctx, position); err != nil {
 		// Rollback
 		s.statsRepo.Delete(ctx, char.ID)
@@ -299,7 +333,7 @@ func (s *CharacterService) ListCharactersByUser(ctx context.Context, userID stri
 	return activeChars, nil
 }
 
-// DeleteCharacter soft deletes a character
+// DeleteCharacter soft deletes a character, ending its play session
 func (s *CharacterService) DeleteCharacter(ctx context.Context, characterID string, userID string) error {
 	// Validate ownership
 	if err := s.ValidateCharacterOwnership(ctx, characterID, userID); err != nil {
@@ -309,8 +343,26 @@ func (s *CharacterService) DeleteCharacter(ctx context.Context, characterID stri
 	charID, _ := uuid.Parse(characterID)
 	uid, _ := uuid.Parse(userID)
 	
-	if err := s.characterRepo.SoftDelete(ctx, charID); err != nil {
-		return fmt.Errorf("failed to delete character: %w", err)
+	if s.playSessions != nil {
+		// Its play session ends in the same transaction, so the character can't stay online
+		ended, err := s.playSessions.DeleteCharacter(ctx, charID, time.Now())
+		if err != nil {
+			return fmt.Errorf("failed to delete character: %w", err)
+		}
+		if ended != nil {
+			s.playSessionEnded(ctx, ended)
+		}
+	} else {
+		if err := s.characterRepo.SoftDelete(ctx, charID); err != nil {
+			return fmt.Errorf("failed to delete character: %w", err)
+		}
+		if s.cache != nil {
+			if selected, err := s.cache.GetSelectedCharacter(ctx, uid); err == nil && selected == charID {
+				if err := s.clearSelection(ctx, uid, character.PlaySessionEndDeleted); err != nil {
+					s.logger.WithError(err).Warn("Failed to deselect deleted character")
+				}
+			}
+		}
 	}
 
 	// Get character info for event
@@ -597,91 +649,91 @@ func (s *CharacterService) GetStats(ctx context.Context, characterID string) (*c
 
 // AllocateStatPoint allocates a stat point to a primary stat
 func (s *CharacterService) AllocateStatPoint(ctx context.Context, characterID string, stat string) (*character.Stats, error) {
-	charID, err := uuid.Parse(characterID)
-	if err != nil {
-		return nil, character.ErrInvalidCharacterID
-	}
-
 	// Get character to verify it exists and get class type
-	char, err := s.characterRepo.GetByID(ctx, charID)
+	char, err := s.activeCharacter(ctx, characterID)
 	if err != nil {
-		return nil, character.ErrCharacterNotFound
-	}
-	if char.IsDeleted {
-		return nil, character.ErrCharacterDeleted
+		return nil, err
 	}
 
-	// Get stats
-	stats, err := s.statsRepo.GetByCharacterID(ctx, charID)
-	if err != nil {
-		return nil, character.ErrStatsNotFound
-	}
+	var updated *character.Stats
+	var previous map[string]int
+	err = s.updateStats(ctx, char.ID, func(stats *character.Stats, ledger portsCharacter.StatLedger) error {
+		previous = primaryStatValues(stats)
+
+		// Allocate point
+		if err := stats.AllocateStatPoint(stat); err != nil {
+			return err
+		}
+
+		// Recalculate derived stats
+		s.characterDefinitions().CalculateDerivedStats(stats, char.ClassType)
+
+		// Record the allocation so it can be undone
+		if ledger != nil {
+			allocation := character.NewStatAllocation(char.ID, stat, 1)
+			if err := ledger.RecordAllocations(ctx, []*character.StatAllocation{allocation}); err != nil {
+				return err
+			}
+		}
 
-	// Allocate point
-	if err := stats.AllocateStatPoint(stat); err != nil {
+		updated = stats
+		return nil
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	// Recalculate derived stats
-	stats.CalculateDerivedStats(char.ClassType)
+	s.afterStatsUpdate(ctx, char, statsUpdateAllocation, previous, updated, 0)
 
-	// Update in database
-	if err := s.statsRepo.Update(ctx, stats); err != nil {
-		return nil, fmt.Errorf("failed to update stats: %w", err)
-	}
+	return updated, nil
+}
 
-	// Invalidate stats cache
-	if s.cache != nil {
-		if err := s.cache.DeleteStats(ctx, charID); err != nil {
-			s.logger.WithError(err).Warn("Failed to invalidate stats cache after update")
-		}
+// AllocateStatPoints spends points on several primary stats in one transaction with a single
+// stats updated event. With preview set the resulting stats are returned without being saved.
+func (s *CharacterService) AllocateStatPoints(ctx context.Context, characterID string, points map[string]int, preview bool) (*character.Stats, error) {
+	char, err := s.activeCharacter(ctx, characterID)
+	if err != nil {
+		return nil, err
 	}
 
-	// Publish stats updated event
-	if s.eventPublisher != nil {
-		// Create a map of previous stats (before allocation)
-		previousStats := map[string]int{
-			"strength":     stats.Strength - 1, // We allocated 1 point
-			"dexterity":    stats.Dexterity,
-			"intelligence": stats.Intelligence,
-			"wisdom":       stats.Wisdom,
-			"constitution": stats.Constitution,
+	if preview {
+		stats, err := s.statsRepo.GetByCharacterID(ctx, char.ID)
+		if err != nil {
+			return nil, character.ErrStatsNotFound
 		}
-		// Adjust the previous stat that was changed
-		if stat == "strength" { previousStats["strength"] = stats.Strength - 1 }
-		if stat == "dexterity" { previousStats["dexterity"] = stats.Dexterity - 1 }
-		if stat == "intelligence" { previousStats["intelligence"] = stats.Intelligence - 1 }
-		if stat == "wisdom" { previousStats["wisdom"] = stats.Wisdom - 1 }
-		if stat == "constitution" { previousStats["constitution"] = stats.Constitution - 1 }
-		
-		newStats := map[string]int{
-			"strength":     stats.Strength,
-			"dexterity":    stats.Dexterity,
-			"intelligence": stats.Intelligence,
-			"wisdom":       stats.Wisdom,
-			"constitution": stats.Constitution,
+		if err := stats.AllocateStatPoints(points); err != nil {
+			return nil, err
 		}
-		
-		changes := map[string]int{stat: 1}
-		
-		event := &character.CharacterStatsUpdatedEvent{
-			BaseEvent: character.BaseEvent{
-				EventType:   character.EventCharacterStatsUpdated,
-				CharacterID: characterID,
-				UserID:      char.UserID.String(),
-			},
-			UpdateType:    "stat_allocation",
-			PreviousStats: previousStats,
-			NewStats:      newStats,
-			Changes:       changes,
+		s.characterDefinitions().CalculateDerivedStats(stats, char.ClassType)
+		return stats, nil
+	}
+
+	var updated *character.Stats
+	var previous map[string]int
+	err = s.updateStats(ctx, char.ID, func(stats *character.Stats, ledger portsCharacter.StatLedger) error {
+		previous = primaryStatValues(stats)
+		if err := stats.AllocateStatPoints(points); err != nil {
+			return err
 		}
-		
-		if err := s.eventPublisher.PublishCharacterStatsUpdated(ctx, event); err != nil {
-			s.logger.WithError(err).Warn("Failed to publish stats updated event")
+		s.characterDefinitions().CalculateDerivedStats(stats, char.ClassType)
+
+		// Record each stat separately so allocations can be undone
+		if ledger != nil {
+			if err := ledger.RecordAllocations(ctx, character.NewStatAllocations(char.ID, points)); err != nil {
+				return err
+			}
 		}
+
+		updated = stats
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return stats, nil
+	s.afterStatsUpdate(ctx, char, statsUpdateAllocation, previous, updated, 0)
+
+	return updated, nil
 }
 
 // GetPosition retrieves character position
@@ -893,6 +945,43 @@ func (s *CharacterService) ValidateCharacterOwnership(ctx context.Context, chara
 
 // CanCreateCharacter checks if a user can create more characters
 func (s *CharacterService) CanCreateCharacter(ctx context.Context, userID string) (bool, error) {
+	return s.canCreateCharacter(ctx, userID, s.config.MaxCharactersPerUser)
+}
+
+// characterLimit returns the character limit for a full or guest account
+func (s *CharacterService) characterLimit(isGuest bool) int {
+	if isGuest && s.config.MaxCharactersPerGuest > 0 {
+		return s.config.MaxCharactersPerGuest
+	}
+	return s.config.MaxCharactersPerUser
+}
+
+// accountCharacterLimit returns how many characters another user's account may hold: its own
+// max_characters, which premium raises, capped at the guest limit for guest accounts
+func (s *CharacterService) accountCharacterLimit(ctx context.Context, userID uuid.UUID) (int, error) {
+	maxCharacters, isGuest, err := s.characterRepo.GetAccountLimit(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	if isGuest && s.config.MaxCharactersPerGuest > 0 && s.config.MaxCharactersPerGuest < maxCharacters {
+		return s.config.MaxCharactersPerGuest, nil
+	}
+	return maxCharacters, nil
+}
+
+// defaultDefinitions are used until a definitions provider is set
+var defaultDefinitions = character.DefaultDefinitions()
+
+// characterDefinitions returns the class and race definitions currently in effect
+func (s *CharacterService) characterDefinitions() *character.Definitions {
+	if s.definitions != nil {
+		return s.definitions.Definitions()
+	}
+	return defaultDefinitions
+}
+
+// canCreateCharacter checks a user's character count against a limit
+func (s *CharacterService) canCreateCharacter(ctx context.Context, userID string, limit int) (bool, error) {
 	uid, err := uuid.Parse(userID)
 	if err != nil {
 		return false, character.ErrInvalidUserID
@@ -904,7 +993,7 @@ func (s *CharacterService) CanCreateCharacter(ctx context.Context, userID string
 	if s.cache != nil {
 		cachedCount, found, err := s.cache.GetCharacterCount(ctx, uid)
 		if err == nil && found {
-			return cachedCount < s.config.MaxCharactersPerUser, nil
+			return cachedCount < limit, nil
 		}
 		// Log cache miss but continue
 		if err != nil {
@@ -925,7 +1014,7 @@ func (s *CharacterService) CanCreateCharacter(ctx context.Context, userID string
 		}
 	}
 
-	return count < s.config.MaxCharactersPerUser, nil
+	return count < limit, nil
 }
 
 // SelectCharacter selects a character for gameplay
@@ -947,10 +1036,28 @@ func (s *CharacterService) SelectCharacter(ctx context.Context, characterID stri
 		return character.ErrCharacterDeleted
 	}
 
-	// Update last selected time
-	char.LastSelectedAt = time.Now()
-	if err := s.characterRepo.Update(ctx, char); err != nil {
-		s.logger.WithError(err).Warn("Failed to update last selected time")
+	// Accounts under parental controls may only enter the world within their limits
+	if s.playtime != nil {
+		allowed, err := s.playtime.PlaytimeAllowed(ctx, userID)
+		if err != nil {
+			// Fail closed: a ward must not get around their limits while auth is unreachable
+			s.logger.WithError(err).WithField("user_id", userID).Error("Failed to check play time limits")
+			return character.ErrPlaytimeUnavailable
+		}
+		if !allowed {
+			return character.ErrPlaytimeLimitReached
+		}
+	}
+
+	// Ends the play session of any character selected before this one
+	if err := s.startPlaySession(ctx, char, sessionID); err != nil {
+		return err
+	}
+
+	if s.cache != nil {
+		if err := s.cache.SetSelectedCharacter(ctx, char.UserID, charID, s.cacheTTL.SelectedCharacter); err != nil {
+			s.logger.WithError(err).Warn("Failed to cache selected character")
+		}
 	}
 
 	// Publish character selected event
@@ -969,24 +1076,24 @@ func (s *CharacterService) SelectCharacter(ctx context.Context, characterID stri
 			s.logger.WithError(err).Warn("Failed to publish character selected event")
 		}
 		
-		// Also publish character online event
-		position, _ := s.positionRepo.GetByCharacterID(ctx, charID)
-		if position != nil {
-			onlineEvent := &character.CharacterOnlineEvent{
-				BaseEvent: character.BaseEvent{
-					EventType:   character.EventCharacterOnline,
-					CharacterID: characterID,
-					UserID:      userID,
-				},
-				Name:      char.Name,
-				SessionID: sessionID,
-				WorldID:   position.WorldID,
-				ZoneID:    position.ZoneID,
-			}
-			
-			if err := s.eventPublisher.PublishCharacterOnline(ctx, onlineEvent); err != nil {
-				s.logger.WithError(err).Warn("Failed to publish character online event")
-			}
+		// Also publish character online event; presence tracking relies on it even
+		// for characters without a stored position yet
+		onlineEvent := &character.CharacterOnlineEvent{
+			BaseEvent: character.BaseEvent{
+				EventType:   character.EventCharacterOnline,
+				CharacterID: characterID,
+				UserID:      userID,
+			},
+			Name:      char.Name,
+			SessionID: sessionID,
+		}
+		if position, _ := s.positionRepo.GetByCharacterID(ctx, charID); position != nil {
+			onlineEvent.WorldID = position.WorldID
+			onlineEvent.ZoneID = position.ZoneID
+		}
+		
+		if err := s.eventPublisher.PublishCharacterOnline(ctx, onlineEvent); err != nil {
+			s.logger.WithError(err).Warn("Failed to publish character online event")
 		}
 	}
 
@@ -1000,29 +1107,14 @@ func (s *CharacterService) SelectCharacter(ctx context.Context, characterID stri
 	return nil
 }
 
-// validateCharacterName validates a character name
+// validateCharacterName validates a character name against the configured length limits and the name policy
 func (s *CharacterService) validateCharacterName(name string) error {
 	// Check length
 	if len(name) < s.config.MinCharacterNameLength || len(name) > s.config.MaxCharacterNameLength {
 		return character.ErrInvalidCharacterName
 	}
 
-	// Check for valid characters (letters, numbers, spaces, hyphens)
-	validName := regexp.MustCompile(`^[a-zA-Z0-9\s\-]+$`)
-	if !validName.MatchString(name) {
-		return character.ErrInvalidCharacterName
-	}
-
-	// Check for offensive words (simplified - in production would use a more comprehensive filter)
-	offensiveWords := []string{"admin", "gm", "gamemaster", "moderator"}
-	nameLower := strings.ToLower(name)
-	for _, word := range offensiveWords {
-		if strings.Contains(nameLower, word) {
-			return character.ErrInvalidCharacterName
-		}
-	}
-
-	return nil
+	return s.namePolicy().Check(character.NameKindCharacter, name, false)
 }
 
 // applyAppearanceOptions applies custom appearance options to an appearance entity
@@ -1076,18 +1168,16 @@ func (s *CharacterService) GetSelectedCharacter(ctx context.Context, userID stri
 	return nil, character.ErrNoCharacterSelected
 }
 
-// DeselectCharacter removes the character selection for a user
-func (s *CharacterService) DeselectCharacter(ctx context.Context, userID string) error {
+// DeselectCharacter ends the account's play session and removes its character selection.
+// With a sessionID only a selection held by that login session is ended.
+func (s *CharacterService) DeselectCharacter(ctx context.Context, userID string, sessionID string) error {
 	uid, err := uuid.Parse(userID)
 	if err != nil {
 		return character.ErrInvalidUserID
 	}
 
-	// Remove selected character from cache
-	if s.cache != nil {
-		if err := s.cache.DeleteSelectedCharacter(ctx, uid); err != nil {
-			return fmt.Errorf("failed to deselect character: %w", err)
-		}
+	if err := s.endSelection(ctx, uid, sessionID, character.PlaySessionEndDeselect); err != nil {
+		return err
 	}
 
 	s.logger.WithFields(map[string]interface{}{
@@ -0,0 +1,182 @@
+package character
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/mmorpg-template/backend/internal/domain/character"
+	portsCharacter "github.com/mmorpg-template/backend/internal/ports/character"
+)
+
+// defaultProgression is used until a progression provider is set
+var defaultProgression = character.DefaultProgression()
+
+// GrantExperience adds experience to a character, applying any level-ups it causes.
+// Each level gained awards the stat and skill points of the progression table, and a
+// single level-up event covers a jump of several levels. Experience past the level
+// cap is discarded. The grant ID makes retries safe: a grant ID already applied adds
+// nothing and reports the character's current progression as a duplicate.
+func (s *CharacterService) GrantExperience(ctx context.Context, characterID, grantID string, amount int64, source string) (*character.ExperienceGain, error) {
+	charID, err := uuid.Parse(characterID)
+	if err != nil {
+		return nil, character.ErrInvalidCharacterID
+	}
+	if grantID == "" {
+		return nil, character.ErrGrantIDRequired
+	}
+	if len(grantID) > character.MaxGrantIDLength {
+		return nil, character.ErrInvalidGrantID
+	}
+	if amount <= 0 || amount > character.MaxExperienceGrant {
+		return nil, character.ErrInvalidExperienceAmount
+	}
+
+	char, err := s.characterRepo.GetByID(ctx, charID)
+	if err != nil {
+		return nil, character.ErrCharacterNotFound
+	}
+	if char.IsDeleted {
+		return nil, character.ErrCharacterDeleted
+	}
+
+	// One table for the whole grant, even if it is reloaded meanwhile
+	progression := s.progressionTable()
+
+	gain := &character.ExperienceGain{
+		CharacterID:        charID,
+		Source:             source,
+		Experience:         char.Experience,
+		PreviousLevel:      char.Level,
+		Level:              char.Level,
+		ProgressionVersion: progression.Version,
+	}
+	if char.Level >= progression.MaxLevel {
+		return gain, nil
+	}
+
+	experience, err := s.characterRepo.AddExperience(ctx, charID, grantID, amount, progression.MaxExperience())
+	if errors.Is(err, character.ErrDuplicateGrant) {
+		gain.Duplicate = true
+		return gain, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	// Concurrent grants may land between the read and the add, so derive the
+	// amount from the total the add returned
+	gain.Amount = amount
+	if capped := experience - char.Experience; capped < amount {
+		gain.Amount = capped
+	}
+	gain.Experience = experience
+
+	level := progression.LevelForExperience(experience)
+	if level > char.Level {
+		previous, err := s.levelUp(ctx, char, level, progression)
+		if err != nil {
+			return nil, err
+		}
+		if previous < level {
+			gain.PreviousLevel = previous
+			gain.Level = level
+			gain.StatPoints, gain.SkillPoints = progression.Rewards(previous, level)
+
+			if s.cache != nil {
+				if err := s.cache.DeleteStats(ctx, charID); err != nil {
+					s.logger.WithError(err).Warn("Failed to invalidate stats cache after level up")
+				}
+			}
+
+			char.Level = gain.Level
+			char.Experience = gain.Experience
+			s.captureRestorePoint(ctx, char, nil, character.RestorePointLevelUp)
+		}
+	}
+
+	if s.cache != nil {
+		if err := s.cache.DeleteCharacter(ctx, charID); err != nil {
+			s.logger.WithError(err).Warn("Failed to invalidate character cache after experience grant")
+		}
+		if err := s.cache.DeleteUserCharacters(ctx, char.UserID); err != nil {
+			s.logger.WithError(err).Warn("Failed to invalidate user characters cache after experience grant")
+		}
+	}
+
+	if gain.LeveledUp() && s.eventPublisher != nil {
+		event := &character.CharacterLevelUpEvent{
+			BaseEvent: character.BaseEvent{
+				EventType:   character.EventCharacterLevelUp,
+				CharacterID: characterID,
+				UserID:      char.UserID.String(),
+			},
+			Name:          char.Name,
+			PreviousLevel: gain.PreviousLevel,
+			NewLevel:      gain.Level,
+			Experience:    gain.Experience,
+			StatPoints:    gain.StatPoints,
+			SkillPoints:   gain.SkillPoints,
+			Source:        source,
+
+			ProgressionVersion: gain.ProgressionVersion,
+		}
+
+		if err := s.eventPublisher.PublishCharacterLevelUp(ctx, event); err != nil {
+			s.logger.WithError(err).Warn("Failed to publish character level up event")
+		}
+	}
+
+	fields := map[string]interface{}{
+		"character_id": characterID,
+		"grant_id":     grantID,
+		"amount":       gain.Amount,
+		"source":       source,
+		"experience":   gain.Experience,
+	}
+	if gain.LeveledUp() {
+		fields["previous_level"] = gain.PreviousLevel
+		fields["level"] = gain.Level
+		s.logger.WithFields(fields).Info("Character leveled up")
+	} else {
+		s.logger.WithFields(fields).Debug("Experience granted")
+	}
+
+	return gain, nil
+}
+
+// progressionTable returns the progression table currently in effect
+func (s *CharacterService) progressionTable() *character.ProgressionTable {
+	if s.progression != nil {
+		return s.progression.Progression()
+	}
+	return defaultProgression
+}
+
+// levelUp raises a character's level and adds the stat and skill points of the levels gained,
+// returning the level it replaced. With a stats transactor both are saved in one transaction,
+// so a failed stats write cannot leave the level raised without its points.
+func (s *CharacterService) levelUp(ctx context.Context, char *character.Character, level int, progression *character.ProgressionTable) (int, error) {
+	award := func(previous int, stats *character.Stats) error {
+		statPoints, skillPoints := progression.Rewards(previous, level)
+		stats.AddStatPoints(statPoints)
+		stats.AddSkillPoints(skillPoints)
+		s.characterDefinitions().CalculateDerivedStats(stats, char.ClassType)
+		return nil
+	}
+
+	if s.statsTx != nil {
+		return s.statsTx.RaiseLevel(ctx, char.ID, level, award)
+	}
+
+	previous, err := s.characterRepo.RaiseLevel(ctx, char.ID, level)
+	if err != nil || previous >= level {
+		return previous, err
+	}
+	err = s.updateStats(ctx, char.ID, func(stats *character.Stats, _ portsCharacter.StatLedger) error {
+		return award(previous, stats)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return previous, nil
+}
@@ -0,0 +1,120 @@
+package character_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mmorpg-template/backend/internal/application/character"
+	domainCharacter "github.com/mmorpg-template/backend/internal/domain/character"
+	portsCharacter "github.com/mmorpg-template/backend/internal/ports/character"
+	"github.com/mmorpg-template/backend/pkg/logger"
+)
+
+// memoryStatsTransactor keeps a character's level and stats in memory and only
+// commits them when the whole transaction succeeds
+type memoryStatsTransactor struct {
+	portsCharacter.StatsTransactor
+	level    int
+	stats    domainCharacter.Stats
+	ledger   portsCharacter.StatLedger
+	writeErr error
+}
+
+func (t *memoryStatsTransactor) RaiseLevel(ctx context.Context, characterID uuid.UUID, level int, fn func(previous int, stats *domainCharacter.Stats) error) (int, error) {
+	previous := t.level
+	if previous >= level {
+		return previous, nil
+	}
+
+	stats := t.stats
+	if err := fn(previous, &stats); err != nil {
+		return 0, err
+	}
+	if t.writeErr != nil {
+		return 0, t.writeErr
+	}
+	t.level, t.stats = level, stats
+	return previous, nil
+}
+
+func TestCharacterService_GrantExperienceLevelUp(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(tx *memoryStatsTransactor) (*character.CharacterService, *MockCharacterRepo, *domainCharacter.Character) {
+		char := &domainCharacter.Character{ID: uuid.New(), UserID: uuid.New(), Name: "TestHero", Level: 1}
+		charRepo := new(MockCharacterRepo)
+		charRepo.On("GetByID", ctx, char.ID).Return(char, nil)
+		// Enough for level 3
+		charRepo.On("AddExperience", ctx, char.ID, "grant-1", int64(400), mock.Anything).Return(int64(400), nil)
+
+		service := character.NewCharacterService(charRepo, new(MockAppearanceRepo), new(MockStatsRepo), new(MockPositionRepo),
+			nil, nil, &character.Config{}, logger.NewNoop())
+		service.SetStatsTransactor(tx)
+		return service, charRepo, char
+	}
+
+	t.Run("level and points are saved together", func(t *testing.T) {
+		tx := &memoryStatsTransactor{level: 1}
+		service, charRepo, char := setup(tx)
+
+		gain, err := service.GrantExperience(ctx, char.ID.String(), "grant-1", 400, "quest")
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, gain.PreviousLevel)
+		assert.Equal(t, 3, gain.Level)
+		assert.Equal(t, 3, tx.level)
+		assert.Equal(t, 2*domainCharacter.DefaultStatPointsPerLevel, tx.stats.StatPointsAvailable)
+		assert.Equal(t, 2*domainCharacter.DefaultSkillPointsPerLevel, tx.stats.SkillPointsAvailable)
+		charRepo.AssertNotCalled(t, "RaiseLevel", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("failed stats write leaves the level unchanged", func(t *testing.T) {
+		tx := &memoryStatsTransactor{level: 1, writeErr: errors.New("connection reset")}
+		service, _, char := setup(tx)
+
+		_, err := service.GrantExperience(ctx, char.ID.String(), "grant-1", 400, "quest")
+		assert.Error(t, err)
+
+		assert.Equal(t, 1, tx.level)
+		assert.Zero(t, tx.stats.StatPointsAvailable)
+	})
+
+	t.Run("level already raised by a concurrent grant awards nothing", func(t *testing.T) {
+		tx := &memoryStatsTransactor{level: 3}
+		service, _, char := setup(tx)
+
+		gain, err := service.GrantExperience(ctx, char.ID.String(), "grant-1", 400, "quest")
+		require.NoError(t, err)
+
+		assert.False(t, gain.LeveledUp())
+		assert.Zero(t, tx.stats.StatPointsAvailable)
+	})
+}
+
+func TestCharacterService_GrantExperienceIdempotent(t *testing.T) {
+	ctx := context.Background()
+	char := &domainCharacter.Character{ID: uuid.New(), UserID: uuid.New(), Name: "TestHero", Level: 2, Experience: 150}
+	charRepo := new(MockCharacterRepo)
+	charRepo.On("GetByID", ctx, char.ID).Return(char, nil)
+	charRepo.On("AddExperience", ctx, char.ID, "grant-1", int64(400), mock.Anything).Return(int64(0), domainCharacter.ErrDuplicateGrant)
+
+	service := character.NewCharacterService(charRepo, new(MockAppearanceRepo), new(MockStatsRepo), new(MockPositionRepo),
+		nil, nil, &character.Config{}, logger.NewNoop())
+
+	_, err := service.GrantExperience(ctx, char.ID.String(), "", 400, "quest")
+	assert.Equal(t, domainCharacter.ErrGrantIDRequired, err)
+
+	gain, err := service.GrantExperience(ctx, char.ID.String(), "grant-1", 400, "quest")
+	require.NoError(t, err)
+	assert.True(t, gain.Duplicate)
+	assert.Zero(t, gain.Amount)
+	assert.Equal(t, int64(150), gain.Experience)
+	assert.Equal(t, 2, gain.Level)
+	charRepo.AssertNotCalled(t, "RaiseLevel", mock.Anything, mock.Anything, mock.Anything)
+}
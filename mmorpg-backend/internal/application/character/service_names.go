@@ -0,0 +1,41 @@
+package character
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mmorpg-template/backend/internal/domain/character"
+	portsCharacter "github.com/mmorpg-template/backend/internal/ports/character"
+)
+
+// SetNamePolicy replaces the built-in name policy with a data-driven one
+func (s *CharacterService) SetNamePolicy(provider portsCharacter.NamePolicyProvider) {
+	s.namePolicies = provider
+}
+
+// defaultNamePolicy is used until a name policy provider is set
+var defaultNamePolicy = character.DefaultNamePolicy()
+
+// namePolicy returns the name policy currently in effect
+func (s *CharacterService) namePolicy() *character.NamePolicy {
+	if s.namePolicies != nil {
+		return s.namePolicies.NamePolicy()
+	}
+	return defaultNamePolicy
+}
+
+// CheckCharacterName runs the same checks as character creation: the name policy, then availability
+func (s *CharacterService) CheckCharacterName(ctx context.Context, name string) error {
+	if err := s.validateCharacterName(name); err != nil {
+		return err
+	}
+	return s.checkNameAvailable(ctx, name, uuid.Nil)
+}
+
+// ValidateName checks a player-chosen name of any kind, such as a guild or chat channel name, against the name policy
+func (s *CharacterService) ValidateName(ctx context.Context, kind character.NameKind, name string) error {
+	if kind == character.NameKindCharacter {
+		return s.validateCharacterName(name)
+	}
+	return s.namePolicy().Check(kind, name, false)
+}
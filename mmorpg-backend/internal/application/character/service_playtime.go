@@ -0,0 +1,332 @@
+package character
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mmorpg-template/backend/internal/domain/character"
+	"github.com/mmorpg-template/backend/internal/ports"
+	portsCharacter "github.com/mmorpg-template/backend/internal/ports/character"
+)
+
+const (
+	// playSessionLockKey makes sure only one replica closes stale play sessions at a time
+	playSessionLockKey = "character-play-sessions"
+	playSessionLockTTL = 5 * time.Minute
+
+	// staleSessionBatchSize bounds how many stale play sessions are closed per sweep
+	staleSessionBatchSize = 500
+
+	// maxPlayTimeRange bounds how many days of play time are listed at once
+	maxPlayTimeRange = 366 * 24 * time.Hour
+)
+
+// SetPlaySessions enables play session tracking and play time accounting
+func (s *CharacterService) SetPlaySessions(sessions portsCharacter.PlaySessionRepository) {
+	s.playSessions = sessions
+}
+
+// HeartbeatCharacter renews the login session's lease on the account's selection of a
+// character. It returns ErrSelectedElsewhere when another login session holds the selection
+// and ErrNoCharacterSelected when the character isn't selected, including after the lease
+// expired.
+func (s *CharacterService) HeartbeatCharacter(ctx context.Context, characterID string, userID string, sessionID string) error {
+	charID, err := uuid.Parse(characterID)
+	if err != nil {
+		return character.ErrInvalidCharacterID
+	}
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return character.ErrInvalidUserID
+	}
+	if sessionID == "" {
+		return character.ErrSessionIDRequired
+	}
+	if s.playSessions == nil {
+		selected, err := s.GetSelectedCharacter(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if selected.ID != charID {
+			return character.ErrNoCharacterSelected
+		}
+		return nil
+	}
+
+	now := time.Now()
+	session, err := s.playSessions.Heartbeat(ctx, uid, charID, sessionID, now, s.leaseExpiredBefore(now))
+	if errors.Is(err, character.ErrNoCharacterSelected) {
+		return s.lostSelection(ctx, uid, sessionID)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Keep the cached selection from expiring while the character is played
+	if s.cache != nil {
+		if err := s.cache.SetSelectedCharacter(ctx, uid, session.CharacterID, s.cacheTTL.SelectedCharacter); err != nil {
+			s.logger.WithError(err).Warn("Failed to refresh selected character")
+		}
+	}
+	return nil
+}
+
+// EndPlaySession deselects the account's character, for example when its game client
+// disconnects. With a sessionID only a selection held by that login session is ended, so a
+// client kicked by a takeover can't end the new session. Without a selection it does nothing.
+func (s *CharacterService) EndPlaySession(ctx context.Context, userID string, sessionID string, reason string) error {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return character.ErrInvalidUserID
+	}
+	if reason == "" {
+		reason = character.PlaySessionEndDisconnect
+	}
+	return s.endSelection(ctx, uid, sessionID, reason)
+}
+
+// EndLoginSession deselects the character selected in a login session that ended. An empty
+// sessionID means all of the account's login sessions ended.
+func (s *CharacterService) EndLoginSession(ctx context.Context, userID string, sessionID string) error {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return character.ErrInvalidUserID
+	}
+	return s.endSelection(ctx, uid, sessionID, character.PlaySessionEndLogout)
+}
+
+// CloseStalePlaySessions ends play sessions whose heartbeats stopped before the given time,
+// counting their play time up to the last heartbeat. When another replica holds the lock it
+// does nothing.
+func (s *CharacterService) CloseStalePlaySessions(ctx context.Context, heartbeatBefore time.Time) (int, error) {
+	if s.playSessions == nil {
+		return 0, nil
+	}
+	if s.locker != nil {
+		lock, err := s.locker.TryLock(ctx, playSessionLockKey, playSessionLockTTL)
+		if errors.Is(err, ports.ErrLockHeld) {
+			return 0, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to acquire play session lock: %w", err)
+		}
+		defer func() {
+			if err := lock.Release(context.Background()); err != nil {
+				s.logger.WithError(err).Warn("Failed to release play session lock")
+			}
+		}()
+	}
+
+	closed := 0
+	for {
+		sessions, err := s.playSessions.ListStale(ctx, heartbeatBefore, staleSessionBatchSize)
+		if err != nil {
+			return closed, err
+		}
+		for _, session := range sessions {
+			if err := s.closePlaySession(ctx, session, character.PlaySessionEndTimeout); err != nil {
+				if errors.Is(err, character.ErrNoCharacterSelected) {
+					continue
+				}
+				return closed, err
+			}
+			closed++
+		}
+		if len(sessions) < staleSessionBatchSize {
+			break
+		}
+	}
+
+	if closed > 0 {
+		s.logger.WithField("closed", closed).Info("Closed stale play sessions")
+	}
+	return closed, nil
+}
+
+// GetDailyPlayTime lists a character's play time per UTC day from one day up to, but not
+// including, another, oldest first
+func (s *CharacterService) GetDailyPlayTime(ctx context.Context, characterID string, from, to time.Time) ([]*character.DailyPlayTime, error) {
+	charID, err := uuid.Parse(characterID)
+	if err != nil {
+		return nil, character.ErrInvalidCharacterID
+	}
+	if !to.After(from) || to.Sub(from) > maxPlayTimeRange {
+		return nil, character.ErrInvalidPlayTimeRange
+	}
+	if s.playSessions == nil {
+		return []*character.DailyPlayTime{}, nil
+	}
+	return s.playSessions.ListDailyPlayTime(ctx, charID, from, to)
+}
+
+// startPlaySession takes the account's selection lease for char in a login session. A
+// selection held by the same login session, or whose lease expired, is ended; one held by
+// another login session is taken over or refused according to the selection policy.
+func (s *CharacterService) startPlaySession(ctx context.Context, char *character.Character, sessionID string) error {
+	if s.playSessions == nil {
+		return nil
+	}
+
+	now := time.Now()
+	current, err := s.playSessions.GetOpenByUserID(ctx, char.UserID)
+	switch {
+	case errors.Is(err, character.ErrNoCharacterSelected):
+	case err != nil:
+		return err
+	case current.LeaseExpired(now, s.config.SelectionLease):
+		err = s.closePlaySession(ctx, current, character.PlaySessionEndTimeout)
+	case current.HeldBy(sessionID):
+		err = s.closePlaySession(ctx, current, character.PlaySessionEndSwitch)
+	case s.config.SelectionPolicy == character.SelectionRefuse:
+		return character.ErrSelectedElsewhere
+	default:
+		s.logger.WithFields(map[string]interface{}{
+			"user_id":          char.UserID,
+			"session_id":       sessionID,
+			"previous_session": current.SessionID,
+		}).Info("Character selection taken over from another session")
+		err = s.closePlaySession(ctx, current, character.PlaySessionEndTakeover)
+	}
+	// Someone else closing it first is fine; Start fails if they also started a new one
+	if err != nil && !errors.Is(err, character.ErrNoCharacterSelected) {
+		return err
+	}
+
+	return s.playSessions.Start(ctx, character.NewPlaySession(char, sessionID, now))
+}
+
+// leaseExpiredBefore is the heartbeat time before which selection leases have expired
+func (s *CharacterService) leaseExpiredBefore(now time.Time) time.Time {
+	if s.config.SelectionLease <= 0 {
+		return time.Time{}
+	}
+	return now.Add(-s.config.SelectionLease)
+}
+
+// lostSelection explains why a heartbeat renewed nothing. A selection whose lease expired is
+// closed right away rather than waiting for the sweep.
+func (s *CharacterService) lostSelection(ctx context.Context, userID uuid.UUID, sessionID string) error {
+	session, err := s.playSessions.GetOpenByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case session.LeaseExpired(time.Now(), s.config.SelectionLease):
+		if err := s.closePlaySession(ctx, session, character.PlaySessionEndTimeout); err != nil && !errors.Is(err, character.ErrNoCharacterSelected) {
+			return err
+		}
+	case session.SessionID != sessionID:
+		return character.ErrSelectedElsewhere
+	}
+	// The login session holds the selection, but of another character
+	return character.ErrNoCharacterSelected
+}
+
+// endSelection ends the account's play session, if it belongs to the login session when one
+// is given, and clears the cached selection. Without play session tracking only the cached
+// selection is known.
+func (s *CharacterService) endSelection(ctx context.Context, userID uuid.UUID, sessionID string, reason string) error {
+	if s.playSessions == nil {
+		return s.clearSelection(ctx, userID, reason)
+	}
+
+	session, err := s.playSessions.GetOpenByUserID(ctx, userID)
+	if errors.Is(err, character.ErrNoCharacterSelected) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !session.HeldBy(sessionID) {
+		return nil
+	}
+
+	if err := s.closePlaySession(ctx, session, reason); err != nil && !errors.Is(err, character.ErrNoCharacterSelected) {
+		return err
+	}
+	return nil
+}
+
+// closePlaySession ends a play session, adds its time to the character and announces that
+// the character went offline
+func (s *CharacterService) closePlaySession(ctx context.Context, session *character.PlaySession, reason string) error {
+	session.End(time.Now(), reason)
+	if err := s.playSessions.End(ctx, session); err != nil {
+		return err
+	}
+	s.playSessionEnded(ctx, session)
+	return nil
+}
+
+// playSessionEnded clears the selection of a closed play session and announces that its
+// character went offline
+func (s *CharacterService) playSessionEnded(ctx context.Context, session *character.PlaySession) {
+	reason := session.EndReason
+	if s.cache != nil {
+		// Only clear the selection if it still points at this session's character
+		if selected, err := s.cache.GetSelectedCharacter(ctx, session.UserID); err == nil && selected == session.CharacterID {
+			if err := s.cache.DeleteSelectedCharacter(ctx, session.UserID); err != nil {
+				s.logger.WithError(err).Warn("Failed to clear selected character")
+			}
+		}
+		if err := s.cache.InvalidateCharacterData(ctx, session.CharacterID); err != nil {
+			s.logger.WithError(err).Warn("Failed to invalidate character cache after play session")
+		}
+	}
+
+	s.publishCharacterOffline(ctx, session.CharacterID, session.UserID, session.SessionID, session.Duration(), reason)
+
+	s.logger.WithFields(map[string]interface{}{
+		"character_id": session.CharacterID,
+		"user_id":      session.UserID,
+		"session_id":   session.SessionID,
+		"duration":     session.Duration().String(),
+		"reason":       reason,
+	}).Info("Play session ended")
+}
+
+// clearSelection removes the cached selection and announces that its character went offline.
+// The cached selection doesn't record its login session, so any login session may end it, and
+// the online duration is unknown.
+func (s *CharacterService) clearSelection(ctx context.Context, userID uuid.UUID, reason string) error {
+	if s.cache == nil {
+		return nil
+	}
+
+	charID, _ := s.cache.GetSelectedCharacter(ctx, userID)
+	if err := s.cache.DeleteSelectedCharacter(ctx, userID); err != nil {
+		return fmt.Errorf("failed to deselect character: %w", err)
+	}
+	if charID != uuid.Nil {
+		s.publishCharacterOffline(ctx, charID, userID, "", 0, reason)
+	}
+	return nil
+}
+
+func (s *CharacterService) publishCharacterOffline(ctx context.Context, charID, userID uuid.UUID, sessionID string, duration time.Duration, reason string) {
+	if s.eventPublisher == nil {
+		return
+	}
+
+	event := &character.CharacterOfflineEvent{
+		BaseEvent: character.BaseEvent{
+			EventType:   character.EventCharacterOffline,
+			CharacterID: charID.String(),
+			UserID:      userID.String(),
+		},
+		SessionID:      sessionID,
+		OnlineDuration: duration,
+		Reason:         reason,
+	}
+	if char, err := s.characterRepo.GetByID(ctx, charID); err == nil {
+		event.Name = char.Name
+	}
+
+	if err := s.eventPublisher.PublishCharacterOffline(ctx, event); err != nil {
+		s.logger.WithError(err).Warn("Failed to publish character offline event")
+	}
+}
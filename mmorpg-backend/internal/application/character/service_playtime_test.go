@@ -0,0 +1,98 @@
+package character_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mmorpg-template/backend/internal/application/character"
+	domainCharacter "github.com/mmorpg-template/backend/internal/domain/character"
+	portsCharacter "github.com/mmorpg-template/backend/internal/ports/character"
+	"github.com/mmorpg-template/backend/pkg/logger"
+)
+
+// memoryPlaySessions keeps play sessions in memory and matches heartbeats like the repository
+type memoryPlaySessions struct {
+	portsCharacter.PlaySessionRepository
+	sessions []*domainCharacter.PlaySession
+}
+
+func (r *memoryPlaySessions) GetOpenByUserID(ctx context.Context, userID uuid.UUID) (*domainCharacter.PlaySession, error) {
+	for _, session := range r.sessions {
+		if session.UserID == userID && session.EndedAt == nil {
+			return session, nil
+		}
+	}
+	return nil, domainCharacter.ErrNoCharacterSelected
+}
+
+func (r *memoryPlaySessions) Heartbeat(ctx context.Context, userID, characterID uuid.UUID, sessionID string, at, expiredBefore time.Time) (*domainCharacter.PlaySession, error) {
+	for _, session := range r.sessions {
+		if session.UserID == userID && session.CharacterID == characterID && sessionID != "" && session.SessionID == sessionID &&
+			session.EndedAt == nil && !session.LastHeartbeatAt.Before(expiredBefore) {
+			session.LastHeartbeatAt = at
+			return session, nil
+		}
+	}
+	return nil, domainCharacter.ErrNoCharacterSelected
+}
+
+func (r *memoryPlaySessions) End(ctx context.Context, session *domainCharacter.PlaySession) error {
+	return nil
+}
+
+func (r *memoryPlaySessions) DeleteCharacter(ctx context.Context, characterID uuid.UUID, at time.Time) (*domainCharacter.PlaySession, error) {
+	for _, session := range r.sessions {
+		if session.CharacterID == characterID && session.EndedAt == nil {
+			session.End(at, domainCharacter.PlaySessionEndDeleted)
+			return session, nil
+		}
+	}
+	return nil, nil
+}
+
+func TestCharacterService_HeartbeatCharacter(t *testing.T) {
+	ctx := context.Background()
+	char := &domainCharacter.Character{ID: uuid.New(), UserID: uuid.New(), Name: "TestHero"}
+	other := uuid.New()
+
+	sessions := &memoryPlaySessions{}
+	sessions.sessions = append(sessions.sessions, domainCharacter.NewPlaySession(char, "session-1", time.Now()))
+	service := character.NewCharacterService(new(MockCharacterRepo), new(MockAppearanceRepo), new(MockStatsRepo), new(MockPositionRepo),
+		nil, nil, &character.Config{SelectionLease: time.Minute}, logger.NewNoop())
+	service.SetPlaySessions(sessions)
+
+	heartbeat := func(characterID uuid.UUID, sessionID string) error {
+		return service.HeartbeatCharacter(ctx, characterID.String(), char.UserID.String(), sessionID)
+	}
+
+	assert.NoError(t, heartbeat(char.ID, "session-1"))
+	assert.Equal(t, domainCharacter.ErrSessionIDRequired, heartbeat(char.ID, ""))
+	assert.Equal(t, domainCharacter.ErrSelectedElsewhere, heartbeat(char.ID, "session-2"))
+	assert.Equal(t, domainCharacter.ErrNoCharacterSelected, heartbeat(other, "session-1"))
+}
+
+func TestCharacterService_DeleteCharacterEndsPlaySession(t *testing.T) {
+	ctx := context.Background()
+	char := &domainCharacter.Character{ID: uuid.New(), UserID: uuid.New(), Name: "TestHero"}
+	charRepo := new(MockCharacterRepo)
+	charRepo.On("GetByID", ctx, char.ID).Return(char, nil)
+
+	sessions := &memoryPlaySessions{}
+	sessions.sessions = append(sessions.sessions, domainCharacter.NewPlaySession(char, "session-1", time.Now()))
+	publisher := &recordingPublisher{}
+	service := character.NewCharacterService(charRepo, new(MockAppearanceRepo), new(MockStatsRepo), new(MockPositionRepo),
+		nil, publisher, &character.Config{}, logger.NewNoop())
+	service.SetPlaySessions(sessions)
+
+	assert.NoError(t, service.DeleteCharacter(ctx, char.ID.String(), char.UserID.String()))
+
+	assert.NotNil(t, sessions.sessions[0].EndedAt)
+	if assert.Len(t, publisher.offline, 1) {
+		assert.Equal(t, domainCharacter.PlaySessionEndDeleted, publisher.offline[0].Reason)
+	}
+	charRepo.AssertNotCalled(t, "SoftDelete", ctx, char.ID)
+}
@@ -0,0 +1,142 @@
+package character
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mmorpg-template/backend/internal/domain/character"
+	"github.com/mmorpg-template/backend/internal/ports"
+)
+
+const (
+	// defaultPurgeBatchSize bounds how many expired characters are purged per statement
+	defaultPurgeBatchSize = 100
+
+	// purgeLockKey makes sure only one replica purges at a time. The lock is
+	// refreshed after every batch, so purgeLockTTL only needs to cover one batch.
+	purgeLockKey = "character-purge"
+	purgeLockTTL = 5 * time.Minute
+)
+
+// SetLocker enables distributed locks so background jobs run on one replica at a time
+func (s *CharacterService) SetLocker(locker ports.Locker) {
+	s.locker = locker
+}
+
+// PurgeDeletedCharacters permanently removes soft-deleted characters whose recovery period
+// has ended, in batches, and publishes a purged event for each. It returns how many were
+// purged; when another replica holds the purge lock it does nothing.
+func (s *CharacterService) PurgeDeletedCharacters(ctx context.Context) (int, error) {
+	var lock ports.Lock
+	if s.locker != nil {
+		var err error
+		lock, err = s.locker.TryLock(ctx, purgeLockKey, purgeLockTTL)
+		if errors.Is(err, ports.ErrLockHeld) {
+			return 0, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to acquire purge lock: %w", err)
+		}
+		defer func() {
+			if err := lock.Release(context.Background()); err != nil {
+				s.logger.WithError(err).Warn("Failed to release purge lock")
+			}
+		}()
+	}
+
+	batchSize := s.config.PurgeBatchSize
+	if batchSize <= 0 {
+		batchSize = defaultPurgeBatchSize
+	}
+
+	total := 0
+	for {
+		purged, err := s.characterRepo.PurgeDeleted(ctx, time.Now(), batchSize)
+		if err != nil {
+			return total, err
+		}
+
+		for _, char := range purged {
+			s.afterPurge(ctx, char)
+		}
+
+		total += len(purged)
+		if len(purged) < batchSize {
+			break
+		}
+		if lock != nil {
+			if err := lock.Refresh(ctx, purgeLockTTL); err != nil {
+				// Another replica may have taken over; it picks up where this one stopped
+				s.logger.WithError(err).Warn("Lost purge lock, stopping")
+				break
+			}
+		}
+	}
+
+	if total > 0 {
+		s.logger.WithField("count", total).Info("Purged deleted characters")
+	}
+	return total, nil
+}
+
+// afterPurge drops cached data for a purged character and tells other services it is gone
+func (s *CharacterService) afterPurge(ctx context.Context, char *character.Character) {
+	if s.cache != nil {
+		if err := s.cache.InvalidateCharacterData(ctx, char.ID); err != nil {
+			s.logger.WithError(err).Warn("Failed to invalidate character cache after purge")
+		}
+		if err := s.cache.DeleteUserCharacters(ctx, char.UserID); err != nil {
+			s.logger.WithError(err).Warn("Failed to invalidate character list cache after purge")
+		}
+		if err := s.cache.DeleteCharacterCount(ctx, char.UserID); err != nil {
+			s.logger.WithError(err).Warn("Failed to invalidate character count cache after purge")
+		}
+	}
+
+	if s.eventPublisher != nil {
+		event := &character.CharacterPurgedEvent{
+			BaseEvent: character.BaseEvent{
+				EventType:   character.EventCharacterPurged,
+				CharacterID: char.ID.String(),
+				UserID:      char.UserID.String(),
+			},
+			Name:      char.Name,
+			DeletedAt: char.DeletedAt,
+		}
+		if err := s.eventPublisher.PublishCharacterPurged(ctx, event); err != nil {
+			s.logger.WithError(err).Warn("Failed to publish character purged event")
+		}
+	}
+}
+
+// ForgetDeletedAccount drops what is held for an account the auth service deleted along with
+// its characters, and announces each of those characters as purged
+func (s *CharacterService) ForgetDeletedAccount(ctx context.Context, userID string, characters []*character.Character) error {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	for _, char := range characters {
+		char.UserID = uid
+		s.afterPurge(ctx, char)
+	}
+
+	if s.cache != nil {
+		if err := s.cache.DeleteSelectedCharacter(ctx, uid); err != nil {
+			s.logger.WithError(err).Warn("Failed to clear selected character of deleted account")
+		}
+		if err := s.cache.InvalidateUserData(ctx, uid); err != nil {
+			s.logger.WithError(err).Warn("Failed to invalidate cache of deleted account")
+		}
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"userID":     userID,
+		"characters": len(characters),
+	}).Info("Forgot deleted account")
+	return nil
+}
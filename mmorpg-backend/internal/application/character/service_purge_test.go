@@ -0,0 +1,141 @@
+package character_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mmorpg-template/backend/internal/application/character"
+	domainCharacter "github.com/mmorpg-template/backend/internal/domain/character"
+	"github.com/mmorpg-template/backend/internal/ports"
+	portsCharacter "github.com/mmorpg-template/backend/internal/ports/character"
+	"github.com/mmorpg-template/backend/pkg/logger"
+)
+
+// stubLocker hands out a single stubLock, or fails with err
+type stubLocker struct {
+	lock *stubLock
+	err  error
+}
+
+func (l *stubLocker) TryLock(ctx context.Context, key string, ttl time.Duration) (ports.Lock, error) {
+	if l.err != nil {
+		return nil, l.err
+	}
+	return l.lock, nil
+}
+
+// stubLock counts refreshes and fails them with refreshErr
+type stubLock struct {
+	refreshes  int
+	refreshErr error
+	released   bool
+}
+
+func (l *stubLock) Refresh(ctx context.Context, ttl time.Duration) error {
+	l.refreshes++
+	return l.refreshErr
+}
+
+func (l *stubLock) Release(ctx context.Context) error {
+	l.released = true
+	return nil
+}
+
+// recordingPublisher collects purged events
+type recordingPublisher struct {
+	portsCharacter.EventPublisher
+	purged  []*domainCharacter.CharacterPurgedEvent
+	offline []*domainCharacter.CharacterOfflineEvent
+}
+
+func (p *recordingPublisher) PublishCharacterPurged(ctx context.Context, event *domainCharacter.CharacterPurgedEvent) error {
+	p.purged = append(p.purged, event)
+	return nil
+}
+
+func (p *recordingPublisher) PublishCharacterOffline(ctx context.Context, event *domainCharacter.CharacterOfflineEvent) error {
+	p.offline = append(p.offline, event)
+	return nil
+}
+
+func (p *recordingPublisher) PublishCharacterDeleted(ctx context.Context, event *domainCharacter.CharacterDeletedEvent) error {
+	return nil
+}
+
+func (p *recordingPublisher) PublishCharacterTransferred(ctx context.Context, event *domainCharacter.CharacterTransferredEvent) error {
+	return nil
+}
+
+func purgedBatch(n int) []*domainCharacter.Character {
+	batch := make([]*domainCharacter.Character, n)
+	for i := range batch {
+		batch[i] = &domainCharacter.Character{ID: uuid.New(), UserID: uuid.New(), Name: "Gone", IsDeleted: true}
+	}
+	return batch
+}
+
+func TestCharacterService_PurgeDeletedCharacters(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(locker ports.Locker) (*character.CharacterService, *MockCharacterRepo, *recordingPublisher) {
+		charRepo := new(MockCharacterRepo)
+		publisher := &recordingPublisher{}
+		service := character.NewCharacterService(charRepo, new(MockAppearanceRepo), new(MockStatsRepo), new(MockPositionRepo),
+			nil, publisher, &character.Config{PurgeBatchSize: 2}, logger.NewNoop())
+		service.SetLocker(locker)
+		return service, charRepo, publisher
+	}
+
+	t.Run("purges batches until a short one", func(t *testing.T) {
+		lock := &stubLock{}
+		service, charRepo, publisher := setup(&stubLocker{lock: lock})
+		first, second := purgedBatch(2), purgedBatch(1)
+		charRepo.On("PurgeDeleted", ctx, mock.Anything, 2).Return(first, nil).Once()
+		charRepo.On("PurgeDeleted", ctx, mock.Anything, 2).Return(second, nil).Once()
+
+		total, err := service.PurgeDeletedCharacters(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, 3, total)
+		charRepo.AssertNumberOfCalls(t, "PurgeDeleted", 2)
+		assert.Equal(t, 1, lock.refreshes, "refreshed between batches")
+		assert.True(t, lock.released)
+
+		require.Len(t, publisher.purged, 3, "one event per purged character")
+		for i, char := range append(first, second...) {
+			assert.Equal(t, char.ID.String(), publisher.purged[i].CharacterID)
+			assert.Equal(t, domainCharacter.EventCharacterPurged, publisher.purged[i].EventType)
+		}
+	})
+
+	t.Run("does nothing while another replica holds the lock", func(t *testing.T) {
+		service, charRepo, publisher := setup(&stubLocker{err: ports.ErrLockHeld})
+
+		total, err := service.PurgeDeletedCharacters(ctx)
+		require.NoError(t, err)
+
+		assert.Zero(t, total)
+		charRepo.AssertNotCalled(t, "PurgeDeleted", mock.Anything, mock.Anything, mock.Anything)
+		assert.Empty(t, publisher.purged)
+	})
+
+	t.Run("stops when the lock is lost", func(t *testing.T) {
+		lock := &stubLock{refreshErr: ports.ErrLockLost}
+		service, charRepo, publisher := setup(&stubLocker{lock: lock})
+		charRepo.On("PurgeDeleted", ctx, mock.Anything, 2).Return(purgedBatch(2), nil)
+
+		total, err := service.PurgeDeletedCharacters(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, total)
+		charRepo.AssertNumberOfCalls(t, "PurgeDeleted", 1)
+		assert.Len(t, publisher.purged, 2)
+		assert.True(t, lock.released)
+	})
+}
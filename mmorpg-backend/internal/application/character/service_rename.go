@@ -0,0 +1,135 @@
+package character
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mmorpg-template/backend/internal/domain/character"
+	portsCharacter "github.com/mmorpg-template/backend/internal/ports/character"
+)
+
+// SetNameHistory enables rename history, the rename cooldown and name holds
+func (s *CharacterService) SetNameHistory(names portsCharacter.NameHistoryRepository) {
+	s.names = names
+}
+
+// RenameCharacter changes a character's name, subject to a cooldown. The old name stays
+// reserved for the owner's account for the hold period so it can't be sniped by someone else.
+func (s *CharacterService) RenameCharacter(ctx context.Context, characterID, userID, newName string) (*character.Character, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, character.ErrInvalidUserID
+	}
+	char, err := s.activeCharacter(ctx, characterID)
+	if err != nil {
+		return nil, err
+	}
+	if char.UserID != uid {
+		return nil, character.ErrCharacterBelongsToOther
+	}
+	if char.Name == newName {
+		return nil, character.ErrCharacterNameUnchanged
+	}
+	if err := s.validateCharacterName(newName); err != nil {
+		return nil, err
+	}
+
+	// Case-only changes keep the same name and don't need an availability check
+	if !strings.EqualFold(char.Name, newName) {
+		if err := s.checkNameAvailable(ctx, newName, uid); err != nil {
+			return nil, err
+		}
+	}
+
+	oldName := char.Name
+	change := character.NewNameChange(char.ID, oldName, newName)
+	change.ChangedBy = &uid
+	change.Reason = character.NameChangeReasonPlayer
+	if s.config.NameHoldPeriod > 0 {
+		holdUntil := change.ChangedAt.Add(s.config.NameHoldPeriod)
+		change.HoldUntil = &holdUntil
+	}
+
+	char.Name = newName
+	char.UpdatedAt = change.ChangedAt
+	if s.names != nil {
+		// The cooldown is checked with the character locked, so two renames sent together
+		// can't both pass it
+		err = s.names.Rename(ctx, change, s.config.RenameCooldown)
+	} else {
+		err = s.characterRepo.Update(ctx, char)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if s.cache != nil {
+		if err := s.cache.DeleteCharacter(ctx, char.ID); err != nil {
+			s.logger.WithError(err).Warn("Failed to invalidate character cache after rename")
+		}
+		if err := s.cache.DeleteUserCharacters(ctx, char.UserID); err != nil {
+			s.logger.WithError(err).Warn("Failed to invalidate character list cache after rename")
+		}
+	}
+
+	if s.eventPublisher != nil {
+		event := &character.CharacterRenamedEvent{
+			BaseEvent: character.BaseEvent{
+				EventType:   character.EventCharacterRenamed,
+				CharacterID: char.ID.String(),
+				UserID:      char.UserID.String(),
+			},
+			OldName: oldName,
+			NewName: newName,
+		}
+		if err := s.eventPublisher.PublishCharacterRenamed(ctx, event); err != nil {
+			s.logger.WithError(err).Warn("Failed to publish character renamed event")
+		}
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"character_id": char.ID,
+		"old_name":     oldName,
+		"new_name":     newName,
+	}).Info("Character renamed")
+
+	return char, nil
+}
+
+// GetNameHistory lists a character's previous names, newest first
+func (s *CharacterService) GetNameHistory(ctx context.Context, characterID string) ([]*character.NameChange, error) {
+	char, err := s.activeCharacter(ctx, characterID)
+	if err != nil {
+		return nil, err
+	}
+	if s.names == nil {
+		return []*character.NameChange{}, nil
+	}
+	return s.names.ListByCharacterID(ctx, char.ID)
+}
+
+// checkNameAvailable returns ErrCharacterNameTaken if the name is in use or held for another account
+func (s *CharacterService) checkNameAvailable(ctx context.Context, name string, userID uuid.UUID) error {
+	exists, err := s.characterRepo.NameExists(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to check name availability: %w", err)
+	}
+	if exists {
+		return character.ErrCharacterNameTaken
+	}
+
+	if s.names != nil {
+		held, err := s.names.IsNameHeld(ctx, name, userID, time.Now())
+		if err != nil {
+			// Fail closed, or a held name could be taken while the check is failing
+			return fmt.Errorf("failed to check name hold: %w", err)
+		}
+		if held {
+			return character.ErrCharacterNameTaken
+		}
+	}
+	return nil
+}
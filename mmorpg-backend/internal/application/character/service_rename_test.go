@@ -0,0 +1,77 @@
+package character_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mmorpg-template/backend/internal/application/character"
+	domainCharacter "github.com/mmorpg-template/backend/internal/domain/character"
+	portsCharacter "github.com/mmorpg-template/backend/internal/ports/character"
+	"github.com/mmorpg-template/backend/pkg/logger"
+)
+
+// memoryNameHistory keeps renames in memory and enforces the cooldown like the repository,
+// at the moment of the rename
+type memoryNameHistory struct {
+	portsCharacter.NameHistoryRepository
+	changes []*domainCharacter.NameChange
+	holdErr error
+}
+
+func (r *memoryNameHistory) Rename(ctx context.Context, change *domainCharacter.NameChange, cooldown time.Duration) error {
+	for _, previous := range r.changes {
+		if previous.CharacterID == change.CharacterID && change.ChangedAt.Sub(previous.ChangedAt) < cooldown {
+			return domainCharacter.ErrRenameTooSoon
+		}
+	}
+	r.changes = append(r.changes, change)
+	return nil
+}
+
+func (r *memoryNameHistory) IsNameHeld(ctx context.Context, name string, exceptUserID uuid.UUID, at time.Time) (bool, error) {
+	return false, r.holdErr
+}
+
+func TestCharacterService_RenameCharacter(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(names *memoryNameHistory) (*character.CharacterService, *domainCharacter.Character) {
+		char := &domainCharacter.Character{ID: uuid.New(), UserID: uuid.New(), Name: "OldHero"}
+		charRepo := new(MockCharacterRepo)
+		charRepo.On("GetByID", ctx, char.ID).Return(char, nil)
+		charRepo.On("NameExists", ctx, mock.Anything).Return(false, nil)
+
+		config := &character.Config{MinCharacterNameLength: 3, MaxCharacterNameLength: 16, RenameCooldown: time.Hour}
+		service := character.NewCharacterService(charRepo, new(MockAppearanceRepo), new(MockStatsRepo), new(MockPositionRepo),
+			nil, nil, config, logger.NewNoop())
+		service.SetNameHistory(names)
+		return service, char
+	}
+
+	t.Run("cooldown is enforced by the rename itself", func(t *testing.T) {
+		names := &memoryNameHistory{}
+		service, char := setup(names)
+
+		_, err := service.RenameCharacter(ctx, char.ID.String(), char.UserID.String(), "NewHero")
+		require.NoError(t, err)
+		_, err = service.RenameCharacter(ctx, char.ID.String(), char.UserID.String(), "OtherHero")
+		assert.Equal(t, domainCharacter.ErrRenameTooSoon, err)
+		assert.Len(t, names.changes, 1)
+	})
+
+	t.Run("failed hold check refuses the name", func(t *testing.T) {
+		names := &memoryNameHistory{holdErr: errors.New("connection reset")}
+		service, char := setup(names)
+
+		_, err := service.RenameCharacter(ctx, char.ID.String(), char.UserID.String(), "NewHero")
+		assert.Error(t, err)
+		assert.Empty(t, names.changes)
+	})
+}
@@ -0,0 +1,274 @@
+package character
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mmorpg-template/backend/internal/domain/character"
+	"github.com/mmorpg-template/backend/internal/ports"
+	portsCharacter "github.com/mmorpg-template/backend/internal/ports/character"
+)
+
+const (
+	// defaultRestorePointLimit and maxRestorePointLimit bound how many restore points are listed
+	defaultRestorePointLimit = 50
+	maxRestorePointLimit     = 200
+
+	// restorePointLockKey makes sure only one replica captures restore points at a time
+	restorePointLockKey = "character-restore-points"
+	restorePointLockTTL = 10 * time.Minute
+
+	statsUpdateRollback = "rollback"
+)
+
+// errRestorePointsDisabled is returned when no restore point repository is set
+var errRestorePointsDisabled = errors.New("character restore points are not enabled")
+
+// SetRestorePoints enables restore points and game master rollbacks
+func (s *CharacterService) SetRestorePoints(restorePoints portsCharacter.RestorePointRepository) {
+	s.restorePoints = restorePoints
+}
+
+// CreateRestorePoint captures a character's current state on request by a game master
+func (s *CharacterService) CreateRestorePoint(ctx context.Context, characterID string) (*character.RestorePoint, error) {
+	if s.restorePoints == nil {
+		return nil, errRestorePointsDisabled
+	}
+	char, err := s.activeCharacter(ctx, characterID)
+	if err != nil {
+		return nil, err
+	}
+	return s.createRestorePoint(ctx, char, nil, character.RestorePointManual)
+}
+
+// ListRestorePoints lists a character's most recent restore points, newest first
+func (s *CharacterService) ListRestorePoints(ctx context.Context, characterID string, limit int) ([]*character.RestorePoint, error) {
+	charID, err := uuid.Parse(characterID)
+	if err != nil {
+		return nil, character.ErrInvalidCharacterID
+	}
+	if s.restorePoints == nil {
+		return []*character.RestorePoint{}, nil
+	}
+	if limit <= 0 {
+		limit = defaultRestorePointLimit
+	}
+	if limit > maxRestorePointLimit {
+		limit = maxRestorePointLimit
+	}
+	return s.restorePoints.ListByCharacterID(ctx, charID, limit)
+}
+
+// RollbackCharacter restores a character's level, experience, stats and position to a restore
+// point. The replaced state is kept as a new restore point, so a mistaken rollback can itself
+// be rolled back. Stat allocations made before the rollback can no longer be undone. A character
+// that is being played is refused with ErrCharacterOnline.
+func (s *CharacterService) RollbackCharacter(ctx context.Context, req *portsCharacter.RollbackCharacterRequest) (*character.CharacterRollback, error) {
+	if s.restorePoints == nil {
+		return nil, errRestorePointsDisabled
+	}
+	charID, err := uuid.Parse(req.CharacterID)
+	if err != nil {
+		return nil, character.ErrInvalidCharacterID
+	}
+	pointID, err := uuid.Parse(req.RestorePointID)
+	if err != nil {
+		return nil, character.ErrRestorePointNotFound
+	}
+	performedBy, err := uuid.Parse(req.PerformedBy)
+	if err != nil {
+		return nil, character.ErrInvalidUserID
+	}
+	if req.Reason == "" {
+		return nil, character.ErrRollbackReasonRequired
+	}
+
+	point, err := s.restorePoints.GetByID(ctx, pointID)
+	if err != nil {
+		return nil, err
+	}
+	if point.CharacterID != charID {
+		return nil, character.ErrRestorePointNotFound
+	}
+
+	definitions := s.characterDefinitions()
+	var (
+		char             *character.Character
+		stats            *character.Stats
+		position         *character.Position
+		result           *character.RollbackResult
+		previousLevel    int
+		previousStats    map[string]int
+		previousPosition character.Position
+	)
+	err = s.restorePoints.Rollback(ctx, charID, func(c *character.Character, st *character.Stats, p *character.Position, ledger portsCharacter.StatLedger) (*character.RollbackResult, error) {
+		previousLevel = c.Level
+		previousStats = primaryStatValues(st)
+		previousPosition = *p
+
+		r, err := point.Rollback(definitions, c, st, p, performedBy, req.Reason)
+		if err != nil {
+			return nil, err
+		}
+		// Undoing an allocation made before the rollback would refund points the restored
+		// stats never spent
+		if err := ledger.RevertAllAllocations(ctx, charID, r.Rollback.CreatedAt); err != nil {
+			return nil, err
+		}
+		char, stats, position, result = c, st, p, r
+		return r, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	rollback := result.Rollback
+
+	if s.cache != nil {
+		if err := s.cache.InvalidateCharacterData(ctx, charID); err != nil {
+			s.logger.WithError(err).Warn("Failed to invalidate character cache after rollback")
+		}
+		if err := s.cache.DeleteUserCharacters(ctx, char.UserID); err != nil {
+			s.logger.WithError(err).Warn("Failed to invalidate user characters cache after rollback")
+		}
+	}
+
+	s.afterStatsUpdate(ctx, char, statsUpdateRollback, previousStats, stats, 0)
+
+	if s.eventPublisher != nil {
+		positionEvent := &character.CharacterPositionUpdatedEvent{
+			BaseEvent: character.BaseEvent{
+				EventType:   character.EventCharacterPositionUpdated,
+				CharacterID: char.ID.String(),
+				UserID:      char.UserID.String(),
+			},
+			PreviousPosition: &previousPosition,
+			NewPosition:      position,
+			MovementType:     "rollback",
+		}
+		if err := s.eventPublisher.PublishCharacterPositionUpdated(ctx, positionEvent); err != nil {
+			s.logger.WithError(err).Warn("Failed to publish position updated event")
+		}
+
+		event := &character.CharacterRolledBackEvent{
+			BaseEvent: character.BaseEvent{
+				EventType:   character.EventCharacterRolledBack,
+				CharacterID: char.ID.String(),
+				UserID:      char.UserID.String(),
+			},
+			RestorePointID: point.ID.String(),
+			RestoredTo:     point.CreatedAt,
+			BackupID:       rollback.BackupID.String(),
+			PreviousLevel:  previousLevel,
+			Level:          char.Level,
+			Experience:     char.Experience,
+			PerformedBy:    rollback.PerformedBy.String(),
+			Reason:         rollback.Reason,
+		}
+		if err := s.eventPublisher.PublishCharacterRolledBack(ctx, event); err != nil {
+			s.logger.WithError(err).Warn("Failed to publish character rolled back event")
+		}
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"character_id":     char.ID,
+		"restore_point_id": point.ID,
+		"restored_to":      point.CreatedAt,
+		"backup_id":        rollback.BackupID,
+		"performed_by":     rollback.PerformedBy,
+	}).Info("Character rolled back")
+
+	return rollback, nil
+}
+
+// GetRollbackHistory lists a character's rollbacks, newest first
+func (s *CharacterService) GetRollbackHistory(ctx context.Context, characterID string) ([]*character.CharacterRollback, error) {
+	charID, err := uuid.Parse(characterID)
+	if err != nil {
+		return nil, character.ErrInvalidCharacterID
+	}
+	if s.restorePoints == nil {
+		return []*character.CharacterRollback{}, nil
+	}
+	return s.restorePoints.ListRollbacks(ctx, charID)
+}
+
+// CaptureRestorePoints takes a periodic restore point of every character that changed since
+// the given time and removes restore points past the retention period. When another replica
+// holds the lock it does nothing.
+func (s *CharacterService) CaptureRestorePoints(ctx context.Context, since time.Time) (int64, error) {
+	if s.restorePoints == nil {
+		return 0, nil
+	}
+	if s.locker != nil {
+		lock, err := s.locker.TryLock(ctx, restorePointLockKey, restorePointLockTTL)
+		if errors.Is(err, ports.ErrLockHeld) {
+			return 0, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to acquire restore point lock: %w", err)
+		}
+		defer func() {
+			if err := lock.Release(context.Background()); err != nil {
+				s.logger.WithError(err).Warn("Failed to release restore point lock")
+			}
+		}()
+	}
+
+	captured, err := s.restorePoints.CaptureChanged(ctx, character.RestorePointPeriodic, since)
+	if err != nil {
+		return 0, err
+	}
+
+	var pruned int64
+	if s.config.RestorePointRetention > 0 {
+		if pruned, err = s.restorePoints.DeleteBefore(ctx, time.Now().Add(-s.config.RestorePointRetention)); err != nil {
+			return captured, err
+		}
+	}
+
+	if captured > 0 || pruned > 0 {
+		s.logger.WithFields(map[string]interface{}{
+			"captured": captured,
+			"pruned":   pruned,
+		}).Info("Captured character restore points")
+	}
+	return captured, nil
+}
+
+// captureRestorePoint takes a restore point when something notable happens to a character.
+// Failures are logged, since the change itself should not fail for want of a restore point.
+// A nil stats captures the stats as currently saved.
+func (s *CharacterService) captureRestorePoint(ctx context.Context, char *character.Character, stats *character.Stats, trigger string) {
+	if s.restorePoints == nil {
+		return
+	}
+	if _, err := s.createRestorePoint(ctx, char, stats, trigger); err != nil {
+		s.logger.WithError(err).WithFields(map[string]interface{}{
+			"character_id": char.ID,
+			"trigger":      trigger,
+		}).Warn("Failed to capture restore point")
+	}
+}
+
+func (s *CharacterService) createRestorePoint(ctx context.Context, char *character.Character, stats *character.Stats, trigger string) (*character.RestorePoint, error) {
+	if stats == nil {
+		var err error
+		stats, err = s.statsRepo.GetByCharacterID(ctx, char.ID)
+		if err != nil {
+			return nil, character.ErrStatsNotFound
+		}
+	}
+	position, err := s.positionRepo.GetByCharacterID(ctx, char.ID)
+	if err != nil {
+		return nil, character.ErrPositionNotFound
+	}
+
+	point := character.NewRestorePoint(trigger, char, stats, position)
+	if err := s.restorePoints.Create(ctx, point); err != nil {
+		return nil, err
+	}
+	return point, nil
+}
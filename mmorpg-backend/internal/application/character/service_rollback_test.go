@@ -0,0 +1,88 @@
+package character_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mmorpg-template/backend/internal/application/character"
+	domainCharacter "github.com/mmorpg-template/backend/internal/domain/character"
+	portsCharacter "github.com/mmorpg-template/backend/internal/ports/character"
+	"github.com/mmorpg-template/backend/pkg/logger"
+)
+
+// memoryRollbacks rolls back a character kept in memory, committing only when fn succeeds
+type memoryRollbacks struct {
+	memoryRestorePoints
+	char     domainCharacter.Character
+	stats    domainCharacter.Stats
+	position domainCharacter.Position
+	ledger   *memoryStatLedger
+	online   bool
+}
+
+func (r *memoryRollbacks) GetByID(ctx context.Context, id uuid.UUID) (*domainCharacter.RestorePoint, error) {
+	for _, point := range r.points {
+		if point.ID == id {
+			return point, nil
+		}
+	}
+	return nil, domainCharacter.ErrRestorePointNotFound
+}
+
+func (r *memoryRollbacks) Rollback(ctx context.Context, characterID uuid.UUID, fn func(char *domainCharacter.Character, stats *domainCharacter.Stats, position *domainCharacter.Position, ledger portsCharacter.StatLedger) (*domainCharacter.RollbackResult, error)) error {
+	if r.online {
+		return domainCharacter.ErrCharacterOnline
+	}
+	char, stats, position := r.char, r.stats, r.position
+	result, err := fn(&char, &stats, &position, r.ledger)
+	if err != nil {
+		return err
+	}
+	r.char, r.stats, r.position = char, stats, position
+	r.points = append(r.points, result.Backup)
+	return nil
+}
+
+func TestCharacterService_RollbackCharacter(t *testing.T) {
+	ctx := context.Background()
+	char := &domainCharacter.Character{ID: uuid.New(), UserID: uuid.New(), Name: "TestHero", Level: 5,
+		ClassType: domainCharacter.ClassWarrior, Race: domainCharacter.RaceHuman}
+	baseline := domainCharacter.DefaultDefinitions().BaseAttributes(char.ClassType, char.Race)
+
+	stats := domainCharacter.NewStats(char.ID)
+	stats.SetAttributes(baseline)
+	position := domainCharacter.NewPosition(char.ID)
+	point := domainCharacter.NewRestorePoint(domainCharacter.RestorePointManual, char, stats, position)
+
+	// Points allocated after the restore point was taken
+	stats.Strength += 3
+	rollbacks := &memoryRollbacks{char: *char, stats: *stats, position: *position, ledger: &memoryStatLedger{}}
+	rollbacks.points = []*domainCharacter.RestorePoint{point}
+
+	service := character.NewCharacterService(new(MockCharacterRepo), new(MockAppearanceRepo), new(MockStatsRepo), new(MockPositionRepo),
+		nil, nil, &character.Config{}, logger.NewNoop())
+	service.SetRestorePoints(rollbacks)
+
+	req := &portsCharacter.RollbackCharacterRequest{
+		CharacterID:    char.ID.String(),
+		RestorePointID: point.ID.String(),
+		PerformedBy:    uuid.New().String(),
+		Reason:         "Exploit",
+	}
+	_, err := service.RollbackCharacter(ctx, req)
+	require.NoError(t, err)
+
+	assert.Equal(t, baseline.Strength, rollbacks.stats.Strength)
+	// Undoing the later allocation would take strength below the restored value
+	assert.Equal(t, 1, rollbacks.ledger.revertedAll)
+
+	// A character being played would overwrite the rollback with its next save
+	rollbacks.online = true
+	_, err = service.RollbackCharacter(ctx, req)
+	assert.Equal(t, domainCharacter.ErrCharacterOnline, err)
+	assert.Equal(t, 1, rollbacks.ledger.revertedAll)
+}
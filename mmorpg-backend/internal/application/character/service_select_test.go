@@ -0,0 +1,57 @@
+package character_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mmorpg-template/backend/internal/application/character"
+	domainCharacter "github.com/mmorpg-template/backend/internal/domain/character"
+	"github.com/mmorpg-template/backend/pkg/logger"
+)
+
+type stubPlaytimeChecker struct {
+	allowed bool
+	err     error
+}
+
+func (c *stubPlaytimeChecker) PlaytimeAllowed(ctx context.Context, userID string) (bool, error) {
+	return c.allowed, c.err
+}
+
+func TestCharacterService_SelectCharacterPlaytime(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	char := &domainCharacter.Character{ID: uuid.New(), UserID: userID, Name: "TestHero"}
+
+	tests := []struct {
+		name    string
+		checker *stubPlaytimeChecker
+		want    error
+	}{
+		{"within limits", &stubPlaytimeChecker{allowed: true}, nil},
+		{"limit reached", &stubPlaytimeChecker{allowed: false}, domainCharacter.ErrPlaytimeLimitReached},
+		{"auth unreachable fails closed", &stubPlaytimeChecker{err: errors.New("nats: timeout")}, domainCharacter.ErrPlaytimeUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			charRepo := new(MockCharacterRepo)
+			charRepo.On("GetByID", ctx, char.ID).Return(char, nil)
+
+			service := character.NewCharacterService(charRepo, new(MockAppearanceRepo), new(MockStatsRepo), new(MockPositionRepo),
+				nil, nil, &character.Config{}, logger.NewNoop())
+			service.SetPlaytimeChecker(tt.checker)
+
+			err := service.SelectCharacter(ctx, char.ID.String(), userID.String(), uuid.New().String())
+			if tt.want == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package character
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/mmorpg-template/backend/internal/domain/character"
+	portsCharacter "github.com/mmorpg-template/backend/internal/ports/character"
+)
+
+// importNameAttempts bounds how many numbered variants of a taken name an import tries
+const importNameAttempts = 20
+
+// errImportDisabled is returned when no character importer is set
+var errImportDisabled = errors.New("character import is not enabled")
+
+// SetImporter enables creating characters from snapshots
+func (s *CharacterService) SetImporter(importer portsCharacter.CharacterImporter) {
+	s.importer = importer
+}
+
+// ExportCharacter captures a character with its appearance, stats and position as a snapshot
+func (s *CharacterService) ExportCharacter(ctx context.Context, characterID string) (*character.CharacterSnapshot, error) {
+	char, err := s.activeCharacter(ctx, characterID)
+	if err != nil {
+		return nil, err
+	}
+
+	appearance, err := s.appearanceRepo.GetByCharacterID(ctx, char.ID)
+	if err != nil {
+		return nil, character.ErrAppearanceNotFound
+	}
+	stats, err := s.statsRepo.GetByCharacterID(ctx, char.ID)
+	if err != nil {
+		return nil, character.ErrStatsNotFound
+	}
+	position, err := s.positionRepo.GetByCharacterID(ctx, char.ID)
+	if err != nil {
+		return nil, character.ErrPositionNotFound
+	}
+
+	return character.NewCharacterSnapshot(char, appearance, stats, position), nil
+}
+
+// ImportCharacter recreates a snapshot as a new character on an account. Without a name the
+// snapshot's name is used, numbered if it is taken; a given name must be free. A slot of zero
+// picks the account's lowest free slot.
+func (s *CharacterService) ImportCharacter(ctx context.Context, req *portsCharacter.ImportCharacterRequest) (*character.Character, error) {
+	if s.importer == nil {
+		return nil, errImportDisabled
+	}
+	if req.Snapshot == nil {
+		return nil, character.ErrInvalidSnapshot
+	}
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		return nil, character.ErrInvalidUserID
+	}
+
+	count, err := s.characterRepo.CountByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count characters: %w", err)
+	}
+	limit, err := s.accountCharacterLimit(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= limit {
+		return nil, character.ErrCharacterLimitReached
+	}
+
+	slot := req.SlotNumber
+	if slot == 0 {
+		if slot, err = s.freeSlot(ctx, userID); err != nil {
+			return nil, err
+		}
+	} else if slot < 1 || slot > character.MaxSlotNumber {
+		return nil, character.ErrInvalidSlotNumber
+	} else if existing, err := s.characterRepo.GetByUserIDAndSlot(ctx, userID, slot); err == nil && existing != nil {
+		return nil, character.ErrSlotOccupied
+	}
+
+	name := req.Name
+	if name != "" {
+		if err := s.validateCharacterName(name); err != nil {
+			return nil, err
+		}
+		if err := s.checkNameAvailable(ctx, name, userID); err != nil {
+			return nil, err
+		}
+	} else if name, err = s.importName(ctx, req.Snapshot.Character.Name, userID); err != nil {
+		return nil, err
+	}
+
+	imported, err := req.Snapshot.Restore(s.characterDefinitions(), s.progressionTable().MaxLevel, userID, name, slot)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.importer.ImportCharacter(ctx, imported); err != nil {
+		return nil, err
+	}
+	char := imported.Character
+
+	if s.cache != nil {
+		if err := s.cache.InvalidateUserData(ctx, userID); err != nil {
+			s.logger.WithError(err).Warn("Failed to invalidate user cache after character import")
+		}
+	}
+
+	if s.eventPublisher != nil {
+		event := &character.CharacterCreatedEvent{
+			BaseEvent: character.BaseEvent{
+				EventType:   character.EventCharacterCreated,
+				CharacterID: char.ID.String(),
+				UserID:      userID.String(),
+			},
+			Name:       char.Name,
+			ClassType:  char.ClassType,
+			Race:       char.Race,
+			Gender:     char.Gender,
+			Level:      char.Level,
+			SlotNumber: char.SlotNumber,
+		}
+		if err := s.eventPublisher.PublishCharacterCreated(ctx, event); err != nil {
+			s.logger.WithError(err).Warn("Failed to publish character created event")
+		}
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"character_id":     char.ID,
+		"user_id":          userID,
+		"name":             char.Name,
+		"source_id":        req.Snapshot.SourceID,
+		"snapshot_version": req.Snapshot.Version,
+		"performed_by":     req.PerformedBy,
+	}).Info("Character imported from snapshot")
+
+	return char, nil
+}
+
+// importName returns name, or the first numbered variant of it that is valid and free
+func (s *CharacterService) importName(ctx context.Context, name string, userID uuid.UUID) (string, error) {
+	var lastErr error
+	for n := 1; n <= importNameAttempts; n++ {
+		candidate := name
+		if n > 1 {
+			suffix := strconv.Itoa(n)
+			runes := []rune(name)
+			for len(string(runes))+len(suffix) > s.config.MaxCharacterNameLength && len(runes) > 0 {
+				runes = runes[:len(runes)-1]
+			}
+			candidate = string(runes) + suffix
+		}
+
+		if lastErr = s.validateCharacterName(candidate); lastErr != nil {
+			continue
+		}
+		if lastErr = s.checkNameAvailable(ctx, candidate, userID); lastErr == nil {
+			return candidate, nil
+		}
+		if lastErr != character.ErrCharacterNameTaken {
+			return "", lastErr
+		}
+	}
+	return "", lastErr
+}
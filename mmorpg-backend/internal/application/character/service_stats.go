@@ -0,0 +1,277 @@
+package character
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mmorpg-template/backend/internal/domain/character"
+	portsCharacter "github.com/mmorpg-template/backend/internal/ports/character"
+)
+
+// errStatLedgerUnavailable is returned by respec and undo when no stats transactor is set
+var errStatLedgerUnavailable = errors.New("stat ledger is not configured")
+
+// Stats update types reported in stats updated events
+const (
+	statsUpdateAllocation = "stat_allocation"
+	statsUpdateRespec     = "respec"
+	statsUpdateUndo       = "allocation_undo"
+)
+
+// SetStatsTransactor enables atomic stat changes with allocation history, respecs and undo
+func (s *CharacterService) SetStatsTransactor(transactor portsCharacter.StatsTransactor) {
+	s.statsTx = transactor
+}
+
+// GetRespecQuote returns the cost and availability of a character's next respec
+func (s *CharacterService) GetRespecQuote(ctx context.Context, characterID string) (*character.RespecQuote, error) {
+	if s.statsTx == nil {
+		return nil, errStatLedgerUnavailable
+	}
+	char, err := s.activeCharacter(ctx, characterID)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := s.statsTx.Ledger().GetRespecHistory(ctx, char.ID)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := s.statsRepo.GetByCharacterID(ctx, char.ID)
+	if err != nil {
+		return nil, character.ErrStatsNotFound
+	}
+
+	quote := s.respecPolicy().Quote(history)
+	quote.RefundablePoints = stats.RefundablePoints(s.characterDefinitions().BaseAttributes(char.ClassType, char.Race))
+	return quote, nil
+}
+
+// RespecStats resets a character's primary stats to the class and race baseline and returns
+// all allocated points. Each respec costs more and waits longer than the one before.
+func (s *CharacterService) RespecStats(ctx context.Context, characterID string) (*character.RespecResult, error) {
+	if s.statsTx == nil {
+		return nil, errStatLedgerUnavailable
+	}
+	char, err := s.activeCharacter(ctx, characterID)
+	if err != nil {
+		return nil, err
+	}
+
+	definitions := s.characterDefinitions()
+	baseline := definitions.BaseAttributes(char.ClassType, char.Race)
+	policy := s.respecPolicy()
+	now := time.Now()
+
+	var result *character.RespecResult
+	var previous map[string]int
+	var before character.Stats
+	err = s.statsTx.UpdateStats(ctx, char.ID, func(stats *character.Stats, ledger portsCharacter.StatLedger) error {
+		// Checked under the stats lock so concurrent respecs cannot both pass
+		history, err := ledger.GetRespecHistory(ctx, char.ID)
+		if err != nil {
+			return err
+		}
+		quote := policy.Quote(history)
+		if !quote.Available(now) {
+			return character.ErrRespecOnCooldown
+		}
+		before = *stats
+		previous = primaryStatValues(stats)
+		refunded := stats.Respec(baseline)
+		if refunded == 0 {
+			return character.ErrNothingToRespec
+		}
+		definitions.CalculateDerivedStats(stats, char.ClassType)
+
+		respec := &character.Respec{
+			ID:             uuid.New(),
+			CharacterID:    char.ID,
+			Number:         quote.Number,
+			RefundedPoints: refunded,
+			Cost:           quote.Cost,
+			CreatedAt:      now,
+		}
+		if err := ledger.RevertAllAllocations(ctx, char.ID, now); err != nil {
+			return err
+		}
+		if err := ledger.RecordRespec(ctx, respec); err != nil {
+			return err
+		}
+
+		result = &character.RespecResult{
+			Stats:           stats,
+			Respec:          respec,
+			NextAvailableAt: now.Add(policy.Cooldown(respec.Number)),
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Taken once the respec is committed, from the stats it replaced
+	s.captureRestorePoint(ctx, char, &before, character.RestorePointRespec)
+	s.afterStatsUpdate(ctx, char, statsUpdateRespec, previous, result.Stats, result.Respec.Cost)
+
+	s.logger.WithFields(map[string]interface{}{
+		"character_id": char.ID,
+		"respec":       result.Respec.Number,
+		"refunded":     result.Respec.RefundedPoints,
+		"cost":         result.Respec.Cost,
+	}).Info("Character stats respecced")
+
+	return result, nil
+}
+
+// UndoStatAllocations reverts up to count of the character's most recent allocations made
+// within the undo window
+func (s *CharacterService) UndoStatAllocations(ctx context.Context, characterID string, count int) (*character.StatUndoResult, error) {
+	if s.statsTx == nil {
+		return nil, errStatLedgerUnavailable
+	}
+	policy := s.respecPolicy()
+	if count < 1 || count > policy.MaxUndo {
+		return nil, character.ErrInvalidUndoCount
+	}
+	char, err := s.activeCharacter(ctx, characterID)
+	if err != nil {
+		return nil, err
+	}
+
+	definitions := s.characterDefinitions()
+	baseline := definitions.BaseAttributes(char.ClassType, char.Race)
+	now := time.Now()
+
+	var result *character.StatUndoResult
+	var previous map[string]int
+	err = s.statsTx.UpdateStats(ctx, char.ID, func(stats *character.Stats, ledger portsCharacter.StatLedger) error {
+		allocations, err := ledger.RecentAllocations(ctx, char.ID, now.Add(-policy.UndoWindow), count)
+		if err != nil {
+			return err
+		}
+		if len(allocations) == 0 {
+			return character.ErrNothingToUndo
+		}
+
+		previous = primaryStatValues(stats)
+		ids := make([]uuid.UUID, 0, len(allocations))
+		for _, allocation := range allocations {
+			if err := stats.DeallocateStatPoints(allocation.Stat, allocation.Points, baseline); err != nil {
+				return err
+			}
+			ids = append(ids, allocation.ID)
+		}
+		definitions.CalculateDerivedStats(stats, char.ClassType)
+
+		if err := ledger.RevertAllocations(ctx, ids, now); err != nil {
+			return err
+		}
+		result = &character.StatUndoResult{Stats: stats, Undone: allocations}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.afterStatsUpdate(ctx, char, statsUpdateUndo, previous, result.Stats, 0)
+	return result, nil
+}
+
+// activeCharacter loads a character that is not deleted
+func (s *CharacterService) activeCharacter(ctx context.Context, characterID string) (*character.Character, error) {
+	charID, err := uuid.Parse(characterID)
+	if err != nil {
+		return nil, character.ErrInvalidCharacterID
+	}
+
+	char, err := s.characterRepo.GetByID(ctx, charID)
+	if err != nil {
+		return nil, character.ErrCharacterNotFound
+	}
+	if char.IsDeleted {
+		return nil, character.ErrCharacterDeleted
+	}
+	return char, nil
+}
+
+// updateStats applies fn to a character's stats and saves them. With a stats transactor the
+// change is atomic and fn gets a ledger in the same transaction; without one the ledger is nil.
+func (s *CharacterService) updateStats(ctx context.Context, charID uuid.UUID, fn func(stats *character.Stats, ledger portsCharacter.StatLedger) error) error {
+	if s.statsTx != nil {
+		return s.statsTx.UpdateStats(ctx, charID, fn)
+	}
+
+	stats, err := s.statsRepo.GetByCharacterID(ctx, charID)
+	if err != nil {
+		return character.ErrStatsNotFound
+	}
+	if err := fn(stats, nil); err != nil {
+		return err
+	}
+	if err := s.statsRepo.Update(ctx, stats); err != nil {
+		return fmt.Errorf("failed to update stats: %w", err)
+	}
+	return nil
+}
+
+// afterStatsUpdate invalidates cached stats and publishes a stats updated event
+func (s *CharacterService) afterStatsUpdate(ctx context.Context, char *character.Character, updateType string, previous map[string]int, stats *character.Stats, cost int64) {
+	if s.cache != nil {
+		if err := s.cache.DeleteStats(ctx, char.ID); err != nil {
+			s.logger.WithError(err).Warn("Failed to invalidate stats cache after update")
+		}
+	}
+
+	if s.eventPublisher == nil {
+		return
+	}
+
+	current := primaryStatValues(stats)
+	changes := make(map[string]int)
+	for name, value := range current {
+		if diff := value - previous[name]; diff != 0 {
+			changes[name] = diff
+		}
+	}
+
+	event := &character.CharacterStatsUpdatedEvent{
+		BaseEvent: character.BaseEvent{
+			EventType:   character.EventCharacterStatsUpdated,
+			CharacterID: char.ID.String(),
+			UserID:      char.UserID.String(),
+		},
+		UpdateType:    updateType,
+		PreviousStats: previous,
+		NewStats:      current,
+		Changes:       changes,
+		Cost:          cost,
+	}
+	if err := s.eventPublisher.PublishCharacterStatsUpdated(ctx, event); err != nil {
+		s.logger.WithError(err).Warn("Failed to publish stats updated event")
+	}
+}
+
+// primaryStatValues returns the primary stats and available points by name
+func primaryStatValues(stats *character.Stats) map[string]int {
+	return map[string]int{
+		character.AttributeStrength:     stats.Strength,
+		character.AttributeDexterity:    stats.Dexterity,
+		character.AttributeIntelligence: stats.Intelligence,
+		character.AttributeWisdom:       stats.Wisdom,
+		character.AttributeConstitution: stats.Constitution,
+		character.AttributeCharisma:     stats.Charisma,
+		"stat_points_available":         stats.StatPointsAvailable,
+	}
+}
+
+// respecPolicy returns the configured respec rules, or the built-in ones
+func (s *CharacterService) respecPolicy() character.RespecPolicy {
+	if s.config.Respec == (character.RespecPolicy{}) {
+		return character.DefaultRespecPolicy()
+	}
+	return s.config.Respec
+}
@@ -0,0 +1,111 @@
+package character_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mmorpg-template/backend/internal/application/character"
+	domainCharacter "github.com/mmorpg-template/backend/internal/domain/character"
+	portsCharacter "github.com/mmorpg-template/backend/internal/ports/character"
+	"github.com/mmorpg-template/backend/pkg/logger"
+)
+
+// memoryStatLedger records respecs in memory
+type memoryStatLedger struct {
+	portsCharacter.StatLedger
+	respecs     []*domainCharacter.Respec
+	recordErr   error
+	revertedAll int
+}
+
+func (l *memoryStatLedger) GetRespecHistory(ctx context.Context, characterID uuid.UUID) (*domainCharacter.RespecHistory, error) {
+	return &domainCharacter.RespecHistory{Count: len(l.respecs)}, nil
+}
+
+func (l *memoryStatLedger) RevertAllAllocations(ctx context.Context, characterID uuid.UUID, at time.Time) error {
+	l.revertedAll++
+	return nil
+}
+
+func (l *memoryStatLedger) RecordRespec(ctx context.Context, respec *domainCharacter.Respec) error {
+	if l.recordErr != nil {
+		return l.recordErr
+	}
+	l.respecs = append(l.respecs, respec)
+	return nil
+}
+
+// UpdateStats commits the stats only when fn succeeds
+func (t *memoryStatsTransactor) UpdateStats(ctx context.Context, characterID uuid.UUID, fn func(stats *domainCharacter.Stats, ledger portsCharacter.StatLedger) error) error {
+	stats := t.stats
+	if err := fn(&stats, t.ledger); err != nil {
+		return err
+	}
+	t.stats = stats
+	return nil
+}
+
+// memoryRestorePoints collects created restore points
+type memoryRestorePoints struct {
+	portsCharacter.RestorePointRepository
+	points []*domainCharacter.RestorePoint
+}
+
+func (r *memoryRestorePoints) Create(ctx context.Context, point *domainCharacter.RestorePoint) error {
+	r.points = append(r.points, point)
+	return nil
+}
+
+func TestCharacterService_RespecStatsRestorePoint(t *testing.T) {
+	ctx := context.Background()
+	char := &domainCharacter.Character{ID: uuid.New(), UserID: uuid.New(), Name: "TestHero", Level: 5,
+		ClassType: domainCharacter.ClassWarrior, Race: domainCharacter.RaceHuman}
+	baseline := domainCharacter.DefaultDefinitions().BaseAttributes(char.ClassType, char.Race)
+
+	setup := func(ledger *memoryStatLedger) (*character.CharacterService, *memoryStatsTransactor, *memoryRestorePoints) {
+		stats := domainCharacter.NewStats(char.ID)
+		stats.SetAttributes(baseline)
+		stats.Strength += 3
+
+		charRepo := new(MockCharacterRepo)
+		charRepo.On("GetByID", ctx, char.ID).Return(char, nil)
+		positionRepo := new(MockPositionRepo)
+		positionRepo.On("GetByCharacterID", ctx, char.ID).Return(&domainCharacter.Position{CharacterID: char.ID}, nil)
+
+		tx := &memoryStatsTransactor{stats: *stats, ledger: ledger}
+		restorePoints := &memoryRestorePoints{}
+		service := character.NewCharacterService(charRepo, new(MockAppearanceRepo), new(MockStatsRepo), positionRepo,
+			nil, nil, &character.Config{}, logger.NewNoop())
+		service.SetStatsTransactor(tx)
+		service.SetRestorePoints(restorePoints)
+		return service, tx, restorePoints
+	}
+
+	t.Run("captures the stats the respec replaced", func(t *testing.T) {
+		service, tx, restorePoints := setup(&memoryStatLedger{})
+
+		_, err := service.RespecStats(ctx, char.ID.String())
+		require.NoError(t, err)
+
+		assert.Equal(t, baseline.Strength, tx.stats.Strength)
+		require.Len(t, restorePoints.points, 1)
+		assert.Equal(t, domainCharacter.RestorePointRespec, restorePoints.points[0].Trigger)
+		assert.Equal(t, baseline.Strength+3, restorePoints.points[0].Stats.Strength)
+	})
+
+	t.Run("failed respec leaves no restore point", func(t *testing.T) {
+		service, tx, restorePoints := setup(&memoryStatLedger{recordErr: errors.New("connection reset")})
+
+		_, err := service.RespecStats(ctx, char.ID.String())
+		assert.Error(t, err)
+
+		assert.Equal(t, baseline.Strength+3, tx.stats.Strength)
+		assert.Empty(t, restorePoints.points)
+	})
+}
@@ -69,6 +69,16 @@ func (m *MockCharacterRepo) Delete(ctx context.Context, id uuid.UUID) error {
 	return args.Error(0)
 }
 
+func (m *MockCharacterRepo) AddExperience(ctx context.Context, id uuid.UUID, grantID string, amount, max int64) (int64, error) {
+	args := m.Called(ctx, id, grantID, amount, max)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockCharacterRepo) RaiseLevel(ctx context.Context, id uuid.UUID, level int) (int, error) {
+	args := m.Called(ctx, id, level)
+	return args.Int(0), args.Error(1)
+}
+
 func (m *MockCharacterRepo) SoftDelete(ctx context.Context, id uuid.UUID) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
@@ -84,6 +94,14 @@ func (m *MockCharacterRepo) CleanupDeleted(ctx context.Context) error {
 	return args.Error(0)
 }
 
+func (m *MockCharacterRepo) PurgeDeleted(ctx context.Context, before time.Time, limit int) ([]*domainCharacter.Character, error) {
+	args := m.Called(ctx, before, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domainCharacter.Character), args.Error(1)
+}
+
 func (m *MockCharacterRepo) NameExists(ctx context.Context, name string) (bool, error) {
 	args := m.Called(ctx, name)
 	return args.Bool(0), args.Error(1)
@@ -94,6 +112,11 @@ func (m *MockCharacterRepo) CountByUserID(ctx context.Context, userID uuid.UUID)
 	return args.Int(0), args.Error(1)
 }
 
+func (m *MockCharacterRepo) GetAccountLimit(ctx context.Context, userID uuid.UUID) (int, bool, error) {
+	args := m.Called(ctx, userID)
+	return args.Int(0), args.Bool(1), args.Error(2)
+}
+
 // Mock appearance repository
 type MockAppearanceRepo struct {
 	mock.Mock
@@ -247,6 +270,8 @@ func TestCharacterService_CreateCharacter(t *testing.T) {
 		appearanceRepo,
 		statsRepo,
 		positionRepo,
+		nil,
+		nil,
 		config,
 		log,
 	)
@@ -351,6 +376,8 @@ func TestCharacterService_GetCharacter(t *testing.T) {
 		appearanceRepo,
 		statsRepo,
 		positionRepo,
+		nil,
+		nil,
 		config,
 		log,
 	)
@@ -432,6 +459,8 @@ func TestCharacterService_DeleteAndRestore(t *testing.T) {
 		appearanceRepo,
 		statsRepo,
 		positionRepo,
+		nil,
+		nil,
 		config,
 		log,
 	)
@@ -443,7 +472,8 @@ func TestCharacterService_DeleteAndRestore(t *testing.T) {
 			Name:   "TestHero",
 		}
 		
-		charRepo.On("GetByID", ctx, charID).Return(char, nil).Once()
+		// Once for the ownership check, once more for the deleted event
+		charRepo.On("GetByID", ctx, charID).Return(char, nil).Twice()
 		charRepo.On("SoftDelete", ctx, charID).Return(nil).Once()
 		
 		err := service.DeleteCharacter(ctx, charID.String(), userID.String())
@@ -462,7 +492,8 @@ func TestCharacterService_DeleteAndRestore(t *testing.T) {
 			DeletionScheduledAt: &deletionTime,
 		}
 		
-		charRepo.On("GetByID", ctx, charID).Return(char, nil).Once()
+		// Once for the ownership check, once more to check the recovery period
+		charRepo.On("GetByID", ctx, charID).Return(char, nil).Twice()
 		charRepo.On("Restore", ctx, charID).Return(nil).Once()
 		
 		err := service.RestoreCharacter(ctx, charID.String(), userID.String())
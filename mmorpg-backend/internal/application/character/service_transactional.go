@@ -4,6 +4,7 @@
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/mmorpg-template/backend/internal/domain/character"
@@ -53,7 +54,7 @@ func (s *TransactionalCharacterService) CreateCharacterWithTransaction(ctx conte
 	}
 
 	// Pre-transaction validations
-	canCreate, err := s.CanCreateCharacter(ctx, req.UserID)
+	canCreate, err := s.canCreateCharacter(ctx, req.UserID, s.characterLimit(req.IsGuest))
 	if err != nil {
 		return nil, fmt.Errorf("failed to check character limit: %w", err)
 	}
@@ -65,19 +66,13 @@ func (s *TransactionalCharacterService) CreateCharacterWithTransaction(ctx conte
 		return nil, err
 	}
 
-	exists, err := s.characterRepo.NameExists(ctx, req.Name)
-	if err != nil {
-		return nil, fmt.Errorf("failed to check name availability: %w", err)
-	}
-	if exists {
-		return nil, character.ErrCharacterNameTaken
+	if err := s.checkNameAvailable(ctx, req.Name, userID); err != nil {
+		return nil, err
 	}
 
-	if !character.IsValidClass(req.ClassType) {
-		return nil, character.ErrInvalidClass
-	}
-	if !character.IsValidRace(req.Race) {
-		return nil, character.ErrInvalidRace
+	definitions := s.characterDefinitions()
+	if err := definitions.CheckCombination(req.ClassType, req.Race); err != nil {
+		return nil, err
 	}
 	if !character.IsValidGender(req.Gender) {
 		return nil, character.ErrInvalidGender
@@ -146,15 +141,14 @@ func (s *TransactionalCharacterService) CreateCharacterWithTransaction(ctx conte
 	}
 
 	// Create stats
-	stats := character.NewStats(char.ID, req.ClassType)
+	stats := definitions.NewStats(char.ID, req.ClassType, req.Race)
 	if err := txStatsRepo.Create(ctx, stats); err != nil {
 		_ = tx.Rollback()
 		return nil, fmt.Errorf("failed to create stats: %w", err)
 	}
 
 	// Create position
-	position := character.NewPosition(char.ID)
-	position.ApplyClassStartingPosition(req.ClassType)
+	position := definitions.StartingPosition(char.ID, req.ClassType, req.Race)
 	if err := txPositionRepo.Create(ctx, position); err != nil {
 		_ = tx.Rollback()
 		return nil, fmt.Errorf("failed to create position: %w", err)
@@ -191,14 +185,14 @@ func (r *transactionalCharacterRepo) Create(ctx context.Context, char *character
 			last_played_at, total_play_time
 		) VALUES (
 			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11,
-			$12, $13, $14, $15, $16
+			$12, $13, $14, $15, make_interval(secs => $16)
 		)`
 
 	_, err := r.tx.ExecContext(ctx, query,
 		char.ID, char.UserID, char.Name, char.SlotNumber, char.Level,
 		char.Experience, char.ClassType, char.Race, char.Gender,
 		char.IsDeleted, char.DeletedAt, char.DeletionScheduledAt,
-		char.CreatedAt, char.UpdatedAt, char.LastPlayedAt, char.TotalPlayTime,
+		char.CreatedAt, char.UpdatedAt, char.LastPlayedAt, char.TotalPlayTime.Seconds(),
 	)
 	return err
 }
@@ -209,17 +203,18 @@ func (r *transactionalCharacterRepo) GetByUserIDAndSlot(ctx context.Context, use
 			id, user_id, name, slot_number, level, experience,
 			class_type, race, gender, is_deleted, deleted_at,
 			deletion_scheduled_at, created_at, updated_at,
-			last_played_at, total_play_time
+			last_played_at, COALESCE(EXTRACT(EPOCH FROM total_play_time), 0)::BIGINT
 		FROM characters
 		WHERE user_id = $1 AND slot_number = $2`
 
 	var char character.Character
+	var playSeconds int64
 	err := r.tx.QueryRowContext(ctx, query, userID, slot).Scan(
 		&char.ID, &char.UserID, &char.Name, &char.SlotNumber,
 		&char.Level, &char.Experience, &char.ClassType, &char.Race,
 		&char.Gender, &char.IsDeleted, &char.DeletedAt,
 		&char.DeletionScheduledAt, &char.CreatedAt, &char.UpdatedAt,
-		&char.LastPlayedAt, &char.TotalPlayTime,
+		&char.LastPlayedAt, &playSeconds,
 	)
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -227,6 +222,7 @@ func (r *transactionalCharacterRepo) GetByUserIDAndSlot(ctx context.Context, use
 	if err != nil {
 		return nil, err
 	}
+	char.TotalPlayTime = time.Duration(playSeconds) * time.Second
 	return &char, nil
 }
 
@@ -0,0 +1,164 @@
+package character
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/mmorpg-template/backend/internal/domain/character"
+	portsCharacter "github.com/mmorpg-template/backend/internal/ports/character"
+)
+
+// errTransfersDisabled is returned when no transfer repository is set
+var errTransfersDisabled = errors.New("character transfers are not enabled")
+
+// SetTransfers enables moving characters between accounts
+func (s *CharacterService) SetTransfers(transfers portsCharacter.TransferRepository) {
+	s.transfers = transfers
+}
+
+// SetPendingActivityChecker blocks transfers of characters with open trades or mail
+func (s *CharacterService) SetPendingActivityChecker(checker portsCharacter.PendingActivityChecker) {
+	s.pendingChecker = checker
+}
+
+// TransferCharacter moves a character to another account on behalf of support staff. A slot of
+// zero picks the target account's lowest free slot. Characters with pending activity stay put
+// until it settles. A play session of the character ends with the transfer, and both accounts
+// lose their character selection and cached character lists.
+func (s *CharacterService) TransferCharacter(ctx context.Context, req *portsCharacter.TransferCharacterRequest) (*character.CharacterTransfer, error) {
+	if s.transfers == nil {
+		return nil, errTransfersDisabled
+	}
+	toUserID, err := uuid.Parse(req.ToUserID)
+	if err != nil {
+		return nil, character.ErrInvalidUserID
+	}
+	performedBy, err := uuid.Parse(req.PerformedBy)
+	if err != nil {
+		return nil, character.ErrInvalidUserID
+	}
+	char, err := s.activeCharacter(ctx, req.CharacterID)
+	if err != nil {
+		return nil, err
+	}
+
+	slot := req.SlotNumber
+	if slot == 0 && toUserID != char.UserID {
+		if slot, err = s.freeSlot(ctx, toUserID); err != nil {
+			return nil, err
+		}
+	}
+	transfer := character.NewCharacterTransfer(char, toUserID, slot, performedBy, req.Reason)
+	if err := transfer.Validate(); err != nil {
+		return nil, err
+	}
+
+	count, err := s.characterRepo.CountByUserID(ctx, toUserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count characters: %w", err)
+	}
+	limit, err := s.accountCharacterLimit(ctx, toUserID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= limit {
+		return nil, character.ErrCharacterLimitReached
+	}
+	// Soft-deleted characters keep their slot until they are purged
+	if existing, err := s.characterRepo.GetByUserIDAndSlot(ctx, toUserID, slot); err == nil && existing != nil {
+		return nil, character.ErrSlotOccupied
+	}
+
+	if s.pendingChecker != nil {
+		pending, err := s.pendingChecker.PendingActivity(ctx, char.ID.String())
+		if err != nil {
+			// Fail closed; moving a character mid-trade could duplicate or lose items
+			return nil, fmt.Errorf("failed to check pending activity: %w", err)
+		}
+		if len(pending) > 0 {
+			return nil, &character.TransferBlocked{Pending: pending}
+		}
+	}
+
+	s.captureRestorePoint(ctx, char, nil, character.RestorePointTransfer)
+	ended, err := s.transfers.Transfer(ctx, transfer)
+	if err != nil {
+		return nil, err
+	}
+	if ended != nil {
+		s.playSessionEnded(ctx, ended)
+	}
+
+	if s.cache != nil {
+		if err := s.cache.InvalidateCharacterData(ctx, char.ID); err != nil {
+			s.logger.WithError(err).Warn("Failed to invalidate character cache after transfer")
+		}
+		for _, userID := range []uuid.UUID{transfer.FromUserID, transfer.ToUserID} {
+			if err := s.cache.InvalidateUserData(ctx, userID); err != nil {
+				s.logger.WithError(err).WithField("user_id", userID).Warn("Failed to invalidate user cache after transfer")
+			}
+		}
+	}
+
+	if s.eventPublisher != nil {
+		event := &character.CharacterTransferredEvent{
+			BaseEvent: character.BaseEvent{
+				EventType:   character.EventCharacterTransferred,
+				CharacterID: char.ID.String(),
+				UserID:      transfer.ToUserID.String(),
+			},
+			Name:        char.Name,
+			FromUserID:  transfer.FromUserID.String(),
+			FromSlot:    transfer.FromSlot,
+			ToSlot:      transfer.ToSlot,
+			PerformedBy: transfer.PerformedBy.String(),
+			Reason:      transfer.Reason,
+		}
+		if err := s.eventPublisher.PublishCharacterTransferred(ctx, event); err != nil {
+			s.logger.WithError(err).Warn("Failed to publish character transferred event")
+		}
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"character_id": char.ID,
+		"from_user_id": transfer.FromUserID,
+		"to_user_id":   transfer.ToUserID,
+		"to_slot":      transfer.ToSlot,
+		"performed_by": transfer.PerformedBy,
+	}).Info("Character transferred")
+
+	return transfer, nil
+}
+
+// GetTransferHistory lists the account transfers of a character, newest first
+func (s *CharacterService) GetTransferHistory(ctx context.Context, characterID string) ([]*character.CharacterTransfer, error) {
+	charID, err := uuid.Parse(characterID)
+	if err != nil {
+		return nil, character.ErrInvalidCharacterID
+	}
+	if s.transfers == nil {
+		return []*character.CharacterTransfer{}, nil
+	}
+	return s.transfers.ListByCharacterID(ctx, charID)
+}
+
+// freeSlot returns the lowest slot with no character, live or soft-deleted, on an account
+func (s *CharacterService) freeSlot(ctx context.Context, userID uuid.UUID) (int, error) {
+	chars, err := s.characterRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list characters: %w", err)
+	}
+
+	used := make(map[int]bool, len(chars))
+	for _, char := range chars {
+		used[char.SlotNumber] = true
+	}
+	for slot := 1; slot <= character.MaxSlotNumber; slot++ {
+		if !used[slot] {
+			return slot, nil
+		}
+	}
+	return 0, character.ErrSlotOccupied
+}
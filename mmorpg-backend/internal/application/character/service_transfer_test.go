@@ -0,0 +1,100 @@
+package character_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mmorpg-template/backend/internal/application/character"
+	domainCharacter "github.com/mmorpg-template/backend/internal/domain/character"
+	portsCharacter "github.com/mmorpg-template/backend/internal/ports/character"
+	"github.com/mmorpg-template/backend/pkg/logger"
+)
+
+// memoryTransfers records the transfers it is asked to make
+type memoryTransfers struct {
+	portsCharacter.TransferRepository
+	transfers []*domainCharacter.CharacterTransfer
+	// ended is the play session the transfer ends
+	ended *domainCharacter.PlaySession
+}
+
+func (r *memoryTransfers) Transfer(ctx context.Context, transfer *domainCharacter.CharacterTransfer) (*domainCharacter.PlaySession, error) {
+	r.transfers = append(r.transfers, transfer)
+	if r.ended != nil {
+		r.ended.End(transfer.CreatedAt, domainCharacter.PlaySessionEndTransfer)
+	}
+	return r.ended, nil
+}
+
+func TestCharacterService_TransferCharacter(t *testing.T) {
+	ctx := context.Background()
+
+	var char *domainCharacter.Character
+	publisher := &recordingPublisher{}
+	setup := func(count, maxCharacters int, isGuest bool) (*character.CharacterService, *memoryTransfers, *portsCharacter.TransferCharacterRequest) {
+		char = &domainCharacter.Character{ID: uuid.New(), UserID: uuid.New(), Name: "Hero", SlotNumber: 1}
+		toUserID := uuid.New()
+		charRepo := new(MockCharacterRepo)
+		charRepo.On("GetByID", ctx, char.ID).Return(char, nil)
+		charRepo.On("CountByUserID", ctx, toUserID).Return(count, nil)
+		charRepo.On("GetAccountLimit", ctx, toUserID).Return(maxCharacters, isGuest, nil)
+		charRepo.On("GetByUserIDAndSlot", ctx, toUserID, mock.Anything).Return(nil, domainCharacter.ErrCharacterNotFound)
+
+		config := &character.Config{MaxCharactersPerUser: 5, MaxCharactersPerGuest: 1}
+		service := character.NewCharacterService(charRepo, new(MockAppearanceRepo), new(MockStatsRepo), new(MockPositionRepo),
+			nil, publisher, config, logger.NewNoop())
+		transfers := &memoryTransfers{}
+		service.SetTransfers(transfers)
+
+		req := &portsCharacter.TransferCharacterRequest{
+			CharacterID: char.ID.String(),
+			ToUserID:    toUserID.String(),
+			SlotNumber:  7,
+			PerformedBy: uuid.New().String(),
+			Reason:      "Account merge",
+		}
+		return service, transfers, req
+	}
+
+	t.Run("premium account may hold more than the default limit", func(t *testing.T) {
+		service, transfers, req := setup(6, 10, false)
+
+		_, err := service.TransferCharacter(ctx, req)
+		require.NoError(t, err)
+		assert.Len(t, transfers.transfers, 1)
+	})
+
+	t.Run("full account is refused", func(t *testing.T) {
+		service, transfers, req := setup(10, 10, false)
+
+		_, err := service.TransferCharacter(ctx, req)
+		assert.Equal(t, domainCharacter.ErrCharacterLimitReached, err)
+		assert.Empty(t, transfers.transfers)
+	})
+
+	t.Run("guest account is held to the guest limit", func(t *testing.T) {
+		service, transfers, req := setup(1, 5, true)
+
+		_, err := service.TransferCharacter(ctx, req)
+		assert.Equal(t, domainCharacter.ErrCharacterLimitReached, err)
+		assert.Empty(t, transfers.transfers)
+	})
+
+	t.Run("play session ends with the transfer", func(t *testing.T) {
+		service, transfers, req := setup(0, 5, false)
+		transfers.ended = domainCharacter.NewPlaySession(char, "session-1", time.Now())
+
+		_, err := service.TransferCharacter(ctx, req)
+		require.NoError(t, err)
+		if assert.Len(t, publisher.offline, 1) {
+			assert.Equal(t, domainCharacter.PlaySessionEndTransfer, publisher.offline[0].Reason)
+			assert.Equal(t, char.UserID.String(), publisher.offline[0].UserID)
+		}
+	})
+}
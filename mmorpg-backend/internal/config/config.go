@@ -28,35 +28,35 @@ type ServerConfig struct {
 }
 
 type DatabaseConfig struct {
-	URL            string
-	MaxConnections int
-	MaxIdleConns   int
+	URL             string
+	MaxConnections  int
+	MaxIdleConns    int
 	ConnMaxLifetime int
 }
 
 type RedisConfig struct {
-	URL             string
-	PoolSize        int
-	MinIdleConns    int
-	MaxRetries      int
-	DB              int
+	URL          string
+	PoolSize     int
+	MinIdleConns int
+	MaxRetries   int
+	DB           int
 }
 
 type NATSConfig struct {
-	URL            string
-	ClusterID      string
-	ClientID       string
-	MaxReconnects  int
-	ReconnectWait  int
+	URL           string
+	ClusterID     string
+	ClientID      string
+	MaxReconnects int
+	ReconnectWait int
 }
 
 type SecurityConfig struct {
-	JWTSecret         string
-	JWTExpiry         int
-	RefreshExpiry     int
-	BcryptCost        int
-	RateLimitPerIP    int
-	RateLimitPerUser  int
+	JWTSecret        string
+	JWTExpiry        int
+	RefreshExpiry    int
+	BcryptCost       int
+	RateLimitPerIP   int
+	RateLimitPerUser int
 }
 
 type GameConfig struct {
@@ -73,25 +73,118 @@ type MetricsConfig struct {
 }
 
 type AuthConfig struct {
-	Port              int
-	JWTAccessSecret   string
-	JWTRefreshSecret  string
-	MaxSessionsPerUser int
-	LoginRateLimit    int
-	LoginRateLimitWindow int
-	MaxLoginAttempts  int
+	Port             int
+	JWTAccessSecret  string
+	JWTRefreshSecret string
+	// JWTServiceSecret signs service-to-service tokens; every service that validates them needs it
+	JWTServiceSecret string
+	// ServiceURL, ServiceClientID and ServiceClientSecret let another service obtain
+	// its own service token from the auth service
+	ServiceURL          string
+	ServiceClientID     string
+	ServiceClientSecret string
+	// TokenValidationCacheSeconds is how long other services cache token validation results
+	TokenValidationCacheSeconds    int
+	MaxSessionsPerUser             int
+	LoginRateLimit                 int
+	LoginRateLimitWindow           int
+	MaxLoginAttempts               int
+	SecurityEventRetentionDays     int
+	AccountLockoutThreshold        int
+	AccountLockoutWindow           int
+	AccountLockoutBase             int
+	AccountLockoutMax              int
+	RequireNewDeviceConfirmation   bool
+	BreachedPasswordDir            string
+	BreachedPasswordMinCount       int
+	PasswordDenylistFile           string
+	GuestCreationLimit             int
+	GuestCreationWindow            int
+	GuestIdleDays                  int
+	UsernameChangeCooldownDays     int
+	UsernameHoldDays               int
+	RegistrationPowIPThreshold     int
+	RegistrationPowSubnetThreshold int
+	RegistrationPowWindow          int
+	RegistrationPowBaseDifficulty  int
+	RegistrationPowMaxDifficulty   int
+	LoginHistoryRetentionDays      int
+	// GeoIPDatabaseFile is an offline CSV of IP ranges used to locate logins (empty disables it)
+	GeoIPDatabaseFile string
+	// PlaytimeWarningMinutes are the remaining play times at which wards are warned before logout
+	PlaytimeWarningMinutes []int
+	// Notifier selects how account notifications are delivered: "smtp", or "log" for development
+	Notifier     string
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	SMTPFrom     string
+	// NotificationLinkURL is the account page that emailed one-time links point to
+	NotificationLinkURL string
 }
 
 type CharacterConfig struct {
 	Port                   int
 	MaxCharactersPerUser   int
+	MaxCharactersPerGuest  int
 	MaxCharacterNameLength int
 	MinCharacterNameLength int
 	DefaultStartingLevel   int
 	DefaultStartingExp     int64
+	// ProgressionFile is a JSON table of experience, rewards and level cap (empty uses the built-in curve)
+	ProgressionFile string
+	// ProgressionReloadSeconds is how often the progression file is checked for changes (0 disables reloads)
+	ProgressionReloadSeconds int
+	// DefinitionsFile is a JSON file of classes and races (empty uses the built-in ones)
+	DefinitionsFile string
+	// DefinitionsReloadSeconds is how often the definitions file is checked for changes (0 disables reloads)
+	DefinitionsReloadSeconds int
+	// NamePolicyFile is a JSON file of name rules and word lists (empty uses the built-in policy)
+	NamePolicyFile string
+	// NamePolicyReloadSeconds is how often the name policy file is checked for changes (0 disables reloads)
+	NamePolicyReloadSeconds int
+	// RespecFreeCount is how many respecs a character gets without cost
+	RespecFreeCount int
+	// RespecBaseCost is the cost of the first paid respec; each later one costs RespecCostMultiplier times more, up to RespecMaxCost
+	RespecBaseCost       int64
+	RespecCostMultiplier float64
+	RespecMaxCost        int64
+	// RespecBaseCooldownMinutes is the wait after the first respec; it grows by RespecCooldownMultiplier up to RespecMaxCooldownHours
+	RespecBaseCooldownMinutes int
+	RespecCooldownMultiplier  float64
+	RespecMaxCooldownHours    int
+	// StatUndoWindowMinutes is how long stat allocations can be undone
+	StatUndoWindowMinutes int
+	// StatUndoMaxAllocations is the most allocations one undo may revert
+	StatUndoMaxAllocations int
+	// RenameCooldownDays is the minimum time between renames of a character
+	RenameCooldownDays int
+	// NameHoldDays is how long a released character name stays reserved for its previous owner
+	NameHoldDays int
+	// PurgeIntervalMinutes is how often soft-deleted characters past their recovery period are purged (0 disables purging)
+	PurgeIntervalMinutes int
+	// PurgeBatchSize bounds how many characters are purged per statement
+	PurgeBatchSize int
+	// RestorePointIntervalMinutes is how often restore points are taken of characters that changed (0 disables them)
+	RestorePointIntervalMinutes int
+	// RestorePointRetentionDays is how long restore points are kept before they are removed
+	RestorePointRetentionDays int
+	// PlaySessionTimeoutSeconds is how long a play session may go without a heartbeat before it is closed
+	// and its character selection lease expires
+	PlaySessionTimeoutSeconds int
+	// PlaySessionSweepSeconds is how often play sessions without heartbeats are looked for (0 disables the sweep)
+	PlaySessionSweepSeconds int
+	// SelectionPolicy is what selecting a character from a second login session does: takeover or refuse
+	SelectionPolicy string
+	// OptionalPendingActivity lists the pending activity kinds (trade, mail) whose check may go
+	// unanswered, for deployments without that service; any other unanswered check blocks transfers
+	OptionalPendingActivity []string
+	// EnforceParentalControls refuses to start without the service credentials needed to check
+	// play time limits at character selection; disable it only in development
+	EnforceParentalControls bool
 }
 
-
 func setDefaults() {
 	// Server defaults
 	viper.SetDefault("server.port", "8080")
@@ -143,18 +236,79 @@ func setDefaults() {
 	viper.SetDefault("auth.port", 8081)
 	viper.SetDefault("auth.jwtAccessSecret", "change-me-access-secret")
 	viper.SetDefault("auth.jwtRefreshSecret", "change-me-refresh-secret")
+	viper.SetDefault("auth.jwtServiceSecret", "change-me-service-secret")
+	viper.SetDefault("auth.serviceURL", "http://localhost:8081")
+	viper.SetDefault("auth.serviceClientID", "")
+	viper.SetDefault("auth.serviceClientSecret", "")
+	viper.SetDefault("auth.tokenValidationCacheSeconds", 5)
 	viper.SetDefault("auth.maxSessionsPerUser", 10)
 	viper.SetDefault("auth.loginRateLimit", 10)
 	viper.SetDefault("auth.loginRateLimitWindow", 900) // 15 minutes
 	viper.SetDefault("auth.maxLoginAttempts", 5)
-	
+	viper.SetDefault("auth.securityEventRetentionDays", 365)
+	viper.SetDefault("auth.accountLockoutThreshold", 10)
+	viper.SetDefault("auth.accountLockoutWindow", 86400) // 24 hours
+	viper.SetDefault("auth.accountLockoutBase", 60)      // 1 minute
+	viper.SetDefault("auth.accountLockoutMax", 86400)    // 24 hours
+	viper.SetDefault("auth.requireNewDeviceConfirmation", false)
+	viper.SetDefault("auth.breachedPasswordDir", "")
+	viper.SetDefault("auth.breachedPasswordMinCount", 1)
+	viper.SetDefault("auth.passwordDenylistFile", "")
+	viper.SetDefault("auth.guestCreationLimit", 5)
+	viper.SetDefault("auth.guestCreationWindow", 3600) // 1 hour
+	viper.SetDefault("auth.guestIdleDays", 30)
+	viper.SetDefault("auth.usernameChangeCooldownDays", 30)
+	viper.SetDefault("auth.usernameHoldDays", 90)
+	viper.SetDefault("auth.registrationPowIPThreshold", 3)
+	viper.SetDefault("auth.registrationPowSubnetThreshold", 20)
+	viper.SetDefault("auth.registrationPowWindow", 3600)
+	viper.SetDefault("auth.registrationPowBaseDifficulty", 18)
+	viper.SetDefault("auth.registrationPowMaxDifficulty", 26)
+	viper.SetDefault("auth.loginHistoryRetentionDays", 90)
+	viper.SetDefault("auth.geoIPDatabaseFile", "")
+	viper.SetDefault("auth.playtimeWarningMinutes", []int{15, 5, 1})
+	viper.SetDefault("auth.notifier", "log")
+	viper.SetDefault("auth.smtpHost", "")
+	viper.SetDefault("auth.smtpPort", 587)
+	viper.SetDefault("auth.smtpUsername", "")
+	viper.SetDefault("auth.smtpPassword", "")
+	viper.SetDefault("auth.smtpFrom", "")
+	viper.SetDefault("auth.notificationLinkURL", "http://localhost:3000/account")
+
 	// Character defaults
 	viper.SetDefault("character.port", 8082)
 	viper.SetDefault("character.maxCharactersPerUser", 5)
+	viper.SetDefault("character.maxCharactersPerGuest", 1)
 	viper.SetDefault("character.maxCharacterNameLength", 30)
 	viper.SetDefault("character.minCharacterNameLength", 3)
 	viper.SetDefault("character.defaultStartingLevel", 1)
 	viper.SetDefault("character.defaultStartingExp", 0)
+	viper.SetDefault("character.progressionFile", "")
+	viper.SetDefault("character.progressionReloadSeconds", 30)
+	viper.SetDefault("character.definitionsFile", "")
+	viper.SetDefault("character.definitionsReloadSeconds", 30)
+	viper.SetDefault("character.namePolicyFile", "")
+	viper.SetDefault("character.namePolicyReloadSeconds", 30)
+	viper.SetDefault("character.respecFreeCount", 1)
+	viper.SetDefault("character.respecBaseCost", 100)
+	viper.SetDefault("character.respecCostMultiplier", 2.0)
+	viper.SetDefault("character.respecMaxCost", 50000)
+	viper.SetDefault("character.respecBaseCooldownMinutes", 60)
+	viper.SetDefault("character.respecCooldownMultiplier", 2.0)
+	viper.SetDefault("character.respecMaxCooldownHours", 168)
+	viper.SetDefault("character.statUndoWindowMinutes", 10)
+	viper.SetDefault("character.statUndoMaxAllocations", 20)
+	viper.SetDefault("character.renameCooldownDays", 30)
+	viper.SetDefault("character.nameHoldDays", 90)
+	viper.SetDefault("character.purgeIntervalMinutes", 60)
+	viper.SetDefault("character.purgeBatchSize", 100)
+	viper.SetDefault("character.restorePointIntervalMinutes", 60)
+	viper.SetDefault("character.restorePointRetentionDays", 30)
+	viper.SetDefault("character.playSessionTimeoutSeconds", 120)
+	viper.SetDefault("character.playSessionSweepSeconds", 60)
+	viper.SetDefault("character.selectionPolicy", "takeover")
+	viper.SetDefault("character.optionalPendingActivity", []string{})
+	viper.SetDefault("character.enforceParentalControls", true)
 }
 
 func (c *Config) Validate() error {
@@ -245,4 +399,4 @@ func LoadConfig() (*Config, error) {
 	}
 
 	return cfg, nil
-}
\ No newline at end of file
+}
@@ -14,6 +14,12 @@
 	ErrEmailNotVerified      = errors.New("email not verified")
 	ErrUsernameAlreadyTaken  = errors.New("username already taken")
 	ErrEmailAlreadyTaken     = errors.New("email already taken")
+	ErrNotGuestAccount       = errors.New("account is not a guest account")
+	ErrGuestDeviceRequired   = errors.New("device ID is required for guest login")
+	ErrUsernameChangeTooSoon = errors.New("username was changed too recently")
+	ErrGuestNotAllowed       = errors.New("not available for guest accounts")
+	ErrUsernameUnchanged     = errors.New("new username is the same as the current one")
+	ErrEmailUnchanged        = errors.New("new email is the same as the current one")
 	
 	// Session errors
 	ErrSessionNotFound       = errors.New("session not found")
@@ -21,31 +27,57 @@
 	ErrSessionInvalid        = errors.New("session invalid")
 	ErrTooManySessions       = errors.New("too many active sessions")
 	
+	// Device errors
+	ErrDeviceNotFound        = errors.New("device not found")
+	ErrDeviceNotConfirmed    = errors.New("new device must be confirmed by email")
+	
+	// Service account errors
+	ErrServiceAccountNotFound   = errors.New("service account not found")
+	ErrServiceAccountExists     = errors.New("service account already exists")
+	ErrInvalidClientCredentials = errors.New("invalid client credentials")
+	ErrInsufficientScope        = errors.New("insufficient scope")
+	ErrInvalidScope             = errors.New("invalid scope")
+	ErrInvalidServiceName       = errors.New("service account name is required")
+	
+	// Parental control errors
+	ErrParentalControlsNotFound = errors.New("account has no parental controls")
+	ErrNotGuardian              = errors.New("not the guardian of this account")
+	ErrGuardianAlreadyLinked    = errors.New("account already has a guardian")
+	ErrCannotGuardSelf          = errors.New("cannot be your own guardian")
+	ErrInvalidPlaytimeLimits    = errors.New("invalid play time limits")
+	
 	// Token errors
 	ErrInvalidToken          = errors.New("invalid token")
 	ErrTokenExpired          = errors.New("token expired")
 	ErrTokenMalformed        = errors.New("token malformed")
 	ErrTokenSignatureInvalid = errors.New("token signature invalid")
 	ErrRefreshTokenInvalid   = errors.New("refresh token invalid")
+	ErrAuthUnavailable       = errors.New("auth service unavailable")
 	
 	// Password errors
 	ErrPasswordTooWeak       = errors.New("password too weak")
 	ErrPasswordMismatch      = errors.New("password mismatch")
+	ErrPasswordBreached      = errors.New("password appears in a breached or common password list")
 	
 	// Rate limiting errors
 	ErrTooManyAttempts       = errors.New("too many login attempts")
+	ErrAccountLocked         = errors.New("account is temporarily locked")
+	ErrProofOfWorkRequired   = errors.New("proof of work required")
+	ErrProofOfWorkInvalid    = errors.New("proof of work invalid or expired")
 	
 	// Validation errors
 	ErrInvalidEmail          = errors.New("invalid email format")
 	ErrInvalidUsername       = errors.New("invalid username format")
 	ErrTermsNotAccepted      = errors.New("terms of service not accepted")
+	ErrInvalidAccountStatus  = errors.New("invalid account status")
 )
 
 // IsAuthError checks if an error is an authentication error
 func IsAuthError(err error) bool {
 	switch err {
 	case ErrUserNotFound, ErrInvalidCredentials, ErrAccountNotActive,
-		ErrAccountSuspended, ErrAccountBanned, ErrEmailNotVerified,
+		ErrAccountSuspended, ErrAccountBanned, ErrEmailNotVerified, ErrAccountLocked,
+		ErrDeviceNotConfirmed,
 		ErrSessionExpired, ErrSessionInvalid, ErrInvalidToken,
 		ErrTokenExpired, ErrTokenMalformed, ErrTokenSignatureInvalid:
 		return true
@@ -57,8 +89,11 @@ func IsAuthError(err error) bool {
 // IsValidationError checks if an error is a validation error
 func IsValidationError(err error) bool {
 	switch err {
-	case ErrInvalidEmail, ErrInvalidUsername, ErrPasswordTooWeak,
-		ErrTermsNotAccepted, ErrUsernameAlreadyTaken, ErrEmailAlreadyTaken:
+	case ErrInvalidEmail, ErrInvalidUsername, ErrPasswordTooWeak, ErrPasswordBreached,
+		ErrTermsNotAccepted, ErrUsernameAlreadyTaken, ErrEmailAlreadyTaken,
+		ErrInvalidAccountStatus, ErrGuestDeviceRequired,
+		ErrUsernameUnchanged, ErrEmailUnchanged,
+		ErrInvalidScope, ErrInvalidServiceName, ErrInvalidPlaytimeLimits:
 		return true
 	default:
 		return false
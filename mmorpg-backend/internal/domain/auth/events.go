@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"time"
+)
+
+// EventType represents the type of account event published to other services
+type EventType string
+
+const (
+	EventUsernameChanged EventType = "user.username.changed"
+	EventEmailChanged    EventType = "user.email.changed"
+	EventPlaytimeWarning EventType = "user.playtime.warning"
+	EventPlaytimeLogout  EventType = "user.playtime.logout"
+	EventSessionEnded    EventType = "user.session.ended"
+	EventGuestDeleted    EventType = "user.guest.deleted"
+)
+
+// BaseEvent contains common fields for all account events
+type BaseEvent struct {
+	EventID   string    `json:"event_id"`
+	EventType EventType `json:"event_type"`
+	UserID    string    `json:"user_id"`
+	Timestamp time.Time `json:"timestamp"`
+	Version   string    `json:"version"`
+}
+
+// UsernameChangedEvent is emitted when a user changes their username,
+// so display names in chat, guild rosters and friend lists can be updated
+type UsernameChangedEvent struct {
+	BaseEvent
+	OldUsername string `json:"old_username"`
+	NewUsername string `json:"new_username"`
+}
+
+// EmailChangedEvent is emitted when a user's email change completes.
+// Addresses are not included; services that need them should ask auth.
+type EmailChangedEvent struct {
+	BaseEvent
+}
+
+// PlaytimeWarningEvent is emitted when an account under parental controls is
+// about to run out of play time, so the game can warn the player
+type PlaytimeWarningEvent struct {
+	BaseEvent
+	CharacterID      string `json:"character_id"`
+	SessionID        string `json:"session_id"`
+	MinutesRemaining int    `json:"minutes_remaining"`
+	Reason           string `json:"reason"`
+}
+
+// PlaytimeLogoutEvent is emitted when an account runs out of play time.
+// The auth session has already been ended; game servers should disconnect the character.
+type PlaytimeLogoutEvent struct {
+	BaseEvent
+	CharacterID string `json:"character_id"`
+	SessionID   string `json:"session_id"`
+	Reason      string `json:"reason"`
+}
+
+// SessionEndedEvent is emitted when a login session ends through logout, revocation or a
+// play time limit, so the character service can take the account's character offline.
+// An empty SessionID means every session of the user ended.
+type SessionEndedEvent struct {
+	BaseEvent
+	SessionID string `json:"session_id,omitempty"`
+	// Reason is the security event type that ended the session, such as logout
+	Reason string `json:"reason"`
+}
+
+// GuestDeletedEvent is emitted when an idle guest account is deleted. Its characters were
+// deleted with it, so the character service should drop what it still holds for them.
+type GuestDeletedEvent struct {
+	BaseEvent
+	Characters []DeletedCharacter `json:"characters,omitempty"`
+}
@@ -0,0 +1,52 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IdentityField names a user attribute whose changes are tracked
+type IdentityField string
+
+const (
+	IdentityFieldUsername IdentityField = "username"
+	IdentityFieldEmail    IdentityField = "email"
+)
+
+// IdentityChange records a change of username or email
+type IdentityChange struct {
+	ID       uuid.UUID
+	UserID   uuid.UUID
+	Field    IdentityField
+	OldValue string
+	NewValue string
+	// HoldUntil reserves the old value for the previous owner until this time
+	HoldUntil *time.Time
+	ChangedAt time.Time
+}
+
+// NewIdentityChange creates a change record stamped with the current time
+func NewIdentityChange(userID uuid.UUID, field IdentityField, oldValue, newValue string) *IdentityChange {
+	return &IdentityChange{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Field:     field,
+		OldValue:  oldValue,
+		NewValue:  newValue,
+		ChangedAt: time.Now(),
+	}
+}
+
+// PendingEmailChange tracks an email change awaiting confirmation from both addresses.
+// It doesn't change once requested; confirmations are tracked separately per address so
+// two confirmations arriving together can't overwrite each other.
+type PendingEmailChange struct {
+	// ChangeID ties confirmation tokens to this request so tokens from an
+	// earlier, superseded request can't confirm it
+	ChangeID    string    `json:"change_id"`
+	UserID      string    `json:"user_id"`
+	OldEmail    string    `json:"old_email"`
+	NewEmail    string    `json:"new_email"`
+	RequestedAt time.Time `json:"requested_at"`
+}
@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// KnownDevice is a device a user has previously logged in from
+type KnownDevice struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	DeviceKey string
+	UserAgent string
+	// LastIPAddress and LastNetwork describe where the device was last seen
+	LastIPAddress string
+	LastNetwork   string
+	Confirmed     bool
+	FirstSeenAt   time.Time
+	LastSeenAt    time.Time
+}
+
+// NewKnownDevice creates a record for a device seen for the first time
+func NewKnownDevice(userID uuid.UUID, deviceKey, ipAddress, userAgent string) *KnownDevice {
+	now := time.Now()
+	return &KnownDevice{
+		ID:            uuid.New(),
+		UserID:        userID,
+		DeviceKey:     deviceKey,
+		UserAgent:     userAgent,
+		LastIPAddress: ipAddress,
+		LastNetwork:   NetworkOf(ipAddress),
+		FirstSeenAt:   now,
+		LastSeenAt:    now,
+	}
+}
+
+// Touch records another login from the device
+func (d *KnownDevice) Touch(ipAddress, userAgent string) {
+	d.LastIPAddress = ipAddress
+	d.LastNetwork = NetworkOf(ipAddress)
+	if userAgent != "" {
+		d.UserAgent = userAgent
+	}
+	d.LastSeenAt = time.Now()
+}
+
+// DeviceKey identifies a client device. Clients should send a stable device ID;
+// when they don't, the user agent is used as a coarse fingerprint.
+func DeviceKey(deviceID, userAgent string) string {
+	if deviceID != "" {
+		return deviceID
+	}
+	sum := sha256.Sum256([]byte(userAgent))
+	return "ua:" + hex.EncodeToString(sum[:8])
+}
+
+// NetworkOf returns the network an IP address belongs to (/24 for IPv4, /48 for IPv6)
+// so that address churn within an ISP allocation isn't reported as a new location
+func NetworkOf(ipAddress string) string {
+	ip := net.ParseIP(ipAddress)
+	if ip == nil {
+		return ""
+	}
+	if v4 := ip.To4(); v4 != nil {
+		return (&net.IPNet{IP: v4.Mask(net.CIDRMask(24, 32)), Mask: net.CIDRMask(24, 32)}).String()
+	}
+	return (&net.IPNet{IP: ip.Mask(net.CIDRMask(48, 128)), Mask: net.CIDRMask(48, 128)}).String()
+}
+
+// DeviceConfirmation is the payload stored behind a new-device confirmation token
+type DeviceConfirmation struct {
+	UserID    string `json:"user_id"`
+	DeviceKey string `json:"device_key"`
+	IPAddress string `json:"ip_address"`
+	UserAgent string `json:"user_agent"`
+}
@@ -0,0 +1,35 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		ip       string
+		expected string
+	}{
+		{"ipv4", "203.0.113.57", "203.0.113.0/24"},
+		{"ipv6", "2001:db8:abcd:12::1", "2001:db8:abcd::/48"},
+		{"invalid", "not-an-ip", ""},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NetworkOf(tt.ip))
+		})
+	}
+}
+
+func TestDeviceKey(t *testing.T) {
+	assert.Equal(t, "device-123", DeviceKey("device-123", "Mozilla/5.0"))
+
+	fingerprint := DeviceKey("", "Mozilla/5.0")
+	assert.Contains(t, fingerprint, "ua:")
+	assert.Equal(t, fingerprint, DeviceKey("", "Mozilla/5.0"))
+	assert.NotEqual(t, fingerprint, DeviceKey("", "UnrealEngine/5.3"))
+}
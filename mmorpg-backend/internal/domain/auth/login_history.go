@@ -0,0 +1,85 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LoginKind distinguishes a password login from a token refresh
+type LoginKind string
+
+const (
+	LoginKindPassword LoginKind = "login"
+	LoginKindRefresh  LoginKind = "refresh"
+	LoginKindGuest    LoginKind = "guest"
+)
+
+// LoginResultSuccess is the result recorded for an accepted login; failures
+// record a short reason such as "invalid_password" instead
+const LoginResultSuccess = "success"
+
+// GeoLocation is the coarse location of an IP address
+type GeoLocation struct {
+	CountryCode string
+	Region      string
+	City        string
+}
+
+// LoginRecord is one entry in a user's login history
+type LoginRecord struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	SessionID string
+	Kind      LoginKind
+	Result    string
+	IPAddress string
+	Location  GeoLocation
+	DeviceID  string
+	UserAgent string
+	CreatedAt time.Time
+}
+
+// NewLoginRecord creates a login history entry stamped with the current time
+func NewLoginRecord(userID uuid.UUID, kind LoginKind, result string) *LoginRecord {
+	return &LoginRecord{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Kind:      kind,
+		Result:    result,
+		CreatedAt: time.Now(),
+	}
+}
+
+// Succeeded reports whether the login was accepted
+func (r *LoginRecord) Succeeded() bool {
+	return r.Result == LoginResultSuccess
+}
+
+// LoginHistoryFilter selects a page of a user's login history
+type LoginHistoryFilter struct {
+	UserID string
+	Since  *time.Time
+	Until  *time.Time
+	Limit  int
+	Offset int
+}
+
+// Login history query bounds
+const (
+	DefaultLoginHistoryLimit = 50
+	MaxLoginHistoryLimit     = 200
+)
+
+// Normalize clamps paging values to sane bounds
+func (f *LoginHistoryFilter) Normalize() {
+	if f.Limit <= 0 {
+		f.Limit = DefaultLoginHistoryLimit
+	}
+	if f.Limit > MaxLoginHistoryLimit {
+		f.Limit = MaxLoginHistoryLimit
+	}
+	if f.Offset < 0 {
+		f.Offset = 0
+	}
+}
@@ -0,0 +1,43 @@
+package auth
+
+import "time"
+
+// NotificationKind identifies the message template to use
+type NotificationKind string
+
+const (
+	NotificationNewDeviceLogin     NotificationKind = "new_device_login"
+	NotificationNewNetworkLogin    NotificationKind = "new_network_login"
+	NotificationDeviceConfirmation NotificationKind = "device_confirmation"
+	NotificationAccountUnlock      NotificationKind = "account_unlock"
+	NotificationPasswordReset      NotificationKind = "password_reset"
+	// Email change: the old address confirms and is warned, the new address proves ownership
+	NotificationEmailChangeOld  NotificationKind = "email_change_old"
+	NotificationEmailChangeNew  NotificationKind = "email_change_new"
+	NotificationEmailChanged    NotificationKind = "email_changed"
+	NotificationUsernameChanged NotificationKind = "username_changed"
+	// NotificationGuardianLink asks a ward to accept a guardian's parental controls
+	NotificationGuardianLink NotificationKind = "guardian_link"
+)
+
+// Notification is a message to a user, rendered and delivered by a Notifier
+type Notification struct {
+	Kind      NotificationKind
+	UserID    string
+	Email     string
+	Username  string
+	Data      map[string]string
+	CreatedAt time.Time
+}
+
+// NewNotification creates a notification addressed to a user
+func NewNotification(kind NotificationKind, user *User) *Notification {
+	return &Notification{
+		Kind:      kind,
+		UserID:    user.ID.String(),
+		Email:     user.Email,
+		Username:  user.Username,
+		Data:      map[string]string{},
+		CreatedAt: time.Now(),
+	}
+}
@@ -0,0 +1,258 @@
+package auth
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Play time restriction reasons
+const (
+	PlaytimeReasonDailyLimit   = "daily_limit"
+	PlaytimeReasonWeeklyLimit  = "weekly_limit"
+	PlaytimeReasonOutsideHours = "outside_allowed_hours"
+)
+
+// Play time limit bounds
+const (
+	MinutesPerDay        = 24 * 60
+	MaxDailyPlayMinutes  = MinutesPerDay
+	MaxWeeklyPlayMinutes = 7 * MinutesPerDay
+	MaxPlayWindows       = 14
+	// GuardianLinkTokenDuration is how long a ward has to accept a guardian link
+	GuardianLinkTokenDuration = 7 * 24 * time.Hour
+)
+
+// PlayWindow is a daily span of local time during which play is allowed.
+// Start and End are minutes after local midnight; End is exclusive and may be
+// 1440 for "until midnight". A window with End <= Start wraps past midnight.
+type PlayWindow struct {
+	// Days the window opens on; empty means every day
+	Days  []time.Weekday `json:"days,omitempty"`
+	Start int            `json:"start"`
+	End   int            `json:"end"`
+}
+
+// opensOn reports whether the window starts on the given weekday
+func (w PlayWindow) opensOn(day time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	for _, d := range w.Days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// remaining returns how long the window stays open from the given local time, or 0 if it is closed
+func (w PlayWindow) remaining(local time.Time) time.Duration {
+	minute := local.Hour()*60 + local.Minute()
+	intoMinute := time.Duration(local.Second())*time.Second + time.Duration(local.Nanosecond())
+
+	var left int
+	switch {
+	case w.Start < w.End:
+		if minute < w.Start || minute >= w.End || !w.opensOn(local.Weekday()) {
+			return 0
+		}
+		left = w.End - minute
+	case minute >= w.Start:
+		// Evening part of a window that wraps past midnight
+		if !w.opensOn(local.Weekday()) {
+			return 0
+		}
+		left = MinutesPerDay - minute + w.End
+	case minute < w.End:
+		// Morning part, opened the previous day
+		if !w.opensOn(local.AddDate(0, 0, -1).Weekday()) {
+			return 0
+		}
+		left = w.End - minute
+	default:
+		return 0
+	}
+
+	return time.Duration(left)*time.Minute - intoMinute
+}
+
+// ParentalControls are play time restrictions a guardian places on an account
+type ParentalControls struct {
+	UserID     uuid.UUID
+	GuardianID uuid.UUID
+	// DailyMinutes and WeeklyMinutes are play budgets; 0 means unlimited.
+	// Days start at local midnight and weeks on Monday.
+	DailyMinutes  int
+	WeeklyMinutes int
+	// Windows restrict play to certain hours; empty means any time
+	Windows []PlayWindow
+	// Timezone is the IANA zone used for days, weeks and windows
+	Timezone  string
+	Enabled   bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewParentalControls links a ward to a guardian with no limits set yet
+func NewParentalControls(userID, guardianID uuid.UUID) *ParentalControls {
+	now := time.Now()
+	return &ParentalControls{
+		UserID:     userID,
+		GuardianID: guardianID,
+		Timezone:   "UTC",
+		Enabled:    true,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
+
+// Validate checks that the limits are within bounds
+func (p *ParentalControls) Validate() error {
+	if p.DailyMinutes < 0 || p.DailyMinutes > MaxDailyPlayMinutes {
+		return fmt.Errorf("%w: daily minutes must be between 0 and %d", ErrInvalidPlaytimeLimits, MaxDailyPlayMinutes)
+	}
+	if p.WeeklyMinutes < 0 || p.WeeklyMinutes > MaxWeeklyPlayMinutes {
+		return fmt.Errorf("%w: weekly minutes must be between 0 and %d", ErrInvalidPlaytimeLimits, MaxWeeklyPlayMinutes)
+	}
+	if len(p.Windows) > MaxPlayWindows {
+		return fmt.Errorf("%w: at most %d play windows", ErrInvalidPlaytimeLimits, MaxPlayWindows)
+	}
+	for _, w := range p.Windows {
+		if w.Start < 0 || w.Start >= MinutesPerDay || w.End < 0 || w.End > MinutesPerDay || w.Start == w.End {
+			return fmt.Errorf("%w: invalid play window", ErrInvalidPlaytimeLimits)
+		}
+		for _, d := range w.Days {
+			if d < time.Sunday || d > time.Saturday {
+				return fmt.Errorf("%w: invalid weekday", ErrInvalidPlaytimeLimits)
+			}
+		}
+	}
+	if _, err := time.LoadLocation(p.Timezone); err != nil {
+		return fmt.Errorf("%w: unknown timezone %q", ErrInvalidPlaytimeLimits, p.Timezone)
+	}
+	return nil
+}
+
+// Location returns the account's timezone, falling back to UTC
+func (p *ParentalControls) Location() *time.Location {
+	loc, err := time.LoadLocation(p.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// DayStart returns local midnight of the day containing t
+func (p *ParentalControls) DayStart(t time.Time) time.Time {
+	local := t.In(p.Location())
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, local.Location())
+}
+
+// WeekStart returns local midnight of the Monday starting the week containing t
+func (p *ParentalControls) WeekStart(t time.Time) time.Time {
+	day := p.DayStart(t)
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+// GuardianLink is the pending guardian request stored behind a link token
+type GuardianLink struct {
+	GuardianID string `json:"guardian_id"`
+	UserID     string `json:"user_id"`
+}
+
+// PlaytimeStatus reports whether an account may play now and for how long
+type PlaytimeStatus struct {
+	Allowed bool
+	// Reason is why play is blocked, or which limit runs out first when allowed
+	Reason string
+	// Remaining is how long play may continue; meaningless when Limited is false
+	Remaining time.Duration
+	Limited   bool
+	UsedToday time.Duration
+	UsedWeek  time.Duration
+}
+
+// UnrestrictedPlaytime is the status of an account without parental controls
+func UnrestrictedPlaytime() *PlaytimeStatus {
+	return &PlaytimeStatus{Allowed: true}
+}
+
+// Evaluate works out whether play is allowed at now given the time already played
+func (p *ParentalControls) Evaluate(now time.Time, usedToday, usedWeek time.Duration) *PlaytimeStatus {
+	status := &PlaytimeStatus{Allowed: true, UsedToday: usedToday, UsedWeek: usedWeek}
+	if !p.Enabled {
+		return status
+	}
+
+	limit := func(remaining time.Duration, reason string) {
+		if remaining < 0 {
+			remaining = 0
+		}
+		if !status.Limited || remaining < status.Remaining {
+			status.Limited = true
+			status.Remaining = remaining
+			status.Reason = reason
+		}
+	}
+
+	if p.DailyMinutes > 0 {
+		limit(time.Duration(p.DailyMinutes)*time.Minute-usedToday, PlaytimeReasonDailyLimit)
+	}
+	if p.WeeklyMinutes > 0 {
+		limit(time.Duration(p.WeeklyMinutes)*time.Minute-usedWeek, PlaytimeReasonWeeklyLimit)
+	}
+	if len(p.Windows) > 0 {
+		local := now.In(p.Location())
+		var open time.Duration
+		for _, w := range p.Windows {
+			if left := w.remaining(local); left > open {
+				open = left
+			}
+		}
+		limit(open, PlaytimeReasonOutsideHours)
+	}
+
+	status.Allowed = !status.Limited || status.Remaining > 0
+	return status
+}
+
+// PlaySession tracks an online character of an account under parental controls
+type PlaySession struct {
+	CharacterID uuid.UUID
+	UserID      uuid.UUID
+	SessionID   string
+	StartedAt   time.Time
+	// AccountedUntil is the point up to which play time has been added to usage
+	AccountedUntil time.Time
+	// LastWarning is the smallest warning threshold already sent, in minutes (0 if none)
+	LastWarning int
+}
+
+// PlaytimeSlice is play time falling on one local calendar day
+type PlaytimeSlice struct {
+	// Day is the local date, expressed as midnight UTC for storage
+	Day      time.Time
+	Duration time.Duration
+}
+
+// SplitByDay divides the span [from, to) into per-day slices in the account's timezone
+func (p *ParentalControls) SplitByDay(from, to time.Time) []PlaytimeSlice {
+	var slices []PlaytimeSlice
+	for from.Before(to) {
+		dayStart := p.DayStart(from)
+		next := dayStart.AddDate(0, 0, 1)
+		end := to
+		if next.Before(end) {
+			end = next
+		}
+		slices = append(slices, PlaytimeSlice{
+			Day:      time.Date(dayStart.Year(), dayStart.Month(), dayStart.Day(), 0, 0, 0, 0, time.UTC),
+			Duration: end.Sub(from),
+		})
+		from = end
+	}
+	return slices
+}
@@ -0,0 +1,134 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParentalControls_Validate(t *testing.T) {
+	controls := NewParentalControls(uuid.New(), uuid.New())
+	assert.NoError(t, controls.Validate())
+
+	controls.DailyMinutes = 90
+	controls.WeeklyMinutes = 600
+	controls.Windows = []PlayWindow{{Start: 15 * 60, End: 19 * 60}, {Start: 22 * 60, End: 60}}
+	controls.Timezone = "Europe/Berlin"
+	assert.NoError(t, controls.Validate())
+
+	invalid := []func(p *ParentalControls){
+		func(p *ParentalControls) { p.DailyMinutes = -1 },
+		func(p *ParentalControls) { p.WeeklyMinutes = MaxWeeklyPlayMinutes + 1 },
+		func(p *ParentalControls) { p.Windows = []PlayWindow{{Start: 600, End: 600}} },
+		func(p *ParentalControls) { p.Windows = []PlayWindow{{Start: 0, End: MinutesPerDay + 1}} },
+		func(p *ParentalControls) { p.Windows = []PlayWindow{{Days: []time.Weekday{7}, Start: 0, End: 60}} },
+		func(p *ParentalControls) { p.Timezone = "Mars/Olympus" },
+	}
+	for _, mutate := range invalid {
+		c := *controls
+		mutate(&c)
+		assert.True(t, errors.Is(c.Validate(), ErrInvalidPlaytimeLimits))
+	}
+}
+
+func TestParentalControls_Evaluate(t *testing.T) {
+	// Wednesday 2026-10-14 16:30 UTC
+	now := time.Date(2026, 10, 14, 16, 30, 0, 0, time.UTC)
+
+	t.Run("no limits allows play", func(t *testing.T) {
+		controls := NewParentalControls(uuid.New(), uuid.New())
+		status := controls.Evaluate(now, 10*time.Hour, 40*time.Hour)
+		assert.True(t, status.Allowed)
+		assert.False(t, status.Limited)
+	})
+
+	t.Run("daily budget", func(t *testing.T) {
+		controls := NewParentalControls(uuid.New(), uuid.New())
+		controls.DailyMinutes = 60
+
+		status := controls.Evaluate(now, 45*time.Minute, 0)
+		assert.True(t, status.Allowed)
+		assert.Equal(t, 15*time.Minute, status.Remaining)
+		assert.Equal(t, PlaytimeReasonDailyLimit, status.Reason)
+
+		status = controls.Evaluate(now, 61*time.Minute, 0)
+		assert.False(t, status.Allowed)
+		assert.Equal(t, time.Duration(0), status.Remaining)
+	})
+
+	t.Run("weekly budget runs out first", func(t *testing.T) {
+		controls := NewParentalControls(uuid.New(), uuid.New())
+		controls.DailyMinutes = 120
+		controls.WeeklyMinutes = 300
+
+		status := controls.Evaluate(now, 10*time.Minute, 295*time.Minute)
+		assert.True(t, status.Allowed)
+		assert.Equal(t, 5*time.Minute, status.Remaining)
+		assert.Equal(t, PlaytimeReasonWeeklyLimit, status.Reason)
+	})
+
+	t.Run("inside and outside windows", func(t *testing.T) {
+		controls := NewParentalControls(uuid.New(), uuid.New())
+		controls.Windows = []PlayWindow{{Start: 15 * 60, End: 17 * 60}}
+
+		status := controls.Evaluate(now, 0, 0)
+		assert.True(t, status.Allowed)
+		assert.Equal(t, 30*time.Minute, status.Remaining)
+
+		status = controls.Evaluate(now.Add(time.Hour), 0, 0)
+		assert.False(t, status.Allowed)
+		assert.Equal(t, PlaytimeReasonOutsideHours, status.Reason)
+	})
+
+	t.Run("windows use the account timezone", func(t *testing.T) {
+		controls := NewParentalControls(uuid.New(), uuid.New())
+		controls.Timezone = "America/New_York" // 16:30 UTC is 12:30 EDT
+		controls.Windows = []PlayWindow{{Start: 15 * 60, End: 17 * 60}}
+		assert.False(t, controls.Evaluate(now, 0, 0).Allowed)
+	})
+
+	t.Run("window wrapping midnight respects opening day", func(t *testing.T) {
+		controls := NewParentalControls(uuid.New(), uuid.New())
+		controls.Windows = []PlayWindow{{Days: []time.Weekday{time.Friday}, Start: 22 * 60, End: 60}}
+
+		friday := time.Date(2026, 10, 16, 23, 0, 0, 0, time.UTC)
+		status := controls.Evaluate(friday, 0, 0)
+		assert.True(t, status.Allowed)
+		assert.Equal(t, 2*time.Hour, status.Remaining)
+
+		saturdayMorning := time.Date(2026, 10, 17, 0, 30, 0, 0, time.UTC)
+		assert.True(t, controls.Evaluate(saturdayMorning, 0, 0).Allowed)
+
+		thursday := time.Date(2026, 10, 15, 23, 0, 0, 0, time.UTC)
+		assert.False(t, controls.Evaluate(thursday, 0, 0).Allowed)
+	})
+
+	t.Run("disabled controls allow play", func(t *testing.T) {
+		controls := NewParentalControls(uuid.New(), uuid.New())
+		controls.DailyMinutes = 1
+		controls.Enabled = false
+		assert.True(t, controls.Evaluate(now, time.Hour, 0).Allowed)
+	})
+}
+
+func TestParentalControls_Calendar(t *testing.T) {
+	controls := NewParentalControls(uuid.New(), uuid.New())
+	controls.Timezone = "Europe/Berlin"
+	loc := controls.Location()
+
+	// Sunday 2026-10-18 23:30 in Berlin
+	at := time.Date(2026, 10, 18, 23, 30, 0, 0, loc)
+	assert.Equal(t, time.Date(2026, 10, 18, 0, 0, 0, 0, loc), controls.DayStart(at))
+	assert.Equal(t, time.Date(2026, 10, 12, 0, 0, 0, 0, loc), controls.WeekStart(at))
+
+	slices := controls.SplitByDay(at, at.Add(time.Hour))
+	require.Len(t, slices, 2)
+	assert.Equal(t, time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC), slices[0].Day)
+	assert.Equal(t, 30*time.Minute, slices[0].Duration)
+	assert.Equal(t, time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), slices[1].Day)
+	assert.Equal(t, 30*time.Minute, slices[1].Duration)
+}
@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"math/bits"
+	"time"
+)
+
+// Registration proof-of-work limits
+const (
+	// MaxPowDifficulty caps the number of leading zero bits a challenge may demand
+	MaxPowDifficulty = 32
+	// PowChallengeDuration is how long an issued challenge may be solved and redeemed
+	PowChallengeDuration = 5 * time.Minute
+)
+
+// PowChallenge is a hashcash-style puzzle: the client must find a nonce such that
+// SHA-256(Challenge + ":" + nonce) starts with Difficulty zero bits
+type PowChallenge struct {
+	Challenge  string
+	Difficulty int
+	ExpiresAt  time.Time
+}
+
+// PowGrant is the payload stored behind an issued challenge: the difficulty it was
+// issued at and the network it was issued to, which is the only one that may redeem it
+type PowGrant struct {
+	Difficulty int    `json:"difficulty"`
+	Network    string `json:"network"`
+}
+
+// PowNetworkOf returns the network a challenge is bound to: the /24 or /48 of the
+// address, so a client moving within its ISP allocation can still redeem it
+func PowNetworkOf(ipAddress string) string {
+	if network := NetworkOf(ipAddress); network != "" {
+		return network
+	}
+	return ipAddress
+}
+
+// VerifyPowSolution reports whether nonce solves challenge at the given difficulty
+func VerifyPowSolution(challenge, nonce string, difficulty int) bool {
+	if nonce == "" || difficulty < 0 || difficulty > MaxPowDifficulty {
+		return false
+	}
+	sum := sha256.Sum256([]byte(challenge + ":" + nonce))
+	return leadingZeroBits(sum[:]) >= difficulty
+}
+
+// PowDifficulty returns the difficulty for the given number of recent attempts:
+// zero up to threshold, then base bits plus one bit per doubling over the threshold,
+// capped at max
+func PowDifficulty(attempts, threshold, base, max int) int {
+	if threshold <= 0 || attempts <= threshold {
+		return 0
+	}
+	if max > MaxPowDifficulty {
+		max = MaxPowDifficulty
+	}
+	difficulty := base
+	for n := attempts / threshold; n > 1 && difficulty < max; n >>= 1 {
+		difficulty++
+	}
+	if difficulty > max {
+		difficulty = max
+	}
+	return difficulty
+}
+
+func leadingZeroBits(b []byte) int {
+	n := 0
+	for _, v := range b {
+		if v != 0 {
+			return n + bits.LeadingZeros8(v)
+		}
+		n += 8
+	}
+	return n
+}
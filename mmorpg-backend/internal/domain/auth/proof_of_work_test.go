@@ -0,0 +1,56 @@
+package auth
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func solvePow(challenge string, difficulty int) string {
+	for i := 0; ; i++ {
+		nonce := strconv.Itoa(i)
+		if VerifyPowSolution(challenge, nonce, difficulty) {
+			return nonce
+		}
+	}
+}
+
+func TestVerifyPowSolution(t *testing.T) {
+	nonce := solvePow("challenge", 12)
+
+	assert.True(t, VerifyPowSolution("challenge", nonce, 12))
+	assert.True(t, VerifyPowSolution("challenge", nonce, 0))
+	assert.False(t, VerifyPowSolution("other", nonce, 12))
+	assert.False(t, VerifyPowSolution("challenge", "", 0))
+	assert.False(t, VerifyPowSolution("challenge", nonce, MaxPowDifficulty+1))
+}
+
+func TestPowDifficulty(t *testing.T) {
+	tests := []struct {
+		name     string
+		attempts int
+		want     int
+	}{
+		{"below threshold", 3, 0},
+		{"at threshold", 5, 0},
+		{"just over threshold", 6, 16},
+		{"double threshold", 10, 17},
+		{"quadruple threshold", 20, 18},
+		{"capped", 5000, 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, PowDifficulty(tt.attempts, 5, 16, 20))
+		})
+	}
+
+	assert.Equal(t, 0, PowDifficulty(100, 0, 16, 20))
+}
+
+func TestPowNetworkOf(t *testing.T) {
+	assert.Equal(t, "203.0.113.0/24", PowNetworkOf("203.0.113.10"))
+	assert.Equal(t, PowNetworkOf("2001:db8:1::1"), PowNetworkOf("2001:db8:1:ffff::2"))
+	assert.Equal(t, "not-an-ip", PowNetworkOf("not-an-ip"))
+}
@@ -0,0 +1,151 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SecurityEventType identifies the kind of account event being audited
+type SecurityEventType string
+
+const (
+	SecurityEventLoginSuccess          SecurityEventType = "login.success"
+	SecurityEventLoginFailure          SecurityEventType = "login.failure"
+	SecurityEventLogout                SecurityEventType = "logout"
+	SecurityEventPasswordChanged       SecurityEventType = "password.changed"
+	SecurityEventPasswordReset         SecurityEventType = "password.reset"
+	SecurityEventSessionRevoked        SecurityEventType = "session.revoked"
+	SecurityEventAllSessionsEnded      SecurityEventType = "session.revoked_all"
+	SecurityEventRolesChanged          SecurityEventType = "account.roles_changed"
+	SecurityEventStatusChanged         SecurityEventType = "account.status_changed"
+	SecurityEventAccountLocked         SecurityEventType = "account.locked"
+	SecurityEventAccountUnlocked       SecurityEventType = "account.unlocked"
+	SecurityEventNewDevice             SecurityEventType = "device.new"
+	SecurityEventNewNetwork            SecurityEventType = "device.new_network"
+	SecurityEventDeviceConfirmed       SecurityEventType = "device.confirmed"
+	SecurityEventDeviceRemoved         SecurityEventType = "device.removed"
+	SecurityEventGuestCreated          SecurityEventType = "account.guest_created"
+	SecurityEventGuestUpgraded         SecurityEventType = "account.guest_upgraded"
+	SecurityEventGuestDeleted          SecurityEventType = "account.guest_deleted"
+	SecurityEventUsernameChanged       SecurityEventType = "account.username_changed"
+	SecurityEventEmailChangeReq        SecurityEventType = "account.email_change_requested"
+	SecurityEventEmailChanged          SecurityEventType = "account.email_changed"
+	SecurityEventServiceAccountCreated SecurityEventType = "service_account.created"
+	SecurityEventServiceAccountUpdated SecurityEventType = "service_account.updated"
+	SecurityEventServiceTokenIssued    SecurityEventType = "service_token.issued"
+	SecurityEventGuardianLinked        SecurityEventType = "account.guardian_linked"
+	SecurityEventGuardianRemoved       SecurityEventType = "account.guardian_removed"
+	SecurityEventPlaytimeLimitsChanged SecurityEventType = "account.playtime_limits_changed"
+	SecurityEventPlaytimeLogout        SecurityEventType = "session.playtime_logout"
+)
+
+// SecurityEventOutcome records whether the audited action succeeded
+type SecurityEventOutcome string
+
+const (
+	SecurityOutcomeSuccess SecurityEventOutcome = "success"
+	SecurityOutcomeFailure SecurityEventOutcome = "failure"
+	SecurityOutcomeDenied  SecurityEventOutcome = "denied"
+)
+
+// SecurityEvent is an append-only audit record of an account-related action
+type SecurityEvent struct {
+	ID        uuid.UUID
+	EventType SecurityEventType
+	Outcome   SecurityEventOutcome
+	// ActorID is the user who performed the action (empty for anonymous callers)
+	ActorID string
+	// TargetUserID is the account the action applied to (empty if unknown)
+	TargetUserID string
+	SessionID    string
+	IPAddress    string
+	DeviceID     string
+	UserAgent    string
+	Reason       string
+	Metadata     map[string]string
+	CreatedAt    time.Time
+}
+
+// NewSecurityEvent creates a new security event stamped with the current time
+func NewSecurityEvent(eventType SecurityEventType, outcome SecurityEventOutcome, targetUserID string) *SecurityEvent {
+	return &SecurityEvent{
+		ID:           uuid.New(),
+		EventType:    eventType,
+		Outcome:      outcome,
+		TargetUserID: targetUserID,
+		Metadata:     map[string]string{},
+		CreatedAt:    time.Now(),
+	}
+}
+
+// WithClient copies the client details from a request context onto the event
+func (e *SecurityEvent) WithClient(client ClientInfo) *SecurityEvent {
+	if e.ActorID == "" {
+		e.ActorID = client.ActorID
+	}
+	if e.IPAddress == "" {
+		e.IPAddress = client.IPAddress
+	}
+	if e.DeviceID == "" {
+		e.DeviceID = client.DeviceID
+	}
+	if e.UserAgent == "" {
+		e.UserAgent = client.UserAgent
+	}
+	return e
+}
+
+// SecurityEventFilter selects security events for support queries
+type SecurityEventFilter struct {
+	UserID    string
+	ActorID   string
+	IPAddress string
+	DeviceID  string
+	Types     []SecurityEventType
+	Since     *time.Time
+	Until     *time.Time
+	Limit     int
+	Offset    int
+}
+
+// Security event query bounds
+const (
+	DefaultSecurityEventLimit = 50
+	MaxSecurityEventLimit     = 500
+)
+
+// Normalize clamps paging values to sane bounds
+func (f *SecurityEventFilter) Normalize() {
+	if f.Limit <= 0 {
+		f.Limit = DefaultSecurityEventLimit
+	}
+	if f.Limit > MaxSecurityEventLimit {
+		f.Limit = MaxSecurityEventLimit
+	}
+	if f.Offset < 0 {
+		f.Offset = 0
+	}
+}
+
+// ClientInfo describes the caller of an auth operation
+type ClientInfo struct {
+	ActorID   string
+	IPAddress string
+	DeviceID  string
+	UserAgent string
+}
+
+type clientInfoKey struct{}
+
+// ContextWithClient attaches caller details to a context
+func ContextWithClient(ctx context.Context, client ClientInfo) context.Context {
+	return context.WithValue(ctx, clientInfoKey{}, client)
+}
+
+// ClientFromContext returns the caller details attached to a context, if any
+func ClientFromContext(ctx context.Context) ClientInfo {
+	client, _ := ctx.Value(clientInfoKey{}).(ClientInfo)
+	return client
+}
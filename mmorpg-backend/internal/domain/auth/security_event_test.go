@@ -0,0 +1,40 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecurityEventWithClient(t *testing.T) {
+	ctx := ContextWithClient(context.Background(), ClientInfo{
+		ActorID:   "admin-1",
+		IPAddress: "10.0.0.1",
+		DeviceID:  "console",
+		UserAgent: "AdminConsole",
+	})
+
+	event := NewSecurityEvent(SecurityEventLogout, SecurityOutcomeSuccess, "user-1")
+	event.DeviceID = "device-1"
+	event.WithClient(ClientFromContext(ctx))
+
+	assert.Equal(t, "admin-1", event.ActorID)
+	assert.Equal(t, "10.0.0.1", event.IPAddress)
+	assert.Equal(t, "device-1", event.DeviceID, "explicit details win over the caller's")
+	assert.Equal(t, "AdminConsole", event.UserAgent)
+	assert.NotNil(t, event.Metadata)
+
+	assert.Equal(t, ClientInfo{}, ClientFromContext(context.Background()))
+}
+
+func TestSecurityEventFilterNormalize(t *testing.T) {
+	filter := &SecurityEventFilter{Offset: -5}
+	filter.Normalize()
+	assert.Equal(t, DefaultSecurityEventLimit, filter.Limit)
+	assert.Equal(t, 0, filter.Offset)
+
+	filter = &SecurityEventFilter{Limit: MaxSecurityEventLimit + 1}
+	filter.Normalize()
+	assert.Equal(t, MaxSecurityEventLimit, filter.Limit)
+}
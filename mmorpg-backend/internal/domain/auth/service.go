@@ -39,6 +39,11 @@ type RegisterRequest struct {
 	Username     string
 	AcceptTerms  bool
 	ReferralCode string
+	// IPAddress is used to measure registration pressure
+	IPAddress string
+	// PowChallenge and PowNonce carry a solved proof-of-work challenge, if any
+	PowChallenge string
+	PowNonce     string
 }
 
 // Validate validates the registration request
@@ -0,0 +1,46 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewServiceAccount(t *testing.T) {
+	account := NewServiceAccount(" World Server ", []string{ScopeCharacterRead}, "hash")
+
+	assert.Equal(t, "svc-world-server", account.ClientID)
+	assert.True(t, account.IsActive)
+	assert.True(t, account.HasScope(ScopeCharacterRead))
+	assert.False(t, account.HasScope(ScopeCharacterWrite))
+}
+
+func TestServiceAccount_GrantableScopes(t *testing.T) {
+	account := NewServiceAccount("world", []string{ScopeCharacterRead, ScopeUserRead}, "hash")
+
+	scopes, err := account.GrantableScopes(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{ScopeCharacterRead, ScopeUserRead}, scopes)
+
+	scopes, err = account.GrantableScopes([]string{ScopeUserRead})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{ScopeUserRead}, scopes)
+
+	_, err = account.GrantableScopes([]string{ScopeCharacterWrite})
+	assert.Equal(t, ErrInsufficientScope, err)
+}
+
+func TestServiceClaims_IsValid(t *testing.T) {
+	claims := &ServiceClaims{ServiceID: "id", ClientID: "svc-world", TokenType: ServiceTokenType}
+	assert.True(t, claims.IsValid())
+
+	claims.TokenType = ""
+	assert.False(t, claims.IsValid())
+}
+
+func TestIsValidScope(t *testing.T) {
+	assert.True(t, IsValidScope("character:read"))
+	assert.False(t, IsValidScope("character"))
+	assert.False(t, IsValidScope(":read"))
+	assert.False(t, IsValidScope("character: read"))
+}
@@ -30,6 +30,28 @@ type Claims struct {
 	RefreshTokenDuration = 7 * 24 * time.Hour // 7 days
 )
 
+// TokenPurpose scopes one-time verification tokens so a token issued
+// for one flow cannot be replayed against another
+type TokenPurpose string
+
+const (
+	TokenPurposeAccountUnlock TokenPurpose = "account_unlock"
+	TokenPurposeDeviceConfirm TokenPurpose = "device_confirm"
+	// Email change confirmations: one token per address, both point at the pending change
+	TokenPurposeEmailChangeOld TokenPurpose = "email_change_old"
+	TokenPurposeEmailChangeNew TokenPurpose = "email_change_new"
+	TokenPurposeEmailChange    TokenPurpose = "email_change"
+	TokenPurposeRegisterPow    TokenPurpose = "register_pow"
+	TokenPurposeGuardianLink   TokenPurpose = "guardian_link"
+)
+
+// Verification token lifetimes
+const (
+	AccountUnlockTokenDuration = 1 * time.Hour
+	DeviceConfirmTokenDuration = 30 * time.Minute
+	EmailChangeTokenDuration   = 24 * time.Hour
+)
+
 // RefreshClaims represents the claims for a refresh token
 type RefreshClaims struct {
 	UserID    string `json:"uid"`
@@ -1,6 +1,8 @@
 package auth
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -19,10 +21,33 @@ type User struct {
 	CharacterCount   int
 	IsPremium        bool
 	PremiumExpiresAt *time.Time
-	CreatedAt        time.Time
-	UpdatedAt        time.Time
+	// IsGuest marks a device-bound account created without email or password
+	IsGuest       bool
+	GuestDeviceID string
+	// GuestSecretHash is the hash of the secret issued to the guest's device, which it
+	// must present to log in; only loaded for guest logins
+	GuestSecretHash string
+	LastLoginAt     *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
 }
 
+// Account roles
+const (
+	RolePlayer = "player"
+	// RoleGuest restricts an account to guest limits (one character, no trading)
+	RoleGuest = "guest"
+)
+
+// Account limits
+const (
+	DefaultMaxCharacters = 5
+	GuestMaxCharacters   = 1
+)
+
+// GuestEmailDomain is the reserved domain used for guest placeholder emails
+const GuestEmailDomain = "guest.invalid"
+
 // AccountStatus represents the status of a user account
 type AccountStatus int
 
@@ -44,8 +69,8 @@ func NewUser(email, username, passwordHash string) *User {
 		PasswordHash:     passwordHash,
 		EmailVerified:    false,
 		AccountStatus:    AccountStatusPendingVerification,
-		Roles:            []string{"player"},
-		MaxCharacters:    5,
+		Roles:            []string{RolePlayer},
+		MaxCharacters:    DefaultMaxCharacters,
 		CharacterCount:   0,
 		IsPremium:        false,
 		PremiumExpiresAt: nil,
@@ -54,6 +79,45 @@ func NewUser(email, username, passwordHash string) *User {
 	}
 }
 
+// NewGuestUser creates a guest account bound to a device. Guests get placeholder
+// credentials that can never be used to log in with a password.
+func NewGuestUser(deviceID string) *User {
+	user := NewUser("", "", "")
+	short := strings.ReplaceAll(user.ID.String(), "-", "")[:12]
+	user.Email = fmt.Sprintf("guest-%s@%s", user.ID, GuestEmailDomain)
+	user.Username = "Guest_" + short
+	user.PasswordHash = "!guest"
+	user.AccountStatus = AccountStatusActive
+	user.Roles = []string{RoleGuest}
+	user.MaxCharacters = GuestMaxCharacters
+	user.IsGuest = true
+	user.GuestDeviceID = deviceID
+	return user
+}
+
+// UpgradeFromGuest turns a guest into a full account, keeping its ID and characters
+func (u *User) UpgradeFromGuest(email, username, passwordHash string) {
+	u.Email = email
+	u.Username = username
+	u.PasswordHash = passwordHash
+	u.IsGuest = false
+	u.GuestDeviceID = ""
+	u.GuestSecretHash = ""
+	u.EmailVerified = true // For now, auto-verify, as in Register
+	if u.MaxCharacters < DefaultMaxCharacters {
+		u.MaxCharacters = DefaultMaxCharacters
+	}
+
+	roles := []string{RolePlayer}
+	for _, r := range u.Roles {
+		if r != RoleGuest && r != RolePlayer {
+			roles = append(roles, r)
+		}
+	}
+	u.Roles = roles
+	u.UpdatedAt = time.Now()
+}
+
 // CanCreateCharacter checks if the user can create more characters
 func (u *User) CanCreateCharacter() bool {
 	return u.CharacterCount < u.MaxCharacters
@@ -84,4 +148,32 @@ func (u *User) UpdatePremiumStatus(isPremium bool, expiresAt *time.Time) {
 		u.MaxCharacters = 5
 	}
 	u.UpdatedAt = time.Now()
-}
\ No newline at end of file
+}
+
+// DeletedGuest is a guest account removed for inactivity, with the characters deleted along with it
+type DeletedGuest struct {
+	UserID     string
+	Characters []DeletedCharacter
+}
+
+// DeletedCharacter identifies a character removed together with its account
+type DeletedCharacter struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// CharacterCountCorrection is a user whose stored character count did not match their
+// characters. Count is what was stored instead; it never exceeds MaxCharacters, so it is
+// lower than Actual when the account holds more characters than it allows.
+type CharacterCountCorrection struct {
+	UserID        string
+	Previous      int
+	Actual        int
+	Count         int
+	MaxCharacters int
+}
+
+// OverLimit reports whether the user holds more characters than their account allows
+func (c *CharacterCountCorrection) OverLimit() bool {
+	return c.Actual > c.MaxCharacters
+}
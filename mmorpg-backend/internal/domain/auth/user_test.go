@@ -0,0 +1,35 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewGuestUser(t *testing.T) {
+	guest := NewGuestUser("device-1")
+
+	assert.True(t, guest.IsGuest)
+	assert.Equal(t, "device-1", guest.GuestDeviceID)
+	assert.Equal(t, AccountStatusActive, guest.AccountStatus)
+	assert.Equal(t, []string{RoleGuest}, guest.Roles)
+	assert.Equal(t, GuestMaxCharacters, guest.MaxCharacters)
+	assert.Contains(t, guest.Email, "@"+GuestEmailDomain)
+	assert.LessOrEqual(t, len(guest.Username), 30)
+}
+
+func TestUpgradeFromGuest(t *testing.T) {
+	guest := NewGuestUser("device-1")
+	guest.Roles = append(guest.Roles, "tester")
+	id := guest.ID
+
+	guest.UpgradeFromGuest("player@example.com", "player123", "hash")
+
+	assert.Equal(t, id, guest.ID)
+	assert.False(t, guest.IsGuest)
+	assert.Empty(t, guest.GuestDeviceID)
+	assert.Equal(t, "player@example.com", guest.Email)
+	assert.Equal(t, "player123", guest.Username)
+	assert.Equal(t, []string{RolePlayer, "tester"}, guest.Roles)
+	assert.Equal(t, DefaultMaxCharacters, guest.MaxCharacters)
+}
@@ -6,6 +6,9 @@
 	"github.com/google/uuid"
 )
 
+// MaxSlotNumber is the highest character slot an account can use
+const MaxSlotNumber = 100
+
 // Character represents a player character in the game
 type Character struct {
 	ID                   uuid.UUID
@@ -127,28 +130,6 @@ func (c *Character) UpdatePlayTime(sessionDuration time.Duration) {
 	c.UpdatedAt = time.Now()
 }
 
-// IsValidClass checks if the class type is valid
-func IsValidClass(class ClassType) bool {
-	switch class {
-	case ClassWarrior, ClassMage, ClassRogue, ClassPriest, 
-		 ClassRanger, ClassPaladin, ClassWarlock, ClassDruid:
-		return true
-	default:
-		return false
-	}
-}
-
-// IsValidRace checks if the race is valid
-func IsValidRace(race Race) bool {
-	switch race {
-	case RaceHuman, RaceElf, RaceDwarf, RaceOrc, 
-		 RaceGnome, RaceTroll, RaceUndead:
-		return true
-	default:
-		return false
-	}
-}
-
 // IsValidGender checks if the gender is valid
 func IsValidGender(gender Gender) bool {
 	switch gender {
@@ -158,29 +139,3 @@ func IsValidGender(gender Gender) bool {
 		return false
 	}
 }
-
-// GetExperienceForLevel returns the total experience required for a specific level
-func GetExperienceForLevel(level int) int64 {
-	if level <= 1 {
-		return 0
-	}
-	// Simple exponential formula for now
-	// Can be adjusted based on game balance requirements
-	return int64(100 * (level - 1) * (level - 1))
-}
-
-// CalculateLevel calculates the level based on total experience
-func CalculateLevel(experience int64) int {
-	level := 1
-	for {
-		requiredExp := GetExperienceForLevel(level + 1)
-		if experience < requiredExp {
-			break
-		}
-		level++
-		if level >= 100 { // Max level cap
-			break
-		}
-	}
-	return level
-}
\ No newline at end of file
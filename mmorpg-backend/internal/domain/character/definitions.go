@@ -0,0 +1,463 @@
+package character
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// Primary attribute names
+const (
+	AttributeStrength     = "strength"
+	AttributeDexterity    = "dexterity"
+	AttributeIntelligence = "intelligence"
+	AttributeWisdom       = "wisdom"
+	AttributeConstitution = "constitution"
+	AttributeCharisma     = "charisma"
+)
+
+// Derived stat names computed by formulas
+const (
+	DerivedHealthMax      = "health_max"
+	DerivedManaMax        = "mana_max"
+	DerivedStaminaMax     = "stamina_max"
+	DerivedAttackPower    = "attack_power"
+	DerivedSpellPower     = "spell_power"
+	DerivedDefense        = "defense"
+	DerivedCriticalChance = "critical_chance"
+	DerivedDodgeChance    = "dodge_chance"
+	DerivedHealthRegen    = "health_regen"
+	DerivedManaRegen      = "mana_regen"
+	DerivedStaminaRegen   = "stamina_regen"
+)
+
+// attributeNames lists the primary attributes
+var attributeNames = []string{
+	AttributeStrength, AttributeDexterity, AttributeIntelligence,
+	AttributeWisdom, AttributeConstitution, AttributeCharisma,
+}
+
+// MaxPrimaryStat is the highest value a primary attribute can reach
+const MaxPrimaryStat = 999
+
+// DerivedStats lists every stat a formula can define
+var DerivedStats = []string{
+	DerivedHealthMax, DerivedManaMax, DerivedStaminaMax,
+	DerivedAttackPower, DerivedSpellPower, DerivedDefense,
+	DerivedCriticalChance, DerivedDodgeChance,
+	DerivedHealthRegen, DerivedManaRegen, DerivedStaminaRegen,
+}
+
+// Attributes holds the six primary attributes
+type Attributes struct {
+	Strength     int
+	Dexterity    int
+	Intelligence int
+	Wisdom       int
+	Constitution int
+	Charisma     int
+}
+
+// field returns a pointer to the named attribute, or nil if the name is unknown
+func (a *Attributes) field(name string) *int {
+	switch name {
+	case AttributeStrength:
+		return &a.Strength
+	case AttributeDexterity:
+		return &a.Dexterity
+	case AttributeIntelligence:
+		return &a.Intelligence
+	case AttributeWisdom:
+		return &a.Wisdom
+	case AttributeConstitution:
+		return &a.Constitution
+	case AttributeCharisma:
+		return &a.Charisma
+	}
+	return nil
+}
+
+// Get returns the named attribute
+func (a Attributes) Get(name string) (int, bool) {
+	if f := a.field(name); f != nil {
+		return *f, true
+	}
+	return 0, false
+}
+
+// Set changes the named attribute and reports whether the name is known
+func (a *Attributes) Set(name string, value int) bool {
+	f := a.field(name)
+	if f == nil {
+		return false
+	}
+	*f = value
+	return true
+}
+
+// Add returns the sum of two attribute sets
+func (a Attributes) Add(other Attributes) Attributes {
+	return Attributes{
+		Strength:     a.Strength + other.Strength,
+		Dexterity:    a.Dexterity + other.Dexterity,
+		Intelligence: a.Intelligence + other.Intelligence,
+		Wisdom:       a.Wisdom + other.Wisdom,
+		Constitution: a.Constitution + other.Constitution,
+		Charisma:     a.Charisma + other.Charisma,
+	}
+}
+
+// min returns the lowest attribute
+func (a Attributes) min() int {
+	return min(a.Strength, a.Dexterity, a.Intelligence, a.Wisdom, a.Constitution, a.Charisma)
+}
+
+// Formula computes a derived stat as a base value plus weighted primary attributes
+type Formula struct {
+	Base float64
+	// Weights maps attribute names to their multiplier
+	Weights map[string]float64
+}
+
+// Evaluate computes the formula for the given attributes
+func (f Formula) Evaluate(a Attributes) float64 {
+	value := f.Base
+	for name, weight := range f.Weights {
+		attr, _ := a.Get(name)
+		value += float64(attr) * weight
+	}
+	return value
+}
+
+// StartingLocation is where new characters spawn
+type StartingLocation struct {
+	WorldID string
+	ZoneID  string
+	X       float64
+	Y       float64
+	Z       float64
+}
+
+// ClassDefinition describes a playable class
+type ClassDefinition struct {
+	ID   ClassType
+	Name string
+	// BaseAttributes are the starting primary attributes before racial modifiers
+	BaseAttributes Attributes
+	// Formulas override the default derived stat formulas for this class
+	Formulas map[string]Formula
+	Start    StartingLocation
+}
+
+// RaceDefinition describes a playable race
+type RaceDefinition struct {
+	ID   Race
+	Name string
+	// Modifiers are added to the class base attributes
+	Modifiers Attributes
+	// AllowedClasses limits the classes the race may play; empty allows all
+	AllowedClasses []ClassType
+	// Start overrides the class starting location when set
+	Start *StartingLocation
+}
+
+// Definitions holds the playable classes and races with their stat formulas
+type Definitions struct {
+	Version string
+	// Formulas are the derived stat formulas used unless a class overrides them
+	Formulas map[string]Formula
+	Classes  map[ClassType]*ClassDefinition
+	Races    map[Race]*RaceDefinition
+}
+
+// Validate checks that formulas are complete, references resolve and every
+// allowed combination starts with positive attributes
+func (d *Definitions) Validate() error {
+	if d.Version == "" {
+		return fmt.Errorf("%w: version is required", ErrInvalidDefinitions)
+	}
+	if len(d.Classes) == 0 || len(d.Races) == 0 {
+		return fmt.Errorf("%w: at least one class and one race are required", ErrInvalidDefinitions)
+	}
+	for _, stat := range DerivedStats {
+		if _, ok := d.Formulas[stat]; !ok {
+			return fmt.Errorf("%w: missing default formula for %s", ErrInvalidDefinitions, stat)
+		}
+	}
+	if err := validateFormulas(d.Formulas); err != nil {
+		return err
+	}
+
+	for id, class := range d.Classes {
+		if id == "" || class.ID != id {
+			return fmt.Errorf("%w: class %q has a mismatched id", ErrInvalidDefinitions, id)
+		}
+		if err := validateFormulas(class.Formulas); err != nil {
+			return fmt.Errorf("class %s: %w", id, err)
+		}
+		if class.Start.WorldID == "" || class.Start.ZoneID == "" {
+			return fmt.Errorf("%w: class %s has no starting zone", ErrInvalidDefinitions, id)
+		}
+	}
+
+	for id, race := range d.Races {
+		if id == "" || race.ID != id {
+			return fmt.Errorf("%w: race %q has a mismatched id", ErrInvalidDefinitions, id)
+		}
+		if race.Start != nil && (race.Start.WorldID == "" || race.Start.ZoneID == "") {
+			return fmt.Errorf("%w: race %s has an incomplete starting zone", ErrInvalidDefinitions, id)
+		}
+		for _, classID := range race.AllowedClasses {
+			if _, ok := d.Classes[classID]; !ok {
+				return fmt.Errorf("%w: race %s allows unknown class %s", ErrInvalidDefinitions, id, classID)
+			}
+		}
+		for classID, class := range d.Classes {
+			if race.allows(classID) && class.BaseAttributes.Add(race.Modifiers).min() < 1 {
+				return fmt.Errorf("%w: %s %s starts with an attribute below 1", ErrInvalidDefinitions, id, classID)
+			}
+		}
+	}
+	return nil
+}
+
+func validateFormulas(formulas map[string]Formula) error {
+	for stat, formula := range formulas {
+		if !isDerivedStat(stat) {
+			return fmt.Errorf("%w: unknown derived stat %s", ErrInvalidDefinitions, stat)
+		}
+		for name := range formula.Weights {
+			if _, ok := (Attributes{}).Get(name); !ok {
+				return fmt.Errorf("%w: %s uses unknown attribute %s", ErrInvalidDefinitions, stat, name)
+			}
+		}
+	}
+	return nil
+}
+
+func isDerivedStat(name string) bool {
+	for _, stat := range DerivedStats {
+		if stat == name {
+			return true
+		}
+	}
+	return false
+}
+
+// allows reports whether the race may play the class
+func (r *RaceDefinition) allows(class ClassType) bool {
+	if len(r.AllowedClasses) == 0 {
+		return true
+	}
+	for _, allowed := range r.AllowedClasses {
+		if allowed == class {
+			return true
+		}
+	}
+	return false
+}
+
+// ClassIDs returns the defined classes in name order
+func (d *Definitions) ClassIDs() []ClassType {
+	ids := make([]ClassType, 0, len(d.Classes))
+	for id := range d.Classes {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// RaceIDs returns the defined races in name order
+func (d *Definitions) RaceIDs() []Race {
+	ids := make([]Race, 0, len(d.Races))
+	for id := range d.Races {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// CheckCombination verifies that the class and race exist and may be combined
+func (d *Definitions) CheckCombination(class ClassType, race Race) error {
+	if _, ok := d.Classes[class]; !ok {
+		return ErrInvalidClass
+	}
+	raceDef, ok := d.Races[race]
+	if !ok {
+		return ErrInvalidRace
+	}
+	if !raceDef.allows(class) {
+		return ErrClassRaceNotAllowed
+	}
+	return nil
+}
+
+// BaseAttributes returns the starting attributes of a class and race
+func (d *Definitions) BaseAttributes(class ClassType, race Race) Attributes {
+	attributes := NewStats(uuid.Nil).Attributes()
+	if classDef, ok := d.Classes[class]; ok {
+		attributes = classDef.BaseAttributes
+	}
+	if raceDef, ok := d.Races[race]; ok {
+		attributes = attributes.Add(raceDef.Modifiers)
+	}
+	return attributes
+}
+
+// NewStats creates the starting stats of a character, at full health, mana and stamina
+func (d *Definitions) NewStats(characterID uuid.UUID, class ClassType, race Race) *Stats {
+	stats := NewStats(characterID)
+	stats.SetAttributes(d.BaseAttributes(class, race))
+
+	d.CalculateDerivedStats(stats, class)
+	stats.HealthCurrent = stats.HealthMax
+	stats.ManaCurrent = stats.ManaMax
+	stats.StaminaCurrent = stats.StaminaMax
+	return stats
+}
+
+// CalculateDerivedStats recalculates the derived stats from primary attributes.
+// Classes no longer defined fall back to the default formulas.
+func (d *Definitions) CalculateDerivedStats(s *Stats, class ClassType) {
+	attributes := s.Attributes()
+	classDef := d.Classes[class]
+
+	value := func(stat string) float64 {
+		if classDef != nil {
+			if formula, ok := classDef.Formulas[stat]; ok {
+				return formula.Evaluate(attributes)
+			}
+		}
+		return d.Formulas[stat].Evaluate(attributes)
+	}
+	// Integer stats round down; the epsilon absorbs float error such as 0.1*30
+	whole := func(stat string) int {
+		return int(math.Floor(value(stat) + 1e-9))
+	}
+
+	s.HealthMax = whole(DerivedHealthMax)
+	s.ManaMax = whole(DerivedManaMax)
+	s.StaminaMax = whole(DerivedStaminaMax)
+	s.AttackPower = whole(DerivedAttackPower)
+	s.SpellPower = whole(DerivedSpellPower)
+	s.Defense = whole(DerivedDefense)
+	s.CriticalChance = float32(value(DerivedCriticalChance))
+	s.DodgeChance = float32(value(DerivedDodgeChance))
+	s.HealthRegen = float32(value(DerivedHealthRegen))
+	s.ManaRegen = float32(value(DerivedManaRegen))
+	s.StaminaRegen = float32(value(DerivedStaminaRegen))
+
+	s.ClampResources()
+}
+
+// StartingPosition creates the spawn position of a new character.
+// A race starting location takes precedence over the class one.
+func (d *Definitions) StartingPosition(characterID uuid.UUID, class ClassType, race Race) *Position {
+	position := NewPosition(characterID)
+
+	var start *StartingLocation
+	if classDef, ok := d.Classes[class]; ok {
+		start = &classDef.Start
+	}
+	if raceDef, ok := d.Races[race]; ok && raceDef.Start != nil {
+		start = raceDef.Start
+	}
+	if start != nil {
+		position.WorldID = start.WorldID
+		position.ZoneID = start.ZoneID
+		position.PositionX = start.X
+		position.PositionY = start.Y
+		position.PositionZ = start.Z
+		position.SaveSafePosition()
+	}
+	return position
+}
+
+// weights is shorthand for building formula weights
+type weights = map[string]float64
+
+// DefaultDefinitions returns the built-in classes and races. Every race may play every class.
+func DefaultDefinitions() *Definitions {
+	attrs := func(str, con, dex, intl, wis, cha int) Attributes {
+		return Attributes{Strength: str, Constitution: con, Dexterity: dex, Intelligence: intl, Wisdom: wis, Charisma: cha}
+	}
+	start := func(zone string, x, y, z float64) StartingLocation {
+		return StartingLocation{WorldID: "starter_zone", ZoneID: zone, X: x, Y: y, Z: z}
+	}
+
+	martial := map[string]Formula{
+		DerivedHealthMax:   {Base: 100, Weights: weights{AttributeConstitution: 10, AttributeStrength: 2}},
+		DerivedAttackPower: {Weights: weights{AttributeStrength: 2, AttributeDexterity: 1}},
+	}
+	agile := map[string]Formula{
+		DerivedHealthMax:   {Base: 100, Weights: weights{AttributeConstitution: 8, AttributeDexterity: 2}},
+		DerivedAttackPower: {Weights: weights{AttributeDexterity: 2, AttributeStrength: 1}},
+	}
+	arcane := map[string]Formula{
+		DerivedHealthMax:  {Base: 100, Weights: weights{AttributeConstitution: 6, AttributeIntelligence: 1}},
+		DerivedManaMax:    {Base: 50, Weights: weights{AttributeIntelligence: 10, AttributeWisdom: 2}},
+		DerivedSpellPower: {Weights: weights{AttributeIntelligence: 3}},
+		DerivedManaRegen:  {Base: 1, Weights: weights{AttributeWisdom: 0.2}},
+	}
+	divine := map[string]Formula{
+		DerivedHealthMax:  {Base: 100, Weights: weights{AttributeConstitution: 7, AttributeWisdom: 2}},
+		DerivedManaMax:    {Base: 50, Weights: weights{AttributeWisdom: 10, AttributeIntelligence: 2}},
+		DerivedSpellPower: {Weights: weights{AttributeWisdom: 3}},
+		DerivedManaRegen:  {Base: 1, Weights: weights{AttributeWisdom: 0.2}},
+	}
+	paladin := map[string]Formula{
+		DerivedManaMax:    {Base: 50, Weights: weights{AttributeWisdom: 5}},
+		DerivedSpellPower: {Weights: weights{AttributeWisdom: 1, AttributeIntelligence: 0.5}},
+	}
+	for stat, formula := range martial {
+		paladin[stat] = formula
+	}
+
+	classes := []*ClassDefinition{
+		{ID: ClassWarrior, Name: "Warrior", BaseAttributes: attrs(15, 13, 8, 6, 6, 8), Formulas: martial, Start: start("warrior_training_grounds", 100, 50, 100)},
+		{ID: ClassPaladin, Name: "Paladin", BaseAttributes: attrs(13, 12, 8, 8, 10, 10), Formulas: paladin, Start: start("warrior_training_grounds", 100, 50, 100)},
+		{ID: ClassRogue, Name: "Rogue", BaseAttributes: attrs(8, 8, 15, 8, 8, 10), Formulas: agile, Start: start("shadow_alley", 200, -100, 80)},
+		{ID: ClassRanger, Name: "Ranger", BaseAttributes: attrs(10, 10, 13, 8, 10, 8), Formulas: agile, Start: start("hunters_lodge", 150, 150, 110)},
+		{ID: ClassMage, Name: "Mage", BaseAttributes: attrs(6, 8, 8, 15, 10, 8), Formulas: arcane, Start: start("arcane_academy", -100, -50, 150)},
+		{ID: ClassWarlock, Name: "Warlock", BaseAttributes: attrs(6, 8, 8, 13, 8, 13), Formulas: arcane, Start: start("arcane_academy", -100, -50, 150)},
+		{ID: ClassPriest, Name: "Priest", BaseAttributes: attrs(6, 8, 8, 10, 15, 10), Formulas: divine, Start: start("sacred_grove", -200, 100, 120)},
+		{ID: ClassDruid, Name: "Druid", BaseAttributes: attrs(8, 10, 8, 10, 13, 8), Formulas: divine, Start: start("sacred_grove", -200, 100, 120)},
+	}
+	races := []*RaceDefinition{
+		{ID: RaceHuman, Name: "Human"},
+		{ID: RaceElf, Name: "Elf"},
+		{ID: RaceDwarf, Name: "Dwarf"},
+		{ID: RaceOrc, Name: "Orc"},
+		{ID: RaceGnome, Name: "Gnome"},
+		{ID: RaceTroll, Name: "Troll"},
+		{ID: RaceUndead, Name: "Undead"},
+	}
+
+	defs := &Definitions{
+		Version: "builtin",
+		Formulas: map[string]Formula{
+			DerivedHealthMax:      {Base: 100, Weights: weights{AttributeConstitution: 8}},
+			DerivedManaMax:        {Base: 50, Weights: weights{AttributeIntelligence: 2}},
+			DerivedStaminaMax:     {Base: 100, Weights: weights{AttributeConstitution: 5, AttributeStrength: 2}},
+			DerivedAttackPower:    {Weights: weights{AttributeStrength: 1, AttributeDexterity: 1}},
+			DerivedSpellPower:     {},
+			DerivedDefense:        {Weights: weights{AttributeConstitution: 2, AttributeStrength: 0.5, AttributeDexterity: 0.5}},
+			DerivedCriticalChance: {Base: 5, Weights: weights{AttributeDexterity: 0.1}},
+			DerivedDodgeChance:    {Base: 5, Weights: weights{AttributeDexterity: 0.2}},
+			DerivedHealthRegen:    {Base: 1, Weights: weights{AttributeConstitution: 0.1}},
+			DerivedManaRegen:      {Base: 1, Weights: weights{AttributeWisdom: 0.05}},
+			DerivedStaminaRegen:   {Base: 5, Weights: weights{AttributeConstitution: 0.2}},
+		},
+		Classes: make(map[ClassType]*ClassDefinition, len(classes)),
+		Races:   make(map[Race]*RaceDefinition, len(races)),
+	}
+	for _, class := range classes {
+		defs.Classes[class.ID] = class
+	}
+	for _, race := range races {
+		defs.Races[race.ID] = race
+	}
+	return defs
+}
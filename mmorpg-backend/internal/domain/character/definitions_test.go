@@ -0,0 +1,93 @@
+package character
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultDefinitions(t *testing.T) {
+	defs := DefaultDefinitions()
+	require.NoError(t, defs.Validate())
+	assert.Len(t, defs.ClassIDs(), 8)
+	assert.Len(t, defs.RaceIDs(), 7)
+
+	t.Run("warrior starting stats", func(t *testing.T) {
+		stats := defs.NewStats(uuid.New(), ClassWarrior, RaceHuman)
+		assert.Equal(t, 15, stats.Strength)
+		assert.Equal(t, 13, stats.Constitution)
+		assert.Equal(t, 100+13*10+15*2, stats.HealthMax)
+		assert.Equal(t, stats.HealthMax, stats.HealthCurrent)
+		assert.Equal(t, 15*2+8, stats.AttackPower)
+		assert.Equal(t, 13*2+(15+8)/2, stats.Defense)
+		assert.Equal(t, 0, stats.SpellPower)
+		assert.InDelta(t, 5.8, stats.CriticalChance, 0.001)
+	})
+
+	t.Run("class formula overrides", func(t *testing.T) {
+		stats := defs.NewStats(uuid.New(), ClassPaladin, RaceDwarf)
+		assert.Equal(t, (10*2+8)/2, stats.SpellPower)
+		assert.Equal(t, 50+10*5, stats.ManaMax)
+
+		stats = defs.NewStats(uuid.New(), ClassMage, RaceElf)
+		assert.Equal(t, 15*3, stats.SpellPower)
+		assert.InDelta(t, 1+10*0.2, stats.ManaRegen, 0.001)
+	})
+
+	t.Run("starting position by class", func(t *testing.T) {
+		position := defs.StartingPosition(uuid.New(), ClassRogue, RaceOrc)
+		assert.Equal(t, "shadow_alley", position.ZoneID)
+		assert.Equal(t, position.ZoneID, position.SafeZoneID)
+	})
+}
+
+func TestDefinitions_Races(t *testing.T) {
+	defs := DefaultDefinitions()
+	defs.Races[RaceDwarf].Modifiers = Attributes{Strength: 2, Constitution: 2, Dexterity: -2}
+	defs.Races[RaceDwarf].AllowedClasses = []ClassType{ClassWarrior, ClassPriest}
+	defs.Races[RaceDwarf].Start = &StartingLocation{WorldID: "eastern_kingdoms", ZoneID: "dun_morogh", X: -6240}
+	require.NoError(t, defs.Validate())
+
+	t.Run("combinations", func(t *testing.T) {
+		assert.NoError(t, defs.CheckCombination(ClassWarrior, RaceDwarf))
+		assert.Equal(t, ErrClassRaceNotAllowed, defs.CheckCombination(ClassMage, RaceDwarf))
+		assert.Equal(t, ErrInvalidClass, defs.CheckCombination("bard", RaceDwarf))
+		assert.Equal(t, ErrInvalidRace, defs.CheckCombination(ClassWarrior, "pixie"))
+	})
+
+	t.Run("modifiers apply to base attributes", func(t *testing.T) {
+		stats := defs.NewStats(uuid.New(), ClassWarrior, RaceDwarf)
+		assert.Equal(t, 17, stats.Strength)
+		assert.Equal(t, 15, stats.Constitution)
+		assert.Equal(t, 6, stats.Dexterity)
+	})
+
+	t.Run("race starting location wins", func(t *testing.T) {
+		position := defs.StartingPosition(uuid.New(), ClassWarrior, RaceDwarf)
+		assert.Equal(t, "dun_morogh", position.ZoneID)
+		assert.Equal(t, -6240.0, position.PositionX)
+	})
+}
+
+func TestDefinitions_Validate(t *testing.T) {
+	invalid := map[string]func(d *Definitions){
+		"missing version":       func(d *Definitions) { d.Version = "" },
+		"missing default":       func(d *Definitions) { delete(d.Formulas, DerivedDefense) },
+		"unknown derived stat":  func(d *Definitions) { d.Classes[ClassMage].Formulas = map[string]Formula{"luck": {}} },
+		"unknown attribute":     func(d *Definitions) { d.Formulas[DerivedDefense] = Formula{Weights: weights{"agility": 1}} },
+		"unknown allowed class": func(d *Definitions) { d.Races[RaceElf].AllowedClasses = []ClassType{"bard"} },
+		"attribute below one":   func(d *Definitions) { d.Races[RaceGnome].Modifiers = Attributes{Strength: -6} },
+		"class without zone":    func(d *Definitions) { d.Classes[ClassRogue].Start.ZoneID = "" },
+		"mismatched class id":   func(d *Definitions) { d.Classes["bard"] = &ClassDefinition{ID: ClassMage} },
+	}
+	for name, mutate := range invalid {
+		t.Run(name, func(t *testing.T) {
+			defs := DefaultDefinitions()
+			mutate(defs)
+			assert.True(t, errors.Is(defs.Validate(), ErrInvalidDefinitions))
+		})
+	}
+}
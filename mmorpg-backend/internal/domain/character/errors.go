@@ -14,11 +14,30 @@
 	ErrInvalidSlotNumber         = errors.New("invalid slot number")
 	ErrSlotOccupied              = errors.New("character slot is already occupied")
 	ErrCharacterBelongsToOther   = errors.New("character belongs to another user")
+	ErrPlaytimeLimitReached      = errors.New("play time limit reached for this account")
+	ErrPlaytimeUnavailable       = errors.New("play time limits cannot be checked right now")
+	ErrRenameTooSoon             = errors.New("character was renamed too recently")
+	ErrCharacterNameUnchanged    = errors.New("new name is the same as the current one")
+	ErrTransferSameAccount       = errors.New("character already belongs to the target account")
+	ErrTransferReasonRequired    = errors.New("a reason is required to transfer a character")
+	ErrTransferBlocked           = errors.New("character has pending trades or mail")
+	ErrInvalidSnapshot           = errors.New("invalid character snapshot")
+	ErrRestorePointNotFound      = errors.New("restore point not found")
+	ErrRollbackReasonRequired    = errors.New("a reason is required to roll back a character")
+	ErrSelectionConflict         = errors.New("another character was selected at the same time")
+	ErrInvalidPlayTimeRange      = errors.New("invalid play time date range")
+	ErrSelectedElsewhere         = errors.New("a character is already selected in another session")
+	ErrSessionIDRequired         = errors.New("a login session ID is required")
+	ErrCharacterOnline           = errors.New("character is being played")
 	
 	// Class/Race/Gender errors
-	ErrInvalidClass  = errors.New("invalid character class")
-	ErrInvalidRace   = errors.New("invalid character race")
-	ErrInvalidGender = errors.New("invalid character gender")
+	ErrInvalidClass           = errors.New("invalid character class")
+	ErrInvalidRace            = errors.New("invalid character race")
+	ErrInvalidGender          = errors.New("invalid character gender")
+	ErrClassRaceNotAllowed    = errors.New("race cannot play this class")
+	ErrInvalidDefinitions     = errors.New("invalid class and race definitions")
+	ErrInvalidNamePolicy      = errors.New("invalid name policy")
+	ErrInvalidSelectionPolicy = errors.New("invalid selection policy")
 	
 	// Appearance errors
 	ErrAppearanceNotFound      = errors.New("character appearance not found")
@@ -39,6 +58,20 @@
 	ErrInsufficientMana       = errors.New("insufficient mana")
 	ErrInsufficientStamina    = errors.New("insufficient stamina")
 	ErrStatMaxReached         = errors.New("stat maximum value reached")
+	ErrRespecOnCooldown       = errors.New("stat respec is on cooldown")
+	ErrNothingToRespec        = errors.New("no allocated stat points to respec")
+	ErrNothingToUndo          = errors.New("no recent stat allocations to undo")
+	ErrInvalidUndoCount       = errors.New("invalid number of allocations to undo")
+	ErrInvalidAllocation      = errors.New("invalid stat allocation")
+	ErrNotEnoughStatPoints    = errors.New("not enough stat points available")
+	ErrStatBelowBaseline      = errors.New("stat cannot go below its class and race starting value")
+	
+	// Experience errors
+	ErrInvalidExperienceAmount = errors.New("experience amount must be positive and within the grant limit")
+	ErrInvalidProgression      = errors.New("invalid progression table")
+	ErrGrantIDRequired         = errors.New("experience grant ID is required")
+	ErrInvalidGrantID          = errors.New("experience grant ID is too long")
+	ErrDuplicateGrant          = errors.New("experience grant was already applied")
 	
 	// Position errors
 	ErrPositionNotFound    = errors.New("character position not found")
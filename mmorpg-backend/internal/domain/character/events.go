@@ -13,6 +13,10 @@
 	EventCharacterDeleted   EventType = "character.deleted"
 	EventCharacterRestored  EventType = "character.restored"
 	EventCharacterSelected  EventType = "character.selected"
+	EventCharacterRenamed   EventType = "character.renamed"
+	EventCharacterPurged    EventType = "character.purged"
+	EventCharacterTransferred EventType = "character.transferred"
+	EventCharacterRolledBack  EventType = "character.rolled_back"
 	
 	// Character update events
 	EventCharacterPositionUpdated   EventType = "character.position.updated"
@@ -61,6 +65,46 @@ type CharacterRestoredEvent struct {
 	RestoreReason  string `json:"restore_reason,omitempty"`
 }
 
+// CharacterPurgedEvent is emitted when a soft-deleted character is permanently removed
+// after its recovery period, so other services can drop data tied to it
+type CharacterPurgedEvent struct {
+	BaseEvent
+	Name      string     `json:"name"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// CharacterRenamedEvent is emitted when a character changes its name
+type CharacterRenamedEvent struct {
+	BaseEvent
+	OldName string `json:"old_name"`
+	NewName string `json:"new_name"`
+}
+
+// CharacterTransferredEvent is emitted when support staff move a character to another account.
+// UserID is the new owner.
+type CharacterTransferredEvent struct {
+	BaseEvent
+	Name        string `json:"name"`
+	FromUserID  string `json:"from_user_id"`
+	FromSlot    int    `json:"from_slot"`
+	ToSlot      int    `json:"to_slot"`
+	PerformedBy string `json:"performed_by"`
+	Reason      string `json:"reason"`
+}
+
+// CharacterRolledBackEvent is emitted when a game master restores a character to a restore point
+type CharacterRolledBackEvent struct {
+	BaseEvent
+	RestorePointID string    `json:"restore_point_id"`
+	RestoredTo     time.Time `json:"restored_to"`
+	BackupID       string    `json:"backup_id"`
+	PreviousLevel  int       `json:"previous_level"`
+	Level          int       `json:"level"`
+	Experience     int64     `json:"experience"`
+	PerformedBy    string    `json:"performed_by"`
+	Reason         string    `json:"reason"`
+}
+
 // CharacterSelectedEvent is emitted when a player selects a character for gameplay
 type CharacterSelectedEvent struct {
 	BaseEvent
@@ -84,6 +128,7 @@ type CharacterStatsUpdatedEvent struct {
 	PreviousStats map[string]int    `json:"previous_stats,omitempty"`
 	NewStats      map[string]int    `json:"new_stats"`
 	Changes       map[string]int    `json:"changes"` // diff between previous and new
+	Cost          int64             `json:"cost,omitempty"` // respec cost, charged by the economy service
 }
 
 // CharacterAppearanceUpdatedEvent is emitted when character appearance changes
@@ -102,6 +147,9 @@ type CharacterLevelUpEvent struct {
 	Experience    int64  `json:"experience"`
 	StatPoints    int    `json:"stat_points_gained"`
 	SkillPoints   int    `json:"skill_points_gained"`
+	Source        string `json:"source,omitempty"`
+	// ProgressionVersion is the progression table version the level-up was applied with
+	ProgressionVersion string `json:"progression_version,omitempty"`
 }
 
 // CharacterOnlineEvent is emitted when a character comes online
@@ -0,0 +1,40 @@
+package character
+
+import "github.com/google/uuid"
+
+// MaxExperienceGrant bounds a single grant, catching runaway or malicious callers
+const MaxExperienceGrant int64 = 100_000_000
+
+// MaxGrantIDLength bounds the caller-chosen ID that makes a grant idempotent
+const MaxGrantIDLength = 100
+
+// Experience sources reported by world servers
+const (
+	ExperienceSourceKill    = "kill"
+	ExperienceSourceQuest   = "quest"
+	ExperienceSourceExplore = "explore"
+	ExperienceSourceCraft   = "craft"
+	ExperienceSourceGM      = "gm"
+)
+
+// ExperienceGain is the outcome of granting experience to a character
+type ExperienceGain struct {
+	CharacterID uuid.UUID
+	Source      string
+	// Amount is the experience actually added, which is less than requested at the level cap
+	Amount        int64
+	Experience    int64
+	PreviousLevel int
+	Level         int
+	StatPoints    int
+	SkillPoints   int
+	// ProgressionVersion identifies the progression table the grant was applied with
+	ProgressionVersion string
+	// Duplicate is set when the grant ID was already applied; nothing was added
+	Duplicate bool
+}
+
+// LeveledUp reports whether the grant raised the character's level
+func (g *ExperienceGain) LeveledUp() bool {
+	return g.Level > g.PreviousLevel
+}
@@ -0,0 +1,366 @@
+package character
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// NameKind is the kind of player-chosen name a policy checks
+type NameKind string
+
+// Name kinds sharing the name policy
+const (
+	NameKindCharacter NameKind = "character"
+	NameKindGuild     NameKind = "guild"
+	NameKindChannel   NameKind = "channel"
+)
+
+// Reasons a name breaks the name policy
+const (
+	NameReasonUnknownKind    = "unknown_kind"
+	NameReasonLength         = "length"
+	NameReasonCharacters     = "invalid_characters"
+	NameReasonFormat         = "invalid_format"
+	NameReasonScript         = "script_not_allowed"
+	NameReasonMixedScripts   = "mixed_scripts"
+	NameReasonProfanity      = "profanity"
+	NameReasonReserved       = "reserved"
+	NameReasonReservedPrefix = "reserved_prefix"
+)
+
+// NameViolation reports why a name was rejected. It matches ErrInvalidCharacterName
+// with errors.Is so callers that only care whether a name is valid need not unwrap it.
+type NameViolation struct {
+	Kind   NameKind
+	Reason string
+}
+
+// Error implements error
+func (v *NameViolation) Error() string {
+	return fmt.Sprintf("invalid %s name: %s", v.Kind, v.Reason)
+}
+
+// Is reports whether target is ErrInvalidCharacterName
+func (v *NameViolation) Is(target error) bool {
+	return target == ErrInvalidCharacterName
+}
+
+// NameRules are the shape rules for one kind of name
+type NameRules struct {
+	// MinLength and MaxLength count characters, not bytes
+	MinLength int
+	MaxLength int
+	// Scripts lists the Unicode scripts letters may come from, e.g. "Latin" or "Cyrillic"
+	Scripts []string
+	// SingleScript rejects names mixing letters of several scripts
+	SingleScript bool
+	AllowDigits  bool
+	// Separators are the characters allowed between words, e.g. " -'".
+	// A name may not start or end with one or use two in a row.
+	Separators string
+}
+
+// NameWordList holds blocked words
+type NameWordList struct {
+	// Profanity is rejected anywhere in a name
+	Profanity []string
+	// Reserved is rejected as a whole name or a whole word of one
+	Reserved []string
+	// Allowed words are ignored when looking for profanity, so "Scunthorpe" stays valid
+	Allowed []string
+}
+
+// NamePolicy decides which player-chosen names are acceptable.
+//
+// Names are compared after folding: accents are stripped, homoglyphs such as Cyrillic
+// "а" become their Latin lookalikes, leetspeak digits become letters and separators
+// are dropped, so "4dm1n", "A-d-m-i-n" and "Аdmin" all match "admin". Repeated letters
+// are also collapsed, catching stretched words.
+type NamePolicy struct {
+	Version string
+	Rules   map[NameKind]NameRules
+	// Words apply to every language; Languages adds lists per language code.
+	// All lists are enforced, since every player sees every name.
+	Words     NameWordList
+	Languages map[string]NameWordList
+	// ReservedPrefixes mark staff names such as "GM"; only privileged callers may use them
+	ReservedPrefixes []string
+	Leetspeak        map[rune]rune
+	Homoglyphs       map[rune]rune
+
+	// Folded lookup tables built by Compile
+	profanity []string
+	reserved  map[string]bool
+	allowed   []string
+	prefixes  []string
+}
+
+// Compile validates the policy and prepares it for Check
+func (p *NamePolicy) Compile() error {
+	if p.Version == "" {
+		return fmt.Errorf("%w: version is required", ErrInvalidNamePolicy)
+	}
+	if len(p.Rules) == 0 {
+		return fmt.Errorf("%w: no name rules", ErrInvalidNamePolicy)
+	}
+	for kind, rules := range p.Rules {
+		if rules.MinLength < 1 || rules.MaxLength < rules.MinLength {
+			return fmt.Errorf("%w: %s name lengths must satisfy 1 <= min <= max", ErrInvalidNamePolicy, kind)
+		}
+		if len(rules.Scripts) == 0 {
+			return fmt.Errorf("%w: %s names allow no scripts", ErrInvalidNamePolicy, kind)
+		}
+		for _, script := range rules.Scripts {
+			if _, ok := unicode.Scripts[script]; !ok {
+				return fmt.Errorf("%w: unknown script %q", ErrInvalidNamePolicy, script)
+			}
+		}
+	}
+
+	lists := []NameWordList{p.Words}
+	for _, list := range p.Languages {
+		lists = append(lists, list)
+	}
+
+	p.profanity, p.allowed, p.prefixes = nil, nil, nil
+	p.reserved = make(map[string]bool)
+	for _, list := range lists {
+		for _, word := range list.Profanity {
+			if folded := p.compact(word); folded != "" {
+				p.profanity = append(p.profanity, folded)
+			}
+		}
+		for _, word := range list.Reserved {
+			if folded := p.compact(word); folded != "" {
+				p.reserved[folded] = true
+			}
+		}
+		for _, word := range list.Allowed {
+			if folded := p.compact(word); folded != "" {
+				p.allowed = append(p.allowed, folded)
+			}
+		}
+	}
+	for _, prefix := range p.ReservedPrefixes {
+		if folded := p.compact(prefix); folded != "" {
+			p.prefixes = append(p.prefixes, folded)
+		}
+	}
+	return nil
+}
+
+// Check returns a *NameViolation if name is not acceptable as the given kind.
+// Privileged callers such as game masters may use reserved prefixes.
+func (p *NamePolicy) Check(kind NameKind, name string, privileged bool) error {
+	rules, ok := p.Rules[kind]
+	if !ok {
+		return &NameViolation{Kind: kind, Reason: NameReasonUnknownKind}
+	}
+	violation := func(reason string) error {
+		return &NameViolation{Kind: kind, Reason: reason}
+	}
+
+	name = norm.NFC.String(name)
+	if n := utf8.RuneCountInString(name); n < rules.MinLength || n > rules.MaxLength {
+		return violation(NameReasonLength)
+	}
+	if reason := checkShape(name, rules); reason != "" {
+		return violation(reason)
+	}
+
+	folded := p.fold(name)
+	words := strings.FieldsFunc(folded, isNameSeparator)
+	compact := strings.Join(words, "")
+	collapsed := collapseRepeats(compact)
+
+	for _, form := range []string{compact, collapsed} {
+		if p.reserved[form] {
+			return violation(NameReasonReserved)
+		}
+	}
+	for _, word := range words {
+		if p.reserved[word] || p.reserved[collapseRepeats(word)] {
+			return violation(NameReasonReserved)
+		}
+	}
+
+	for _, form := range []string{compact, collapsed} {
+		for _, allowed := range p.allowed {
+			form = strings.ReplaceAll(form, allowed, "|")
+		}
+		for _, term := range p.profanity {
+			if strings.Contains(form, term) {
+				return violation(NameReasonProfanity)
+			}
+		}
+	}
+
+	if !privileged && len(words) > 0 && p.hasReservedPrefix(name, words[0]) {
+		return violation(NameReasonReservedPrefix)
+	}
+	return nil
+}
+
+// hasReservedPrefix reports whether the name starts with a staff prefix, either as its
+// own word ("GM Bob") or run into a capitalised name ("GMBob"), but not as the start
+// of an ordinary word ("Gmork")
+func (p *NamePolicy) hasReservedPrefix(name, firstWord string) bool {
+	for _, prefix := range p.prefixes {
+		if firstWord == prefix {
+			return true
+		}
+	}
+
+	mapped := []rune(p.mapRunes(name, false))
+	for _, raw := range p.ReservedPrefixes {
+		prefix := []rune(raw)
+		if len(mapped) <= len(prefix) || string(mapped[:len(prefix)]) != raw {
+			continue
+		}
+		if !unicode.IsLower(mapped[len(prefix)]) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkShape returns the reason a name breaks the character and script rules, or ""
+func checkShape(name string, rules NameRules) string {
+	var (
+		scripts       = make(map[string]bool)
+		lastSeparator = true
+	)
+	for _, r := range name {
+		switch {
+		case strings.ContainsRune(rules.Separators, r):
+			if lastSeparator {
+				return NameReasonFormat
+			}
+			lastSeparator = true
+			continue
+		case r >= '0' && r <= '9':
+			if !rules.AllowDigits {
+				return NameReasonCharacters
+			}
+		case unicode.IsLetter(r):
+			// Fullwidth forms and ligatures are only there to dodge the word lists
+			if norm.NFKC.String(string(r)) != string(r) {
+				return NameReasonCharacters
+			}
+			script := letterScript(r, rules.Scripts)
+			if script == "" {
+				return NameReasonScript
+			}
+			scripts[script] = true
+		default:
+			return NameReasonCharacters
+		}
+		lastSeparator = false
+	}
+	if lastSeparator {
+		return NameReasonFormat
+	}
+	if rules.SingleScript && len(scripts) > 1 {
+		return NameReasonMixedScripts
+	}
+	return ""
+}
+
+// letterScript returns which of the allowed scripts contains r, or ""
+func letterScript(r rune, scripts []string) string {
+	for _, script := range scripts {
+		if unicode.Is(unicode.Scripts[script], r) {
+			return script
+		}
+	}
+	return ""
+}
+
+// fold lowercases a name, strips accents and maps homoglyphs and leetspeak to letters
+func (p *NamePolicy) fold(s string) string {
+	return p.mapRunes(strings.ToLower(s), true)
+}
+
+// compact folds a word and drops everything but letters and digits
+func (p *NamePolicy) compact(s string) string {
+	return strings.Join(strings.FieldsFunc(p.fold(s), isNameSeparator), "")
+}
+
+// mapRunes strips accents and replaces homoglyphs, and leetspeak when leet is set
+func (p *NamePolicy) mapRunes(s string, leet bool) string {
+	var b strings.Builder
+	for _, r := range norm.NFKD.String(s) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		if mapped, ok := p.Homoglyphs[r]; ok {
+			r = mapped
+		}
+		if leet {
+			if mapped, ok := p.Leetspeak[r]; ok {
+				r = mapped
+			}
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// isNameSeparator reports whether r splits the words of a folded name
+func isNameSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+// collapseRepeats replaces runs of the same character with a single one
+func collapseRepeats(s string) string {
+	var b strings.Builder
+	var last rune = -1
+	for _, r := range s {
+		if r != last {
+			b.WriteRune(r)
+		}
+		last = r
+	}
+	return b.String()
+}
+
+// DefaultNamePolicy returns the built-in name policy
+func DefaultNamePolicy() *NamePolicy {
+	policy := &NamePolicy{
+		Version: "builtin",
+		Rules: map[NameKind]NameRules{
+			NameKindCharacter: {MinLength: 3, MaxLength: 30, Scripts: []string{"Latin"}, SingleScript: true, AllowDigits: true, Separators: " -"},
+			NameKindGuild:     {MinLength: 3, MaxLength: 24, Scripts: []string{"Latin"}, SingleScript: true, AllowDigits: true, Separators: " -'"},
+			NameKindChannel:   {MinLength: 2, MaxLength: 20, Scripts: []string{"Latin"}, SingleScript: true, AllowDigits: true, Separators: "-_"},
+		},
+		Words: NameWordList{
+			Profanity: []string{"fuck", "shit", "cunt", "bitch", "whore", "slut", "nazi", "hitler"},
+			Reserved:  []string{"admin", "administrator", "gm", "gamemaster", "moderator", "system", "support", "staff"},
+			Allowed:   []string{"scunthorpe", "nazir"},
+		},
+		ReservedPrefixes: []string{"GM", "Admin", "Dev", "Mod"},
+		Leetspeak: map[rune]rune{
+			'0': 'o', '1': 'i', '3': 'e', '4': 'a', '5': 's', '7': 't', '8': 'b', '9': 'g',
+			'@': 'a', '$': 's', '!': 'i', '|': 'l',
+		},
+		Homoglyphs: map[rune]rune{
+			// Cyrillic
+			'а': 'a', 'в': 'b', 'е': 'e', 'к': 'k', 'м': 'm', 'н': 'h', 'о': 'o',
+			'р': 'p', 'с': 'c', 'т': 't', 'у': 'y', 'х': 'x', 'і': 'i', 'ј': 'j', 'ѕ': 's',
+			'А': 'A', 'В': 'B', 'Е': 'E', 'К': 'K', 'М': 'M', 'Н': 'H', 'О': 'O', 'Р': 'P',
+			'С': 'C', 'Т': 'T', 'Х': 'X', 'І': 'I', 'Ј': 'J', 'Ѕ': 'S',
+			// Greek
+			'α': 'a', 'ε': 'e', 'ι': 'i', 'κ': 'k', 'ν': 'v', 'ο': 'o', 'ρ': 'p', 'τ': 't', 'υ': 'u', 'χ': 'x',
+			'Α': 'A', 'Β': 'B', 'Ε': 'E', 'Ζ': 'Z', 'Η': 'H', 'Ι': 'I', 'Κ': 'K', 'Μ': 'M',
+			'Ν': 'N', 'Ο': 'O', 'Ρ': 'P', 'Τ': 'T', 'Υ': 'Y', 'Χ': 'X',
+		},
+	}
+	if err := policy.Compile(); err != nil {
+		panic(err)
+	}
+	return policy
+}
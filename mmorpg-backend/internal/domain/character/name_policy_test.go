@@ -0,0 +1,76 @@
+package character
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamePolicy_Check(t *testing.T) {
+	policy := DefaultNamePolicy()
+
+	valid := []string{"Aragorn", "Mary-Jane", "Dark Knight 2", "Gmork", "Modesty", "Scunthorpe", "Zoë"}
+	for _, name := range valid {
+		assert.NoError(t, policy.Check(NameKindCharacter, name, false), name)
+	}
+
+	invalid := map[string]string{
+		"Al":          NameReasonLength,
+		"Bob_Smith":   NameReasonCharacters,
+		"-Bob":        NameReasonFormat,
+		"Bob  Smith":  NameReasonFormat,
+		"Боб":         NameReasonScript,
+		"Admin":       NameReasonReserved,
+		"4dm1n":       NameReasonReserved,
+		"A-d-m-i-n":   NameReasonReserved,
+		"Sir Gm":      NameReasonReserved,
+		"Fuuuck":      NameReasonProfanity,
+		"Sh1tlord":    NameReasonProfanity,
+		"Big F u c k": NameReasonProfanity,
+		"GMBob":       NameReasonReservedPrefix,
+		"Dev Ops":     NameReasonReservedPrefix,
+		"ＧＭＢｏｂ":       NameReasonCharacters,
+	}
+	for name, reason := range invalid {
+		err := policy.Check(NameKindCharacter, name, false)
+		var violation *NameViolation
+		require.True(t, errors.As(err, &violation), name)
+		assert.Equal(t, reason, violation.Reason, name)
+		assert.ErrorIs(t, err, ErrInvalidCharacterName)
+	}
+
+	assert.NoError(t, policy.Check(NameKindCharacter, "GMBob", true))
+	assert.Error(t, policy.Check(NameKindGuild, "The Bitches", false))
+	assert.NoError(t, policy.Check(NameKindChannel, "trade_eu", false))
+	assert.Error(t, policy.Check("pet", "Rex", false))
+}
+
+func TestNamePolicy_ScriptsAndLanguages(t *testing.T) {
+	policy := &NamePolicy{
+		Version: "test",
+		Rules: map[NameKind]NameRules{
+			NameKindCharacter: {MinLength: 3, MaxLength: 20, Scripts: []string{"Latin", "Cyrillic"}, SingleScript: true},
+		},
+		Languages: map[string]NameWordList{
+			"de": {Profanity: []string{"arsch"}},
+		},
+		Homoglyphs: DefaultNamePolicy().Homoglyphs,
+	}
+	require.NoError(t, policy.Compile())
+
+	assert.NoError(t, policy.Check(NameKindCharacter, "Борис", false))
+	assert.ErrorIs(t, policy.Check(NameKindCharacter, "Аdmin", false), ErrInvalidCharacterName)
+	assert.Error(t, policy.Check(NameKindCharacter, "Arschgeige", false))
+	assert.Error(t, policy.Check(NameKindCharacter, "Àrsch", false))
+
+	var violation *NameViolation
+	require.True(t, errors.As(policy.Check(NameKindCharacter, "Аdmin", false), &violation))
+	assert.Equal(t, NameReasonMixedScripts, violation.Reason)
+
+	broken := &NamePolicy{Version: "x", Rules: map[NameKind]NameRules{NameKindCharacter: {MinLength: 3, MaxLength: 2, Scripts: []string{"Latin"}}}}
+	assert.ErrorIs(t, broken.Compile(), ErrInvalidNamePolicy)
+	broken.Rules[NameKindCharacter] = NameRules{MinLength: 1, MaxLength: 2, Scripts: []string{"Klingon"}}
+	assert.ErrorIs(t, broken.Compile(), ErrInvalidNamePolicy)
+}
@@ -0,0 +1,146 @@
+package character
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Why a play session ended, reported as the reason of the character offline event
+const (
+	PlaySessionEndDeselect   = "deselect"
+	PlaySessionEndSwitch     = "switch"
+	PlaySessionEndLogout     = "logout"
+	PlaySessionEndDisconnect = "disconnect"
+	PlaySessionEndTimeout    = "timeout"
+	// PlaySessionEndTakeover means another login session selected a character and the
+	// client of this one should be disconnected
+	PlaySessionEndTakeover = "takeover"
+	// PlaySessionEndTransfer means the character moved to another account
+	PlaySessionEndTransfer = "transfer"
+	// PlaySessionEndDeleted means the character was deleted
+	PlaySessionEndDeleted = "deleted"
+)
+
+// SelectionPolicy decides what happens when an account selects a character while another
+// login session holds the selection
+type SelectionPolicy string
+
+const (
+	// SelectionTakeover ends the other session's play session, kicking its client
+	SelectionTakeover SelectionPolicy = "takeover"
+	// SelectionRefuse rejects the selection until the other session deselects or its lease expires
+	SelectionRefuse SelectionPolicy = "refuse"
+)
+
+// ParseSelectionPolicy parses a configured selection policy; empty means takeover
+func ParseSelectionPolicy(value string) (SelectionPolicy, error) {
+	switch policy := SelectionPolicy(value); policy {
+	case "":
+		return SelectionTakeover, nil
+	case SelectionTakeover, SelectionRefuse:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrInvalidSelectionPolicy, value)
+	}
+}
+
+// PlaySession is the time a character spends selected for gameplay, from selection until it
+// is deselected, another character is selected, the login session ends or heartbeats stop
+type PlaySession struct {
+	ID          uuid.UUID
+	CharacterID uuid.UUID
+	UserID      uuid.UUID
+	// SessionID is the login session the character was selected in
+	SessionID       string
+	StartedAt       time.Time
+	LastHeartbeatAt time.Time
+	EndedAt         *time.Time
+	EndReason       string
+}
+
+// NewPlaySession starts a play session for a character
+func NewPlaySession(char *Character, sessionID string, now time.Time) *PlaySession {
+	return &PlaySession{
+		ID:              uuid.New(),
+		CharacterID:     char.ID,
+		UserID:          char.UserID,
+		SessionID:       sessionID,
+		StartedAt:       now,
+		LastHeartbeatAt: now,
+	}
+}
+
+// End closes the session at the given time, or at its last heartbeat when it timed out,
+// since nothing is known about the character after that
+func (s *PlaySession) End(at time.Time, reason string) {
+	if reason == PlaySessionEndTimeout {
+		at = s.LastHeartbeatAt
+	}
+	if at.Before(s.StartedAt) {
+		at = s.StartedAt
+	}
+	s.EndedAt = &at
+	s.EndReason = reason
+}
+
+// LeaseExpired reports whether the session went longer than the lease without a heartbeat.
+// An expired session no longer holds the account's selection. A zero lease never expires.
+func (s *PlaySession) LeaseExpired(now time.Time, lease time.Duration) bool {
+	return lease > 0 && now.Sub(s.LastHeartbeatAt) > lease
+}
+
+// HeldBy reports whether the session was started from the given login session. Sessions
+// without a login session ID can't be told apart and match any.
+func (s *PlaySession) HeldBy(sessionID string) bool {
+	return s.SessionID == "" || sessionID == "" || s.SessionID == sessionID
+}
+
+// Duration is how long the session lasted, or has lasted so far
+func (s *PlaySession) Duration() time.Duration {
+	if s.EndedAt == nil {
+		return time.Since(s.StartedAt)
+	}
+	return s.EndedAt.Sub(s.StartedAt)
+}
+
+// DailyPlayTime is a character's play time on one UTC day
+type DailyPlayTime struct {
+	CharacterID uuid.UUID
+	UserID      uuid.UUID
+	Day         time.Time
+	PlayTime    time.Duration
+	// Sessions counts the sessions that started on this day
+	Sessions int
+}
+
+// DailyPlayTime splits an ended session across the UTC days it spans
+func (s *PlaySession) DailyPlayTime() []*DailyPlayTime {
+	if s.EndedAt == nil {
+		return nil
+	}
+
+	var days []*DailyPlayTime
+	start, end := s.StartedAt.UTC(), s.EndedAt.UTC()
+	for first := true; first || start.Before(end); first = false {
+		day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+		until := day.AddDate(0, 0, 1)
+		if end.Before(until) {
+			until = end
+		}
+
+		daily := &DailyPlayTime{
+			CharacterID: s.CharacterID,
+			UserID:      s.UserID,
+			Day:         day,
+			PlayTime:    until.Sub(start),
+		}
+		if first {
+			daily.Sessions = 1
+		}
+		days = append(days, daily)
+		start = until
+	}
+	return days
+}
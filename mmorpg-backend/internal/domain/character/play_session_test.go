@@ -0,0 +1,78 @@
+package character
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlaySession_End(t *testing.T) {
+	char := NewCharacter(uuid.New(), "Aragorn", 1, ClassWarrior, RaceHuman, GenderMale)
+	start := time.Date(2026, 3, 1, 20, 0, 0, 0, time.UTC)
+
+	session := NewPlaySession(char, "session-1", start)
+	session.LastHeartbeatAt = start.Add(40 * time.Minute)
+	session.End(start.Add(2*time.Hour), PlaySessionEndTimeout)
+	assert.Equal(t, 40*time.Minute, session.Duration(), "timed out sessions end at the last heartbeat")
+
+	session = NewPlaySession(char, "session-1", start)
+	session.End(start.Add(-time.Minute), PlaySessionEndLogout)
+	assert.Equal(t, time.Duration(0), session.Duration())
+	assert.Equal(t, PlaySessionEndLogout, session.EndReason)
+}
+
+func TestPlaySession_DailyPlayTime(t *testing.T) {
+	char := NewCharacter(uuid.New(), "Aragorn", 1, ClassWarrior, RaceHuman, GenderMale)
+	start := time.Date(2026, 3, 1, 22, 30, 0, 0, time.UTC)
+
+	session := NewPlaySession(char, "session-1", start)
+	assert.Nil(t, session.DailyPlayTime())
+
+	session.End(start.Add(27*time.Hour), PlaySessionEndDeselect)
+	days := session.DailyPlayTime()
+	require.Len(t, days, 3)
+	assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), days[0].Day)
+	assert.Equal(t, 90*time.Minute, days[0].PlayTime)
+	assert.Equal(t, 1, days[0].Sessions)
+	assert.Equal(t, 24*time.Hour, days[1].PlayTime)
+	assert.Equal(t, 0, days[1].Sessions)
+	assert.Equal(t, 90*time.Minute, days[2].PlayTime)
+
+	session = NewPlaySession(char, "session-2", start)
+	session.End(start, PlaySessionEndDeselect)
+	days = session.DailyPlayTime()
+	require.Len(t, days, 1)
+	assert.Equal(t, time.Duration(0), days[0].PlayTime)
+	assert.Equal(t, 1, days[0].Sessions)
+}
+
+func TestPlaySession_Lease(t *testing.T) {
+	char := NewCharacter(uuid.New(), "Aragorn", 1, ClassWarrior, RaceHuman, GenderMale)
+	start := time.Date(2026, 3, 1, 20, 0, 0, 0, time.UTC)
+	session := NewPlaySession(char, "session-1", start)
+
+	assert.False(t, session.LeaseExpired(start.Add(2*time.Minute), 2*time.Minute))
+	assert.True(t, session.LeaseExpired(start.Add(3*time.Minute), 2*time.Minute))
+	assert.False(t, session.LeaseExpired(start.Add(24*time.Hour), 0), "a zero lease never expires")
+
+	assert.True(t, session.HeldBy("session-1"))
+	assert.False(t, session.HeldBy("session-2"))
+	assert.True(t, session.HeldBy(""))
+	assert.True(t, NewPlaySession(char, "", start).HeldBy("session-2"))
+}
+
+func TestParseSelectionPolicy(t *testing.T) {
+	policy, err := ParseSelectionPolicy("")
+	require.NoError(t, err)
+	assert.Equal(t, SelectionTakeover, policy)
+
+	policy, err = ParseSelectionPolicy("refuse")
+	require.NoError(t, err)
+	assert.Equal(t, SelectionRefuse, policy)
+
+	_, err = ParseSelectionPolicy("queue")
+	assert.ErrorIs(t, err, ErrInvalidSelectionPolicy)
+}
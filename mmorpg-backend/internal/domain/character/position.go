@@ -242,50 +242,3 @@ func (p *Position) Validate() error {
 	
 	return nil
 }
-
-// ApplyClassStartingPosition sets class-specific starting positions
-func (p *Position) ApplyClassStartingPosition(classType ClassType) {
-	// Different classes might start in different areas
-	switch classType {
-	case ClassWarrior, ClassPaladin:
-		p.WorldID = "starter_zone"
-		p.ZoneID = "warrior_training_grounds"
-		p.PositionX = 100.0
-		p.PositionY = 50.0
-		p.PositionZ = 100.0
-	case ClassMage, ClassWarlock:
-		p.WorldID = "starter_zone"
-		p.ZoneID = "arcane_academy"
-		p.PositionX = -100.0
-		p.PositionY = -50.0
-		p.PositionZ = 150.0
-	case ClassRogue:
-		p.WorldID = "starter_zone"
-		p.ZoneID = "shadow_alley"
-		p.PositionX = 200.0
-		p.PositionY = -100.0
-		p.PositionZ = 80.0
-	case ClassPriest, ClassDruid:
-		p.WorldID = "starter_zone"
-		p.ZoneID = "sacred_grove"
-		p.PositionX = -200.0
-		p.PositionY = 100.0
-		p.PositionZ = 120.0
-	case ClassRanger:
-		p.WorldID = "starter_zone"
-		p.ZoneID = "hunters_lodge"
-		p.PositionX = 150.0
-		p.PositionY = 150.0
-		p.PositionZ = 110.0
-	default:
-		// Default starting position
-		p.WorldID = "starter_zone"
-		p.ZoneID = "tutorial_area"
-		p.PositionX = 0.0
-		p.PositionY = 0.0
-		p.PositionZ = 100.0
-	}
-	
-	// Save as safe position
-	p.SaveSafePosition()
-}
\ No newline at end of file
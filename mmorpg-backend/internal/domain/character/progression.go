@@ -0,0 +1,133 @@
+package character
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Progression bounds
+const (
+	// MaxProgressionLevel is the highest level cap a progression table may set
+	MaxProgressionLevel = 1000
+	// DefaultMaxLevel is the level cap of the built-in progression
+	DefaultMaxLevel = 100
+	// DefaultStatPointsPerLevel and DefaultSkillPointsPerLevel are the built-in level-up rewards
+	DefaultStatPointsPerLevel  = 5
+	DefaultSkillPointsPerLevel = 1
+)
+
+// LevelDefinition describes one level of a progression table
+type LevelDefinition struct {
+	Level int
+	// Experience is the total experience needed to reach the level
+	Experience int64
+	// StatPoints and SkillPoints are awarded on reaching the level
+	StatPoints  int
+	SkillPoints int
+}
+
+// ProgressionTable defines the experience curve, level cap and level-up rewards.
+// Levels holds every level from 1 to MaxLevel in order.
+type ProgressionTable struct {
+	Version  string
+	MaxLevel int
+	Levels   []LevelDefinition
+}
+
+// DefaultProgression returns the built-in curve: 100*(level-1)^2 total experience,
+// 5 stat points and 1 skill point per level, capped at level 100
+func DefaultProgression() *ProgressionTable {
+	table := &ProgressionTable{
+		Version:  "builtin",
+		MaxLevel: DefaultMaxLevel,
+		Levels:   make([]LevelDefinition, 0, DefaultMaxLevel),
+	}
+	for level := 1; level <= DefaultMaxLevel; level++ {
+		def := LevelDefinition{
+			Level:      level,
+			Experience: int64(100 * (level - 1) * (level - 1)),
+		}
+		if level > 1 {
+			def.StatPoints = DefaultStatPointsPerLevel
+			def.SkillPoints = DefaultSkillPointsPerLevel
+		}
+		table.Levels = append(table.Levels, def)
+	}
+	return table
+}
+
+// Validate checks that the table is complete and the curve strictly increases
+func (t *ProgressionTable) Validate() error {
+	if t.Version == "" {
+		return fmt.Errorf("%w: version is required", ErrInvalidProgression)
+	}
+	if t.MaxLevel < 1 || t.MaxLevel > MaxProgressionLevel {
+		return fmt.Errorf("%w: max level must be between 1 and %d", ErrInvalidProgression, MaxProgressionLevel)
+	}
+	if len(t.Levels) != t.MaxLevel {
+		return fmt.Errorf("%w: expected %d levels, got %d", ErrInvalidProgression, t.MaxLevel, len(t.Levels))
+	}
+
+	for i, def := range t.Levels {
+		if def.Level != i+1 {
+			return fmt.Errorf("%w: level %d is out of order or missing", ErrInvalidProgression, i+1)
+		}
+		if def.StatPoints < 0 || def.SkillPoints < 0 {
+			return fmt.Errorf("%w: level %d has negative rewards", ErrInvalidProgression, def.Level)
+		}
+		if i == 0 {
+			if def.Experience != 0 {
+				return fmt.Errorf("%w: level 1 must require 0 experience", ErrInvalidProgression)
+			}
+			continue
+		}
+		if def.Experience <= t.Levels[i-1].Experience {
+			return fmt.Errorf("%w: level %d must require more experience than level %d", ErrInvalidProgression, def.Level, def.Level-1)
+		}
+	}
+	return nil
+}
+
+// ExperienceForLevel returns the total experience needed to reach a level,
+// clamped to the table's range
+func (t *ProgressionTable) ExperienceForLevel(level int) int64 {
+	if level <= 1 {
+		return 0
+	}
+	if level > t.MaxLevel {
+		level = t.MaxLevel
+	}
+	return t.Levels[level-1].Experience
+}
+
+// LevelForExperience returns the level reached with the given total experience
+func (t *ProgressionTable) LevelForExperience(experience int64) int {
+	// Index of the first level not yet reached
+	i := sort.Search(len(t.Levels), func(i int) bool {
+		return t.Levels[i].Experience > experience
+	})
+	if i == 0 {
+		return 1
+	}
+	return t.Levels[i-1].Level
+}
+
+// MaxExperience returns the total experience at which the level cap is reached
+func (t *ProgressionTable) MaxExperience() int64 {
+	return t.ExperienceForLevel(t.MaxLevel)
+}
+
+// Rewards returns the stat and skill points earned going from one level to another
+func (t *ProgressionTable) Rewards(fromLevel, toLevel int) (statPoints, skillPoints int) {
+	if toLevel > t.MaxLevel {
+		toLevel = t.MaxLevel
+	}
+	for level := fromLevel + 1; level <= toLevel; level++ {
+		if level < 1 {
+			continue
+		}
+		statPoints += t.Levels[level-1].StatPoints
+		skillPoints += t.Levels[level-1].SkillPoints
+	}
+	return statPoints, skillPoints
+}
@@ -0,0 +1,81 @@
+package character
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultProgression(t *testing.T) {
+	table := DefaultProgression()
+	require.NoError(t, table.Validate())
+
+	assert.Equal(t, int64(0), table.ExperienceForLevel(1))
+	assert.Equal(t, int64(100), table.ExperienceForLevel(2))
+	assert.Equal(t, int64(100*99*99), table.MaxExperience())
+}
+
+func TestProgressionTable_LevelForExperience(t *testing.T) {
+	table := DefaultProgression()
+
+	assert.Equal(t, 1, table.LevelForExperience(0))
+	assert.Equal(t, 1, table.LevelForExperience(table.ExperienceForLevel(2)-1))
+	assert.Equal(t, 2, table.LevelForExperience(table.ExperienceForLevel(2)))
+	assert.Equal(t, 5, table.LevelForExperience(table.ExperienceForLevel(5)+1))
+	assert.Equal(t, table.MaxLevel, table.LevelForExperience(table.MaxExperience()))
+	assert.Equal(t, table.MaxLevel, table.LevelForExperience(table.MaxExperience()*10))
+}
+
+func TestProgressionTable_Rewards(t *testing.T) {
+	table := &ProgressionTable{
+		Version:  "test",
+		MaxLevel: 4,
+		Levels: []LevelDefinition{
+			{Level: 1},
+			{Level: 2, Experience: 10, StatPoints: 3, SkillPoints: 1},
+			{Level: 3, Experience: 30, StatPoints: 3},
+			{Level: 4, Experience: 60, StatPoints: 10, SkillPoints: 2},
+		},
+	}
+	require.NoError(t, table.Validate())
+
+	stat, skill := table.Rewards(1, 4)
+	assert.Equal(t, 16, stat)
+	assert.Equal(t, 3, skill)
+
+	stat, skill = table.Rewards(3, 3)
+	assert.Zero(t, stat)
+	assert.Zero(t, skill)
+
+	// Levels past the cap earn nothing
+	stat, _ = table.Rewards(3, 10)
+	assert.Equal(t, 10, stat)
+}
+
+func TestProgressionTable_Validate(t *testing.T) {
+	valid := func() *ProgressionTable {
+		return &ProgressionTable{
+			Version:  "test",
+			MaxLevel: 3,
+			Levels:   []LevelDefinition{{Level: 1}, {Level: 2, Experience: 10}, {Level: 3, Experience: 20}},
+		}
+	}
+	require.NoError(t, valid().Validate())
+
+	invalid := []func(p *ProgressionTable){
+		func(p *ProgressionTable) { p.Version = "" },
+		func(p *ProgressionTable) { p.MaxLevel = 0 },
+		func(p *ProgressionTable) { p.MaxLevel = 4 },
+		func(p *ProgressionTable) { p.Levels[0].Experience = 5 },
+		func(p *ProgressionTable) { p.Levels[2].Experience = 10 },
+		func(p *ProgressionTable) { p.Levels[1].Level = 3 },
+		func(p *ProgressionTable) { p.Levels[1].StatPoints = -1 },
+	}
+	for _, mutate := range invalid {
+		table := valid()
+		mutate(table)
+		assert.True(t, errors.Is(table.Validate(), ErrInvalidProgression))
+	}
+}
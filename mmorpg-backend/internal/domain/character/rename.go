@@ -0,0 +1,41 @@
+package character
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Built-in rename rules
+const (
+	DefaultRenameCooldown = 30 * 24 * time.Hour
+	DefaultNameHoldPeriod = 90 * 24 * time.Hour
+)
+
+// NameChangeReasonPlayer marks a rename requested by the character's owner
+const NameChangeReasonPlayer = "player"
+
+// NameChange records a character rename
+type NameChange struct {
+	ID          uuid.UUID
+	CharacterID uuid.UUID
+	OldName     string
+	NewName     string
+	// ChangedBy is the account that requested the rename
+	ChangedBy *uuid.UUID
+	Reason    string
+	// HoldUntil reserves the old name for the previous owner until this time
+	HoldUntil *time.Time
+	ChangedAt time.Time
+}
+
+// NewNameChange creates a rename record stamped with the current time
+func NewNameChange(characterID uuid.UUID, oldName, newName string) *NameChange {
+	return &NameChange{
+		ID:          uuid.New(),
+		CharacterID: characterID,
+		OldName:     oldName,
+		NewName:     newName,
+		ChangedAt:   time.Now(),
+	}
+}
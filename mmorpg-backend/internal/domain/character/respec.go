@@ -0,0 +1,167 @@
+package character
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Built-in respec and undo rules
+const (
+	DefaultFreeRespecs              = 1
+	DefaultRespecBaseCost           = 100
+	DefaultRespecCostMultiplier     = 2.0
+	DefaultRespecMaxCost            = 50000
+	DefaultRespecBaseCooldown       = time.Hour
+	DefaultRespecCooldownMultiplier = 2.0
+	DefaultRespecMaxCooldown        = 7 * 24 * time.Hour
+	DefaultStatUndoWindow           = 10 * time.Minute
+	DefaultMaxStatUndo              = 20
+)
+
+// StatAllocation records stat points spent on a primary attribute
+type StatAllocation struct {
+	ID          uuid.UUID
+	CharacterID uuid.UUID
+	Stat        string
+	Points      int
+	AllocatedAt time.Time
+}
+
+// NewStatAllocation creates an allocation record
+func NewStatAllocation(characterID uuid.UUID, stat string, points int) *StatAllocation {
+	return &StatAllocation{
+		ID:          uuid.New(),
+		CharacterID: characterID,
+		Stat:        stat,
+		Points:      points,
+		AllocatedAt: time.Now(),
+	}
+}
+
+// NewStatAllocations creates one allocation record per stat in a bulk allocation
+func NewStatAllocations(characterID uuid.UUID, points map[string]int) []*StatAllocation {
+	allocations := make([]*StatAllocation, 0, len(points))
+	for _, stat := range attributeNames {
+		if n := points[stat]; n > 0 {
+			allocations = append(allocations, NewStatAllocation(characterID, stat, n))
+		}
+	}
+	return allocations
+}
+
+// RespecPolicy sets how respec cost and cooldown escalate.
+// The first FreeRespecs respecs cost nothing; after that the cost starts at BaseCost
+// and is multiplied by CostMultiplier for each further respec, up to MaxCost.
+// The cooldown after the first respec is BaseCooldown and grows the same way, up to MaxCooldown.
+type RespecPolicy struct {
+	FreeRespecs        int
+	BaseCost           int64
+	CostMultiplier     float64
+	MaxCost            int64
+	BaseCooldown       time.Duration
+	CooldownMultiplier float64
+	MaxCooldown        time.Duration
+	// UndoWindow is how long recent allocations can be undone
+	UndoWindow time.Duration
+	// MaxUndo is the most allocations one undo may revert
+	MaxUndo int
+}
+
+// DefaultRespecPolicy returns the built-in respec rules
+func DefaultRespecPolicy() RespecPolicy {
+	return RespecPolicy{
+		FreeRespecs:        DefaultFreeRespecs,
+		BaseCost:           DefaultRespecBaseCost,
+		CostMultiplier:     DefaultRespecCostMultiplier,
+		MaxCost:            DefaultRespecMaxCost,
+		BaseCooldown:       DefaultRespecBaseCooldown,
+		CooldownMultiplier: DefaultRespecCooldownMultiplier,
+		MaxCooldown:        DefaultRespecMaxCooldown,
+		UndoWindow:         DefaultStatUndoWindow,
+		MaxUndo:            DefaultMaxStatUndo,
+	}
+}
+
+// RespecHistory summarizes a character's past respecs
+type RespecHistory struct {
+	Count int
+	// LastRespecAt is zero if the character never respecced
+	LastRespecAt time.Time
+}
+
+// Respec is a completed respec
+type Respec struct {
+	ID          uuid.UUID
+	CharacterID uuid.UUID
+	// Number counts the character's respecs, starting at 1
+	Number         int
+	RefundedPoints int
+	Cost           int64
+	CreatedAt      time.Time
+}
+
+// RespecQuote describes the next respec available to a character
+type RespecQuote struct {
+	Number int
+	Cost   int64
+	// AvailableAt is when the cooldown of the previous respec ends
+	AvailableAt time.Time
+	// RefundablePoints is how many allocated points a respec would return
+	RefundablePoints int
+}
+
+// Available reports whether the cooldown has passed
+func (q *RespecQuote) Available(now time.Time) bool {
+	return !now.Before(q.AvailableAt)
+}
+
+// RespecResult is the outcome of a respec
+type RespecResult struct {
+	Stats  *Stats
+	Respec *Respec
+	// NextAvailableAt is when the character may respec again
+	NextAvailableAt time.Time
+}
+
+// StatUndoResult is the outcome of undoing recent allocations
+type StatUndoResult struct {
+	Stats  *Stats
+	Undone []*StatAllocation
+}
+
+// Quote returns the cost and availability of the next respec
+func (p RespecPolicy) Quote(history *RespecHistory) *RespecQuote {
+	quote := &RespecQuote{Number: history.Count + 1, Cost: p.Cost(history.Count + 1)}
+	if history.Count > 0 {
+		quote.AvailableAt = history.LastRespecAt.Add(p.Cooldown(history.Count))
+	}
+	return quote
+}
+
+// Cost returns the cost of the nth respec
+func (p RespecPolicy) Cost(n int) int64 {
+	paid := n - p.FreeRespecs
+	if paid < 1 {
+		return 0
+	}
+	return int64(escalate(float64(p.BaseCost), p.CostMultiplier, paid-1, float64(p.MaxCost)))
+}
+
+// Cooldown returns the wait after the nth respec
+func (p RespecPolicy) Cooldown(n int) time.Duration {
+	if n < 1 {
+		return 0
+	}
+	return time.Duration(escalate(float64(p.BaseCooldown), p.CooldownMultiplier, n-1, float64(p.MaxCooldown)))
+}
+
+// escalate returns base*multiplier^steps, capped at max when max is positive
+func escalate(base, multiplier float64, steps int, max float64) float64 {
+	value := base * math.Pow(multiplier, float64(steps))
+	if max > 0 && value > max {
+		return max
+	}
+	return value
+}
@@ -0,0 +1,99 @@
+package character
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRespecPolicy_Escalation(t *testing.T) {
+	policy := DefaultRespecPolicy()
+
+	assert.Equal(t, int64(0), policy.Cost(1))
+	assert.Equal(t, int64(100), policy.Cost(2))
+	assert.Equal(t, int64(200), policy.Cost(3))
+	assert.Equal(t, int64(400), policy.Cost(4))
+	assert.Equal(t, int64(DefaultRespecMaxCost), policy.Cost(50))
+
+	assert.Equal(t, time.Duration(0), policy.Cooldown(0))
+	assert.Equal(t, time.Hour, policy.Cooldown(1))
+	assert.Equal(t, 2*time.Hour, policy.Cooldown(2))
+	assert.Equal(t, DefaultRespecMaxCooldown, policy.Cooldown(20))
+}
+
+func TestRespecPolicy_Quote(t *testing.T) {
+	policy := DefaultRespecPolicy()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	first := policy.Quote(&RespecHistory{})
+	assert.Equal(t, 1, first.Number)
+	assert.Equal(t, int64(0), first.Cost)
+	assert.True(t, first.Available(now))
+
+	second := policy.Quote(&RespecHistory{Count: 1, LastRespecAt: now.Add(-30 * time.Minute)})
+	assert.Equal(t, 2, second.Number)
+	assert.Equal(t, int64(100), second.Cost)
+	assert.False(t, second.Available(now))
+	assert.Equal(t, now.Add(30*time.Minute), second.AvailableAt)
+	assert.True(t, second.Available(now.Add(30*time.Minute)))
+}
+
+func TestStats_RespecAndDeallocate(t *testing.T) {
+	definitions := DefaultDefinitions()
+	baseline := definitions.BaseAttributes(ClassWarrior, RaceHuman)
+
+	stats := NewStats(uuid.New())
+	stats.SetAttributes(baseline)
+	stats.AddStatPoints(5)
+	require.NoError(t, stats.AllocateStatPoint(AttributeStrength))
+	require.NoError(t, stats.AllocateStatPoint(AttributeStrength))
+	require.NoError(t, stats.AllocateStatPoint(AttributeWisdom))
+	assert.Equal(t, 3, stats.RefundablePoints(baseline))
+
+	require.NoError(t, stats.DeallocateStatPoints(AttributeWisdom, 1, baseline))
+	assert.Equal(t, 3, stats.StatPointsAvailable)
+	assert.Equal(t, baseline.Wisdom, stats.Wisdom)
+	assert.ErrorIs(t, stats.DeallocateStatPoints("luck", 1, baseline), ErrInvalidStatType)
+	assert.ErrorIs(t, stats.DeallocateStatPoints(AttributeWisdom, 1, baseline), ErrStatBelowBaseline)
+	assert.ErrorIs(t, stats.DeallocateStatPoints(AttributeStrength, 3, baseline), ErrStatBelowBaseline)
+	assert.Equal(t, 3, stats.StatPointsAvailable)
+
+	assert.Equal(t, 2, stats.Respec(baseline))
+	assert.Equal(t, 5, stats.StatPointsAvailable)
+	assert.Equal(t, baseline, stats.Attributes())
+	assert.Equal(t, 0, stats.Respec(baseline))
+}
+
+func TestStats_AllocateStatPoints(t *testing.T) {
+	stats := NewStats(uuid.New())
+	stats.SetAttributes(Attributes{Strength: 10, Dexterity: 10, Intelligence: 10, Wisdom: 10, Constitution: 10, Charisma: 10})
+	stats.AddStatPoints(5)
+
+	invalid := map[error]map[string]int{
+		ErrInvalidAllocation:   {AttributeStrength: 0},
+		ErrInvalidStatType:     {"luck": 1},
+		ErrNotEnoughStatPoints: {AttributeStrength: 3, AttributeWisdom: 3},
+		ErrStatMaxReached:      {AttributeStrength: MaxPrimaryStat},
+	}
+	for want, points := range invalid {
+		assert.ErrorIs(t, stats.AllocateStatPoints(points), want)
+		assert.Equal(t, 5, stats.StatPointsAvailable)
+		assert.Equal(t, 10, stats.Strength)
+	}
+	assert.ErrorIs(t, stats.AllocateStatPoints(nil), ErrInvalidAllocation)
+
+	points := map[string]int{AttributeStrength: 3, AttributeWisdom: 2}
+	require.NoError(t, stats.AllocateStatPoints(points))
+	assert.Equal(t, 13, stats.Strength)
+	assert.Equal(t, 12, stats.Wisdom)
+	assert.Equal(t, 0, stats.StatPointsAvailable)
+
+	allocations := NewStatAllocations(stats.CharacterID, points)
+	require.Len(t, allocations, 2)
+	assert.Equal(t, AttributeStrength, allocations[0].Stat)
+	assert.Equal(t, 3, allocations[0].Points)
+	assert.Equal(t, AttributeWisdom, allocations[1].Stat)
+}
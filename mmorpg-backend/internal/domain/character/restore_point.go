@@ -0,0 +1,114 @@
+package character
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// What caused a restore point to be taken
+const (
+	// RestorePointPeriodic is taken on a schedule for characters that changed since the last run
+	RestorePointPeriodic = "periodic"
+	// RestorePointLevelUp is taken right after a character levels up
+	RestorePointLevelUp = "level_up"
+	// RestorePointRespec is taken right before a respec
+	RestorePointRespec = "respec"
+	// RestorePointTransfer is taken right before a character moves to another account
+	RestorePointTransfer = "transfer"
+	// RestorePointManual is taken on request by a game master
+	RestorePointManual = "manual"
+	// RestorePointRollback is taken right before a rollback, so the rollback can be undone
+	RestorePointRollback = "rollback"
+)
+
+// RestorePoint is a character's progression, stats and position at a point in time,
+// which game masters can roll the character back to
+type RestorePoint struct {
+	ID          uuid.UUID
+	CharacterID uuid.UUID
+	Trigger     string
+	Level       int
+	Experience  int64
+	Stats       SnapshotStats
+	Position    SnapshotPosition
+	// Inventory is kept for the inventory service as it sent it; rollbacks here don't restore it
+	Inventory json.RawMessage
+	CreatedAt time.Time
+}
+
+// NewRestorePoint captures a character's current state
+func NewRestorePoint(trigger string, char *Character, stats *Stats, position *Position) *RestorePoint {
+	return &RestorePoint{
+		ID:          uuid.New(),
+		CharacterID: char.ID,
+		Trigger:     trigger,
+		Level:       char.Level,
+		Experience:  char.Experience,
+		Stats:       newSnapshotStats(stats),
+		Position:    newSnapshotPosition(position),
+		CreatedAt:   time.Now(),
+	}
+}
+
+// CharacterRollback records a game master restoring a character to a restore point
+type CharacterRollback struct {
+	ID             uuid.UUID
+	CharacterID    uuid.UUID
+	RestorePointID uuid.UUID
+	// BackupID is the restore point holding the state the rollback replaced
+	BackupID uuid.UUID
+	// PerformedBy is the staff account that rolled the character back
+	PerformedBy uuid.UUID
+	Reason      string
+	CreatedAt   time.Time
+}
+
+// RollbackResult is the outcome of rolling a character back
+type RollbackResult struct {
+	Rollback *CharacterRollback
+	// Backup is the state before the rollback, saved as a restore point
+	Backup *RestorePoint
+}
+
+// Rollback restores char, stats and position to the restore point. The state it replaces
+// is returned as a backup restore point so the rollback itself can be undone.
+func (p *RestorePoint) Rollback(definitions *Definitions, char *Character, stats *Stats, position *Position, performedBy uuid.UUID, reason string) (*RollbackResult, error) {
+	if reason == "" {
+		return nil, ErrRollbackReasonRequired
+	}
+	if p.CharacterID != char.ID {
+		return nil, ErrRestorePointNotFound
+	}
+
+	backup := NewRestorePoint(RestorePointRollback, char, stats, position)
+
+	now := time.Now()
+	char.Level = p.Level
+	char.Experience = p.Experience
+	char.UpdatedAt = now
+
+	p.Stats.applyTo(stats)
+	definitions.CalculateDerivedStats(stats, char.ClassType)
+	stats.UpdatedAt = now
+
+	if err := p.Position.applyTo(position); err != nil {
+		return nil, err
+	}
+	position.LastMovement = now
+	position.UpdatedAt = now
+
+	return &RollbackResult{
+		Rollback: &CharacterRollback{
+			ID:             uuid.New(),
+			CharacterID:    char.ID,
+			RestorePointID: p.ID,
+			BackupID:       backup.ID,
+			PerformedBy:    performedBy,
+			Reason:         reason,
+			CreatedAt:      now,
+		},
+		Backup: backup,
+	}, nil
+}
@@ -0,0 +1,51 @@
+package character
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestorePoint_Rollback(t *testing.T) {
+	defs := DefaultDefinitions()
+	char := NewCharacter(uuid.New(), "Aragorn", 1, ClassWarrior, RaceHuman, GenderMale)
+	char.Level = 10
+	char.Experience = 5000
+	stats := defs.NewStats(char.ID, ClassWarrior, RaceHuman)
+	position := defs.StartingPosition(char.ID, ClassWarrior, RaceHuman)
+	point := NewRestorePoint(RestorePointPeriodic, char, stats, position)
+
+	// A scam or bug changes the character after the restore point was taken
+	char.Level = 3
+	char.Experience = 100
+	stats.Strength = 1
+	stats.StatPointsAvailable = 0
+	position.ZoneID = "exploit_zone"
+	position.EnterInstance(uuid.New(), "dungeon")
+
+	staff := uuid.New()
+	_, err := point.Rollback(defs, char, stats, position, staff, "")
+	assert.ErrorIs(t, err, ErrRollbackReasonRequired)
+
+	result, err := point.Rollback(defs, char, stats, position, staff, "scammed, ticket #42")
+	require.NoError(t, err)
+	assert.Equal(t, 10, char.Level)
+	assert.Equal(t, int64(5000), char.Experience)
+	assert.Equal(t, point.Stats.Strength, stats.Strength)
+	assert.Equal(t, point.Position.ZoneID, position.ZoneID)
+	assert.False(t, position.IsInInstance())
+
+	assert.Equal(t, RestorePointRollback, result.Backup.Trigger)
+	assert.Equal(t, 3, result.Backup.Level)
+	assert.Equal(t, 1, result.Backup.Stats.Strength)
+	assert.Equal(t, "exploit_zone", result.Backup.Position.ZoneID)
+	assert.Equal(t, point.ID, result.Rollback.RestorePointID)
+	assert.Equal(t, result.Backup.ID, result.Rollback.BackupID)
+	assert.Equal(t, staff, result.Rollback.PerformedBy)
+
+	other := NewCharacter(uuid.New(), "Boromir", 1, ClassWarrior, RaceHuman, GenderMale)
+	_, err = point.Rollback(defs, other, stats, position, staff, "wrong character")
+	assert.ErrorIs(t, err, ErrRestorePointNotFound)
+}
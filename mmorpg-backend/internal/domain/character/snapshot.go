@@ -0,0 +1,296 @@
+package character
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SnapshotVersion is the character snapshot format written by exports.
+// Imports accept this version and older ones.
+const SnapshotVersion = 1
+
+// CharacterSnapshot is a portable copy of a character, its appearance, stats and position.
+// It carries no account or slot and its IDs are never reused, so it can be imported into any
+// account or environment. Derived stats are recalculated from the class definitions on import.
+type CharacterSnapshot struct {
+	Version    int       `json:"version"`
+	ExportedAt time.Time `json:"exported_at"`
+	// SourceID is the exported character's ID, kept for reference only
+	SourceID   string             `json:"source_id,omitempty"`
+	Character  SnapshotCharacter  `json:"character"`
+	Appearance SnapshotAppearance `json:"appearance"`
+	Stats      SnapshotStats      `json:"stats"`
+	Position   SnapshotPosition   `json:"position"`
+}
+
+// SnapshotCharacter holds the character's identity and progression
+type SnapshotCharacter struct {
+	Name             string    `json:"name"`
+	Level            int       `json:"level"`
+	Experience       int64     `json:"experience"`
+	ClassType        ClassType `json:"class_type"`
+	Race             Race      `json:"race"`
+	Gender           Gender    `json:"gender"`
+	TotalPlayTimeSec int64     `json:"total_play_time_seconds"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// SnapshotAppearance holds the character's customization
+type SnapshotAppearance struct {
+	FaceType        int             `json:"face_type"`
+	SkinColor       string          `json:"skin_color"`
+	EyeColor        string          `json:"eye_color"`
+	HairStyle       int             `json:"hair_style"`
+	HairColor       string          `json:"hair_color"`
+	FacialHairStyle int             `json:"facial_hair_style"`
+	FacialHairColor string          `json:"facial_hair_color,omitempty"`
+	BodyType        BodyType        `json:"body_type"`
+	Height          float32         `json:"height"`
+	BodyProportions BodyProportions `json:"body_proportions"`
+	Scars           []int           `json:"scars,omitempty"`
+	Tattoos         []int           `json:"tattoos,omitempty"`
+	Accessories     []int           `json:"accessories,omitempty"`
+}
+
+// SnapshotStats holds the primary attributes, current resources and unspent points
+type SnapshotStats struct {
+	Strength             int `json:"strength"`
+	Dexterity            int `json:"dexterity"`
+	Intelligence         int `json:"intelligence"`
+	Wisdom               int `json:"wisdom"`
+	Constitution         int `json:"constitution"`
+	Charisma             int `json:"charisma"`
+	HealthCurrent        int `json:"health_current"`
+	ManaCurrent          int `json:"mana_current"`
+	StaminaCurrent       int `json:"stamina_current"`
+	StatPointsAvailable  int `json:"stat_points_available"`
+	SkillPointsAvailable int `json:"skill_points_available"`
+}
+
+// SnapshotPosition holds where the character stands and respawns. Instances are
+// left out since they don't exist in another environment.
+type SnapshotPosition struct {
+	WorldID       string  `json:"world_id"`
+	ZoneID        string  `json:"zone_id"`
+	MapID         string  `json:"map_id,omitempty"`
+	PositionX     float64 `json:"position_x"`
+	PositionY     float64 `json:"position_y"`
+	PositionZ     float64 `json:"position_z"`
+	RotationPitch float32 `json:"rotation_pitch"`
+	RotationYaw   float32 `json:"rotation_yaw"`
+	RotationRoll  float32 `json:"rotation_roll"`
+	SafeWorldID   string  `json:"safe_world_id"`
+	SafeZoneID    string  `json:"safe_zone_id"`
+	SafePositionX float64 `json:"safe_position_x"`
+	SafePositionY float64 `json:"safe_position_y"`
+	SafePositionZ float64 `json:"safe_position_z"`
+}
+
+// NewCharacterSnapshot captures a character in the current snapshot format
+func NewCharacterSnapshot(char *Character, appearance *Appearance, stats *Stats, position *Position) *CharacterSnapshot {
+	return &CharacterSnapshot{
+		Version:    SnapshotVersion,
+		ExportedAt: time.Now().UTC(),
+		SourceID:   char.ID.String(),
+		Character: SnapshotCharacter{
+			Name:             char.Name,
+			Level:            char.Level,
+			Experience:       char.Experience,
+			ClassType:        char.ClassType,
+			Race:             char.Race,
+			Gender:           char.Gender,
+			TotalPlayTimeSec: int64(char.TotalPlayTime / time.Second),
+			CreatedAt:        char.CreatedAt,
+		},
+		Appearance: SnapshotAppearance{
+			FaceType:        appearance.FaceType,
+			SkinColor:       appearance.SkinColor,
+			EyeColor:        appearance.EyeColor,
+			HairStyle:       appearance.HairStyle,
+			HairColor:       appearance.HairColor,
+			FacialHairStyle: appearance.FacialHairStyle,
+			FacialHairColor: appearance.FacialHairColor,
+			BodyType:        appearance.BodyType,
+			Height:          appearance.Height,
+			BodyProportions: appearance.BodyProportions,
+			Scars:           appearance.Scars,
+			Tattoos:         appearance.Tattoos,
+			Accessories:     appearance.Accessories,
+		},
+		Stats:    newSnapshotStats(stats),
+		Position: newSnapshotPosition(position),
+	}
+}
+
+func newSnapshotStats(stats *Stats) SnapshotStats {
+	return SnapshotStats{
+		Strength:             stats.Strength,
+		Dexterity:            stats.Dexterity,
+		Intelligence:         stats.Intelligence,
+		Wisdom:               stats.Wisdom,
+		Constitution:         stats.Constitution,
+		Charisma:             stats.Charisma,
+		HealthCurrent:        stats.HealthCurrent,
+		ManaCurrent:          stats.ManaCurrent,
+		StaminaCurrent:       stats.StaminaCurrent,
+		StatPointsAvailable:  stats.StatPointsAvailable,
+		SkillPointsAvailable: stats.SkillPointsAvailable,
+	}
+}
+
+// validate rejects negative attributes, resources and points
+func (st SnapshotStats) validate() error {
+	for _, value := range []int{
+		st.Strength, st.Dexterity, st.Intelligence, st.Wisdom, st.Constitution, st.Charisma,
+		st.HealthCurrent, st.ManaCurrent, st.StaminaCurrent, st.StatPointsAvailable, st.SkillPointsAvailable,
+	} {
+		if value < 0 {
+			return fmt.Errorf("stats must not be negative")
+		}
+	}
+	return nil
+}
+
+// applyTo copies the snapshot onto stats. Derived stats are left for the caller to recalculate.
+func (st SnapshotStats) applyTo(stats *Stats) {
+	stats.SetAttributes(Attributes{
+		Strength:     st.Strength,
+		Dexterity:    st.Dexterity,
+		Intelligence: st.Intelligence,
+		Wisdom:       st.Wisdom,
+		Constitution: st.Constitution,
+		Charisma:     st.Charisma,
+	})
+	stats.HealthCurrent = st.HealthCurrent
+	stats.ManaCurrent = st.ManaCurrent
+	stats.StaminaCurrent = st.StaminaCurrent
+	stats.StatPointsAvailable = st.StatPointsAvailable
+	stats.SkillPointsAvailable = st.SkillPointsAvailable
+}
+
+func newSnapshotPosition(position *Position) SnapshotPosition {
+	return SnapshotPosition{
+		WorldID:       position.WorldID,
+		ZoneID:        position.ZoneID,
+		MapID:         position.MapID,
+		PositionX:     position.PositionX,
+		PositionY:     position.PositionY,
+		PositionZ:     position.PositionZ,
+		RotationPitch: position.RotationPitch,
+		RotationYaw:   position.RotationYaw,
+		RotationRoll:  position.RotationRoll,
+		SafeWorldID:   position.SafeWorldID,
+		SafeZoneID:    position.SafeZoneID,
+		SafePositionX: position.SafePositionX,
+		SafePositionY: position.SafePositionY,
+		SafePositionZ: position.SafePositionZ,
+	}
+}
+
+// applyTo moves position to the snapshot's location and validates it. The character is
+// taken out of any instance and left standing still.
+func (p SnapshotPosition) applyTo(position *Position) error {
+	position.WorldID = p.WorldID
+	position.ZoneID = p.ZoneID
+	position.MapID = p.MapID
+	position.SetPosition(p.PositionX, p.PositionY, p.PositionZ)
+	position.SetRotation(p.RotationPitch, p.RotationYaw, p.RotationRoll)
+	position.SetVelocity(0, 0, 0)
+	position.LeaveInstance()
+	position.SafeWorldID = p.SafeWorldID
+	position.SafeZoneID = p.SafeZoneID
+	position.SafePositionX = p.SafePositionX
+	position.SafePositionY = p.SafePositionY
+	position.SafePositionZ = p.SafePositionZ
+	if position.WorldID == "" || position.ZoneID == "" {
+		return ErrInvalidWorldID
+	}
+	return position.Validate()
+}
+
+// ImportedCharacter is a snapshot recreated as new entities
+type ImportedCharacter struct {
+	Character  *Character
+	Appearance *Appearance
+	Stats      *Stats
+	Position   *Position
+}
+
+// Restore validates the snapshot against the class and race definitions and level cap, and
+// recreates it as a new character with the given owner, name and slot. Validation failures
+// match ErrInvalidSnapshot with errors.Is.
+func (s *CharacterSnapshot) Restore(definitions *Definitions, maxLevel int, userID uuid.UUID, name string, slot int) (*ImportedCharacter, error) {
+	if s.Version < 1 || s.Version > SnapshotVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidSnapshot, s.Version)
+	}
+	invalid := func(err error) error {
+		return fmt.Errorf("%w: %v", ErrInvalidSnapshot, err)
+	}
+
+	c := s.Character
+	if err := definitions.CheckCombination(c.ClassType, c.Race); err != nil {
+		return nil, invalid(err)
+	}
+	if !IsValidGender(c.Gender) {
+		return nil, invalid(ErrInvalidGender)
+	}
+	if c.Level < 1 || c.Level > maxLevel {
+		return nil, invalid(fmt.Errorf("level %d is outside 1-%d", c.Level, maxLevel))
+	}
+	if c.Experience < 0 || c.TotalPlayTimeSec < 0 {
+		return nil, invalid(fmt.Errorf("experience and play time must not be negative"))
+	}
+
+	char := NewCharacter(userID, name, slot, c.ClassType, c.Race, c.Gender)
+	char.Level = c.Level
+	char.Experience = c.Experience
+	char.TotalPlayTime = time.Duration(c.TotalPlayTimeSec) * time.Second
+
+	a := s.Appearance
+	appearance := NewAppearance(char.ID)
+	appearance.FaceType = a.FaceType
+	appearance.SkinColor = a.SkinColor
+	appearance.EyeColor = a.EyeColor
+	appearance.HairStyle = a.HairStyle
+	appearance.HairColor = a.HairColor
+	appearance.FacialHairStyle = a.FacialHairStyle
+	appearance.FacialHairColor = a.FacialHairColor
+	appearance.BodyType = a.BodyType
+	appearance.Height = a.Height
+	appearance.BodyProportions = a.BodyProportions
+	appearance.Scars = orEmpty(a.Scars)
+	appearance.Tattoos = orEmpty(a.Tattoos)
+	appearance.Accessories = orEmpty(a.Accessories)
+	if err := appearance.Validate(); err != nil {
+		return nil, invalid(err)
+	}
+
+	if err := s.Stats.validate(); err != nil {
+		return nil, invalid(err)
+	}
+	stats := NewStats(char.ID)
+	s.Stats.applyTo(stats)
+	definitions.CalculateDerivedStats(stats, c.ClassType)
+
+	position := NewPosition(char.ID)
+	if err := s.Position.applyTo(position); err != nil {
+		return nil, invalid(err)
+	}
+
+	return &ImportedCharacter{
+		Character:  char,
+		Appearance: appearance,
+		Stats:      stats,
+		Position:   position,
+	}, nil
+}
+
+// orEmpty keeps list columns from being stored as null when a snapshot omits them
+func orEmpty(values []int) []int {
+	if values == nil {
+		return []int{}
+	}
+	return values
+}
@@ -0,0 +1,69 @@
+package character
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCharacterSnapshot_RoundTrip(t *testing.T) {
+	defs := DefaultDefinitions()
+	char := NewCharacter(uuid.New(), "Aragorn", 2, ClassWarrior, RaceHuman, GenderMale)
+	char.Level = 12
+	char.Experience = 4200
+	appearance := NewAppearance(char.ID)
+	stats := defs.NewStats(char.ID, ClassWarrior, RaceHuman)
+	stats.StatPointsAvailable = 3
+	position := defs.StartingPosition(char.ID, ClassWarrior, RaceHuman)
+
+	data, err := json.Marshal(NewCharacterSnapshot(char, appearance, stats, position))
+	require.NoError(t, err)
+	var snapshot CharacterSnapshot
+	require.NoError(t, json.Unmarshal(data, &snapshot))
+	assert.Equal(t, SnapshotVersion, snapshot.Version)
+	assert.Equal(t, char.ID.String(), snapshot.SourceID)
+
+	owner := uuid.New()
+	imported, err := snapshot.Restore(defs, 100, owner, "Strider", 1)
+	require.NoError(t, err)
+	assert.NotEqual(t, char.ID, imported.Character.ID)
+	assert.Equal(t, owner, imported.Character.UserID)
+	assert.Equal(t, "Strider", imported.Character.Name)
+	assert.Equal(t, 12, imported.Character.Level)
+	assert.Equal(t, int64(4200), imported.Character.Experience)
+	assert.Equal(t, imported.Character.ID, imported.Stats.CharacterID)
+	assert.Equal(t, stats.Strength, imported.Stats.Strength)
+	assert.Equal(t, stats.HealthMax, imported.Stats.HealthMax)
+	assert.Equal(t, 3, imported.Stats.StatPointsAvailable)
+	assert.Equal(t, position.ZoneID, imported.Position.ZoneID)
+}
+
+func TestCharacterSnapshot_RestoreInvalid(t *testing.T) {
+	defs := DefaultDefinitions()
+	char := NewCharacter(uuid.New(), "Aragorn", 1, ClassWarrior, RaceHuman, GenderMale)
+	valid := func() *CharacterSnapshot {
+		return NewCharacterSnapshot(char, NewAppearance(char.ID),
+			defs.NewStats(char.ID, ClassWarrior, RaceHuman), defs.StartingPosition(char.ID, ClassWarrior, RaceHuman))
+	}
+
+	invalid := map[string]func(s *CharacterSnapshot){
+		"future version": func(s *CharacterSnapshot) { s.Version = SnapshotVersion + 1 },
+		"unknown class":  func(s *CharacterSnapshot) { s.Character.ClassType = "bard" },
+		"level over cap": func(s *CharacterSnapshot) { s.Character.Level = 101 },
+		"negative stat":  func(s *CharacterSnapshot) { s.Stats.Strength = -1 },
+		"missing zone":   func(s *CharacterSnapshot) { s.Position.ZoneID = "" },
+		"invalid gender": func(s *CharacterSnapshot) { s.Character.Gender = "x" },
+		"negative exp":   func(s *CharacterSnapshot) { s.Character.Experience = -5 },
+	}
+	for name, mutate := range invalid {
+		t.Run(name, func(t *testing.T) {
+			snapshot := valid()
+			mutate(snapshot)
+			_, err := snapshot.Restore(defs, 100, uuid.New(), "Strider", 1)
+			assert.ErrorIs(t, err, ErrInvalidSnapshot)
+		})
+	}
+}
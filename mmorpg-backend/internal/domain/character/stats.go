@@ -48,8 +48,9 @@ type Stats struct {
 	UpdatedAt            time.Time
 }
 
-// NewStats creates new character stats with class-specific defaults
-func NewStats(characterID uuid.UUID, classType ClassType) *Stats {
+// NewStats creates new character stats with neutral defaults.
+// Use Definitions.NewStats for the starting stats of a class and race.
+func NewStats(characterID uuid.UUID) *Stats {
 	now := time.Now()
 	stats := &Stats{
 		ID:                   uuid.New(),
@@ -85,146 +86,34 @@ func NewStats(characterID uuid.UUID, classType ClassType) *Stats {
 		UpdatedAt:            now,
 	}
 
-	// Apply class-specific starting stats
-	stats.ApplyClassDefaults(classType)
-	stats.CalculateDerivedStats(classType)
-	
 	return stats
 }
 
-// ApplyClassDefaults sets class-specific starting primary stats
-func (s *Stats) ApplyClassDefaults(classType ClassType) {
-	switch classType {
-	case ClassWarrior:
-		s.Strength = 15
-		s.Constitution = 13
-		s.Dexterity = 8
-		s.Intelligence = 6
-		s.Wisdom = 6
-		s.Charisma = 8
-	case ClassMage:
-		s.Strength = 6
-		s.Constitution = 8
-		s.Dexterity = 8
-		s.Intelligence = 15
-		s.Wisdom = 10
-		s.Charisma = 8
-	case ClassRogue:
-		s.Strength = 8
-		s.Constitution = 8
-		s.Dexterity = 15
-		s.Intelligence = 8
-		s.Wisdom = 8
-		s.Charisma = 10
-	case ClassPriest:
-		s.Strength = 6
-		s.Constitution = 8
-		s.Dexterity = 8
-		s.Intelligence = 10
-		s.Wisdom = 15
-		s.Charisma = 10
-	case ClassRanger:
-		s.Strength = 10
-		s.Constitution = 10
-		s.Dexterity = 13
-		s.Intelligence = 8
-		s.Wisdom = 10
-		s.Charisma = 8
-	case ClassPaladin:
-		s.Strength = 13
-		s.Constitution = 12
-		s.Dexterity = 8
-		s.Intelligence = 8
-		s.Wisdom = 10
-		s.Charisma = 10
-	case ClassWarlock:
-		s.Strength = 6
-		s.Constitution = 8
-		s.Dexterity = 8
-		s.Intelligence = 13
-		s.Wisdom = 8
-		s.Charisma = 13
-	case ClassDruid:
-		s.Strength = 8
-		s.Constitution = 10
-		s.Dexterity = 8
-		s.Intelligence = 10
-		s.Wisdom = 13
-		s.Charisma = 8
+// Attributes returns the primary attributes
+func (s *Stats) Attributes() Attributes {
+	return Attributes{
+		Strength:     s.Strength,
+		Dexterity:    s.Dexterity,
+		Intelligence: s.Intelligence,
+		Wisdom:       s.Wisdom,
+		Constitution: s.Constitution,
+		Charisma:     s.Charisma,
 	}
 }
 
-// CalculateDerivedStats recalculates all derived stats based on primary stats and class
-func (s *Stats) CalculateDerivedStats(classType ClassType) {
-	// Health calculation
-	switch classType {
-	case ClassWarrior, ClassPaladin:
-		s.HealthMax = 100 + (s.Constitution * 10) + (s.Strength * 2)
-	case ClassRogue, ClassRanger:
-		s.HealthMax = 100 + (s.Constitution * 8) + (s.Dexterity * 2)
-	case ClassMage, ClassWarlock:
-		s.HealthMax = 100 + (s.Constitution * 6) + (s.Intelligence * 1)
-	case ClassPriest, ClassDruid:
-		s.HealthMax = 100 + (s.Constitution * 7) + (s.Wisdom * 2)
-	default:
-		s.HealthMax = 100 + (s.Constitution * 8)
-	}
-
-	// Mana calculation
-	switch classType {
-	case ClassMage, ClassWarlock:
-		s.ManaMax = 50 + (s.Intelligence * 10) + (s.Wisdom * 2)
-	case ClassPriest, ClassDruid:
-		s.ManaMax = 50 + (s.Wisdom * 10) + (s.Intelligence * 2)
-	case ClassPaladin:
-		s.ManaMax = 50 + (s.Wisdom * 5)
-	default:
-		s.ManaMax = 50 + (s.Intelligence * 2)
-	}
-
-	// Stamina calculation
-	s.StaminaMax = 100 + (s.Constitution * 5) + (s.Strength * 2)
-
-	// Attack power calculation
-	switch classType {
-	case ClassWarrior, ClassPaladin:
-		s.AttackPower = s.Strength*2 + s.Dexterity
-	case ClassRogue, ClassRanger:
-		s.AttackPower = s.Dexterity*2 + s.Strength
-	default:
-		s.AttackPower = s.Strength + s.Dexterity
-	}
-
-	// Spell power calculation
-	switch classType {
-	case ClassMage, ClassWarlock:
-		s.SpellPower = s.Intelligence * 3
-	case ClassPriest, ClassDruid:
-		s.SpellPower = s.Wisdom * 3
-	case ClassPaladin:
-		s.SpellPower = (s.Wisdom*2 + s.Intelligence) / 2
-	default:
-		s.SpellPower = 0
-	}
-
-	// Defense calculation
-	s.Defense = s.Constitution*2 + (s.Strength+s.Dexterity)/2
-
-	// Critical and dodge chances
-	s.CriticalChance = 5.0 + float32(s.Dexterity)*0.1
-	s.DodgeChance = 5.0 + float32(s.Dexterity)*0.2
-
-	// Regeneration rates
-	s.HealthRegen = 1.0 + float32(s.Constitution)*0.1
-	switch classType {
-	case ClassMage, ClassWarlock, ClassPriest, ClassDruid:
-		s.ManaRegen = 1.0 + float32(s.Wisdom)*0.2
-	default:
-		s.ManaRegen = 1.0 + float32(s.Wisdom)*0.05
-	}
-	s.StaminaRegen = 5.0 + float32(s.Constitution)*0.2
+// SetAttributes replaces the primary attributes
+func (s *Stats) SetAttributes(a Attributes) {
+	s.Strength = a.Strength
+	s.Dexterity = a.Dexterity
+	s.Intelligence = a.Intelligence
+	s.Wisdom = a.Wisdom
+	s.Constitution = a.Constitution
+	s.Charisma = a.Charisma
+	s.UpdatedAt = time.Now()
+}
 
-	// Ensure current values don't exceed maximums
+// ClampResources ensures current values don't exceed maximums
+func (s *Stats) ClampResources() {
 	if s.HealthCurrent > s.HealthMax {
 		s.HealthCurrent = s.HealthMax
 	}
@@ -264,6 +153,80 @@ func (s *Stats) AllocateStatPoint(stat string) error {
 	return nil
 }
 
+// AllocateStatPoints spends points on several primary stats at once.
+// Nothing changes unless the whole allocation is valid.
+func (s *Stats) AllocateStatPoints(points map[string]int) error {
+	if len(points) == 0 {
+		return ErrInvalidAllocation
+	}
+
+	attributes := s.Attributes()
+	total := 0
+	for stat, n := range points {
+		value, ok := attributes.Get(stat)
+		if !ok {
+			return ErrInvalidStatType
+		}
+		if n < 1 || n > MaxPrimaryStat {
+			return ErrInvalidAllocation
+		}
+		if value+n > MaxPrimaryStat {
+			return ErrStatMaxReached
+		}
+		attributes.Set(stat, value+n)
+		total += n
+	}
+	if total > s.StatPointsAvailable {
+		return ErrNotEnoughStatPoints
+	}
+
+	s.SetAttributes(attributes)
+	s.StatPointsAvailable -= total
+	return nil
+}
+
+// DeallocateStatPoints returns points spent on a primary stat to the available pool.
+// The stat can't drop below its value in baseline, the class and race starting attributes.
+func (s *Stats) DeallocateStatPoints(stat string, points int, baseline Attributes) error {
+	attributes := s.Attributes()
+	value, ok := attributes.Get(stat)
+	if !ok {
+		return ErrInvalidStatType
+	}
+	if points < 1 {
+		return ErrInvalidAllocation
+	}
+	if base, _ := baseline.Get(stat); value-points < base {
+		return ErrStatBelowBaseline
+	}
+	attributes.Set(stat, value-points)
+	s.SetAttributes(attributes)
+	s.StatPointsAvailable += points
+	return nil
+}
+
+// RefundablePoints returns how many points are allocated above the baseline attributes
+func (s *Stats) RefundablePoints(baseline Attributes) int {
+	current := s.Attributes()
+	refundable := 0
+	for _, name := range attributeNames {
+		value, _ := current.Get(name)
+		base, _ := baseline.Get(name)
+		if value > base {
+			refundable += value - base
+		}
+	}
+	return refundable
+}
+
+// Respec resets the primary stats to the baseline and returns the allocated points
+func (s *Stats) Respec(baseline Attributes) int {
+	refunded := s.RefundablePoints(baseline)
+	s.SetAttributes(baseline)
+	s.StatPointsAvailable += refunded
+	return refunded
+}
+
 // AddStatPoints adds stat points (usually from leveling up)
 func (s *Stats) AddStatPoints(points int) {
 	s.StatPointsAvailable += points
@@ -0,0 +1,77 @@
+package character
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Pending activity that blocks a character transfer
+const (
+	PendingActivityTrade = "trade"
+	PendingActivityMail  = "mail"
+)
+
+// TransferBlocked reports the pending activity that keeps a character from being
+// transferred. It matches ErrTransferBlocked with errors.Is.
+type TransferBlocked struct {
+	Pending []string
+}
+
+// Error implements error
+func (b *TransferBlocked) Error() string {
+	return fmt.Sprintf("character has pending %s", strings.Join(b.Pending, " and "))
+}
+
+// Is reports whether target is ErrTransferBlocked
+func (b *TransferBlocked) Is(target error) bool {
+	return target == ErrTransferBlocked
+}
+
+// CharacterTransfer records a character moved between accounts by support staff
+type CharacterTransfer struct {
+	ID          uuid.UUID
+	CharacterID uuid.UUID
+	FromUserID  uuid.UUID
+	ToUserID    uuid.UUID
+	FromSlot    int
+	ToSlot      int
+	// PerformedBy is the staff account that made the transfer
+	PerformedBy uuid.UUID
+	Reason      string
+	CreatedAt   time.Time
+}
+
+// NewCharacterTransfer creates a transfer of a character to a slot on another account
+func NewCharacterTransfer(char *Character, toUserID uuid.UUID, toSlot int, performedBy uuid.UUID, reason string) *CharacterTransfer {
+	return &CharacterTransfer{
+		ID:          uuid.New(),
+		CharacterID: char.ID,
+		FromUserID:  char.UserID,
+		ToUserID:    toUserID,
+		FromSlot:    char.SlotNumber,
+		ToSlot:      toSlot,
+		PerformedBy: performedBy,
+		Reason:      reason,
+		CreatedAt:   time.Now(),
+	}
+}
+
+// Validate checks that the transfer moves the character to a different account for a stated reason
+func (t *CharacterTransfer) Validate() error {
+	if t.ToUserID == uuid.Nil {
+		return ErrInvalidUserID
+	}
+	if t.ToUserID == t.FromUserID {
+		return ErrTransferSameAccount
+	}
+	if t.ToSlot < 1 || t.ToSlot > MaxSlotNumber {
+		return ErrInvalidSlotNumber
+	}
+	if t.Reason == "" {
+		return ErrTransferReasonRequired
+	}
+	return nil
+}
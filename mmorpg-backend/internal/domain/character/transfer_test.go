@@ -0,0 +1,28 @@
+package character
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCharacterTransfer_Validate(t *testing.T) {
+	char := NewCharacter(uuid.New(), "Aragorn", 2, ClassWarrior, RaceHuman, GenderMale)
+	staff := uuid.New()
+
+	transfer := NewCharacterTransfer(char, uuid.New(), 1, staff, "account merge")
+	assert.NoError(t, transfer.Validate())
+	assert.Equal(t, char.UserID, transfer.FromUserID)
+	assert.Equal(t, 2, transfer.FromSlot)
+
+	assert.ErrorIs(t, NewCharacterTransfer(char, char.UserID, 1, staff, "merge").Validate(), ErrTransferSameAccount)
+	assert.ErrorIs(t, NewCharacterTransfer(char, uuid.Nil, 1, staff, "merge").Validate(), ErrInvalidUserID)
+	assert.ErrorIs(t, NewCharacterTransfer(char, uuid.New(), MaxSlotNumber+1, staff, "merge").Validate(), ErrInvalidSlotNumber)
+	assert.ErrorIs(t, NewCharacterTransfer(char, uuid.New(), 1, staff, "").Validate(), ErrTransferReasonRequired)
+
+	blocked := &TransferBlocked{Pending: []string{PendingActivityMail, PendingActivityTrade}}
+	assert.True(t, errors.Is(blocked, ErrTransferBlocked))
+	assert.Equal(t, "character has pending mail and trade", blocked.Error())
+}
@@ -12,6 +12,9 @@ type AuthService interface {
 	// Register creates a new user account
 	Register(ctx context.Context, req *auth.RegisterRequest) (*auth.User, error)
 	
+	// RegistrationChallenge issues a proof-of-work challenge sized to the IP's registration pressure
+	RegistrationChallenge(ctx context.Context, ipAddress string) (*auth.PowChallenge, error)
+	
 	// Login authenticates a user and returns tokens
 	Login(ctx context.Context, email, password, deviceID, ipAddress, userAgent string) (*auth.TokenPair, *auth.User, error)
 	
@@ -39,9 +42,97 @@ type AuthService interface {
 	// ResetPassword completes a password reset
 	ResetPassword(ctx context.Context, token, newPassword string) error
 	
+	// RequestAccountUnlock sends an unlock link for a locked account
+	RequestAccountUnlock(ctx context.Context, email string) error
+	
+	// UnlockAccount clears an account lockout using an unlock token
+	UnlockAccount(ctx context.Context, token string) error
+	
+	// ConfirmDevice trusts a new device using an emailed confirmation token
+	ConfirmDevice(ctx context.Context, token string) error
+	
+	// ListKnownDevices retrieves the devices a user has logged in from
+	ListKnownDevices(ctx context.Context, userID string) ([]*auth.KnownDevice, error)
+	
+	// RemoveKnownDevice forgets one of a user's devices
+	RemoveKnownDevice(ctx context.Context, userID, deviceID string) error
+	
+	// GuestLogin logs in the guest account bound to a device, creating it on first use.
+	// It returns the device secret when one was newly issued.
+	GuestLogin(ctx context.Context, deviceID, deviceSecret, ipAddress, userAgent string) (*auth.TokenPair, *auth.User, string, error)
+	
+	// UpgradeGuest converts a guest into a full account, keeping its ID and characters
+	UpgradeGuest(ctx context.Context, userID string, req *auth.RegisterRequest) (*auth.User, error)
+	
+	// ChangeUsername renames a user, subject to a cooldown
+	ChangeUsername(ctx context.Context, userID, newUsername string) (*auth.User, error)
+	
+	// RequestEmailChange sends confirmation links to the current and new addresses
+	RequestEmailChange(ctx context.Context, userID, newEmail, currentPassword string) error
+	
+	// ConfirmEmailChange confirms one address and reports whether the change was applied
+	ConfirmEmailChange(ctx context.Context, token string) (bool, error)
+	
+	// CreateServiceAccount registers an internal caller and returns its client secret
+	CreateServiceAccount(ctx context.Context, name string, scopes []string) (*auth.ServiceAccount, string, error)
+	
+	// ListServiceAccounts retrieves all service accounts
+	ListServiceAccounts(ctx context.Context) ([]*auth.ServiceAccount, error)
+	
+	// UpdateServiceAccount changes the scopes and active flag of a service account
+	UpdateServiceAccount(ctx context.Context, clientID string, scopes []string, active bool) (*auth.ServiceAccount, error)
+	
+	// RotateServiceAccountSecret replaces a service account's client secret and returns the new one
+	RotateServiceAccountSecret(ctx context.Context, clientID string) (string, error)
+	
+	// IssueServiceToken exchanges client credentials for a scoped service token
+	IssueServiceToken(ctx context.Context, clientID, clientSecret string, scopes []string) (*auth.ServiceToken, error)
+	
+	// ListIdentityHistory retrieves a user's username and email changes
+	ListIdentityHistory(ctx context.Context, userID string) ([]*auth.IdentityChange, error)
+	
+	// ListLoginHistory retrieves a page of a user's login history
+	ListLoginHistory(ctx context.Context, filter *auth.LoginHistoryFilter) ([]*auth.LoginRecord, error)
+	
+	// RequestGuardianLink asks the owner of wardEmail to place their account under the guardian's parental controls
+	RequestGuardianLink(ctx context.Context, guardianID, wardEmail string) error
+	
+	// ConfirmGuardianLink accepts a guardian link
+	ConfirmGuardianLink(ctx context.Context, token string) error
+	
+	// ListWards retrieves the accounts a guardian manages
+	ListWards(ctx context.Context, guardianID string) ([]*auth.ParentalControls, error)
+	
+	// GetWardPlaytime retrieves a ward's limits and play time status
+	GetWardPlaytime(ctx context.Context, guardianID, wardID string) (*auth.ParentalControls, *auth.PlaytimeStatus, error)
+	
+	// SetPlaytimeLimits replaces a ward's play time limits
+	SetPlaytimeLimits(ctx context.Context, guardianID, wardID string, limits *auth.ParentalControls) (*auth.ParentalControls, error)
+	
+	// RemoveWard ends a guardian link and lifts the ward's limits
+	RemoveWard(ctx context.Context, guardianID, wardID string) error
+	
+	// GetPlaytime retrieves an account's own limits (nil if none) and play time status
+	GetPlaytime(ctx context.Context, userID string) (*auth.ParentalControls, *auth.PlaytimeStatus, error)
+	
+	// CheckPlaytime reports whether an account may start playing now
+	CheckPlaytime(ctx context.Context, userID string) (*auth.PlaytimeStatus, error)
+	
+	// GetSession retrieves an active session by ID
+	GetSession(ctx context.Context, sessionID string) (*auth.Session, error)
+	
 	// GetUserSessions retrieves all active sessions for a user
 	GetUserSessions(ctx context.Context, userID string) ([]*auth.Session, error)
 	
 	// RevokeSession revokes a specific session
 	RevokeSession(ctx context.Context, sessionID string) error
+	
+	// UpdateUserRoles replaces a user's roles
+	UpdateUserRoles(ctx context.Context, userID string, roles []string, reason string) error
+	
+	// SetAccountStatus suspends, bans or reactivates an account
+	SetAccountStatus(ctx context.Context, userID string, status auth.AccountStatus, reason string) error
+	
+	// QuerySecurityEvents retrieves security audit records
+	QuerySecurityEvents(ctx context.Context, filter *auth.SecurityEventFilter) ([]*auth.SecurityEvent, error)
 }
\ No newline at end of file
@@ -3,6 +3,8 @@
 import (
 	"context"
 	"time"
+
+	"github.com/mmorpg-template/backend/internal/domain/auth"
 )
 
 // TokenCache defines the interface for caching tokens and related data
@@ -42,4 +44,26 @@ type TokenCache interface {
 	
 	// DeletePasswordResetToken removes a password reset token
 	DeletePasswordResetToken(ctx context.Context, token string) error
+	
+	// SetAccountLock locks an account until the given time
+	SetAccountLock(ctx context.Context, userID string, lockedUntil time.Time) error
+	
+	// GetAccountLock returns when the account lock expires (zero time if not locked)
+	GetAccountLock(ctx context.Context, userID string) (time.Time, error)
+	
+	// DeleteAccountLock removes an account lock
+	DeleteAccountLock(ctx context.Context, userID string) error
+	
+	// SetVerificationToken stores a one-time token hash for a purpose (unlock, email change, ...)
+	SetVerificationToken(ctx context.Context, purpose auth.TokenPurpose, tokenHash string, value string, expiration time.Duration) error
+	
+	// GetVerificationToken retrieves the value stored for a one-time token hash
+	GetVerificationToken(ctx context.Context, purpose auth.TokenPurpose, tokenHash string) (string, error)
+	
+	// DeleteVerificationToken removes a one-time token
+	DeleteVerificationToken(ctx context.Context, purpose auth.TokenPurpose, tokenHash string) error
+	
+	// ConfirmSide atomically marks one side of a two-sided confirmation and reports whether
+	// the other side was already confirmed. Only the call that completes it reports true.
+	ConfirmSide(ctx context.Context, purpose auth.TokenPurpose, id string, side, otherSide string, expiration time.Duration) (bool, error)
 }
\ No newline at end of file
@@ -0,0 +1,28 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+)
+
+// EventPublisher defines the interface for publishing account events to other services
+type EventPublisher interface {
+	// PublishUsernameChanged publishes a username changed event
+	PublishUsernameChanged(ctx context.Context, event *auth.UsernameChangedEvent) error
+
+	// PublishEmailChanged publishes an email changed event
+	PublishEmailChanged(ctx context.Context, event *auth.EmailChangedEvent) error
+
+	// PublishPlaytimeWarning publishes a play time warning event
+	PublishPlaytimeWarning(ctx context.Context, event *auth.PlaytimeWarningEvent) error
+
+	// PublishPlaytimeLogout publishes a play time forced logout event
+	PublishPlaytimeLogout(ctx context.Context, event *auth.PlaytimeLogoutEvent) error
+
+	// PublishSessionEnded publishes a session ended event
+	PublishSessionEnded(ctx context.Context, event *auth.SessionEndedEvent) error
+
+	// PublishGuestDeleted publishes a guest deleted event
+	PublishGuestDeleted(ctx context.Context, event *auth.GuestDeletedEvent) error
+}
@@ -0,0 +1,24 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+)
+
+// IdentityHistoryRepository defines the interface for username and email change history
+type IdentityHistoryRepository interface {
+	// Record stores an identity change
+	Record(ctx context.Context, change *auth.IdentityChange) error
+
+	// LastChange returns the most recent change of a field for a user, or nil if none
+	LastChange(ctx context.Context, userID string, field auth.IdentityField) (*auth.IdentityChange, error)
+
+	// ListByUserID retrieves a user's identity changes, newest first
+	ListByUserID(ctx context.Context, userID string) ([]*auth.IdentityChange, error)
+
+	// IsUsernameHeld reports whether a released username is still reserved for
+	// another account at the given time
+	IsUsernameHeld(ctx context.Context, username, exceptUserID string, at time.Time) (bool, error)
+}
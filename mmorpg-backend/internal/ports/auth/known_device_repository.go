@@ -0,0 +1,28 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+)
+
+// KnownDeviceRepository defines the interface for the per-user device history
+type KnownDeviceRepository interface {
+	// Get retrieves a device by user and device key
+	Get(ctx context.Context, userID, deviceKey string) (*auth.KnownDevice, error)
+
+	// Save creates or updates a known device and adds its network to the user's known networks
+	Save(ctx context.Context, device *auth.KnownDevice) error
+
+	// ListByUserID retrieves all known devices for a user, most recently seen first
+	ListByUserID(ctx context.Context, userID string) ([]*auth.KnownDevice, error)
+
+	// CountByUserID returns the number of known devices for a user
+	CountByUserID(ctx context.Context, userID string) (int, error)
+
+	// HasNetwork reports whether the user has logged in from a network before, on any device
+	HasNetwork(ctx context.Context, userID, network string) (bool, error)
+
+	// Delete forgets a device
+	Delete(ctx context.Context, userID, deviceID string) error
+}
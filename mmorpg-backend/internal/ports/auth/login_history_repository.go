@@ -0,0 +1,26 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+)
+
+// LoginHistoryRepository defines the interface for per-user login history
+type LoginHistoryRepository interface {
+	// Append stores a login record
+	Append(ctx context.Context, record *auth.LoginRecord) error
+
+	// List retrieves a user's login records matching a filter, newest first
+	List(ctx context.Context, filter *auth.LoginHistoryFilter) ([]*auth.LoginRecord, error)
+
+	// DeleteBefore removes records older than the given time and returns how many were removed
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// GeoLocator resolves an IP address to a coarse location
+type GeoLocator interface {
+	// Locate returns the location of an IP address, or nil if it is unknown
+	Locate(ipAddress string) *auth.GeoLocation
+}
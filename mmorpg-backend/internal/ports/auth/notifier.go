@@ -0,0 +1,13 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+)
+
+// Notifier delivers account notifications (email, push, ...) to users
+type Notifier interface {
+	// Notify sends a notification; implementations choose the template by kind
+	Notify(ctx context.Context, notification *auth.Notification) error
+}
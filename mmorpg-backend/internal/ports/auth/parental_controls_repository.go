@@ -0,0 +1,56 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+)
+
+// ParentalControlsRepository defines the interface for guardian links and play time limits
+type ParentalControlsRepository interface {
+	// Get retrieves the controls on an account, or ErrParentalControlsNotFound
+	Get(ctx context.Context, userID string) (*auth.ParentalControls, error)
+
+	// ListByGuardian retrieves the controls a guardian manages
+	ListByGuardian(ctx context.Context, guardianID string) ([]*auth.ParentalControls, error)
+
+	// Save creates or replaces the controls on an account
+	Save(ctx context.Context, controls *auth.ParentalControls) error
+
+	// Delete removes the controls on an account
+	Delete(ctx context.Context, userID string) error
+}
+
+// PlaytimeRepository defines the interface for play time usage and online sessions
+type PlaytimeRepository interface {
+	// AddUsage adds play time to an account's total for a local calendar day
+	AddUsage(ctx context.Context, userID string, day time.Time, played time.Duration) error
+
+	// SumUsage returns an account's play time over the local calendar days from..to inclusive
+	SumUsage(ctx context.Context, userID string, from, to time.Time) (time.Duration, error)
+
+	// OpenSession starts tracking an online character, replacing any existing entry for it
+	OpenSession(ctx context.Context, session *auth.PlaySession) error
+
+	// UpdateSession stores accounting progress and warnings for an online character
+	UpdateSession(ctx context.Context, session *auth.PlaySession) error
+
+	// CloseSession stops tracking a character and returns its entry, or nil if it was not tracked
+	CloseSession(ctx context.Context, characterID string) (*auth.PlaySession, error)
+
+	// ListSessions retrieves all tracked online characters
+	ListSessions(ctx context.Context) ([]*auth.PlaySession, error)
+
+	// ListSessionsByUser retrieves the tracked online characters of an account
+	ListSessionsByUser(ctx context.Context, userID string) ([]*auth.PlaySession, error)
+}
+
+// PlaytimeTracker receives character presence changes from the game
+type PlaytimeTracker interface {
+	// CharacterOnline starts counting play time for a character
+	CharacterOnline(ctx context.Context, userID, characterID, sessionID string, at time.Time) error
+
+	// CharacterOffline stops counting play time for a character
+	CharacterOffline(ctx context.Context, characterID string, at time.Time) error
+}
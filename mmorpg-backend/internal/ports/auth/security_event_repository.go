@@ -0,0 +1,23 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+)
+
+// SecurityEventRepository defines the interface for the append-only security audit log
+type SecurityEventRepository interface {
+	// Append stores a new security event
+	Append(ctx context.Context, event *auth.SecurityEvent) error
+
+	// Query retrieves security events matching a filter, newest first
+	Query(ctx context.Context, filter *auth.SecurityEventFilter) ([]*auth.SecurityEvent, error)
+
+	// EnsurePartitions creates storage partitions covering events up to the given time
+	EnsurePartitions(ctx context.Context, until time.Time) error
+
+	// DropPartitionsBefore removes partitions that only hold events older than the given time
+	DropPartitionsBefore(ctx context.Context, before time.Time) (int, error)
+}
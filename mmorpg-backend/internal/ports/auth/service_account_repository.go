@@ -0,0 +1,26 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/mmorpg-template/backend/internal/domain/auth"
+)
+
+// ServiceAccountRepository defines the interface for service account persistence
+type ServiceAccountRepository interface {
+	// Create stores a new service account
+	Create(ctx context.Context, account *auth.ServiceAccount) error
+
+	// GetByClientID retrieves a service account by its client ID
+	GetByClientID(ctx context.Context, clientID string) (*auth.ServiceAccount, error)
+
+	// List retrieves all service accounts
+	List(ctx context.Context) ([]*auth.ServiceAccount, error)
+
+	// Update saves the secret, scopes and active flag of a service account
+	Update(ctx context.Context, account *auth.ServiceAccount) error
+
+	// TouchLastUsed records when the account last obtained a token
+	TouchLastUsed(ctx context.Context, clientID string, at time.Time) error
+}
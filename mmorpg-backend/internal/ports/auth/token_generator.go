@@ -11,6 +11,9 @@ type TokenGenerator interface {
 	// GenerateTokenPair generates an access and refresh token pair
 	GenerateTokenPair(ctx context.Context, user *auth.User, sessionID, deviceID string) (*auth.TokenPair, error)
 	
+	// GenerateServiceToken generates a client-credential token with the given scopes
+	GenerateServiceToken(ctx context.Context, account *auth.ServiceAccount, scopes []string) (*auth.ServiceToken, error)
+	
 	// ValidateAccessToken validates an access token and returns the claims
 	ValidateAccessToken(ctx context.Context, token string) (*auth.Claims, error)
 	
@@ -34,4 +37,10 @@ type PasswordHasher interface {
 	
 	// ComparePassword compares a password with its hash
 	ComparePassword(hash, password string) error
-}
\ No newline at end of file
+}
+
+// PasswordScreener rejects passwords known to be compromised or too common
+type PasswordScreener interface {
+	// Screen returns ErrPasswordBreached if the password must not be used
+	Screen(ctx context.Context, password string) error
+}
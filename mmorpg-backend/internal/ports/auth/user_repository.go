@@ -2,6 +2,7 @@
 
 import (
 	"context"
+	"time"
 
 	"github.com/mmorpg-template/backend/internal/domain/auth"
 )
@@ -37,4 +38,25 @@ type UserRepository interface {
 	
 	// DecrementCharacterCount decrements the character count for a user
 	DecrementCharacterCount(ctx context.Context, userID string) error
+	
+	// RecountCharacters sets a user's character count from their active characters and returns it
+	RecountCharacters(ctx context.Context, userID string) (int, error)
+	
+	// ReconcileCharacterCounts recounts the characters of up to limit users with IDs after
+	// afterID, in ID order, capping each count at the user's max_characters. It returns the
+	// counts that were wrong and the last user ID examined, which is empty once none are left.
+	ReconcileCharacterCounts(ctx context.Context, afterID string, limit int) ([]*auth.CharacterCountCorrection, string, error)
+	
+	// GetGuestByDeviceID retrieves the guest account bound to a device
+	GetGuestByDeviceID(ctx context.Context, deviceID string) (*auth.User, error)
+	
+	// DeleteIdleGuests deletes up to limit guests with no login since the given time,
+	// returning them with the characters that were deleted along with them
+	DeleteIdleGuests(ctx context.Context, idleSince time.Time, limit int) ([]*auth.DeletedGuest, error)
+}
+
+// CharacterCountTracker receives character lifecycle changes from the character service
+type CharacterCountTracker interface {
+	// SyncCharacterCount brings a user's character count up to date after one of their characters changed
+	SyncCharacterCount(ctx context.Context, userID string) error
 }
\ No newline at end of file
@@ -2,6 +2,7 @@
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/mmorpg-template/backend/internal/domain/character"
@@ -18,14 +19,27 @@ type CharacterRepository interface {
 	Update(ctx context.Context, char *character.Character) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	
+	// Progression, applied atomically so concurrent grants are not lost.
+	// AddExperience adds to a live character's experience, capped at max, and returns the new total.
+	// The grant ID is recorded with it, and a grant ID already recorded fails with ErrDuplicateGrant.
+	// RaiseLevel sets the level if it is higher than the current one and returns the level it replaced
+	// (equal to level when unchanged), so points for a level are only ever awarded once.
+	AddExperience(ctx context.Context, id uuid.UUID, grantID string, amount, max int64) (int64, error)
+	RaiseLevel(ctx context.Context, id uuid.UUID, level int) (int, error)
+	
 	// Soft delete operations
 	SoftDelete(ctx context.Context, id uuid.UUID) error
 	Restore(ctx context.Context, id uuid.UUID) error
 	CleanupDeleted(ctx context.Context) error
+	// PurgeDeleted permanently removes up to limit soft-deleted characters whose recovery
+	// period ended before the given time and returns them. Appearance, stats and position cascade.
+	PurgeDeleted(ctx context.Context, before time.Time, limit int) ([]*character.Character, error)
 	
 	// Validation
 	NameExists(ctx context.Context, name string) (bool, error)
 	CountByUserID(ctx context.Context, userID uuid.UUID) (int, error)
+	// GetAccountLimit returns the max_characters of an account and whether it is a guest
+	GetAccountLimit(ctx context.Context, userID uuid.UUID) (int, bool, error)
 }
 
 // AppearanceRepository defines the interface for character appearance persistence
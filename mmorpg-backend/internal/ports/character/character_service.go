@@ -2,6 +2,7 @@
 
 import (
 	"context"
+	"time"
 
 	"github.com/mmorpg-template/backend/internal/domain/character"
 )
@@ -15,6 +16,10 @@ type CharacterService interface {
 	ListCharactersByUser(ctx context.Context, userID string) ([]*character.Character, error)
 	DeleteCharacter(ctx context.Context, characterID string, userID string) error
 	RestoreCharacter(ctx context.Context, characterID string, userID string) error
+	RenameCharacter(ctx context.Context, characterID, userID, newName string) (*character.Character, error)
+	GetNameHistory(ctx context.Context, characterID string) ([]*character.NameChange, error)
+	CheckCharacterName(ctx context.Context, name string) error
+	ValidateName(ctx context.Context, kind character.NameKind, name string) error
 	
 	// Character appearance
 	GetAppearance(ctx context.Context, characterID string) (*character.Appearance, error)
@@ -23,6 +28,13 @@ type CharacterService interface {
 	// Character stats
 	GetStats(ctx context.Context, characterID string) (*character.Stats, error)
 	AllocateStatPoint(ctx context.Context, characterID string, stat string) (*character.Stats, error)
+	AllocateStatPoints(ctx context.Context, characterID string, points map[string]int, preview bool) (*character.Stats, error)
+	GetRespecQuote(ctx context.Context, characterID string) (*character.RespecQuote, error)
+	RespecStats(ctx context.Context, characterID string) (*character.RespecResult, error)
+	UndoStatAllocations(ctx context.Context, characterID string, count int) (*character.StatUndoResult, error)
+	
+	// Progression
+	GrantExperience(ctx context.Context, characterID, grantID string, amount int64, source string) (*character.ExperienceGain, error)
 	
 	// Character position
 	GetPosition(ctx context.Context, characterID string) (*character.Position, error)
@@ -35,6 +47,22 @@ type CharacterService interface {
 	
 	// Gameplay
 	SelectCharacter(ctx context.Context, characterID string, userID string, sessionID string) error
+	DeselectCharacter(ctx context.Context, userID string, sessionID string) error
+	HeartbeatCharacter(ctx context.Context, characterID string, userID string, sessionID string) error
+	EndPlaySession(ctx context.Context, userID string, sessionID string, reason string) error
+	EndLoginSession(ctx context.Context, userID string, sessionID string) error
+	ForgetDeletedAccount(ctx context.Context, userID string, characters []*character.Character) error
+	
+	// Support
+	TransferCharacter(ctx context.Context, req *TransferCharacterRequest) (*character.CharacterTransfer, error)
+	GetTransferHistory(ctx context.Context, characterID string) ([]*character.CharacterTransfer, error)
+	ExportCharacter(ctx context.Context, characterID string) (*character.CharacterSnapshot, error)
+	ImportCharacter(ctx context.Context, req *ImportCharacterRequest) (*character.Character, error)
+	CreateRestorePoint(ctx context.Context, characterID string) (*character.RestorePoint, error)
+	ListRestorePoints(ctx context.Context, characterID string, limit int) ([]*character.RestorePoint, error)
+	RollbackCharacter(ctx context.Context, req *RollbackCharacterRequest) (*character.CharacterRollback, error)
+	GetRollbackHistory(ctx context.Context, characterID string) ([]*character.CharacterRollback, error)
+	GetDailyPlayTime(ctx context.Context, characterID string, from, to time.Time) ([]*character.DailyPlayTime, error)
 }
 
 // CreateCharacterRequest represents a request to create a new character
@@ -46,6 +74,37 @@ type CreateCharacterRequest struct {
 	Race       character.Race
 	Gender     character.Gender
 	Appearance *CharacterAppearanceOptions
+	// IsGuest applies the guest character limit
+	IsGuest bool
+}
+
+// TransferCharacterRequest represents a staff request to move a character to another account
+type TransferCharacterRequest struct {
+	CharacterID string
+	ToUserID    string
+	// SlotNumber on the target account; zero picks the lowest free slot
+	SlotNumber  int
+	PerformedBy string
+	Reason      string
+}
+
+// ImportCharacterRequest represents a staff request to recreate a character from a snapshot
+type ImportCharacterRequest struct {
+	UserID   string
+	Snapshot *character.CharacterSnapshot
+	// Name replaces the snapshot's name; empty keeps it, numbered if it is taken
+	Name string
+	// SlotNumber on the account; zero picks the lowest free slot
+	SlotNumber  int
+	PerformedBy string
+}
+
+// RollbackCharacterRequest represents a game master request to restore a character to a restore point
+type RollbackCharacterRequest struct {
+	CharacterID    string
+	RestorePointID string
+	PerformedBy    string
+	Reason         string
 }
 
 // CharacterAppearanceOptions represents optional appearance customization
@@ -92,4 +151,4 @@ type UpdatePositionRequest struct {
 	VelocityX     *float32 `json:"velocity_x,omitempty"`
 	VelocityY     *float32 `json:"velocity_y,omitempty"`
 	VelocityZ     *float32 `json:"velocity_z,omitempty"`
-}
\ No newline at end of file
+}
@@ -0,0 +1,9 @@
+package character
+
+import "github.com/mmorpg-template/backend/internal/domain/character"
+
+// DefinitionsProvider supplies the class and race definitions currently in effect.
+// Implementations may swap the definitions at runtime; callers should fetch them once per operation.
+type DefinitionsProvider interface {
+	Definitions() *character.Definitions
+}
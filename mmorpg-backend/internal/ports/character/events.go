@@ -17,6 +17,18 @@ type EventPublisher interface {
 	// PublishCharacterRestored publishes a character restored event
 	PublishCharacterRestored(ctx context.Context, event *character.CharacterRestoredEvent) error
 	
+	// PublishCharacterPurged publishes a character purged event
+	PublishCharacterPurged(ctx context.Context, event *character.CharacterPurgedEvent) error
+	
+	// PublishCharacterTransferred publishes a character transferred event
+	PublishCharacterTransferred(ctx context.Context, event *character.CharacterTransferredEvent) error
+	
+	// PublishCharacterRolledBack publishes a character rolled back event
+	PublishCharacterRolledBack(ctx context.Context, event *character.CharacterRolledBackEvent) error
+	
+	// PublishCharacterRenamed publishes a character renamed event
+	PublishCharacterRenamed(ctx context.Context, event *character.CharacterRenamedEvent) error
+	
 	// PublishCharacterSelected publishes a character selected event
 	PublishCharacterSelected(ctx context.Context, event *character.CharacterSelectedEvent) error
 	
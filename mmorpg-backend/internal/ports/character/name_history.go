@@ -0,0 +1,27 @@
+package character
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mmorpg-template/backend/internal/domain/character"
+)
+
+// NameHistoryRepository defines the interface for character renames and their history
+type NameHistoryRepository interface {
+	// Rename changes the character's name and records the change atomically.
+	// It returns ErrCharacterNameTaken if another character holds the new name, and
+	// ErrRenameTooSoon if the character was renamed within the cooldown (zero disables it).
+	Rename(ctx context.Context, change *character.NameChange, cooldown time.Duration) error
+
+	// LastChange returns the character's most recent rename, or nil if none
+	LastChange(ctx context.Context, characterID uuid.UUID) (*character.NameChange, error)
+
+	// ListByCharacterID retrieves a character's renames, newest first
+	ListByCharacterID(ctx context.Context, characterID uuid.UUID) ([]*character.NameChange, error)
+
+	// IsNameHeld reports whether a released name is still reserved for a character
+	// of another account at the given time
+	IsNameHeld(ctx context.Context, name string, exceptUserID uuid.UUID, at time.Time) (bool, error)
+}
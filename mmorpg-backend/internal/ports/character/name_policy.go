@@ -0,0 +1,9 @@
+package character
+
+import "github.com/mmorpg-template/backend/internal/domain/character"
+
+// NamePolicyProvider supplies the name policy currently in effect.
+// Implementations may swap the policy at runtime; callers should fetch it once per check.
+type NamePolicyProvider interface {
+	NamePolicy() *character.NamePolicy
+}
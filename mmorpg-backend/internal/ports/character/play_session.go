@@ -0,0 +1,40 @@
+package character
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mmorpg-template/backend/internal/domain/character"
+)
+
+// PlaySessionRepository defines the interface for tracking play sessions and play time
+type PlaySessionRepository interface {
+	// Start saves a new play session. An account has at most one open session; starting
+	// another while one is open returns ErrSelectionConflict. A character that was deleted or
+	// moved to another account returns ErrCharacterNotFound.
+	Start(ctx context.Context, session *character.PlaySession) error
+
+	// GetOpenByUserID retrieves the account's open play session, or ErrNoCharacterSelected
+	GetOpenByUserID(ctx context.Context, userID uuid.UUID) (*character.PlaySession, error)
+
+	// Heartbeat renews the lease of the account's open play session and returns it. Only the
+	// session of that character started from sessionID whose last heartbeat is not before
+	// expiredBefore is renewed; otherwise it returns ErrNoCharacterSelected.
+	Heartbeat(ctx context.Context, userID, characterID uuid.UUID, sessionID string, at, expiredBefore time.Time) (*character.PlaySession, error)
+
+	// ListStale retrieves open play sessions without a heartbeat since the given time
+	ListStale(ctx context.Context, heartbeatBefore time.Time, limit int) ([]*character.PlaySession, error)
+
+	// End closes an ended play session and adds its time to the character's total play
+	// time, last played time and daily play time, atomically. It returns
+	// ErrNoCharacterSelected if the session was already closed.
+	End(ctx context.Context, session *character.PlaySession) error
+
+	// DeleteCharacter soft deletes a character and ends its open play session in one
+	// transaction. It returns the ended session, or nil when the character wasn't being played.
+	DeleteCharacter(ctx context.Context, characterID uuid.UUID, at time.Time) (*character.PlaySession, error)
+
+	// ListDailyPlayTime retrieves a character's play time per day within [from, to), oldest first
+	ListDailyPlayTime(ctx context.Context, characterID uuid.UUID, from, to time.Time) ([]*character.DailyPlayTime, error)
+}
@@ -0,0 +1,8 @@
+package character
+
+import "context"
+
+// PlaytimeChecker reports whether an account's parental controls allow it to play now
+type PlaytimeChecker interface {
+	PlaytimeAllowed(ctx context.Context, userID string) (bool, error)
+}
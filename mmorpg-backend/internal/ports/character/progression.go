@@ -0,0 +1,9 @@
+package character
+
+import "github.com/mmorpg-template/backend/internal/domain/character"
+
+// ProgressionProvider supplies the progression table currently in effect.
+// Implementations may swap the table at runtime; callers should fetch it once per operation.
+type ProgressionProvider interface {
+	Progression() *character.ProgressionTable
+}
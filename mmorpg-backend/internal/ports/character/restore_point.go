@@ -0,0 +1,37 @@
+package character
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mmorpg-template/backend/internal/domain/character"
+)
+
+// RestorePointRepository defines the interface for character restore points and rollbacks
+type RestorePointRepository interface {
+	// Create saves a restore point
+	Create(ctx context.Context, point *character.RestorePoint) error
+
+	// CaptureChanged takes a restore point of every live character whose level, stats or
+	// position changed since the given time and returns how many were taken
+	CaptureChanged(ctx context.Context, trigger string, since time.Time) (int64, error)
+
+	// GetByID retrieves a restore point, or ErrRestorePointNotFound
+	GetByID(ctx context.Context, id uuid.UUID) (*character.RestorePoint, error)
+
+	// ListByCharacterID retrieves a character's most recent restore points, newest first
+	ListByCharacterID(ctx context.Context, characterID uuid.UUID, limit int) ([]*character.RestorePoint, error)
+
+	// DeleteBefore removes restore points taken before the given time and returns how many
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+
+	// Rollback locks the character, its stats and position and passes them to fn along with a
+	// stat ledger in the same transaction. When fn returns a result, the changed records, the
+	// backup restore point and the rollback are saved in one transaction; on error nothing is.
+	// A character with an open play session returns ErrCharacterOnline.
+	Rollback(ctx context.Context, characterID uuid.UUID, fn func(char *character.Character, stats *character.Stats, position *character.Position, ledger StatLedger) (*character.RollbackResult, error)) error
+
+	// ListRollbacks retrieves a character's rollbacks, newest first
+	ListRollbacks(ctx context.Context, characterID uuid.UUID) ([]*character.CharacterRollback, error)
+}
@@ -0,0 +1,15 @@
+package character
+
+import (
+	"context"
+
+	"github.com/mmorpg-template/backend/internal/domain/character"
+)
+
+// CharacterImporter creates a character from a snapshot
+type CharacterImporter interface {
+	// ImportCharacter inserts the character with its appearance, stats and position in one
+	// transaction. It returns ErrCharacterNameTaken or ErrSlotOccupied if another character
+	// took the name or slot first.
+	ImportCharacter(ctx context.Context, imported *character.ImportedCharacter) error
+}
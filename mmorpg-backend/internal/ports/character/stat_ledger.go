@@ -0,0 +1,39 @@
+package character
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mmorpg-template/backend/internal/domain/character"
+)
+
+// StatLedger records stat allocations and respecs
+type StatLedger interface {
+	// RecordAllocations appends allocations to the character's log
+	RecordAllocations(ctx context.Context, allocations []*character.StatAllocation) error
+	// RecentAllocations returns allocations made since the given time that were not reverted, newest first
+	RecentAllocations(ctx context.Context, characterID uuid.UUID, since time.Time, limit int) ([]*character.StatAllocation, error)
+	// RevertAllocations marks allocations as reverted so they cannot be undone again
+	RevertAllocations(ctx context.Context, ids []uuid.UUID, at time.Time) error
+	// RevertAllAllocations marks every allocation of a character as reverted
+	RevertAllAllocations(ctx context.Context, characterID uuid.UUID, at time.Time) error
+	// GetRespecHistory summarizes the character's past respecs
+	GetRespecHistory(ctx context.Context, characterID uuid.UUID) (*character.RespecHistory, error)
+	// RecordRespec appends a respec to the character's history
+	RecordRespec(ctx context.Context, respec *character.Respec) error
+}
+
+// StatsTransactor changes stats atomically together with the stat ledger
+type StatsTransactor interface {
+	// UpdateStats locks the character's stats and passes them to fn with a ledger bound to
+	// the same transaction. The stats are saved when fn returns nil; on error nothing is.
+	UpdateStats(ctx context.Context, characterID uuid.UUID, fn func(stats *character.Stats, ledger StatLedger) error) error
+	// RaiseLevel raises the character's level if it is below level and passes the level it
+	// replaced and the locked stats to fn. The level and stats are saved together when fn
+	// returns nil; on error neither is. It returns the replaced level, and when that is not
+	// below level fn is not called.
+	RaiseLevel(ctx context.Context, characterID uuid.UUID, level int, fn func(previous int, stats *character.Stats) error) (int, error)
+	// Ledger returns a ledger for reads outside a transaction
+	Ledger() StatLedger
+}
@@ -0,0 +1,28 @@
+package character
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mmorpg-template/backend/internal/domain/character"
+)
+
+// TransferRepository defines the interface for moving characters between accounts
+type TransferRepository interface {
+	// Transfer reassigns the character's account and slot and records the transfer atomically,
+	// ending the character's open play session in the same transaction. It returns the ended
+	// session, or nil when the character wasn't being played. It returns ErrSlotOccupied if the
+	// target slot is taken and ErrCharacterNotFound if the character no longer belongs to the
+	// source account.
+	Transfer(ctx context.Context, transfer *character.CharacterTransfer) (*character.PlaySession, error)
+
+	// ListByCharacterID retrieves a character's transfers, newest first
+	ListByCharacterID(ctx context.Context, characterID uuid.UUID) ([]*character.CharacterTransfer, error)
+}
+
+// PendingActivityChecker reports activity that must settle before a character changes accounts,
+// such as open trades or undelivered mail
+type PendingActivityChecker interface {
+	// PendingActivity returns the kinds of pending activity, or none if the character is clear
+	PendingActivity(ctx context.Context, characterID string) ([]string, error)
+}
@@ -0,0 +1,28 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+// Locker hands out distributed locks, e.g. so only one replica runs a background job
+type Locker interface {
+	// TryLock acquires key for ttl without waiting. It returns ErrLockHeld if someone else holds it.
+	TryLock(ctx context.Context, key string, ttl time.Duration) (Lock, error)
+}
+
+// Lock is a held lock. It expires after its ttl unless refreshed, so a crashed holder
+// can't keep it forever.
+type Lock interface {
+	// Refresh extends the lock to ttl from now. It returns ErrLockLost if the lock
+	// already expired and may have been taken by someone else.
+	Refresh(ctx context.Context, ttl time.Duration) error
+	// Release gives the lock up early; releasing a lost lock is a no-op
+	Release(ctx context.Context) error
+}
+
+// Lock errors
+var (
+	ErrLockHeld = NewError("LOCK_HELD", "Lock is held by someone else")
+	ErrLockLost = NewError("LOCK_LOST", "Lock expired before it was refreshed")
+)
@@ -15,6 +15,7 @@ type MessageQueue interface {
 	// Publishing
 	Publish(ctx context.Context, subject string, data []byte) error
 	PublishWithReply(ctx context.Context, subject string, data []byte, timeout time.Duration) ([]byte, error)
+	PublishWithHeaders(ctx context.Context, subject string, data []byte, headers map[string]string) error
 	
 	// Subscribing
 	Subscribe(ctx context.Context, subject string, handler MessageHandler) (QueueSubscription, error)
@@ -22,6 +23,7 @@ type MessageQueue interface {
 	
 	// Request-Reply pattern
 	Request(ctx context.Context, subject string, data []byte, timeout time.Duration) ([]byte, error)
+	RequestWithHeaders(ctx context.Context, subject string, data []byte, headers map[string]string, timeout time.Duration) ([]byte, error)
 	
 	// Streaming
 	CreateStream(ctx context.Context, config StreamConfig) error
@@ -191,5 +193,6 @@ type MessageQueueConfig struct {
 	ErrMQPublish        = NewError("MQ_PUBLISH", "Failed to publish message")
 	ErrMQSubscribe      = NewError("MQ_SUBSCRIBE", "Failed to subscribe")
 	ErrMQTimeout        = NewError("MQ_TIMEOUT", "Message queue operation timeout")
+	ErrMQNoResponders   = NewError("MQ_NO_RESPONDERS", "No responders for request subject")
 	ErrMQInvalidSubject = NewError("MQ_INVALID_SUBJECT", "Invalid subject")
 )
\ No newline at end of file
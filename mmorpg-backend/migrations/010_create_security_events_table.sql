@@ -0,0 +1,87 @@
+-- Create security events audit table
+-- Append-only log of account events, partitioned by month for cheap retention
+CREATE TABLE IF NOT EXISTS security_events (
+    id UUID NOT NULL DEFAULT gen_random_uuid(),
+    event_type VARCHAR(64) NOT NULL,
+    outcome VARCHAR(16) NOT NULL,
+    actor_id UUID,
+    target_user_id UUID,
+    session_id UUID,
+    ip_address INET,
+    device_id VARCHAR(255),
+    user_agent TEXT,
+    reason TEXT,
+    metadata JSONB NOT NULL DEFAULT '{}'::jsonb,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (id, created_at),
+    CONSTRAINT check_security_event_outcome CHECK (outcome IN ('success', 'failure', 'denied'))
+) PARTITION BY RANGE (created_at);
+
+-- Create indexes for support queries
+CREATE INDEX idx_security_events_target_user ON security_events(target_user_id, created_at DESC);
+CREATE INDEX idx_security_events_actor ON security_events(actor_id, created_at DESC);
+CREATE INDEX idx_security_events_ip ON security_events(ip_address, created_at DESC);
+CREATE INDEX idx_security_events_type ON security_events(event_type, created_at DESC);
+
+-- Reject updates and row deletes so the log stays append-only.
+-- Retention drops whole partitions, which does not fire row triggers.
+CREATE OR REPLACE FUNCTION prevent_security_event_mutation()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'security_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER security_events_append_only BEFORE UPDATE OR DELETE
+    ON security_events FOR EACH ROW EXECUTE FUNCTION prevent_security_event_mutation();
+
+-- Create monthly partitions from the month of start_ts through the month of end_ts
+CREATE OR REPLACE FUNCTION create_security_event_partitions(start_ts TIMESTAMP WITH TIME ZONE, end_ts TIMESTAMP WITH TIME ZONE)
+RETURNS void AS $$
+DECLARE
+    month_start DATE := date_trunc('month', start_ts)::date;
+    partition_name TEXT;
+BEGIN
+    WHILE month_start <= date_trunc('month', end_ts)::date LOOP
+        partition_name := 'security_events_' || to_char(month_start, 'YYYYMM');
+        EXECUTE format(
+            'CREATE TABLE IF NOT EXISTS %I PARTITION OF security_events FOR VALUES FROM (%L) TO (%L)',
+            partition_name, month_start, (month_start + INTERVAL '1 month')::date
+        );
+        month_start := (month_start + INTERVAL '1 month')::date;
+    END LOOP;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Drop monthly partitions whose whole range is older than cutoff, returning how many were dropped
+CREATE OR REPLACE FUNCTION drop_security_event_partitions(cutoff TIMESTAMP WITH TIME ZONE)
+RETURNS INTEGER AS $$
+DECLARE
+    part RECORD;
+    dropped INTEGER := 0;
+BEGIN
+    FOR part IN
+        SELECT c.relname
+        FROM pg_inherits i
+        JOIN pg_class c ON c.oid = i.inhrelid
+        JOIN pg_class p ON p.oid = i.inhparent
+        WHERE p.relname = 'security_events'
+          AND c.relname ~ '^security_events_[0-9]{6}$'
+    LOOP
+        IF (to_date(right(part.relname, 6), 'YYYYMM') + INTERVAL '1 month') <= cutoff THEN
+            EXECUTE format('DROP TABLE IF EXISTS %I', part.relname);
+            dropped := dropped + 1;
+        END IF;
+    END LOOP;
+    RETURN dropped;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Create partitions for the current and next month
+SELECT create_security_event_partitions(NOW(), NOW() + INTERVAL '1 month');
+
+-- Add comments for documentation
+COMMENT ON TABLE security_events IS 'Append-only audit log of account security events';
+COMMENT ON COLUMN security_events.actor_id IS 'User who performed the action, NULL for anonymous callers';
+COMMENT ON COLUMN security_events.target_user_id IS 'Account the action applied to';
+COMMENT ON FUNCTION drop_security_event_partitions(TIMESTAMP WITH TIME ZONE) IS 'Retention: drops monthly partitions older than the cutoff';
@@ -0,0 +1,23 @@
+-- Create known devices table
+-- One row per (user, device) the user has logged in from; used to detect
+-- logins from new devices and new networks
+CREATE TABLE IF NOT EXISTS known_devices (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    device_key VARCHAR(255) NOT NULL,
+    user_agent TEXT,
+    last_ip_address INET,
+    last_network CIDR,
+    confirmed BOOLEAN NOT NULL DEFAULT FALSE,
+    first_seen_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    last_seen_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT uq_known_devices_user_device UNIQUE (user_id, device_key)
+);
+
+-- Create indexes for performance
+CREATE INDEX idx_known_devices_user_id ON known_devices(user_id, last_seen_at DESC);
+CREATE INDEX idx_known_devices_user_network ON known_devices(user_id, last_network);
+
+COMMENT ON TABLE known_devices IS 'Devices each user has logged in from, for new-device login alerts';
+COMMENT ON COLUMN known_devices.device_key IS 'Client device ID, or a user-agent fingerprint when none was sent';
+COMMENT ON COLUMN known_devices.last_network IS '/24 (IPv4) or /48 (IPv6) network of the last login';
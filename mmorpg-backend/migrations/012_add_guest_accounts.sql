@@ -0,0 +1,18 @@
+-- Add guest account support to users
+-- Guests are bound to a device ID and use placeholder email/username values
+-- until they upgrade in place to a full account
+ALTER TABLE users ADD COLUMN IF NOT EXISTS is_guest BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS guest_device_id VARCHAR(255);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS last_login_at TIMESTAMP WITH TIME ZONE;
+
+-- One guest account per device
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_guest_device_id
+    ON users(guest_device_id) WHERE is_guest = TRUE;
+
+-- Idle guest cleanup scans guests by last activity
+CREATE INDEX IF NOT EXISTS idx_users_guest_last_login
+    ON users(COALESCE(last_login_at, created_at)) WHERE is_guest = TRUE;
+
+COMMENT ON COLUMN users.is_guest IS 'Device-bound guest account with restricted limits';
+COMMENT ON COLUMN users.guest_device_id IS 'Device the guest account is bound to (NULL once upgraded)';
+COMMENT ON COLUMN users.last_login_at IS 'Time of the last successful login';
@@ -0,0 +1,21 @@
+-- Create table for tracking username and email changes
+CREATE TABLE IF NOT EXISTS user_identity_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    field VARCHAR(20) NOT NULL,
+    old_value VARCHAR(255) NOT NULL,
+    new_value VARCHAR(255) NOT NULL,
+    hold_until TIMESTAMP WITH TIME ZONE,
+    changed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT check_identity_field CHECK (field IN ('username', 'email'))
+);
+
+CREATE INDEX idx_user_identity_history_user ON user_identity_history(user_id, field, changed_at DESC);
+
+-- Released usernames stay reserved for their previous owner until hold_until
+CREATE INDEX idx_user_identity_history_held_username
+    ON user_identity_history(LOWER(old_value), hold_until)
+    WHERE field = 'username' AND hold_until IS NOT NULL;
+
+COMMENT ON TABLE user_identity_history IS 'Username and email change history';
+COMMENT ON COLUMN user_identity_history.hold_until IS 'Old username cannot be claimed by another account before this time';
@@ -0,0 +1,22 @@
+-- Create service accounts table
+-- Identities for internal callers (game services, world servers) that obtain
+-- scoped client-credential tokens from the auth service
+CREATE TABLE IF NOT EXISTS service_accounts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(100) NOT NULL,
+    client_id VARCHAR(120) NOT NULL UNIQUE,
+    secret_hash VARCHAR(255) NOT NULL,
+    scopes TEXT[] NOT NULL DEFAULT ARRAY[]::TEXT[],
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    last_used_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Create trigger to update updated_at
+CREATE TRIGGER update_service_accounts_updated_at BEFORE UPDATE ON service_accounts
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE service_accounts IS 'Service-to-service client credentials';
+COMMENT ON COLUMN service_accounts.secret_hash IS 'SHA-256 hash of the random client secret; the secret itself is only shown when created or rotated';
+COMMENT ON COLUMN service_accounts.scopes IS 'Scopes the account may request, e.g. character:read';
@@ -0,0 +1,25 @@
+-- Create login history table
+-- One row per login or token refresh attempt against a known account
+CREATE TABLE IF NOT EXISTS login_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    session_id UUID,
+    kind VARCHAR(20) NOT NULL,
+    result VARCHAR(50) NOT NULL,
+    ip_address INET,
+    country_code CHAR(2),
+    region VARCHAR(100),
+    city VARCHAR(100),
+    device_id VARCHAR(255),
+    user_agent TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT check_login_kind CHECK (kind IN ('login', 'refresh', 'guest'))
+);
+
+-- Create indexes for performance
+CREATE INDEX idx_login_history_user ON login_history(user_id, created_at DESC);
+CREATE INDEX idx_login_history_created_at ON login_history(created_at);
+
+COMMENT ON TABLE login_history IS 'Login and token refresh history shown to players and support staff';
+COMMENT ON COLUMN login_history.result IS 'success, or the reason the attempt was rejected';
+COMMENT ON COLUMN login_history.country_code IS 'ISO 3166-1 alpha-2 code from the offline GeoIP database';
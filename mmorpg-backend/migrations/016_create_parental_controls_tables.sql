@@ -0,0 +1,45 @@
+-- Create parental controls tables
+-- Guardians place daily/weekly play budgets and allowed hours on a ward's account
+CREATE TABLE IF NOT EXISTS parental_controls (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    guardian_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    daily_minutes INTEGER NOT NULL DEFAULT 0,
+    weekly_minutes INTEGER NOT NULL DEFAULT 0,
+    allowed_windows JSONB NOT NULL DEFAULT '[]',
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT check_parental_controls_minutes CHECK (daily_minutes >= 0 AND weekly_minutes >= 0),
+    CONSTRAINT check_parental_controls_guardian CHECK (guardian_id <> user_id)
+);
+
+CREATE INDEX idx_parental_controls_guardian ON parental_controls(guardian_id);
+
+CREATE TRIGGER update_parental_controls_updated_at BEFORE UPDATE ON parental_controls
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Play time per account and local calendar day
+CREATE TABLE IF NOT EXISTS playtime_usage (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    seconds BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (user_id, day)
+);
+
+-- Online characters of accounts under parental controls
+CREATE TABLE IF NOT EXISTS playtime_sessions (
+    character_id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    session_id UUID,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    accounted_until TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_warning INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX idx_playtime_sessions_user ON playtime_sessions(user_id);
+
+COMMENT ON TABLE parental_controls IS 'Play time limits a guardian places on a ward account';
+COMMENT ON COLUMN parental_controls.allowed_windows IS 'Daily local-time windows, minutes after midnight; empty allows any time';
+COMMENT ON COLUMN playtime_usage.day IS 'Calendar day in the ward''s timezone';
+COMMENT ON COLUMN playtime_sessions.last_warning IS 'Smallest warning threshold already sent, in minutes';
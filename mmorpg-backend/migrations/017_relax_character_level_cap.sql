@@ -0,0 +1,5 @@
+-- Relax the character level constraint
+-- The level cap now comes from the progression data file and is enforced by the character service
+ALTER TABLE characters
+    DROP CONSTRAINT IF EXISTS check_level,
+    ADD CONSTRAINT check_level CHECK (level >= 1);
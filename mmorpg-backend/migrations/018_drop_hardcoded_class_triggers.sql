@@ -0,0 +1,9 @@
+-- Drop database triggers that hard-code class and race data
+-- Starting stats, derived stat formulas and spawn points now come from the class and race
+-- definitions file and are written by the character service, which the triggers would overwrite
+DROP TRIGGER IF EXISTS initialize_character_after_insert ON characters;
+DROP FUNCTION IF EXISTS initialize_character_data();
+
+DROP TRIGGER IF EXISTS recalculate_derived_stats ON character_stats;
+DROP FUNCTION IF EXISTS trigger_calculate_derived_stats();
+DROP FUNCTION IF EXISTS calculate_derived_stats(UUID);
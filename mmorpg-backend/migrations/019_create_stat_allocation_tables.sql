@@ -0,0 +1,26 @@
+-- Create stat allocation and respec history tables
+-- Allocations are logged so recent ones can be undone; respecs are logged to escalate their cost and cooldown
+CREATE TABLE IF NOT EXISTS character_stat_allocations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    character_id UUID NOT NULL REFERENCES characters(id) ON DELETE CASCADE,
+    stat VARCHAR(20) NOT NULL,
+    points INTEGER NOT NULL,
+    allocated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    -- Set when the allocation is undone or cleared by a respec
+    reverted_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT check_allocation_points CHECK (points > 0)
+);
+
+CREATE INDEX idx_character_stat_allocations_recent
+    ON character_stat_allocations(character_id, allocated_at DESC)
+    WHERE reverted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS character_respecs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    character_id UUID NOT NULL REFERENCES characters(id) ON DELETE CASCADE,
+    number INTEGER NOT NULL,
+    refunded_points INTEGER NOT NULL,
+    cost BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT unique_character_respec_number UNIQUE (character_id, number)
+);
@@ -0,0 +1,12 @@
+-- Character renames are recorded by the character service
+-- The service stores who made the change and how long the old name stays reserved,
+-- so the trigger that logged renames without that information is dropped
+DROP TRIGGER IF EXISTS log_name_changes ON characters;
+DROP FUNCTION IF EXISTS log_character_name_change();
+
+-- A released name stays reserved for its previous owner until hold_until
+ALTER TABLE character_name_history ADD COLUMN IF NOT EXISTS hold_until TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_character_name_history_hold
+    ON character_name_history(LOWER(old_name), hold_until)
+    WHERE hold_until IS NOT NULL;
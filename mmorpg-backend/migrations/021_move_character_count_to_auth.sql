@@ -0,0 +1,11 @@
+-- The auth service keeps users.character_count up to date from character lifecycle events
+-- and periodically reconciles it, so character writes no longer update users in the same
+-- transaction
+DROP TRIGGER IF EXISTS maintain_user_character_count ON characters;
+DROP FUNCTION IF EXISTS update_user_character_count();
+
+-- Start from an exact count
+UPDATE users u
+SET character_count = (
+    SELECT COUNT(*) FROM characters c WHERE c.user_id = u.id AND c.is_deleted = FALSE
+);
@@ -0,0 +1,17 @@
+-- Audit log of characters moved between accounts by support staff
+-- Rows outlive the character and both accounts, so there are no foreign keys
+CREATE TABLE IF NOT EXISTS character_transfers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    character_id UUID NOT NULL,
+    from_user_id UUID NOT NULL,
+    to_user_id UUID NOT NULL,
+    from_slot INTEGER NOT NULL,
+    to_slot INTEGER NOT NULL,
+    performed_by UUID NOT NULL,
+    reason TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_character_transfers_character ON character_transfers(character_id, created_at DESC);
+CREATE INDEX idx_character_transfers_from_user ON character_transfers(from_user_id);
+CREATE INDEX idx_character_transfers_to_user ON character_transfers(to_user_id);
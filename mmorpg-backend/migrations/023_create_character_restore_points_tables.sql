@@ -0,0 +1,30 @@
+-- Point-in-time copies of a character's progression, stats and position for game master rollbacks.
+-- stats and position hold the character snapshot JSON format; inventory is reserved for the
+-- inventory service and not restored by the character service.
+CREATE TABLE IF NOT EXISTS character_restore_points (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    character_id UUID NOT NULL REFERENCES characters(id) ON DELETE CASCADE,
+    trigger VARCHAR(32) NOT NULL,
+    level INTEGER NOT NULL,
+    experience BIGINT NOT NULL,
+    stats JSONB NOT NULL,
+    position JSONB NOT NULL,
+    inventory JSONB,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_character_restore_points_character ON character_restore_points(character_id, created_at DESC);
+CREATE INDEX idx_character_restore_points_created_at ON character_restore_points(created_at);
+
+-- Audit log of rollbacks; rows outlive the restore points they reference, so no foreign keys
+CREATE TABLE IF NOT EXISTS character_rollbacks (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    character_id UUID NOT NULL,
+    restore_point_id UUID NOT NULL,
+    backup_id UUID NOT NULL,
+    performed_by UUID NOT NULL,
+    reason TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_character_rollbacks_character ON character_rollbacks(character_id, created_at DESC);
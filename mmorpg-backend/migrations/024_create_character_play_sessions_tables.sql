@@ -0,0 +1,30 @@
+-- Play sessions from character selection until deselect, logout or heartbeat timeout
+CREATE TABLE IF NOT EXISTS character_play_sessions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    character_id UUID NOT NULL REFERENCES characters(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    session_id VARCHAR(255) NOT NULL DEFAULT '',
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_heartbeat_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ended_at TIMESTAMP WITH TIME ZONE,
+    end_reason VARCHAR(32),
+    CONSTRAINT check_play_session_end CHECK (ended_at IS NULL OR ended_at >= started_at)
+);
+
+-- An account plays one character at a time
+CREATE UNIQUE INDEX idx_character_play_sessions_open_user ON character_play_sessions(user_id) WHERE ended_at IS NULL;
+CREATE INDEX idx_character_play_sessions_open_heartbeat ON character_play_sessions(last_heartbeat_at) WHERE ended_at IS NULL;
+CREATE INDEX idx_character_play_sessions_character ON character_play_sessions(character_id, started_at DESC);
+
+-- Play time per character and UTC day for analytics; kept after the character is purged
+CREATE TABLE IF NOT EXISTS character_play_time_daily (
+    character_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    day DATE NOT NULL,
+    play_seconds BIGINT NOT NULL DEFAULT 0,
+    sessions INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (character_id, day)
+);
+
+CREATE INDEX idx_character_play_time_daily_day ON character_play_time_daily(day);
+CREATE INDEX idx_character_play_time_daily_user ON character_play_time_daily(user_id, day);
@@ -0,0 +1,23 @@
+-- Create known networks table
+-- Every network each user has logged in from, on any device. known_devices only keeps a
+-- device's last network, so a user alternating between two networks looked new every time.
+CREATE TABLE IF NOT EXISTS known_networks (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    network CIDR NOT NULL,
+    first_seen_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    last_seen_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, network)
+);
+
+-- Start from the networks the devices were last seen on
+INSERT INTO known_networks (user_id, network, first_seen_at, last_seen_at)
+SELECT user_id, last_network, MIN(first_seen_at), MAX(last_seen_at)
+FROM known_devices
+WHERE last_network IS NOT NULL
+GROUP BY user_id, last_network
+ON CONFLICT DO NOTHING;
+
+DROP INDEX IF EXISTS idx_known_devices_user_network;
+
+COMMENT ON TABLE known_networks IS 'Networks each user has logged in from, for new-network login alerts';
+COMMENT ON COLUMN known_networks.network IS '/24 (IPv4) or /48 (IPv6) network';
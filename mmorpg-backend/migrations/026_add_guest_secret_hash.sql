@@ -0,0 +1,5 @@
+-- Add the guest device secret
+-- Guests logged in on their device ID alone, so anyone who learned a device ID took over the
+-- guest. Each guest is now issued a secret bound to its device; guests created before this
+-- are issued one on their next login.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS guest_secret_hash VARCHAR(255);
@@ -0,0 +1,10 @@
+-- Experience grants applied, by the ID the granting service chose for them. A world server
+-- retrying a grant whose reply was lost sends the same ID, so it is not applied twice.
+CREATE TABLE IF NOT EXISTS character_experience_grants (
+    grant_id VARCHAR(100) PRIMARY KEY,
+    character_id UUID NOT NULL REFERENCES characters(id) ON DELETE CASCADE,
+    amount BIGINT NOT NULL,
+    granted_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_character_experience_grants_character ON character_experience_grants(character_id, granted_at DESC);